@@ -1,16 +1,82 @@
 package main
 
 import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"microservice/internal/adapters/database"
+	"microservice/internal/adapters/messaging"
+	exampleMessaging "microservice/internal/adapters/messaging/example"
+	exampleRepo "microservice/internal/adapters/repository/postgres"
+	"microservice/internal/config"
+	exampleDomain "microservice/internal/core/domain/example"
+	"microservice/internal/core/ports"
+	exampleUseCase "microservice/internal/core/usecase/example"
+	"microservice/internal/platform/logger"
+
 	"go.uber.org/fx"
 )
 
 func main() {
-	fx.New(
-		// TODO:
-		// ConfigModule,
-		// ConsumerModule,
-		// MetricsModule,
-
-		fx.NopLogger,
-	).Run()
+	cfg, err := config.LoadBase()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to load config: %v\n", err)
+		os.Exit(1)
+	}
+
+	fx.New(appModule, fx.StopTimeout(shutdownTimeout(cfg)), fx.NopLogger).Run()
+}
+
+// shutdownTimeout is the deadline shared by all stop hooks.
+func shutdownTimeout(cfg *config.BaseConfig) time.Duration {
+	if cfg.ShutdownTimeout <= 0 {
+		return fx.DefaultTimeout
+	}
+	return time.Duration(cfg.ShutdownTimeout) * time.Second
 }
+
+var appModule = fx.Options(
+	// Platform
+	fx.Provide(config.LoadBase),
+	fx.Provide(config.LoadDatabase),
+	fx.Provide(func(cfg *config.BaseConfig) logger.Config {
+		return logger.Config{
+			Environment: cfg.Environment,
+			Level:       cfg.Logger.Level,
+			Format:      cfg.Logger.Format,
+		}
+	}),
+	fx.Provide(logger.NewZapLogger),
+	fx.Provide(database.NewDatabaseLifecycle),
+
+	// Domain
+	fx.Provide(exampleRepo.NewRepository),
+	fx.Provide(func(repo *exampleRepo.Repository) ports.ExampleRepository { return repo }),
+	fx.Provide(fx.Annotate(exampleDomain.NewService, fx.As(new(exampleUseCase.EntityChecker)))),
+	fx.Provide(fx.Annotate(
+		func(repo ports.ExampleRepository, checker exampleUseCase.EntityChecker) *exampleUseCase.Usecase {
+			return exampleUseCase.NewUsecase(repo, checker)
+		},
+		fx.As(new(exampleMessaging.Manager)),
+	)),
+
+	// Messaging
+	// Replace with a Kafka or NATS client that implements messaging.Consumer.
+	fx.Provide(fx.Annotate(messaging.NewMemoryBroker, fx.As(new(messaging.Consumer)))),
+	fx.Provide(messaging.NewLifecycle),
+	fx.Provide(exampleMessaging.NewHandler),
+	fx.Invoke(func(handler *exampleMessaging.Handler, consumer *messaging.Lifecycle) {
+		handler.Register(consumer)
+	}),
+
+	// Lifecycle Hooks
+	// The consumer starts last and stops first, so messages in progress finish
+	// before the database connection is closed.
+	fx.Invoke(func(lc fx.Lifecycle, db *database.Lifecycle, repo *exampleRepo.Repository, consumer *messaging.Lifecycle) {
+		lc.Append(fx.Hook{OnStart: db.Start, OnStop: db.Stop})
+		lc.Append(fx.Hook{OnStop: func(context.Context) error { return repo.Close() }})
+		lc.Append(fx.Hook{OnStart: consumer.Start, OnStop: consumer.Stop})
+	}),
+)
@@ -0,0 +1,12 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+)
+
+func TestAppModule_Validates(t *testing.T) {
+	require.NoError(t, fx.ValidateApp(appModule, fx.NopLogger))
+}
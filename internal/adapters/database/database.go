@@ -2,18 +2,28 @@ package database
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
 	"microservice/internal/platform/database/postgres"
 	"microservice/internal/platform/logger"
 	"sync"
+	"time"
 
 	"microservice/internal/config"
 )
 
+// ErrFallbackInProduction is returned by Start when the database is
+// unreachable and the in-memory fallback is enabled in production.
+var ErrFallbackInProduction = errors.New("in-memory fallback is not allowed in production")
+
 type Lifecycle struct {
-	cfg    *config.DatabaseConfig
-	logger logger.Logger
-	db     *postgres.DB
-	mu     sync.Mutex
+	cfg      *config.DatabaseConfig
+	logger   logger.Logger
+	db       *postgres.DB
+	replica  *postgres.DB
+	inMemory bool
+	mu       sync.Mutex
 }
 
 func NewDatabaseLifecycle(cfg *config.DatabaseConfig, log logger.Logger) *Lifecycle {
@@ -23,38 +33,177 @@ func NewDatabaseLifecycle(cfg *config.DatabaseConfig, log logger.Logger) *Lifecy
 	}
 }
 
+// NewLifecycleWithConnection wraps an already open connection, for callers that
+// manage the connection themselves. Start replaces it with a new one.
+func NewLifecycleWithConnection(cfg *config.DatabaseConfig, log logger.Logger, db *postgres.DB) *Lifecycle {
+	return &Lifecycle{
+		cfg:    cfg,
+		logger: log,
+		db:     db,
+	}
+}
+
 func (d *Lifecycle) Start(ctx context.Context) error {
 	d.mu.Lock()
-	defer d.mu.Unlock()
-
-	// Close existing connection if any
+	// Close existing connections if any
 	if d.db != nil {
 		d.logger.Warn("Database connection already exists, closing existing connection")
-		if err := d.db.Close(); err != nil {
+		if err := closeConnections(d.db, d.replica); err != nil {
 			d.logger.Error("Failed to close existing database connection", logger.Error(err))
 		}
-		d.db = nil
+		d.db, d.replica = nil, nil
 	}
+	d.inMemory = false
+	d.mu.Unlock()
 
 	d.logger.Info("Starting database connection")
 
-	db, err := postgres.New(&d.cfg.Postgres)
+	// Connecting may retry for a while, so it runs without the lock to keep
+	// Connection, InMemory and Stats answering in the meantime.
+	db, replica, err := d.open(ctx)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
 	if err != nil {
-		d.logger.Error("Failed to create PostgreSQL connection", logger.Error(err))
+		return d.fallBack(err)
+	}
+	d.db = db
+	d.replica = replica
+	return nil
+}
+
+// open connects to the primary and, when one is configured, the read replica.
+func (d *Lifecycle) open(ctx context.Context) (db, replica *postgres.DB, err error) {
+	db, err = d.connectWithRetry(ctx, &d.cfg.Postgres)
+	if err != nil {
+		return nil, nil, err
+	}
+	d.warmUp(ctx, db, &d.cfg.Postgres)
+
+	replicaCfg, ok := d.cfg.Postgres.ReplicaConfig()
+	if !ok {
+		return db, nil, nil
+	}
+
+	d.logger.Info("Starting read replica connection", logger.String("host", replicaCfg.Host))
+
+	replica, err = d.connectWithRetry(ctx, replicaCfg)
+	if err != nil {
+		if closeErr := db.Close(); closeErr != nil {
+			d.logger.Error("Failed to close database after replica connection failure", logger.Error(closeErr))
+		}
+		return nil, nil, fmt.Errorf("read replica: %w", err)
+	}
+	d.warmUp(ctx, replica, replicaCfg)
+	return db, replica, nil
+}
+
+// fallBack handles a database that could not be reached. With MemoryFallback
+// set outside production it switches to in-memory mode instead of failing;
+// otherwise it returns err. Callers must hold the lock.
+func (d *Lifecycle) fallBack(err error) error {
+	if !d.cfg.MemoryFallback {
 		return err
 	}
+	if d.cfg.IsProduction() {
+		return fmt.Errorf("%w: %w", ErrFallbackInProduction, err)
+	}
+
+	d.logger.Warn("DATABASE UNAVAILABLE: serving data from memory, nothing will be persisted",
+		logger.String("host", d.cfg.Postgres.Host),
+		logger.Error(err),
+	)
+	d.inMemory = true
+	return nil
+}
+
+func (d *Lifecycle) connectWithRetry(ctx context.Context, cfg *config.PostgresConfig) (*postgres.DB, error) {
+	attempts := cfg.ConnectRetries + 1
+
+	var lastErr error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		db, err := d.connect(ctx, cfg)
+		if err == nil {
+			d.logger.Info("Successfully connected to PostgreSQL database",
+				logger.String("host", cfg.Host),
+				logger.Int("attempt", attempt),
+			)
+			return db, nil
+		}
+		lastErr = err
+
+		d.logger.Warn("Database connection attempt failed",
+			logger.String("host", cfg.Host),
+			logger.Int("attempt", attempt),
+			logger.Int("max_attempts", attempts),
+			logger.Error(err),
+		)
+
+		if attempt == attempts {
+			break
+		}
+
+		timer := time.NewTimer(cfg.ConnectRetryDelay(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, fmt.Errorf("database connection cancelled after %d attempts: %w", attempt, errors.Join(ctx.Err(), lastErr))
+		case <-timer.C:
+		}
+	}
+
+	return nil, fmt.Errorf("failed to connect to database after %d attempts: %w", attempts, lastErr)
+}
+
+// warmUp fills the pool with cfg.MinIdleConns idle connections. A failure is
+// only logged: the pool still opens connections on demand.
+func (d *Lifecycle) warmUp(ctx context.Context, db *postgres.DB, cfg *config.PostgresConfig) {
+	if cfg.MinIdleConns <= 0 {
+		return
+	}
+
+	start := time.Now()
+	warmed, err := db.WarmUp(ctx, cfg.MinIdleConns)
+	fields := []logger.Field{
+		logger.String("host", cfg.Host),
+		logger.Int("requested", cfg.MinIdleConns),
+		logger.Int("warmed", warmed),
+		logger.String("duration", time.Since(start).String()),
+	}
+	if err != nil {
+		d.logger.Warn("Database connection pool warm-up incomplete", append(fields, logger.Error(err))...)
+		return
+	}
+	d.logger.Info("Warmed up database connection pool", fields...)
+}
+
+func (d *Lifecycle) connect(ctx context.Context, cfg *config.PostgresConfig) (*postgres.DB, error) {
+	db, err := postgres.New(cfg)
+	if err != nil {
+		d.logger.Error("Failed to create PostgreSQL connection", logger.Error(err))
+		return nil, err
+	}
 
 	if err := db.Ping(ctx); err != nil {
 		d.logger.Error("Failed to ping PostgreSQL", logger.Error(err))
 		if closeErr := db.Close(); closeErr != nil {
 			d.logger.Error("Failed to close database after ping failure", logger.Error(closeErr))
 		}
-		return err
+		return nil, err
 	}
 
-	d.db = db
-	d.logger.Info("Successfully connected to PostgreSQL database")
-	return nil
+	return db, nil
+}
+
+// closeConnections closes every non-nil connection and joins the errors.
+func closeConnections(dbs ...*postgres.DB) error {
+	var errs []error
+	for _, db := range dbs {
+		if db != nil {
+			errs = append(errs, db.Close())
+		}
+	}
+	return errors.Join(errs...)
 }
 
 func (d *Lifecycle) Stop(ctx context.Context) error {
@@ -67,14 +216,15 @@ func (d *Lifecycle) Stop(ctx context.Context) error {
 
 	d.logger.Info("Closing database connection")
 
+	db, replica := d.db, d.replica
 	done := make(chan error, 1)
 	go func() {
-		done <- d.db.Close()
+		done <- closeConnections(db, replica)
 	}()
 
 	select {
 	case err := <-done:
-		d.db = nil
+		d.db, d.replica = nil, nil
 		if err != nil {
 			d.logger.Error("Error closing database connection", logger.Error(err))
 			return err
@@ -83,13 +233,45 @@ func (d *Lifecycle) Stop(ctx context.Context) error {
 		return nil
 	case <-ctx.Done():
 		d.logger.Warn("Database shutdown timeout, forcing close")
-		d.db = nil
+		d.db, d.replica = nil, nil
 		return ctx.Err()
 	}
 }
 
+// InMemory reports whether Start fell back to in-memory mode because the
+// database was unreachable. There is no connection in that mode.
+func (d *Lifecycle) InMemory() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.inMemory
+}
+
 func (d *Lifecycle) Connection() *postgres.DB {
 	d.mu.Lock()
 	defer d.mu.Unlock()
 	return d.db
 }
+
+// ReadConnection returns the read replica, or the primary when no replica is
+// configured. Replicas may lag behind the primary, so reads that must observe
+// a preceding write should use Connection.
+func (d *Lifecycle) ReadConnection() *postgres.DB {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.replica != nil {
+		return d.replica
+	}
+	return d.db
+}
+
+func (d *Lifecycle) Config() *config.DatabaseConfig {
+	return d.cfg
+}
+
+func (d *Lifecycle) Stats() (sql.DBStats, bool) {
+	db := d.Connection()
+	if db == nil {
+		return sql.DBStats{}, false
+	}
+	return db.Stats(), true
+}
@@ -2,6 +2,7 @@ package database
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -13,6 +14,7 @@ import (
 	"github.com/testcontainers/testcontainers-go/wait"
 
 	"microservice/internal/config"
+	pgconn "microservice/internal/platform/database/postgres"
 	"microservice/internal/platform/logger"
 )
 
@@ -129,6 +131,63 @@ func (suite *DatabaseTestSuite) TestLifecycle_StartTwice_ClosesExistingConnectio
 	suite.Require().NoError(err)
 }
 
+func (suite *DatabaseTestSuite) TestLifecycle_StartWithReplica() {
+	cfg := *suite.dbConfig
+	cfg.Postgres.ReplicaHost = cfg.Postgres.Host
+	lifecycle := NewDatabaseLifecycle(&cfg, suite.logger)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	suite.Require().NoError(lifecycle.Start(ctx))
+
+	primary := lifecycle.Connection()
+	replica := lifecycle.ReadConnection()
+	suite.Require().NotNil(primary)
+	suite.Require().NotNil(replica)
+	suite.Assert().NotSame(primary, replica)
+	suite.Assert().NoError(replica.Ping(ctx))
+
+	suite.Require().NoError(lifecycle.Stop(ctx))
+	suite.Assert().Nil(lifecycle.Connection())
+	suite.Assert().Nil(lifecycle.ReadConnection())
+}
+
+func (suite *DatabaseTestSuite) TestLifecycle_StartReplicaUnreachable() {
+	cfg := *suite.dbConfig
+	cfg.Postgres.ReplicaHost = "localhost"
+	cfg.Postgres.ReplicaPort = 1
+	lifecycle := NewDatabaseLifecycle(&cfg, suite.logger)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	err := lifecycle.Start(ctx)
+
+	suite.Require().Error(err)
+	suite.Assert().Contains(err.Error(), "read replica")
+	suite.Assert().Nil(lifecycle.Connection())
+	suite.Assert().Nil(lifecycle.ReadConnection())
+}
+
+func (suite *DatabaseTestSuite) TestLifecycle_Start_WarmsUpPool() {
+	cfg := *suite.dbConfig
+	cfg.Postgres.MaxOpenConns = 10
+	cfg.Postgres.MaxIdleConns = 5
+	cfg.Postgres.MinIdleConns = 3
+	lifecycle := NewDatabaseLifecycle(&cfg, suite.logger)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	suite.Require().NoError(lifecycle.Start(ctx))
+	defer func() { suite.Assert().NoError(lifecycle.Stop(ctx)) }()
+
+	stats := lifecycle.Connection().Stats()
+	suite.Assert().Equal(3, stats.Idle)
+	suite.Assert().Equal(3, stats.OpenConnections)
+}
+
 func (suite *DatabaseTestSuite) TestLifecycle_Connection_BeforeStart() {
 	lifecycle := NewDatabaseLifecycle(suite.dbConfig, suite.logger)
 
@@ -351,3 +410,106 @@ func TestLifecycle_StartStop_InvalidConfig(t *testing.T) {
 	err = lifecycle.Stop(ctx)
 	assert.NoError(t, err)
 }
+
+func TestLifecycle_Start_RetriesWithBackoff(t *testing.T) {
+	cfg := &config.DatabaseConfig{
+		Postgres: config.PostgresConfig{
+			Host:                "localhost",
+			Port:                1,
+			User:                "invalid",
+			Password:            "invalid",
+			Database:            "invalid",
+			SSLMode:             "disable",
+			ConnectRetries:      2,
+			ConnectRetryBackoff: 10 * time.Millisecond,
+		},
+	}
+	lifecycle := NewDatabaseLifecycle(cfg, logger.NewNop())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	start := time.Now()
+	err := lifecycle.Start(ctx)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "after 3 attempts")
+	assert.GreaterOrEqual(t, time.Since(start), 30*time.Millisecond)
+	assert.Nil(t, lifecycle.Connection())
+}
+
+func TestLifecycle_Start_RetryCancelledByContext(t *testing.T) {
+	cfg := &config.DatabaseConfig{
+		Postgres: config.PostgresConfig{
+			Host:                "localhost",
+			Port:                1,
+			User:                "invalid",
+			Password:            "invalid",
+			Database:            "invalid",
+			SSLMode:             "disable",
+			ConnectRetries:      10,
+			ConnectRetryBackoff: time.Second,
+		},
+	}
+	lifecycle := NewDatabaseLifecycle(cfg, logger.NewNop())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := lifecycle.Start(ctx)
+
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Contains(t, err.Error(), "after 1 attempts")
+	assert.Contains(t, err.Error(), "connection refused", "keeps the connection error")
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Nil(t, lifecycle.Connection())
+}
+
+func TestLifecycle_Start_RetryDoesNotHoldLock(t *testing.T) {
+	cfg := unreachableConfig(config.EnvDevelopment, false)
+	cfg.Postgres.ConnectRetries = 10
+	cfg.Postgres.ConnectRetryBackoff = time.Second
+	lifecycle := NewDatabaseLifecycle(cfg, logger.NewNop())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	started := make(chan error, 1)
+	go func() { started <- lifecycle.Start(ctx) }()
+	time.Sleep(50 * time.Millisecond)
+
+	answered := make(chan struct{})
+	go func() {
+		lifecycle.Connection()
+		lifecycle.InMemory()
+		close(answered)
+	}()
+	select {
+	case <-answered:
+	case <-time.After(500 * time.Millisecond):
+		t.Error("Connection and InMemory blocked while Start was retrying")
+	}
+
+	cancel()
+	assert.ErrorIs(t, <-started, context.Canceled)
+}
+
+func TestLifecycle_ReadConnection(t *testing.T) {
+	lifecycle := NewDatabaseLifecycle(&config.DatabaseConfig{}, logger.NewNop())
+	assert.Nil(t, lifecycle.ReadConnection())
+
+	primary, err := pgconn.New(&config.PostgresConfig{Host: "localhost"})
+	require.NoError(t, err)
+	defer func() { _ = primary.Close() }()
+	lifecycle.db = primary
+
+	assert.Same(t, primary, lifecycle.ReadConnection(), "falls back to the primary without a replica")
+
+	replica, err := pgconn.New(&config.PostgresConfig{Host: "replica"})
+	require.NoError(t, err)
+	defer func() { _ = replica.Close() }()
+	lifecycle.replica = replica
+
+	assert.Same(t, replica, lifecycle.ReadConnection())
+	assert.Same(t, primary, lifecycle.Connection())
+}
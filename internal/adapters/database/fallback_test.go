@@ -0,0 +1,79 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"microservice/internal/config"
+	"microservice/internal/platform/logger"
+)
+
+// unreachableConfig points at a port nothing listens on, so connecting fails
+// at once and without retries.
+func unreachableConfig(env string, fallback bool) *config.DatabaseConfig {
+	return &config.DatabaseConfig{
+		BaseConfig: config.BaseConfig{Environment: env},
+		Postgres: config.PostgresConfig{
+			Host:     "127.0.0.1",
+			Port:     1,
+			User:     "postgres",
+			Database: "microservice",
+			SSLMode:  "disable",
+		},
+		MemoryFallback: fallback,
+	}
+}
+
+type warnCapture struct {
+	logger.Logger
+	warnings []string
+}
+
+func (w *warnCapture) Warn(msg string, fields ...logger.Field) {
+	w.warnings = append(w.warnings, msg)
+}
+
+func TestLifecycle_Start_FallsBackToMemoryInDevelopment(t *testing.T) {
+	log := &warnCapture{Logger: logger.NewNop()}
+	lifecycle := NewDatabaseLifecycle(unreachableConfig(config.EnvDevelopment, true), log)
+
+	require.NoError(t, lifecycle.Start(context.Background()))
+
+	assert.True(t, lifecycle.InMemory())
+	assert.Nil(t, lifecycle.Connection())
+	assert.Contains(t, log.warnings, "DATABASE UNAVAILABLE: serving data from memory, nothing will be persisted")
+
+	called := false
+	err := lifecycle.InTx(context.Background(), func(ctx context.Context) error {
+		called = true
+		_, ok := TxFromContext(ctx)
+		assert.False(t, ok)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.True(t, called)
+
+	assert.NoError(t, lifecycle.Stop(context.Background()))
+}
+
+func TestLifecycle_Start_RefusesFallbackInProduction(t *testing.T) {
+	lifecycle := NewDatabaseLifecycle(unreachableConfig(config.EnvProduction, true), logger.NewNop())
+
+	err := lifecycle.Start(context.Background())
+
+	assert.ErrorIs(t, err, ErrFallbackInProduction)
+	assert.False(t, lifecycle.InMemory())
+}
+
+func TestLifecycle_Start_FailsWithoutFallback(t *testing.T) {
+	lifecycle := NewDatabaseLifecycle(unreachableConfig(config.EnvDevelopment, false), logger.NewNop())
+
+	err := lifecycle.Start(context.Background())
+
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrFallbackInProduction)
+	assert.False(t, lifecycle.InMemory())
+}
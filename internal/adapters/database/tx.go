@@ -0,0 +1,93 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+var ErrNotConnected = errors.New("database is not connected")
+
+// TxExecutor is the query surface shared by *sql.DB and *sql.Tx, so
+// repository code can run the same statements inside or outside a
+// transaction.
+type TxExecutor interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+var (
+	_ TxExecutor = (*sql.DB)(nil)
+	_ TxExecutor = (*sql.Tx)(nil)
+)
+
+type txKey struct{}
+
+// ContextWithTx returns a copy of ctx that carries tx. Repositories pick it up
+// through Executor, so every call made with the context joins the transaction.
+func ContextWithTx(ctx context.Context, tx *sql.Tx) context.Context {
+	return context.WithValue(ctx, txKey{}, tx)
+}
+
+// TxFromContext returns the transaction carried by ctx, if any.
+func TxFromContext(ctx context.Context) (*sql.Tx, bool) {
+	tx, ok := ctx.Value(txKey{}).(*sql.Tx)
+	return tx, ok && tx != nil
+}
+
+// Executor returns the transaction carried by ctx, or db when there is none.
+func Executor(ctx context.Context, db *sql.DB) TxExecutor {
+	if tx, ok := TxFromContext(ctx); ok {
+		return tx
+	}
+	return db
+}
+
+// WithTx runs fn in a transaction on the primary connection. The transaction
+// is committed when fn returns nil and rolled back when it returns an error or
+// panics; the panic is re-raised after the rollback.
+func (d *Lifecycle) WithTx(ctx context.Context, fn func(tx *sql.Tx) error) (err error) {
+	conn := d.Connection()
+	if conn == nil {
+		return ErrNotConnected
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(tx); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			return errors.Join(err, fmt.Errorf("rollback transaction: %w", rollbackErr))
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// InTx runs fn with a context carrying a transaction, so repository calls made
+// with that context are committed or rolled back together. When ctx already
+// carries a transaction fn joins it instead of starting a new one. In
+// in-memory mode there is nothing to commit and fn runs without a transaction.
+func (d *Lifecycle) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := TxFromContext(ctx); ok || d.InMemory() {
+		return fn(ctx)
+	}
+	return d.WithTx(ctx, func(tx *sql.Tx) error {
+		return fn(ContextWithTx(ctx, tx))
+	})
+}
@@ -0,0 +1,129 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"microservice/internal/config"
+	pgconn "microservice/internal/platform/database/postgres"
+	"microservice/internal/platform/logger"
+)
+
+func newMockLifecycle(t *testing.T) (*Lifecycle, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	return NewLifecycleWithConnection(&config.DatabaseConfig{}, logger.NewNop(), &pgconn.DB{DB: db}), mock
+}
+
+func TestLifecycle_WithTx_Commits(t *testing.T) {
+	lifecycle, mock := newMockLifecycle(t)
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO examples").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := lifecycle.WithTx(context.Background(), func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(context.Background(), "INSERT INTO examples (id) VALUES ($1)", "id")
+		return err
+	})
+
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLifecycle_WithTx_RollsBackOnError(t *testing.T) {
+	lifecycle, mock := newMockLifecycle(t)
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+	fnErr := errors.New("audit write failed")
+
+	err := lifecycle.WithTx(context.Background(), func(*sql.Tx) error { return fnErr })
+
+	assert.ErrorIs(t, err, fnErr)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLifecycle_WithTx_ReportsRollbackFailure(t *testing.T) {
+	lifecycle, mock := newMockLifecycle(t)
+	mock.ExpectBegin()
+	rollbackErr := errors.New("connection lost")
+	mock.ExpectRollback().WillReturnError(rollbackErr)
+	fnErr := errors.New("audit write failed")
+
+	err := lifecycle.WithTx(context.Background(), func(*sql.Tx) error { return fnErr })
+
+	assert.ErrorIs(t, err, fnErr)
+	assert.ErrorIs(t, err, rollbackErr)
+}
+
+func TestLifecycle_WithTx_RollsBackOnPanic(t *testing.T) {
+	lifecycle, mock := newMockLifecycle(t)
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	assert.PanicsWithValue(t, "boom", func() {
+		_ = lifecycle.WithTx(context.Background(), func(*sql.Tx) error { panic("boom") })
+	})
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLifecycle_WithTx_CommitFailure(t *testing.T) {
+	lifecycle, mock := newMockLifecycle(t)
+	mock.ExpectBegin()
+	commitErr := errors.New("serialization failure")
+	mock.ExpectCommit().WillReturnError(commitErr)
+
+	err := lifecycle.WithTx(context.Background(), func(*sql.Tx) error { return nil })
+
+	assert.ErrorIs(t, err, commitErr)
+}
+
+func TestLifecycle_WithTx_NotConnected(t *testing.T) {
+	lifecycle := NewDatabaseLifecycle(&config.DatabaseConfig{}, logger.NewNop())
+
+	err := lifecycle.WithTx(context.Background(), func(*sql.Tx) error {
+		t.Fatal("callback must not run without a connection")
+		return nil
+	})
+
+	assert.ErrorIs(t, err, ErrNotConnected)
+}
+
+func TestLifecycle_InTx_CarriesAndJoinsTransaction(t *testing.T) {
+	lifecycle, mock := newMockLifecycle(t)
+	mock.ExpectBegin()
+	mock.ExpectCommit()
+
+	err := lifecycle.InTx(context.Background(), func(ctx context.Context) error {
+		outer, ok := TxFromContext(ctx)
+		require.True(t, ok)
+		assert.Same(t, outer, Executor(ctx, lifecycle.Connection().DB))
+
+		return lifecycle.InTx(ctx, func(ctx context.Context) error {
+			inner, ok := TxFromContext(ctx)
+			require.True(t, ok)
+			assert.Same(t, outer, inner, "nested calls join the outer transaction")
+			return nil
+		})
+	})
+
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExecutor_WithoutTransaction(t *testing.T) {
+	lifecycle, _ := newMockLifecycle(t)
+	db := lifecycle.Connection().DB
+
+	_, ok := TxFromContext(context.Background())
+	assert.False(t, ok)
+	assert.Same(t, db, Executor(context.Background(), db))
+}
@@ -5,23 +5,115 @@ import (
 	"fmt"
 	"microservice/internal/platform/health"
 	"net/http"
+	"sync"
 	"time"
 )
 
+const (
+	defaultFailureThreshold = 5
+	defaultBreakerCooldown  = 30 * time.Second
+	defaultAPICheckTimeout  = 5 * time.Second
+)
+
+// APIChecker probes an HTTP endpoint and reports it healthy when it answers
+// with an expected status, any 2xx by default.
+// A circuit breaker stops it from hammering a dependency that is down: after
+// the configured number of consecutive failures it reports unhealthy without
+// a request until the cooldown has passed, then lets a single probe through.
+// A successful probe closes the breaker, a failed one opens it again.
 type APIChecker struct {
-	client   *http.Client
-	endpoint string
-	name     string
+	client    *http.Client
+	timeout   time.Duration
+	endpoint  string
+	name      string
+	method    string
+	header    http.Header
+	minStatus int
+	maxStatus int
+
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu       sync.Mutex
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+type APICheckerOption func(*APIChecker)
+
+// WithCircuitBreaker opens the breaker after threshold consecutive failures
+// and keeps it open for cooldown. A threshold of zero or less disables it.
+func WithCircuitBreaker(threshold int, cooldown time.Duration) APICheckerOption {
+	return func(c *APIChecker) {
+		c.threshold = threshold
+		c.cooldown = cooldown
+	}
+}
+
+// WithMethod sends method instead of GET, for health endpoints that only
+// answer POST.
+func WithMethod(method string) APICheckerOption {
+	return func(c *APIChecker) {
+		c.method = method
+	}
+}
+
+// WithHeader adds a header to every probe, such as an Authorization token.
+func WithHeader(key, value string) APICheckerOption {
+	return func(c *APIChecker) {
+		c.header.Add(key, value)
+	}
+}
+
+// WithExpectedStatus treats answers with a status from minStatus to
+// maxStatus, inclusive, as healthy instead of any 2xx. A dependency that
+// answers 401 to an unauthenticated probe is up, for example.
+func WithExpectedStatus(minStatus, maxStatus int) APICheckerOption {
+	return func(c *APIChecker) {
+		c.minStatus = minStatus
+		c.maxStatus = maxStatus
+	}
+}
+
+// WithHTTPClient sends probes through client, so checkers can share one
+// client and its connection pool.
+func WithHTTPClient(client *http.Client) APICheckerOption {
+	return func(c *APIChecker) {
+		c.client = client
+	}
+}
+
+// WithTimeout bounds every probe by timeout instead of 5 seconds. Zero or
+// less leaves probes bounded only by the caller's context.
+func WithTimeout(timeout time.Duration) APICheckerOption {
+	return func(c *APIChecker) {
+		c.timeout = timeout
+	}
 }
 
-func NewAPIChecker(endpoint, name string) *APIChecker {
-	return &APIChecker{
-		client: &http.Client{
-			Timeout: 5 * time.Second,
-		},
-		endpoint: endpoint,
-		name:     name,
+// NewAPIChecker GETs endpoint with a 5 second timeout and reports any 2xx as
+// healthy. The breaker opens after 5 consecutive failures for 30 seconds
+// unless WithCircuitBreaker says otherwise.
+func NewAPIChecker(endpoint, name string, opts ...APICheckerOption) *APIChecker {
+	c := &APIChecker{
+		client:    &http.Client{},
+		timeout:   defaultAPICheckTimeout,
+		endpoint:  endpoint,
+		name:      name,
+		method:    http.MethodGet,
+		header:    http.Header{},
+		minStatus: http.StatusOK,
+		maxStatus: 299,
+		threshold: defaultFailureThreshold,
+		cooldown:  defaultBreakerCooldown,
+		now:       time.Now,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
 func (c *APIChecker) Name() string {
@@ -29,7 +121,66 @@ func (c *APIChecker) Name() string {
 }
 
 func (c *APIChecker) Check(ctx context.Context) health.CheckResult {
-	req, err := http.NewRequestWithContext(ctx, "GET", c.endpoint, nil)
+	if retryIn, open := c.acquire(); open {
+		return health.CheckResult{
+			Status:  health.StatusUnhealthy,
+			Message: "circuit open, api not probed",
+			Error:   fmt.Sprintf("%d or more consecutive failures, next probe in %s", c.threshold, retryIn.Round(time.Second)),
+		}
+	}
+
+	result := c.probe(ctx)
+	c.record(result.Status == health.StatusHealthy)
+	return result
+}
+
+// acquire reports whether the breaker is open, and for how long it stays so.
+// Once the cooldown has passed it admits one caller as the half-open probe and
+// keeps the breaker open for everyone else until that probe is recorded.
+func (c *APIChecker) acquire() (time.Duration, bool) {
+	if c.threshold <= 0 {
+		return 0, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.failures < c.threshold {
+		return 0, false
+	}
+	retryIn := c.openedAt.Add(c.cooldown).Sub(c.now())
+	if retryIn > 0 || c.probing {
+		return max(retryIn, 0), true
+	}
+	c.probing = true
+	return 0, false
+}
+
+func (c *APIChecker) record(healthy bool) {
+	if c.threshold <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.probing = false
+	if healthy {
+		c.failures = 0
+		return
+	}
+	c.failures++
+	if c.failures >= c.threshold {
+		c.openedAt = c.now()
+	}
+}
+
+func (c *APIChecker) probe(ctx context.Context) health.CheckResult {
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, c.method, c.endpoint, nil)
 	if err != nil {
 		return health.CheckResult{
 			Status:  health.StatusUnhealthy,
@@ -37,6 +188,9 @@ func (c *APIChecker) Check(ctx context.Context) health.CheckResult {
 			Error:   err.Error(),
 		}
 	}
+	for key, values := range c.header {
+		req.Header[key] = values
+	}
 
 	resp, err := c.client.Do(req)
 	if err != nil {
@@ -48,7 +202,7 @@ func (c *APIChecker) Check(ctx context.Context) health.CheckResult {
 	}
 	defer func() { _ = resp.Body.Close() }()
 
-	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+	if resp.StatusCode >= c.minStatus && resp.StatusCode <= c.maxStatus {
 		return health.CheckResult{
 			Status:  health.StatusHealthy,
 			Message: fmt.Sprintf("api responding with status %d", resp.StatusCode),
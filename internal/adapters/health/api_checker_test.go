@@ -0,0 +1,169 @@
+package health
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"microservice/internal/platform/health"
+)
+
+func TestAPIChecker_CircuitBreaker(t *testing.T) {
+	var requests atomic.Int32
+	var failing atomic.Bool
+	failing.Store(true)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if failing.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	now := time.Now()
+	checker := NewAPIChecker(server.URL, "test-api", WithCircuitBreaker(3, time.Minute))
+	checker.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, "api returned status 503", checker.Check(ctx).Message)
+	}
+	require.Equal(t, int32(3), requests.Load())
+
+	// Open: no requests reach the dependency during the cooldown.
+	result := checker.Check(ctx)
+	assert.Equal(t, health.StatusUnhealthy, result.Status)
+	assert.Equal(t, "circuit open, api not probed", result.Message)
+	assert.Equal(t, "3 or more consecutive failures, next probe in 1m0s", result.Error)
+	now = now.Add(59 * time.Second)
+	checker.Check(ctx)
+	assert.Equal(t, int32(3), requests.Load())
+
+	// Half-open: a failed probe opens the breaker for another cooldown.
+	now = now.Add(time.Second)
+	assert.Equal(t, "api returned status 503", checker.Check(ctx).Message)
+	assert.Equal(t, "circuit open, api not probed", checker.Check(ctx).Message)
+	assert.Equal(t, int32(4), requests.Load())
+
+	// Half-open: a successful probe closes it.
+	failing.Store(false)
+	now = now.Add(time.Minute)
+	assert.Equal(t, health.StatusHealthy, checker.Check(ctx).Status)
+	assert.Equal(t, health.StatusHealthy, checker.Check(ctx).Status)
+	assert.Equal(t, int32(6), requests.Load())
+}
+
+func TestAPIChecker_CircuitBreaker_SingleHalfOpenProbe(t *testing.T) {
+	release := make(chan struct{})
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) > 1 {
+			<-release
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	now := time.Now()
+	checker := NewAPIChecker(server.URL, "test-api", WithCircuitBreaker(1, time.Second))
+	checker.now = func() time.Time { return now }
+	checker.Check(context.Background())
+	now = now.Add(time.Second)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		checker.Check(context.Background())
+	}()
+	require.Eventually(t, func() bool { return requests.Load() == 2 }, time.Second, time.Millisecond)
+
+	assert.Equal(t, "circuit open, api not probed", checker.Check(context.Background()).Message)
+	close(release)
+	<-done
+	assert.Equal(t, int32(2), requests.Load())
+}
+
+func TestAPIChecker_CircuitBreakerDisabled(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	checker := NewAPIChecker(server.URL, "test-api", WithCircuitBreaker(0, time.Minute))
+	for i := 0; i < 10; i++ {
+		checker.Check(context.Background())
+	}
+
+	assert.Equal(t, int32(10), requests.Load())
+}
+
+func TestAPIChecker_RequestOptions(t *testing.T) {
+	var method, auth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, auth = r.Method, r.Header.Get("Authorization")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := &http.Client{}
+	checker := NewAPIChecker(server.URL, "test-api",
+		WithMethod(http.MethodPost),
+		WithHeader("Authorization", "Bearer probe-token"),
+		WithHTTPClient(client),
+	)
+
+	result := checker.Check(context.Background())
+
+	assert.Equal(t, health.StatusHealthy, result.Status)
+	assert.Equal(t, http.MethodPost, method)
+	assert.Equal(t, "Bearer probe-token", auth)
+	assert.Same(t, client, checker.client)
+}
+
+func TestAPIChecker_ExpectedStatus(t *testing.T) {
+	status := http.StatusUnauthorized
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	upButUnauthorized := NewAPIChecker(server.URL, "test-api", WithExpectedStatus(200, 401))
+	defaults := NewAPIChecker(server.URL, "test-api")
+
+	result := upButUnauthorized.Check(context.Background())
+	assert.Equal(t, health.StatusHealthy, result.Status)
+	assert.Equal(t, "api responding with status 401", result.Message)
+	assert.Equal(t, health.StatusUnhealthy, defaults.Check(context.Background()).Status)
+
+	status = http.StatusForbidden
+	assert.Equal(t, health.StatusUnhealthy, upButUnauthorized.Check(context.Background()).Status)
+}
+
+func TestAPIChecker_Timeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	checker := NewAPIChecker(server.URL, "test-api", WithTimeout(20*time.Millisecond))
+	result := checker.Check(context.Background())
+
+	assert.Equal(t, health.StatusUnhealthy, result.Status)
+	assert.Equal(t, "api request failed", result.Message)
+	assert.Contains(t, result.Error, "context deadline exceeded")
+}
@@ -0,0 +1,116 @@
+package health
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"microservice/internal/platform/health"
+	"os"
+	"time"
+)
+
+// CertificateChecker watches the expiry of PEM certificate files, such as the
+// server's TLS certificate or client certificates used for mTLS. It reports
+// degraded within warnBefore of the earliest NotAfter in any file and
+// unhealthy once a certificate has expired or a file cannot be read.
+type CertificateChecker struct {
+	name       string
+	files      []string
+	warnBefore time.Duration
+	now        func() time.Time
+}
+
+func NewCertificateChecker(name string, files []string, warnBefore time.Duration) *CertificateChecker {
+	return &CertificateChecker{
+		name:       name,
+		files:      files,
+		warnBefore: warnBefore,
+		now:        time.Now,
+	}
+}
+
+func (c *CertificateChecker) Name() string {
+	return c.name
+}
+
+func (c *CertificateChecker) Check(ctx context.Context) health.CheckResult {
+	_ = ctx
+	var (
+		earliest     time.Time
+		earliestFile string
+	)
+	for _, file := range c.files {
+		notAfter, err := certificateExpiry(file)
+		if err != nil {
+			return health.CheckResult{
+				Status:  health.StatusUnhealthy,
+				Message: fmt.Sprintf("failed to read certificate %s", file),
+				Error:   err.Error(),
+			}
+		}
+		if earliestFile == "" || notAfter.Before(earliest) {
+			earliest, earliestFile = notAfter, file
+		}
+	}
+
+	if earliestFile == "" {
+		return health.CheckResult{
+			Status:  health.StatusHealthy,
+			Message: "no certificates configured",
+		}
+	}
+
+	remaining := earliest.Sub(c.now())
+	switch {
+	case remaining <= 0:
+		return health.CheckResult{
+			Status:  health.StatusUnhealthy,
+			Message: fmt.Sprintf("certificate %s expired at %s", earliestFile, earliest.UTC().Format(time.RFC3339)),
+		}
+	case remaining <= c.warnBefore:
+		return health.CheckResult{
+			Status:  health.StatusDegraded,
+			Message: fmt.Sprintf("certificate %s expires at %s", earliestFile, earliest.UTC().Format(time.RFC3339)),
+		}
+	default:
+		return health.CheckResult{
+			Status:  health.StatusHealthy,
+			Message: fmt.Sprintf("certificates valid until %s", earliest.UTC().Format(time.RFC3339)),
+		}
+	}
+}
+
+// certificateExpiry returns the earliest NotAfter of the certificates in a PEM
+// file, so an expiring intermediate in a chain is caught as well.
+func certificateExpiry(file string) (time.Time, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var earliest time.Time
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if earliest.IsZero() || cert.NotAfter.Before(earliest) {
+			earliest = cert.NotAfter
+		}
+	}
+
+	if earliest.IsZero() {
+		return time.Time{}, errors.New("no certificate found")
+	}
+	return earliest, nil
+}
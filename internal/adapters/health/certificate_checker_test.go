@@ -0,0 +1,126 @@
+package health
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"microservice/internal/platform/health"
+)
+
+// writeCertificate writes a self-signed PEM certificate valid until notAfter.
+func writeCertificate(t *testing.T, name string, notAfter time.Time) string {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    notAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	file := filepath.Join(t.TempDir(), name+".pem")
+	require.NoError(t, os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	return file
+}
+
+func TestCertificateChecker_Check(t *testing.T) {
+	now := time.Now()
+	valid := writeCertificate(t, "valid", now.Add(90*24*time.Hour))
+	expiring := writeCertificate(t, "expiring", now.Add(3*24*time.Hour))
+	expired := writeCertificate(t, "expired", now.Add(-time.Hour))
+
+	tests := []struct {
+		name          string
+		files         []string
+		expectStatus  health.Status
+		expectMessage string
+	}{
+		{name: "valid", files: []string{valid}, expectStatus: health.StatusHealthy, expectMessage: "certificates valid until"},
+		{name: "expiring_soon", files: []string{valid, expiring}, expectStatus: health.StatusDegraded, expectMessage: "certificate " + expiring + " expires at"},
+		{name: "expired", files: []string{expiring, expired}, expectStatus: health.StatusUnhealthy, expectMessage: "certificate " + expired + " expired at"},
+		{name: "none_configured", expectStatus: health.StatusHealthy, expectMessage: "no certificates configured"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := NewCertificateChecker("certificates", tt.files, 14*24*time.Hour)
+
+			result := checker.Check(context.Background())
+
+			assert.Equal(t, tt.expectStatus, result.Status)
+			assert.Contains(t, result.Message, tt.expectMessage)
+		})
+	}
+}
+
+func TestCertificateChecker_Check_TransitionsAsExpiryApproaches(t *testing.T) {
+	notAfter := time.Now().Add(30 * 24 * time.Hour).Truncate(time.Second)
+	checker := NewCertificateChecker("certificates", []string{writeCertificate(t, "server", notAfter)}, 7*24*time.Hour)
+
+	steps := []struct {
+		at     time.Time
+		status health.Status
+	}{
+		{at: notAfter.Add(-8 * 24 * time.Hour), status: health.StatusHealthy},
+		{at: notAfter.Add(-7 * 24 * time.Hour), status: health.StatusDegraded},
+		{at: notAfter.Add(-time.Second), status: health.StatusDegraded},
+		{at: notAfter, status: health.StatusUnhealthy},
+	}
+	for _, step := range steps {
+		checker.now = func() time.Time { return step.at }
+		assert.Equal(t, step.status, checker.Check(context.Background()).Status, step.at)
+	}
+}
+
+func TestCertificateChecker_Check_UsesEarliestCertificateInChain(t *testing.T) {
+	leaf, err := os.ReadFile(writeCertificate(t, "leaf", time.Now().Add(365*24*time.Hour)))
+	require.NoError(t, err)
+	intermediate, err := os.ReadFile(writeCertificate(t, "intermediate", time.Now().Add(24*time.Hour)))
+	require.NoError(t, err)
+	chain := filepath.Join(t.TempDir(), "chain.pem")
+	require.NoError(t, os.WriteFile(chain, append(leaf, intermediate...), 0o600))
+
+	result := NewCertificateChecker("certificates", []string{chain}, 7*24*time.Hour).Check(context.Background())
+
+	assert.Equal(t, health.StatusDegraded, result.Status)
+}
+
+func TestCertificateChecker_Check_UnreadableFile(t *testing.T) {
+	notPEM := filepath.Join(t.TempDir(), "not.pem")
+	require.NoError(t, os.WriteFile(notPEM, []byte("not a certificate"), 0o600))
+
+	tests := map[string]string{
+		"missing": filepath.Join(t.TempDir(), "missing.pem"),
+		"not_pem": notPEM,
+	}
+	for name, file := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := NewCertificateChecker("certificates", []string{file}, time.Hour).Check(context.Background())
+
+			assert.Equal(t, health.StatusUnhealthy, result.Status)
+			assert.Equal(t, "failed to read certificate "+file, result.Message)
+			assert.NotEmpty(t, result.Error)
+		})
+	}
+}
+
+func TestCertificateChecker_Name(t *testing.T) {
+	assert.Equal(t, "tls_certificates", NewCertificateChecker("tls_certificates", nil, 0).Name())
+}
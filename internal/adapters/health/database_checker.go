@@ -24,6 +24,13 @@ func (c *DatabaseChecker) Name() string {
 }
 
 func (c *DatabaseChecker) Check(ctx context.Context) health.CheckResult {
+	if c.db.InMemory() {
+		return health.CheckResult{
+			Status:  health.StatusDegraded,
+			Message: "database unavailable, serving data from memory",
+		}
+	}
+
 	db := c.db.Connection()
 	if db == nil {
 		return health.CheckResult{
@@ -41,6 +48,16 @@ func (c *DatabaseChecker) Check(ctx context.Context) health.CheckResult {
 		}
 	}
 
+	if replica := c.db.ReadConnection(); replica != nil && replica != db {
+		if err := replica.Ping(ctx); err != nil {
+			return health.CheckResult{
+				Status:  health.StatusUnhealthy,
+				Message: "database replica connection failed",
+				Error:   err.Error(),
+			}
+		}
+	}
+
 	return health.CheckResult{
 		Status:  health.StatusHealthy,
 		Message: "database connection healthy",
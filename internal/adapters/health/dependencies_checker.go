@@ -0,0 +1,87 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"microservice/internal/platform/health"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DependenciesChecker rolls several dependency checks, such as the readiness
+// endpoints of downstream services, up into one. The checks run in parallel
+// and their results are cached for the configured TTL, so frequent probes do
+// not fan out to every dependency each time. The result is unhealthy when a
+// required dependency is, degraded when any other dependency is not healthy,
+// and carries every dependency's result in Components.
+type DependenciesChecker struct {
+	name    string
+	manager *health.Manager
+}
+
+// NewDependenciesChecker combines checkers under name. A cacheTTL of zero runs
+// every check on each call.
+func NewDependenciesChecker(name string, cacheTTL time.Duration, checkers ...health.Checker) *DependenciesChecker {
+	manager := health.NewManager(health.WithCacheTTL(cacheTTL))
+	for _, checker := range checkers {
+		manager.Register(checker)
+	}
+	return &DependenciesChecker{
+		name:    name,
+		manager: manager,
+	}
+}
+
+// NewServiceReadinessChecker probes the readiness endpoints of the given
+// services, keyed by service name, through a DependenciesChecker.
+func NewServiceReadinessChecker(name string, cacheTTL time.Duration, endpoints map[string]string) *DependenciesChecker {
+	checkers := make([]health.Checker, 0, len(endpoints))
+	for service, endpoint := range endpoints {
+		checkers = append(checkers, NewAPIChecker(endpoint, service))
+	}
+	return NewDependenciesChecker(name, cacheTTL, checkers...)
+}
+
+// AddOptional adds a dependency whose failure degrades the result without
+// making it unhealthy.
+func (c *DependenciesChecker) AddOptional(checker health.Checker) {
+	c.manager.RegisterOptional(checker)
+}
+
+func (c *DependenciesChecker) Name() string {
+	return c.name
+}
+
+func (c *DependenciesChecker) Check(ctx context.Context) health.CheckResult {
+	results := c.manager.CheckAll(ctx)
+
+	var failed, impaired []string
+	for name, result := range results {
+		switch {
+		case result.Status == health.StatusHealthy:
+		case result.Status == health.StatusUnhealthy && !result.Optional:
+			failed = append(failed, name)
+		default:
+			impaired = append(impaired, name)
+		}
+	}
+	sort.Strings(failed)
+	sort.Strings(impaired)
+
+	healthy := len(results) - len(failed) - len(impaired)
+	result := health.CheckResult{
+		Status:     health.StatusHealthy,
+		Message:    fmt.Sprintf("%d of %d dependencies healthy", healthy, len(results)),
+		Components: results,
+	}
+	switch {
+	case len(failed) > 0:
+		result.Status = health.StatusUnhealthy
+		result.Error = "unhealthy: " + strings.Join(failed, ", ")
+	case len(impaired) > 0:
+		result.Status = health.StatusDegraded
+		result.Message += "; impaired: " + strings.Join(impaired, ", ")
+	}
+	return result
+}
@@ -0,0 +1,170 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"microservice/internal/platform/health"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubChecker returns a fixed status. When barrier is set, every call waits
+// until all stubs sharing it have been called, so sequential probing would
+// never get past the first one.
+type stubChecker struct {
+	name    string
+	status  health.Status
+	calls   atomic.Int32
+	barrier *sync.WaitGroup
+}
+
+func (c *stubChecker) Name() string {
+	return c.name
+}
+
+func (c *stubChecker) Check(ctx context.Context) health.CheckResult {
+	c.calls.Add(1)
+	if c.barrier != nil {
+		c.barrier.Done()
+		done := make(chan struct{})
+		go func() {
+			c.barrier.Wait()
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-ctx.Done():
+			return health.CheckResult{Status: health.StatusUnknown, Error: ctx.Err().Error()}
+		}
+	}
+	return health.CheckResult{Status: c.status, Message: string(c.status)}
+}
+
+func TestDependenciesChecker_RollsUpStatus(t *testing.T) {
+	tests := []struct {
+		name            string
+		statuses        []health.Status
+		optional        health.Status
+		expectedStatus  health.Status
+		expectedMessage string
+		expectedError   string
+	}{
+		{
+			name:            "all_healthy",
+			statuses:        []health.Status{health.StatusHealthy, health.StatusHealthy},
+			expectedStatus:  health.StatusHealthy,
+			expectedMessage: "2 of 2 dependencies healthy",
+		},
+		{
+			name:            "required_failure",
+			statuses:        []health.Status{health.StatusHealthy, health.StatusUnhealthy, health.StatusUnhealthy},
+			expectedStatus:  health.StatusUnhealthy,
+			expectedMessage: "1 of 3 dependencies healthy",
+			expectedError:   "unhealthy: dep-1, dep-2",
+		},
+		{
+			name:            "degraded_dependency",
+			statuses:        []health.Status{health.StatusHealthy, health.StatusDegraded},
+			expectedStatus:  health.StatusDegraded,
+			expectedMessage: "1 of 2 dependencies healthy; impaired: dep-1",
+		},
+		{
+			name:            "optional_failure_degrades",
+			statuses:        []health.Status{health.StatusHealthy},
+			optional:        health.StatusUnhealthy,
+			expectedStatus:  health.StatusDegraded,
+			expectedMessage: "1 of 2 dependencies healthy; impaired: optional",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkers := make([]health.Checker, len(tt.statuses))
+			for i, status := range tt.statuses {
+				checkers[i] = &stubChecker{name: fmt.Sprintf("dep-%d", i), status: status}
+			}
+			checker := NewDependenciesChecker("downstream", 0, checkers...)
+			if tt.optional != "" {
+				checker.AddOptional(&stubChecker{name: "optional", status: tt.optional})
+			}
+
+			result := checker.Check(context.Background())
+
+			assert.Equal(t, "downstream", checker.Name())
+			assert.Equal(t, tt.expectedStatus, result.Status)
+			assert.Equal(t, tt.expectedMessage, result.Message)
+			assert.Equal(t, tt.expectedError, result.Error)
+			expectedComponents := len(checkers)
+			if tt.optional != "" {
+				expectedComponents++
+			}
+			assert.Len(t, result.Components, expectedComponents)
+			for i, status := range tt.statuses {
+				assert.Equal(t, status, result.Components["dep-"+string(rune('0'+i))].Status)
+			}
+		})
+	}
+}
+
+func TestDependenciesChecker_ProbesInParallel(t *testing.T) {
+	const n = 4
+	var barrier sync.WaitGroup
+	barrier.Add(n)
+	checkers := make([]health.Checker, n)
+	for i := range checkers {
+		checkers[i] = &stubChecker{name: fmt.Sprintf("dep-%d", i), status: health.StatusHealthy, barrier: &barrier}
+	}
+	checker := NewDependenciesChecker("downstream", 0, checkers...)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	result := checker.Check(ctx)
+
+	assert.Equal(t, health.StatusHealthy, result.Status, "every probe must be in flight at once to pass the barrier")
+}
+
+func TestDependenciesChecker_CachesResults(t *testing.T) {
+	healthy := &stubChecker{name: "healthy", status: health.StatusHealthy}
+	failing := &stubChecker{name: "failing", status: health.StatusUnhealthy}
+	checker := NewDependenciesChecker("downstream", time.Hour, healthy, failing)
+
+	for range 3 {
+		result := checker.Check(context.Background())
+		require.Equal(t, health.StatusUnhealthy, result.Status)
+	}
+
+	assert.Equal(t, int32(1), healthy.calls.Load())
+	assert.Equal(t, int32(1), failing.calls.Load())
+}
+
+func TestServiceReadinessChecker(t *testing.T) {
+	ready := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ready.Close()
+	notReady := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer notReady.Close()
+
+	checker := NewServiceReadinessChecker("services", 0, map[string]string{
+		"billing": ready.URL + "/health/ready",
+		"search":  notReady.URL + "/health/ready",
+	})
+
+	result := checker.Check(context.Background())
+
+	assert.Equal(t, health.StatusUnhealthy, result.Status)
+	assert.Equal(t, "unhealthy: search", result.Error)
+	assert.Equal(t, health.StatusHealthy, result.Components["billing"].Status)
+	assert.Equal(t, health.StatusUnhealthy, result.Components["search"].Status)
+	assert.Equal(t, "api returned status 503", result.Components["search"].Message)
+}
@@ -0,0 +1,66 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"microservice/internal/platform/health"
+)
+
+// errDiskUsageUnsupported is returned by diskUsage on platforms without a
+// statfs implementation.
+var errDiskUsageUnsupported = errors.New("disk usage is not supported on this platform")
+
+// DiskChecker reports unhealthy when the filesystem holding path has less than
+// minFreeBytes available to the service. On platforms where free space cannot
+// be read it reports unknown, which does not fail readiness.
+type DiskChecker struct {
+	name         string
+	path         string
+	minFreeBytes uint64
+	usage        func(path string) (free, total uint64, err error)
+}
+
+func NewDiskChecker(path string, minFreeBytes uint64, name string) *DiskChecker {
+	return &DiskChecker{
+		name:         name,
+		path:         path,
+		minFreeBytes: minFreeBytes,
+		usage:        diskUsage,
+	}
+}
+
+func (c *DiskChecker) Name() string {
+	return c.name
+}
+
+func (c *DiskChecker) Check(ctx context.Context) health.CheckResult {
+	_ = ctx
+	free, total, err := c.usage(c.path)
+	if errors.Is(err, errDiskUsageUnsupported) {
+		return health.CheckResult{
+			Status:  health.StatusUnknown,
+			Message: fmt.Sprintf("cannot read free space of %s", c.path),
+			Error:   err.Error(),
+		}
+	}
+	if err != nil {
+		return health.CheckResult{
+			Status:  health.StatusUnhealthy,
+			Message: fmt.Sprintf("failed to read free space of %s", c.path),
+			Error:   err.Error(),
+		}
+	}
+
+	message := fmt.Sprintf("%d of %d bytes free on %s", free, total, c.path)
+	if free < c.minFreeBytes {
+		return health.CheckResult{
+			Status:  health.StatusUnhealthy,
+			Message: fmt.Sprintf("%s, below the minimum of %d", message, c.minFreeBytes),
+		}
+	}
+	return health.CheckResult{
+		Status:  health.StatusHealthy,
+		Message: message,
+	}
+}
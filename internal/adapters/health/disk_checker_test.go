@@ -0,0 +1,64 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"math"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"microservice/internal/platform/health"
+)
+
+func TestDiskChecker_Check(t *testing.T) {
+	tests := []struct {
+		name          string
+		free, total   uint64
+		err           error
+		expectStatus  health.Status
+		expectMessage string
+	}{
+		{name: "enough_space", free: 2048, total: 4096, expectStatus: health.StatusHealthy, expectMessage: "2048 of 4096 bytes free on /data"},
+		{name: "at_minimum", free: 1024, total: 4096, expectStatus: health.StatusHealthy, expectMessage: "1024 of 4096 bytes free on /data"},
+		{name: "below_minimum", free: 1023, total: 4096, expectStatus: health.StatusUnhealthy, expectMessage: "1023 of 4096 bytes free on /data, below the minimum of 1024"},
+		{name: "statfs_error", err: errors.New("no such file or directory"), expectStatus: health.StatusUnhealthy, expectMessage: "failed to read free space of /data"},
+		{name: "unsupported_platform", err: errDiskUsageUnsupported, expectStatus: health.StatusUnknown, expectMessage: "cannot read free space of /data"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := NewDiskChecker("/data", 1024, "disk")
+			checker.usage = func(path string) (uint64, uint64, error) {
+				assert.Equal(t, "/data", path)
+				return tt.free, tt.total, tt.err
+			}
+
+			result := checker.Check(context.Background())
+
+			assert.Equal(t, "disk", checker.Name())
+			assert.Equal(t, tt.expectStatus, result.Status)
+			assert.Equal(t, tt.expectMessage, result.Message)
+		})
+	}
+}
+
+func TestDiskChecker_Statfs(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("disk usage is only read on linux")
+	}
+	dir := t.TempDir()
+
+	result := NewDiskChecker(dir, 0, "disk").Check(context.Background())
+	assert.Equal(t, health.StatusHealthy, result.Status)
+
+	result = NewDiskChecker(dir, math.MaxUint64, "disk").Check(context.Background())
+	assert.Equal(t, health.StatusUnhealthy, result.Status)
+	assert.Contains(t, result.Message, "below the minimum")
+
+	result = NewDiskChecker(filepath.Join(dir, "missing"), 0, "disk").Check(context.Background())
+	assert.Equal(t, health.StatusUnhealthy, result.Status)
+	assert.NotEmpty(t, result.Error)
+}
@@ -0,0 +1,16 @@
+//go:build linux
+
+package health
+
+import "syscall"
+
+// diskUsage returns the bytes available to unprivileged users and the total
+// size of the filesystem holding path.
+func diskUsage(path string) (free, total uint64, err error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, 0, err
+	}
+	blockSize := uint64(stat.Bsize)
+	return stat.Bavail * blockSize, stat.Blocks * blockSize, nil
+}
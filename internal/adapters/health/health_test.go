@@ -77,6 +77,35 @@ func TestDatabaseChecker_Check_ConnectionFails(t *testing.T) {
 	assert.Equal(t, "database connection is not initialized", result.Message)
 }
 
+func TestCheckers_InMemoryFallback(t *testing.T) {
+	cfg := &config.DatabaseConfig{
+		BaseConfig: config.BaseConfig{Environment: config.EnvDevelopment},
+		Postgres: config.PostgresConfig{
+			Host: "127.0.0.1",
+			Port: 1,
+		},
+		MemoryFallback: true,
+	}
+	db := database.NewDatabaseLifecycle(cfg, logger.NewNop())
+	require.NoError(t, db.Start(context.Background()))
+
+	for _, checker := range []health.Checker{NewDatabaseChecker(db, "db"), NewMigrationChecker(db, 1, "migrations")} {
+		result := checker.Check(context.Background())
+		assert.Equal(t, health.StatusDegraded, result.Status, checker.Name())
+	}
+}
+
+func TestMigrationChecker_Check_NoConnection(t *testing.T) {
+	db := database.NewDatabaseLifecycle(&config.DatabaseConfig{}, logger.NewNop())
+	checker := NewMigrationChecker(db, 1, "migrations")
+
+	result := checker.Check(context.Background())
+
+	assert.Equal(t, "migrations", checker.Name())
+	assert.Equal(t, health.StatusUnhealthy, result.Status)
+	assert.Equal(t, "database connection is not initialized", result.Message)
+}
+
 func TestNewAPIChecker(t *testing.T) {
 	url := "https://example.com"
 	checker := NewAPIChecker(url, "test-api")
@@ -189,6 +218,7 @@ func TestHealthCheckers_InterfaceCompliance(t *testing.T) {
 	var checkers []health.Checker
 
 	checkers = append(checkers, NewDatabaseChecker(db, "db"))
+	checkers = append(checkers, NewMigrationChecker(db, 1, "migrations"))
 	checkers = append(checkers, NewAPIChecker("https://example.com", "api"))
 	checkers = append(checkers, NewMemoryChecker())
 
@@ -309,3 +339,74 @@ func (s *DatabaseCheckerTestSuite) TestDatabaseChecker_Check_Success() {
 	s.Assert().Equal("database connection healthy", result.Message)
 	s.Assert().Empty(result.Error)
 }
+
+func (s *DatabaseCheckerTestSuite) TestDatabaseChecker_Check_ReplicaFails() {
+	cfg := *s.dbLifecycle.Config()
+	cfg.Postgres.ReplicaHost = cfg.Postgres.Host
+	lifecycle := database.NewDatabaseLifecycle(&cfg, logger.NewNop())
+	ctx := context.Background()
+	s.Require().NoError(lifecycle.Start(ctx))
+	defer func() { _ = lifecycle.Stop(ctx) }()
+
+	checker := NewDatabaseChecker(lifecycle, "test-db-replica")
+	s.Assert().Equal(health.StatusHealthy, checker.Check(ctx).Status)
+
+	s.Require().NoError(lifecycle.ReadConnection().Close())
+	result := checker.Check(ctx)
+
+	s.Assert().Equal(health.StatusUnhealthy, result.Status)
+	s.Assert().Equal("database replica connection failed", result.Message)
+	s.Assert().NotEmpty(result.Error)
+}
+
+func (s *DatabaseCheckerTestSuite) setMigrationState(version uint, dirty bool) {
+	db := s.dbLifecycle.Connection()
+	_, err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (version bigint NOT NULL PRIMARY KEY, dirty boolean NOT NULL)`)
+	s.Require().NoError(err)
+	_, err = db.Exec(`TRUNCATE schema_migrations`)
+	s.Require().NoError(err)
+	_, err = db.Exec(`INSERT INTO schema_migrations (version, dirty) VALUES ($1, $2)`, version, dirty)
+	s.Require().NoError(err)
+}
+
+func (s *DatabaseCheckerTestSuite) TestMigrationChecker_Check_FullyMigrated() {
+	s.setMigrationState(2, false)
+	checker := NewMigrationChecker(s.dbLifecycle, 2, "migrations")
+
+	result := checker.Check(context.Background())
+
+	s.Assert().Equal(health.StatusHealthy, result.Status)
+	s.Assert().Equal("schema at version 2", result.Message)
+}
+
+func (s *DatabaseCheckerTestSuite) TestMigrationChecker_Check_PendingMigrations() {
+	s.setMigrationState(1, false)
+	checker := NewMigrationChecker(s.dbLifecycle, 2, "migrations")
+
+	result := checker.Check(context.Background())
+
+	s.Assert().Equal(health.StatusUnhealthy, result.Status)
+	s.Assert().Equal("schema at version 1, expected 2", result.Message)
+}
+
+func (s *DatabaseCheckerTestSuite) TestMigrationChecker_Check_DirtyMigration() {
+	s.setMigrationState(2, true)
+	checker := NewMigrationChecker(s.dbLifecycle, 2, "migrations")
+
+	result := checker.Check(context.Background())
+
+	s.Assert().Equal(health.StatusUnhealthy, result.Status)
+	s.Assert().Equal("migration 2 failed and left the schema dirty", result.Message)
+}
+
+func (s *DatabaseCheckerTestSuite) TestMigrationChecker_Check_NotMigrated() {
+	_, err := s.dbLifecycle.Connection().Exec(`DROP TABLE IF EXISTS schema_migrations`)
+	s.Require().NoError(err)
+	checker := NewMigrationChecker(s.dbLifecycle, 1, "migrations")
+
+	result := checker.Check(context.Background())
+
+	s.Assert().Equal(health.StatusUnhealthy, result.Status)
+	s.Assert().Equal("failed to read migration status", result.Message)
+	s.Assert().NotEmpty(result.Error)
+}
@@ -0,0 +1,85 @@
+package health
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"microservice/internal/platform/health"
+
+	"microservice/internal/adapters/database"
+)
+
+// MigrationChecker reports unhealthy when the schema_migrations table written
+// by golang-migrate is missing, dirty, or behind the version the binary expects.
+type MigrationChecker struct {
+	db              *database.Lifecycle
+	expectedVersion uint
+	name            string
+}
+
+func NewMigrationChecker(db *database.Lifecycle, expectedVersion uint, name string) *MigrationChecker {
+	return &MigrationChecker{
+		db:              db,
+		expectedVersion: expectedVersion,
+		name:            name,
+	}
+}
+
+func (c *MigrationChecker) Name() string {
+	return c.name
+}
+
+func (c *MigrationChecker) Check(ctx context.Context) health.CheckResult {
+	if c.db.InMemory() {
+		return health.CheckResult{
+			Status:  health.StatusDegraded,
+			Message: "database unavailable, no migrations applied to in-memory data",
+		}
+	}
+
+	db := c.db.Connection()
+	if db == nil {
+		return health.CheckResult{
+			Status:  health.StatusUnhealthy,
+			Message: "database connection is not initialized",
+		}
+	}
+
+	var (
+		version uint
+		dirty   bool
+	)
+	err := db.QueryRowContext(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &dirty)
+	if errors.Is(err, sql.ErrNoRows) {
+		return health.CheckResult{
+			Status:  health.StatusUnhealthy,
+			Message: fmt.Sprintf("no migrations applied, expected version %d", c.expectedVersion),
+		}
+	}
+	if err != nil {
+		return health.CheckResult{
+			Status:  health.StatusUnhealthy,
+			Message: "failed to read migration status",
+			Error:   err.Error(),
+		}
+	}
+
+	switch {
+	case dirty:
+		return health.CheckResult{
+			Status:  health.StatusUnhealthy,
+			Message: fmt.Sprintf("migration %d failed and left the schema dirty", version),
+		}
+	case version < c.expectedVersion:
+		return health.CheckResult{
+			Status:  health.StatusUnhealthy,
+			Message: fmt.Sprintf("schema at version %d, expected %d", version, c.expectedVersion),
+		}
+	default:
+		return health.CheckResult{
+			Status:  health.StatusHealthy,
+			Message: fmt.Sprintf("schema at version %d", version),
+		}
+	}
+}
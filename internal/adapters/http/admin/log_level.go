@@ -0,0 +1,55 @@
+package admin
+
+import (
+	"encoding/json"
+	httpErrors "microservice/internal/platform/http"
+	"microservice/internal/platform/logger"
+	"net/http"
+
+	"microservice/internal/adapters/http/response"
+)
+
+type LogLevelHandler struct {
+	setter logger.LevelSetter
+}
+
+func NewLogLevelHandler(setter logger.LevelSetter) *LogLevelHandler {
+	return &LogLevelHandler{
+		setter: setter,
+	}
+}
+
+type LogLevelRequest struct {
+	Level string `json:"level"`
+}
+
+type LogLevelResponse struct {
+	Level logger.Level `json:"level"`
+}
+
+func (h *LogLevelHandler) GetLevel(w http.ResponseWriter, _ *http.Request) {
+	response.RespondJSON(w, http.StatusOK, LogLevelResponse{Level: h.setter.Level()})
+}
+
+func (h *LogLevelHandler) SetLevel(w http.ResponseWriter, r *http.Request) error {
+	var req LogLevelRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return httpErrors.NewBadRequest("invalid request payload", err)
+	}
+
+	var level logger.Level
+	if err := level.Decode(req.Level); err != nil {
+		return httpErrors.NewBadRequest("invalid log level", err)
+	}
+
+	previous := h.setter.Level()
+	h.setter.SetLevel(level)
+
+	logger.FromContext(r.Context()).Warn("Log level changed",
+		logger.String("previous", string(previous)),
+		logger.String("level", string(level)),
+	)
+
+	response.RespondJSON(w, http.StatusOK, LogLevelResponse{Level: level})
+	return nil
+}
@@ -0,0 +1,88 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	httpErrors "microservice/internal/platform/http"
+	"microservice/internal/platform/logger"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogLevelHandler_GetLevel(t *testing.T) {
+	setter := logger.NewNop().(logger.LevelSetter)
+	setter.SetLevel(logger.LevelWarn)
+	handler := NewLogLevelHandler(setter)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/log-level", nil)
+	w := httptest.NewRecorder()
+
+	handler.GetLevel(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"level":"warn"}`, w.Body.String())
+}
+
+func TestLogLevelHandler_SetLevel(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		expectedLevel  logger.Level
+		expectedStatus int
+	}{
+		{
+			name:          "set_debug",
+			body:          `{"level":"debug"}`,
+			expectedLevel: logger.LevelDebug,
+		},
+		{
+			name:          "case_insensitive",
+			body:          `{"level":"ERROR"}`,
+			expectedLevel: logger.LevelError,
+		},
+		{
+			name:           "invalid_level",
+			body:           `{"level":"verbose"}`,
+			expectedLevel:  logger.LevelInfo,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid_json",
+			body:           `not json`,
+			expectedLevel:  logger.LevelInfo,
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setter := logger.NewNop().(logger.LevelSetter)
+			handler := NewLogLevelHandler(setter)
+
+			req := httptest.NewRequest(http.MethodPut, "/admin/log-level", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+
+			err := handler.SetLevel(w, req)
+
+			assert.Equal(t, tt.expectedLevel, setter.Level())
+			if tt.expectedStatus != 0 {
+				var httpErr *httpErrors.Error
+				require.True(t, errors.As(err, &httpErr))
+				assert.Equal(t, tt.expectedStatus, httpErr.StatusCode)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusOK, w.Code)
+
+			var resp LogLevelResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			assert.Equal(t, tt.expectedLevel, resp.Level)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package admin
+
+import (
+	"encoding/json"
+	httpErrors "microservice/internal/platform/http"
+	"microservice/internal/platform/logger"
+	"net/http"
+	"sync/atomic"
+
+	"microservice/internal/adapters/http/response"
+)
+
+// MaintenanceHandler reads and flips the flag behind the Maintenance
+// middleware, so API traffic can be paused without a redeploy.
+type MaintenanceHandler struct {
+	flag *atomic.Bool
+}
+
+func NewMaintenanceHandler(flag *atomic.Bool) *MaintenanceHandler {
+	return &MaintenanceHandler{
+		flag: flag,
+	}
+}
+
+// Flag returns the flag to hand to the Maintenance middleware.
+func (h *MaintenanceHandler) Flag() *atomic.Bool {
+	return h.flag
+}
+
+type MaintenanceRequest struct {
+	Enabled *bool `json:"enabled"`
+}
+
+type MaintenanceResponse struct {
+	Enabled bool `json:"enabled"`
+}
+
+func (h *MaintenanceHandler) GetMode(w http.ResponseWriter, _ *http.Request) {
+	response.RespondJSON(w, http.StatusOK, MaintenanceResponse{Enabled: h.flag.Load()})
+}
+
+func (h *MaintenanceHandler) SetMode(w http.ResponseWriter, r *http.Request) error {
+	var req MaintenanceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return httpErrors.NewBadRequest("invalid request payload", err)
+	}
+	if req.Enabled == nil {
+		return httpErrors.NewBadRequest("enabled is required", nil)
+	}
+
+	previous := h.flag.Swap(*req.Enabled)
+
+	logger.FromContext(r.Context()).Warn("Maintenance mode changed",
+		logger.Any("previous", previous),
+		logger.Any("enabled", *req.Enabled),
+	)
+
+	response.RespondJSON(w, http.StatusOK, MaintenanceResponse{Enabled: *req.Enabled})
+	return nil
+}
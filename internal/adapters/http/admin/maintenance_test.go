@@ -0,0 +1,81 @@
+package admin
+
+import (
+	"errors"
+	httpErrors "microservice/internal/platform/http"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaintenanceHandler_GetMode(t *testing.T) {
+	flag := new(atomic.Bool)
+	flag.Store(true)
+	handler := NewMaintenanceHandler(flag)
+
+	w := httptest.NewRecorder()
+	handler.GetMode(w, httptest.NewRequest(http.MethodGet, "/admin/maintenance", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"enabled":true}`, w.Body.String())
+	assert.Same(t, flag, handler.Flag())
+}
+
+func TestMaintenanceHandler_SetMode(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		expected       bool
+		expectedStatus int
+	}{
+		{
+			name:     "enable",
+			body:     `{"enabled":true}`,
+			expected: true,
+		},
+		{
+			name:     "disable",
+			body:     `{"enabled":false}`,
+			expected: false,
+		},
+		{
+			name:           "missing_enabled",
+			body:           `{}`,
+			expected:       true,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid_json",
+			body:           `not json`,
+			expected:       true,
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flag := new(atomic.Bool)
+			flag.Store(true)
+			handler := NewMaintenanceHandler(flag)
+
+			req := httptest.NewRequest(http.MethodPut, "/admin/maintenance", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+			err := handler.SetMode(w, req)
+
+			if tt.expectedStatus != 0 {
+				var httpErr *httpErrors.Error
+				require.True(t, errors.As(err, &httpErr))
+				assert.Equal(t, tt.expectedStatus, httpErr.StatusCode)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, http.StatusOK, w.Code)
+			}
+			assert.Equal(t, tt.expected, flag.Load())
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package admin
+
+import (
+	"net/http"
+	"runtime"
+
+	"microservice/internal/adapters/http/response"
+	"microservice/internal/version"
+)
+
+// shortCommitLength matches the abbreviation used by git rev-parse --short.
+const shortCommitLength = 7
+
+type VersionResponse struct {
+	version.BuildInfo
+	GitCommitShort string `json:"git_commit_short,omitempty"`
+	GoVersion      string `json:"go_version"`
+	OS             string `json:"os"`
+	Arch           string `json:"arch"`
+}
+
+// Version reports the build information of the running binary, so a
+// deployment can be verified. It has no dependencies and is always mounted.
+func Version(w http.ResponseWriter, _ *http.Request) {
+	info := version.Info()
+	resp := VersionResponse{
+		BuildInfo: info,
+		GoVersion: runtime.Version(),
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+	}
+	if isFullSHA(info.GitCommit) {
+		resp.GitCommitShort = info.GitCommit[:shortCommitLength]
+	}
+	response.RespondJSON(w, http.StatusOK, resp)
+}
+
+// isFullSHA reports whether commit is a complete SHA-1 or SHA-256 object name.
+func isFullSHA(commit string) bool {
+	if len(commit) != 40 && len(commit) != 64 {
+		return false
+	}
+	for _, c := range commit {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') && (c < 'A' || c > 'F') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,62 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"testing"
+
+	"microservice/internal/version"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersion(t *testing.T) {
+	tests := []struct {
+		name          string
+		gitCommit     string
+		expectedShort string
+	}{
+		{name: "full_sha1", gitCommit: "0123456789abcdef0123456789abcdef01234567", expectedShort: "0123456"},
+		{name: "full_sha256", gitCommit: "ABCDEF0123456789abcdef0123456789abcdef0123456789abcdef0123456789", expectedShort: "ABCDEF0"},
+		{name: "already_short", gitCommit: "abc123d"},
+		{name: "unknown", gitCommit: "unknown"},
+		{name: "not_hex", gitCommit: "zzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzz"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			originalVersion, originalBuildTime, originalGitCommit := version.Version, version.BuildTime, version.GitCommit
+			defer func() {
+				version.Version, version.BuildTime, version.GitCommit = originalVersion, originalBuildTime, originalGitCommit
+			}()
+			version.Version = "1.4.0"
+			version.BuildTime = "2025-08-01T10:00:00Z"
+			version.GitCommit = tt.gitCommit
+
+			req := httptest.NewRequest(http.MethodGet, "/version", nil)
+			w := httptest.NewRecorder()
+
+			Version(w, req)
+
+			require.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+			var body map[string]any
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			assert.Equal(t, "1.4.0", body["version"])
+			assert.Equal(t, "2025-08-01T10:00:00Z", body["build_time"])
+			assert.Equal(t, tt.gitCommit, body["git_commit"])
+			assert.Equal(t, runtime.Version(), body["go_version"])
+			assert.Equal(t, runtime.GOOS, body["os"])
+			assert.Equal(t, runtime.GOARCH, body["arch"])
+			if tt.expectedShort == "" {
+				assert.NotContains(t, body, "git_commit_short")
+			} else {
+				assert.Equal(t, tt.expectedShort, body["git_commit_short"])
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package events
+
+import "sync"
+
+// Event is a single server-sent event. Type and ID are optional; Data is sent
+// as is, one data line per line of text.
+type Event struct {
+	ID   string
+	Type string
+	Data string
+}
+
+// Broadcaster fans published events out to every subscriber. Each subscriber
+// has its own buffered channel; one whose buffer is full is dropped instead of
+// blocking Publish, and its channel is closed so the stream ends.
+type Broadcaster struct {
+	buffer      int
+	subscribers map[chan Event]struct{}
+	closed      bool
+	mu          sync.Mutex
+}
+
+func NewBroadcaster(buffer int) *Broadcaster {
+	if buffer <= 0 {
+		buffer = 1
+	}
+
+	return &Broadcaster{
+		buffer:      buffer,
+		subscribers: make(map[chan Event]struct{}),
+	}
+}
+
+// Subscribe registers a new subscriber. The returned channel is closed when
+// the subscriber is dropped, unsubscribed or the broadcaster is closed.
+// unsubscribe is safe to call more than once.
+func (b *Broadcaster) Subscribe() (events <-chan Event, unsubscribe func()) {
+	ch := make(chan Event, b.buffer)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		close(ch)
+		return ch, func() {}
+	}
+	b.subscribers[ch] = struct{}{}
+
+	return ch, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.remove(ch)
+	}
+}
+
+// Publish delivers event to every subscriber without blocking.
+func (b *Broadcaster) Publish(event Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+			b.remove(ch)
+		}
+	}
+}
+
+// Subscribers returns the number of connected subscribers.
+func (b *Broadcaster) Subscribers() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return len(b.subscribers)
+}
+
+// Close ends every subscription and rejects new ones, so open streams finish
+// and the HTTP server can drain.
+func (b *Broadcaster) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	for ch := range b.subscribers {
+		b.remove(ch)
+	}
+}
+
+func (b *Broadcaster) remove(ch chan Event) {
+	if _, ok := b.subscribers[ch]; !ok {
+		return
+	}
+	delete(b.subscribers, ch)
+	close(ch)
+}
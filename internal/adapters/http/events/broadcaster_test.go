@@ -0,0 +1,73 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBroadcaster_PublishReachesEverySubscriber(t *testing.T) {
+	b := NewBroadcaster(4)
+	first, unsubscribeFirst := b.Subscribe()
+	defer unsubscribeFirst()
+	second, unsubscribeSecond := b.Subscribe()
+	defer unsubscribeSecond()
+
+	b.Publish(Event{Type: "created", Data: "1"})
+
+	assert.Equal(t, Event{Type: "created", Data: "1"}, <-first)
+	assert.Equal(t, Event{Type: "created", Data: "1"}, <-second)
+}
+
+func TestBroadcaster_DropsSlowSubscriber(t *testing.T) {
+	b := NewBroadcaster(1)
+	slow, unsubscribeSlow := b.Subscribe()
+	defer unsubscribeSlow()
+	fast, unsubscribeFast := b.Subscribe()
+	defer unsubscribeFast()
+
+	b.Publish(Event{Data: "1"})
+	require.Equal(t, Event{Data: "1"}, <-fast)
+	b.Publish(Event{Data: "2"})
+
+	// The slow subscriber still gets what was buffered, then its channel ends.
+	assert.Equal(t, Event{Data: "1"}, <-slow)
+	_, open := <-slow
+	assert.False(t, open)
+
+	assert.Equal(t, Event{Data: "2"}, <-fast)
+	assert.Equal(t, 1, b.Subscribers())
+}
+
+func TestBroadcaster_Unsubscribe(t *testing.T) {
+	b := NewBroadcaster(1)
+	events, unsubscribe := b.Subscribe()
+	require.Equal(t, 1, b.Subscribers())
+
+	unsubscribe()
+	unsubscribe()
+
+	_, open := <-events
+	assert.False(t, open)
+	assert.Equal(t, 0, b.Subscribers())
+	assert.NotPanics(t, func() { b.Publish(Event{Data: "ignored"}) })
+}
+
+func TestBroadcaster_Close(t *testing.T) {
+	b := NewBroadcaster(1)
+	events, unsubscribe := b.Subscribe()
+	defer unsubscribe()
+
+	b.Close()
+
+	_, open := <-events
+	assert.False(t, open)
+	assert.Equal(t, 0, b.Subscribers())
+
+	late, unsubscribeLate := b.Subscribe()
+	defer unsubscribeLate()
+	_, open = <-late
+	assert.False(t, open)
+	assert.Equal(t, 0, b.Subscribers())
+}
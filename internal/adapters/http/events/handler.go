@@ -0,0 +1,108 @@
+package events
+
+import (
+	"fmt"
+	"microservice/internal/platform/logger"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const defaultHeartbeat = 15 * time.Second
+
+type Handler struct {
+	broadcaster *Broadcaster
+	heartbeat   time.Duration
+}
+
+// NewHandler creates the SSE handler. A non-positive heartbeat falls back to
+// 15 seconds.
+func NewHandler(broadcaster *Broadcaster, heartbeat time.Duration) *Handler {
+	if heartbeat <= 0 {
+		heartbeat = defaultHeartbeat
+	}
+
+	return &Handler{
+		broadcaster: broadcaster,
+		heartbeat:   heartbeat,
+	}
+}
+
+// Stream serves published events as text/event-stream until the client
+// disconnects, the subscriber is dropped for falling behind, or the
+// broadcaster is closed on shutdown. Comment lines are sent as heartbeats so
+// idle connections are not cut by proxies.
+func (h *Handler) Stream(w http.ResponseWriter, r *http.Request) {
+	rc := http.NewResponseController(w)
+	// Streams outlive the server's write timeout.
+	_ = rc.SetWriteDeadline(time.Time{})
+
+	events, unsubscribe := h.broadcaster.Subscribe()
+	defer unsubscribe()
+
+	header := w.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	header.Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	contextLogger := logger.FromContext(r.Context())
+	if err := h.send(w, rc, ": connected\n\n"); err != nil {
+		contextLogger.Warn("Event stream does not support flushing", logger.Error(err))
+		return
+	}
+
+	ticker := time.NewTicker(h.heartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+			if err := h.send(w, rc, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		case event, ok := <-events:
+			if !ok {
+				contextLogger.Debug("Event stream closed by server")
+				return
+			}
+			if err := h.send(w, rc, format(event)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (h *Handler) send(w http.ResponseWriter, rc *http.ResponseController, message string) error {
+	if _, err := fmt.Fprint(w, message); err != nil {
+		return err
+	}
+	return rc.Flush()
+}
+
+// lineBreaks normalizes every SSE line terminator to \n.
+var lineBreaks = strings.NewReplacer("\r\n", "\n", "\r", "\n")
+
+// format encodes event in the SSE wire format. Line breaks in ID and Type are
+// removed so they cannot inject extra fields.
+func format(event Event) string {
+	var b strings.Builder
+	if id := singleLine(event.ID); id != "" {
+		fmt.Fprintf(&b, "id: %s\n", id)
+	}
+	if eventType := singleLine(event.Type); eventType != "" {
+		fmt.Fprintf(&b, "event: %s\n", eventType)
+	}
+	for _, line := range strings.Split(lineBreaks.Replace(event.Data), "\n") {
+		fmt.Fprintf(&b, "data: %s\n", line)
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+func singleLine(s string) string {
+	return strings.ReplaceAll(lineBreaks.Replace(s), "\n", "")
+}
@@ -0,0 +1,112 @@
+package events
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func openStream(t *testing.T, ctx context.Context, url string) (*http.Response, *bufio.Reader) {
+	t.Helper()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = resp.Body.Close() })
+	return resp, bufio.NewReader(resp.Body)
+}
+
+// readMessage reads one SSE message, up to and excluding the blank line.
+func readMessage(t *testing.T, reader *bufio.Reader) string {
+	t.Helper()
+	var lines []string
+	for {
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		line = strings.TrimSuffix(line, "\n")
+		if line == "" {
+			return strings.Join(lines, "\n")
+		}
+		lines = append(lines, line)
+	}
+}
+
+func waitForSubscribers(t *testing.T, b *Broadcaster, n int) {
+	t.Helper()
+	require.Eventually(t, func() bool { return b.Subscribers() == n }, time.Second, 5*time.Millisecond)
+}
+
+func TestHandler_Stream_SendsEvents(t *testing.T) {
+	b := NewBroadcaster(4)
+	server := httptest.NewServer(http.HandlerFunc(NewHandler(b, time.Minute).Stream))
+	t.Cleanup(server.Close)
+
+	resp, reader := openStream(t, context.Background(), server.URL)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	assert.Equal(t, "no-cache", resp.Header.Get("Cache-Control"))
+	assert.Equal(t, ": connected", readMessage(t, reader))
+	waitForSubscribers(t, b, 1)
+
+	b.Publish(Event{ID: "7", Type: "entity.created", Data: "{\"id\":\"a\"}\nsecond line"})
+
+	assert.Equal(t, "id: 7\nevent: entity.created\ndata: {\"id\":\"a\"}\ndata: second line", readMessage(t, reader))
+}
+
+func TestHandler_Stream_SendsHeartbeats(t *testing.T) {
+	b := NewBroadcaster(1)
+	server := httptest.NewServer(http.HandlerFunc(NewHandler(b, 20*time.Millisecond).Stream))
+	t.Cleanup(server.Close)
+
+	_, reader := openStream(t, context.Background(), server.URL)
+	require.Equal(t, ": connected", readMessage(t, reader))
+
+	assert.Equal(t, ": heartbeat", readMessage(t, reader))
+	assert.Equal(t, ": heartbeat", readMessage(t, reader))
+}
+
+func TestHandler_Stream_ClientDisconnect(t *testing.T) {
+	b := NewBroadcaster(1)
+	server := httptest.NewServer(http.HandlerFunc(NewHandler(b, time.Minute).Stream))
+	t.Cleanup(server.Close)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	_, reader := openStream(t, ctx, server.URL)
+	require.Equal(t, ": connected", readMessage(t, reader))
+	waitForSubscribers(t, b, 1)
+
+	cancel()
+
+	waitForSubscribers(t, b, 0)
+}
+
+func TestHandler_Stream_EndsWhenBroadcasterCloses(t *testing.T) {
+	b := NewBroadcaster(1)
+	server := httptest.NewServer(http.HandlerFunc(NewHandler(b, time.Minute).Stream))
+	t.Cleanup(server.Close)
+
+	_, reader := openStream(t, context.Background(), server.URL)
+	require.Equal(t, ": connected", readMessage(t, reader))
+	waitForSubscribers(t, b, 1)
+
+	b.Close()
+
+	rest, err := io.ReadAll(reader)
+	assert.NoError(t, err)
+	assert.Empty(t, rest)
+}
+
+func TestFormat_StripsLineBreaksFromFields(t *testing.T) {
+	message := format(Event{ID: "1\nevent: spoofed", Type: "a\r\nb", Data: "x\ry"})
+
+	assert.Equal(t, "id: 1event: spoofed\nevent: ab\ndata: x\ndata: y\n\n", message)
+}
@@ -8,5 +8,10 @@ import (
 
 type Manager interface {
 	GetEntity(ctx context.Context, id string) (*example.Entity, error)
+	GetEntityByEmail(ctx context.Context, email string) (*example.Entity, error)
+	ListEntities(ctx context.Context) ([]*example.Entity, error)
 	CreateEntity(ctx context.Context, id, email, name string) (*example.Entity, error)
+	UpdateEntity(ctx context.Context, id string, email, name *string) (*example.Entity, error)
+	RestoreEntity(ctx context.Context, id string) (*example.Entity, error)
+	CreateEntities(ctx context.Context, params []example.CreateEntityParams, atomic bool) ([]example.CreateEntityResult, error)
 }
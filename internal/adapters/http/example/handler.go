@@ -1,29 +1,73 @@
 package example
 
 import (
+	"bytes"
+	"context"
+	"encoding/csv"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	httpErrors "microservice/internal/platform/http"
 	"microservice/internal/platform/logger"
+	platformMiddleware "microservice/internal/platform/middleware"
+	"microservice/internal/platform/servertiming"
 	"microservice/internal/platform/validator"
 	"net/http"
+	"reflect"
+	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 
+	"microservice/internal/adapters/http/request"
 	"microservice/internal/adapters/http/response"
 	"microservice/internal/core/domain/example"
 )
 
+var (
+	errBatchTooLarge = errors.New("batch exceeds the maximum number of items")
+	errBatchNotArray = errors.New("batch must be a JSON array")
+)
+
 type Handler struct {
-	manager  Manager
-	validate validator.Validator
+	manager       Manager
+	validate      validator.Validator
+	maxBatchItems int
+	generateIDs   bool
+	strictJSON    bool
+}
+
+type Option func(*Handler)
+
+// WithGeneratedIDs lets create requests omit the ID, leaving it to the manager
+// to generate one.
+func WithGeneratedIDs() Option {
+	return func(h *Handler) {
+		h.generateIDs = true
+	}
 }
 
-func NewHandler(manager Manager, validate validator.Validator) *Handler {
-	return &Handler{
-		manager:  manager,
-		validate: validate,
+// WithDisallowUnknownFields rejects request bodies carrying fields the request
+// type does not declare, instead of ignoring them.
+func WithDisallowUnknownFields() Option {
+	return func(h *Handler) {
+		h.strictJSON = true
+	}
+}
+
+// NewHandler creates the example handler. maxBatchItems caps the number of
+// items accepted by CreateEntities; zero or less disables the cap.
+func NewHandler(manager Manager, validate validator.Validator, maxBatchItems int, opts ...Option) *Handler {
+	h := &Handler{
+		manager:       manager,
+		validate:      validate,
+		maxBatchItems: maxBatchItems,
+	}
+	for _, opt := range opts {
+		opt(h)
 	}
+	return h
 }
 
 func (h *Handler) mapDomainError(err error) error {
@@ -38,6 +82,10 @@ func (h *Handler) mapDomainError(err error) error {
 		return httpErrors.NewBadRequest("Invalid name", err)
 	case errors.Is(err, example.ErrReservedName):
 		return httpErrors.NewBadRequest("Name is reserved", err)
+	case errors.Is(err, example.ErrEntityNotDeleted):
+		return httpErrors.NewConflict("Entity is not deleted", err)
+	case errors.Is(err, example.ErrBatchAborted):
+		return httpErrors.New(http.StatusFailedDependency, "Aborted because another item in the batch failed", err)
 	default:
 		var alreadyExistsErr *example.AlreadyExistsError
 		if errors.As(err, &alreadyExistsErr) {
@@ -47,6 +95,86 @@ func (h *Handler) mapDomainError(err error) error {
 	}
 }
 
+// validateRequest validates req and records the time taken as the validate
+// server timing.
+func (h *Handler) validateRequest(ctx context.Context, req any) error {
+	defer servertiming.Track(ctx, "validate")()
+	return h.validate.Validate(req)
+}
+
+func (h *Handler) respondDecodeError(w http.ResponseWriter, err error) {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		response.RespondError(w, http.StatusRequestEntityTooLarge, errors.New("request body too large"))
+		return
+	}
+
+	var depthErr *platformMiddleware.JSONDepthError
+	if errors.As(err, &depthErr) {
+		response.RespondError(w, http.StatusBadRequest, errors.New("request body is nested too deeply"))
+		return
+	}
+
+	response.RespondError(w, http.StatusBadRequest, errors.New(decodeErrorMessage(err)))
+}
+
+// decodeErrorMessage tells clients whether the body was empty, was not valid
+// JSON or had a value of the wrong type, without echoing the error text of
+// encoding/json.
+func decodeErrorMessage(err error) string {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.Is(err, io.EOF):
+		return "request body is empty"
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return "malformed JSON: unexpected end of input"
+	case errors.Is(err, errBatchNotArray):
+		return errBatchNotArray.Error()
+	case errors.As(err, &syntaxErr):
+		return fmt.Sprintf("malformed JSON at offset %d", syntaxErr.Offset)
+	case errors.As(err, &typeErr):
+		if typeErr.Field == "" {
+			return fmt.Sprintf("request body must be %s", jsonKind(typeErr.Type))
+		}
+		return fmt.Sprintf("field %q must be %s", typeErr.Field, jsonKind(typeErr.Type))
+	}
+
+	// encoding/json has no error type for unknown fields.
+	if field, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+		return "unknown field " + field
+	}
+	return "invalid request payload"
+}
+
+func jsonKind(t reflect.Type) string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return "a string"
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	default:
+		return "an object"
+	}
+}
+
+func (h *Handler) newDecoder(body io.Reader) *json.Decoder {
+	dec := json.NewDecoder(body)
+	if h.strictJSON {
+		dec.DisallowUnknownFields()
+	}
+	return dec
+}
+
 func (h *Handler) GetEntity(w http.ResponseWriter, r *http.Request) error {
 	entityID := chi.URLParam(r, "id")
 
@@ -55,28 +183,119 @@ func (h *Handler) GetEntity(w http.ResponseWriter, r *http.Request) error {
 		return h.mapDomainError(err)
 	}
 
-	response.RespondJSON(w, http.StatusOK, entity)
+	etag, err := response.WeakETag(entity)
+	if err == nil {
+		w.Header().Set("ETag", etag)
+	}
+	// If-None-Match takes precedence over If-Modified-Since when both are sent.
+	ifNoneMatch := r.Header.Get("If-None-Match")
+	notModified := ifNoneMatch != "" && response.ETagMatches(ifNoneMatch, etag)
+	if !entity.UpdatedAt.IsZero() {
+		// HTTP dates have second precision, so compare at that precision too.
+		lastModified := entity.UpdatedAt.UTC().Truncate(time.Second)
+		w.Header().Set("Last-Modified", lastModified.Format(http.TimeFormat))
+		if ifNoneMatch == "" {
+			notModified = notModifiedSince(r, lastModified)
+		}
+	}
+	if notModified {
+		w.WriteHeader(http.StatusNotModified)
+		return nil
+	}
+
+	response.Respond(w, r, http.StatusOK, entity)
+	return nil
+}
+
+// ExportEntities serves every live entity as a CSV download. The export is
+// built in memory, so clients can fetch it in parts with Range requests.
+func (h *Handler) ExportEntities(w http.ResponseWriter, r *http.Request) error {
+	entities, err := h.manager.ListEntities(r.Context())
+	if err != nil {
+		return h.mapDomainError(err)
+	}
+
+	var buf bytes.Buffer
+	out := csv.NewWriter(&buf)
+	_ = out.Write([]string{"id", "email", "name", "updated_at"})
+	var modTime time.Time
+	for _, entity := range entities {
+		_ = out.Write([]string{entity.ID, entity.Email, entity.Name, entity.UpdatedAt.UTC().Format(time.RFC3339)})
+		if entity.UpdatedAt.After(modTime) {
+			modTime = entity.UpdatedAt
+		}
+	}
+	out.Flush()
+	if err := out.Error(); err != nil {
+		return fmt.Errorf("write export: %w", err)
+	}
+
+	response.RespondExport(w, r, "examples.csv", "text/csv; charset=utf-8", modTime, buf.Bytes())
+	return nil
+}
+
+// FindEntity looks an entity up by the email query parameter, which is
+// required. Emails are not unique; when several entities share one, the
+// entity with the lowest ID is returned.
+func (h *Handler) FindEntity(w http.ResponseWriter, r *http.Request) error {
+	email := r.URL.Query().Get("email")
+	if email == "" {
+		return httpErrors.NewBadRequest("email query parameter is required", nil)
+	}
+
+	entity, err := h.manager.GetEntityByEmail(r.Context(), email)
+	if err != nil {
+		return h.mapDomainError(err)
+	}
+
+	response.Respond(w, r, http.StatusOK, entity)
 	return nil
 }
 
+func notModifiedSince(r *http.Request, lastModified time.Time) bool {
+	header := r.Header.Get("If-Modified-Since")
+	if header == "" {
+		return false
+	}
+	since, err := http.ParseTime(header)
+	if err != nil {
+		return false
+	}
+	return !lastModified.After(since)
+}
+
 type CreateEntityRequest struct {
 	ID    string `json:"id" validate:"required"`
 	Email string `json:"email" validate:"required,email"`
 	Name  string `json:"name" validate:"required"`
 }
 
+// generatedIDRequest is a CreateEntityRequest whose ID may be omitted.
+type generatedIDRequest struct {
+	ID    string `json:"id"`
+	Email string `json:"email" validate:"required,email"`
+	Name  string `json:"name" validate:"required"`
+}
+
+func (h *Handler) validateCreate(ctx context.Context, req CreateEntityRequest) error {
+	if h.generateIDs {
+		return h.validateRequest(ctx, generatedIDRequest(req))
+	}
+	return h.validateRequest(ctx, req)
+}
+
 func (h *Handler) CreateEntity(w http.ResponseWriter, r *http.Request) error {
 	contextLogger := logger.FromContext(r.Context())
 
 	var req CreateEntityRequest
 
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	if err := h.newDecoder(r.Body).Decode(&req); err != nil {
 		contextLogger.Warn("Failed to decode request body", logger.Error(err))
-		response.RespondError(w, http.StatusBadRequest, errors.New("invalid request payload"))
+		h.respondDecodeError(w, err)
 		return nil
 	}
 
-	if err := h.validate.Validate(req); err != nil {
+	if err := h.validateCreate(r.Context(), req); err != nil {
 		var validationErr validator.ValidationError
 		if errors.As(err, &validationErr) {
 			contextLogger.Warn("Validation failed", logger.Error(err))
@@ -93,6 +312,186 @@ func (h *Handler) CreateEntity(w http.ResponseWriter, r *http.Request) error {
 		return h.mapDomainError(err)
 	}
 
-	response.RespondJSON(w, http.StatusCreated, entity)
+	response.Respond(w, r, http.StatusCreated, entity)
+	return nil
+}
+
+// PatchEntityRequest describes a partial update. Fields are pointers so that
+// encoding/json can tell an omitted field from an empty one: a key that is
+// absent from the body (or explicitly null) leaves the pointer nil and the
+// field unchanged, while a key set to "" yields a non-nil pointer to an empty
+// string, which is then rejected by validation. The omitnil rule skips
+// validation only for nil pointers.
+type PatchEntityRequest struct {
+	Email *string `json:"email" validate:"omitnil,email"`
+	Name  *string `json:"name" validate:"omitnil,min=1"`
+}
+
+func (h *Handler) PatchEntity(w http.ResponseWriter, r *http.Request) error {
+	contextLogger := logger.FromContext(r.Context())
+	entityID := chi.URLParam(r, "id")
+
+	var req PatchEntityRequest
+
+	if err := h.newDecoder(r.Body).Decode(&req); err != nil {
+		contextLogger.Warn("Failed to decode request body", logger.Error(err))
+		h.respondDecodeError(w, err)
+		return nil
+	}
+
+	if err := h.validateRequest(r.Context(), req); err != nil {
+		var validationErr validator.ValidationError
+		if errors.As(err, &validationErr) {
+			contextLogger.Warn("Validation failed", logger.Error(err))
+			response.RespondJSON(w, http.StatusBadRequest, validationErr)
+		} else {
+			contextLogger.Error("Unexpected validation error", logger.Error(err))
+			response.RespondError(w, http.StatusBadRequest, errors.New("invalid request data"))
+		}
+		return nil
+	}
+
+	entity, err := h.manager.UpdateEntity(r.Context(), entityID, req.Email, req.Name)
+	if err != nil {
+		return h.mapDomainError(err)
+	}
+
+	response.Respond(w, r, http.StatusOK, entity)
+	return nil
+}
+
+// RestoreEntity brings back a soft-deleted entity. It responds 404 when no
+// entity has the ID and 409 when the entity is not deleted.
+func (h *Handler) RestoreEntity(w http.ResponseWriter, r *http.Request) error {
+	entity, err := h.manager.RestoreEntity(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		return h.mapDomainError(err)
+	}
+
+	response.Respond(w, r, http.StatusOK, entity)
+	return nil
+}
+
+type BatchItemResult struct {
+	Index  int             `json:"index"`
+	Status int             `json:"status"`
+	Entity *example.Entity `json:"entity,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
+
+// CreateEntities creates a batch of entities from a JSON array of
+// CreateEntityRequest and reports a result per item. With ?atomic=true a
+// single failing item aborts the whole batch; otherwise valid items are
+// created and failing ones are reported individually. The response is 201
+// when every item was created and 207 otherwise.
+func (h *Handler) CreateEntities(w http.ResponseWriter, r *http.Request) error {
+	contextLogger := logger.FromContext(r.Context())
+
+	var query struct {
+		Atomic bool `query:"atomic"`
+	}
+	if err := request.BindQuery(r, &query); err != nil {
+		return err
+	}
+	atomic := query.Atomic
+
+	reqs, err := h.decodeBatch(r.Body)
+	if err != nil {
+		contextLogger.Warn("Failed to decode batch request body", logger.Error(err))
+		if errors.Is(err, errBatchTooLarge) {
+			response.RespondError(w, http.StatusRequestEntityTooLarge, fmt.Errorf("batch exceeds the maximum of %d items", h.maxBatchItems))
+			return nil
+		}
+		h.respondDecodeError(w, err)
+		return nil
+	}
+	if len(reqs) == 0 {
+		response.RespondError(w, http.StatusBadRequest, errors.New("batch must contain at least one item"))
+		return nil
+	}
+
+	results := make([]BatchItemResult, len(reqs))
+	params := make([]example.CreateEntityParams, 0, len(reqs))
+	indexes := make([]int, 0, len(reqs))
+	for i, req := range reqs {
+		if err := h.validateCreate(r.Context(), req); err != nil {
+			results[i] = BatchItemResult{Index: i, Status: http.StatusBadRequest, Error: err.Error()}
+			continue
+		}
+		params = append(params, example.CreateEntityParams{ID: req.ID, Email: req.Email, Name: req.Name})
+		indexes = append(indexes, i)
+	}
+
+	created := make([]example.CreateEntityResult, len(params))
+	switch {
+	case atomic && len(params) < len(reqs):
+		for j := range created {
+			created[j].Err = example.ErrBatchAborted
+		}
+	case len(params) > 0:
+		created, err = h.manager.CreateEntities(r.Context(), params, atomic)
+		if err != nil {
+			return err
+		}
+	}
+
+	status := http.StatusCreated
+	for j, i := range indexes {
+		results[i] = h.batchItemResult(i, created[j])
+	}
+	for _, result := range results {
+		if result.Status != http.StatusCreated {
+			status = http.StatusMultiStatus
+			break
+		}
+	}
+
+	response.Respond(w, r, status, results)
 	return nil
 }
+
+// decodeBatch reads the JSON array item by item so an oversized batch is
+// rejected as soon as the cap is crossed instead of after buffering it all.
+func (h *Handler) decodeBatch(body io.Reader) ([]CreateEntityRequest, error) {
+	dec := h.newDecoder(body)
+
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		return nil, errBatchNotArray
+	}
+
+	var reqs []CreateEntityRequest
+	for dec.More() {
+		if h.maxBatchItems > 0 && len(reqs) >= h.maxBatchItems {
+			return nil, errBatchTooLarge
+		}
+
+		var req CreateEntityRequest
+		if err := dec.Decode(&req); err != nil {
+			return nil, err
+		}
+		reqs = append(reqs, req)
+	}
+
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+
+	return reqs, nil
+}
+
+func (h *Handler) batchItemResult(index int, result example.CreateEntityResult) BatchItemResult {
+	if result.Err == nil {
+		return BatchItemResult{Index: index, Status: http.StatusCreated, Entity: result.Entity}
+	}
+
+	var httpErr *httpErrors.Error
+	if errors.As(h.mapDomainError(result.Err), &httpErr) {
+		return BatchItemResult{Index: index, Status: httpErr.StatusCode, Error: httpErr.Error()}
+	}
+
+	return BatchItemResult{Index: index, Status: http.StatusInternalServerError, Error: http.StatusText(http.StatusInternalServerError)}
+}
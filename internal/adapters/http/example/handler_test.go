@@ -2,24 +2,32 @@ package example
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"microservice/internal/adapters/http/example/mocks"
 	"microservice/internal/adapters/http/response"
+	validatorAdapter "microservice/internal/adapters/validator"
 	"microservice/internal/core/domain/example"
 	httpErrors "microservice/internal/platform/http"
 	"microservice/internal/platform/logger"
+	platformMiddleware "microservice/internal/platform/middleware"
 	"microservice/internal/platform/validator"
 	validatorMocks "microservice/internal/platform/validator/mocks"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"github.com/vmihailenco/msgpack/v5"
 )
 
 type HandlerTestSuite struct {
@@ -33,7 +41,7 @@ type HandlerTestSuite struct {
 func (suite *HandlerTestSuite) SetupTest() {
 	suite.mockManager = mocks.NewMockManager(suite.T())
 	suite.mockValidator = validatorMocks.NewMockValidator(suite.T())
-	suite.handler = NewHandler(suite.mockManager, suite.mockValidator)
+	suite.handler = NewHandler(suite.mockManager, suite.mockValidator, 100)
 
 	suite.router = chi.NewRouter()
 	suite.router.Get("/entities/{id}", func(w http.ResponseWriter, r *http.Request) {
@@ -48,6 +56,18 @@ func (suite *HandlerTestSuite) SetupTest() {
 		}
 	})
 
+	suite.router.Get("/entities", func(w http.ResponseWriter, r *http.Request) {
+		err := suite.handler.FindEntity(w, r)
+		if err != nil {
+			var httpErr *httpErrors.Error
+			if errors.As(err, &httpErr) {
+				response.RespondError(w, httpErr.StatusCode, httpErr)
+			} else {
+				response.RespondError(w, http.StatusInternalServerError, err)
+			}
+		}
+	})
+
 	suite.router.Post("/entities", func(w http.ResponseWriter, r *http.Request) {
 		err := suite.handler.CreateEntity(w, r)
 		if err != nil {
@@ -59,6 +79,30 @@ func (suite *HandlerTestSuite) SetupTest() {
 			}
 		}
 	})
+
+	suite.router.Patch("/entities/{id}", func(w http.ResponseWriter, r *http.Request) {
+		err := suite.handler.PatchEntity(w, r)
+		if err != nil {
+			var httpErr *httpErrors.Error
+			if errors.As(err, &httpErr) {
+				response.RespondError(w, httpErr.StatusCode, httpErr)
+			} else {
+				response.RespondError(w, http.StatusInternalServerError, err)
+			}
+		}
+	})
+
+	suite.router.Post("/entities/{id}/restore", func(w http.ResponseWriter, r *http.Request) {
+		err := suite.handler.RestoreEntity(w, r)
+		if err != nil {
+			var httpErr *httpErrors.Error
+			if errors.As(err, &httpErr) {
+				response.RespondError(w, httpErr.StatusCode, httpErr)
+			} else {
+				response.RespondError(w, http.StatusInternalServerError, err)
+			}
+		}
+	})
 }
 
 func (suite *HandlerTestSuite) TestGetEntity_Success() {
@@ -89,6 +133,178 @@ func (suite *HandlerTestSuite) TestGetEntity_Success() {
 	assert.Equal(suite.T(), expectedEntity.Name, responseEntity.Name)
 }
 
+func (suite *HandlerTestSuite) TestGetEntity_MsgPack() {
+	expectedEntity := &example.Entity{
+		ID:    "test-id",
+		Email: "test@example.com",
+		Name:  "Test Name",
+	}
+
+	suite.mockManager.EXPECT().
+		GetEntity(mock.Anything, "test-id").
+		Return(expectedEntity, nil).
+		Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/entities/test-id", nil)
+	req.Header.Set("Accept", "application/msgpack")
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Equal(suite.T(), "application/msgpack", w.Header().Get("Content-Type"))
+
+	var responseEntity example.Entity
+	require.NoError(suite.T(), msgpack.Unmarshal(w.Body.Bytes(), &responseEntity))
+	assert.Equal(suite.T(), expectedEntity.ID, responseEntity.ID)
+	assert.Equal(suite.T(), expectedEntity.Email, responseEntity.Email)
+	assert.Equal(suite.T(), expectedEntity.Name, responseEntity.Name)
+}
+
+func (suite *HandlerTestSuite) TestGetEntity_LastModified() {
+	updatedAt := time.Date(2024, 5, 1, 12, 30, 45, 123456789, time.UTC)
+	lastModified := "Wed, 01 May 2024 12:30:45 GMT"
+
+	tests := []struct {
+		name            string
+		ifModifiedSince string
+		expectedStatus  int
+	}{
+		{
+			name:           "first request",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:            "unchanged since the same second",
+			ifModifiedSince: lastModified,
+			expectedStatus:  http.StatusNotModified,
+		},
+		{
+			name:            "unchanged since a later time",
+			ifModifiedSince: "Wed, 01 May 2024 13:00:00 GMT",
+			expectedStatus:  http.StatusNotModified,
+		},
+		{
+			name:            "modified since",
+			ifModifiedSince: "Wed, 01 May 2024 12:30:44 GMT",
+			expectedStatus:  http.StatusOK,
+		},
+		{
+			name:            "unparseable header is ignored",
+			ifModifiedSince: "yesterday",
+			expectedStatus:  http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			suite.mockManager.EXPECT().
+				GetEntity(mock.Anything, "test-id").
+				Return(&example.Entity{ID: "test-id", Email: "test@example.com", Name: "Test Name", UpdatedAt: updatedAt}, nil).
+				Once()
+
+			req := httptest.NewRequest(http.MethodGet, "/entities/test-id", nil)
+			req = req.WithContext(logger.WithLogger(req.Context(), logger.NewNop()))
+			if tt.ifModifiedSince != "" {
+				req.Header.Set("If-Modified-Since", tt.ifModifiedSince)
+			}
+			w := httptest.NewRecorder()
+
+			suite.router.ServeHTTP(w, req)
+
+			assert.Equal(suite.T(), tt.expectedStatus, w.Code)
+			assert.Equal(suite.T(), lastModified, w.Header().Get("Last-Modified"))
+			if tt.expectedStatus == http.StatusNotModified {
+				assert.Empty(suite.T(), w.Body.String())
+			} else {
+				assert.Contains(suite.T(), w.Body.String(), `"test-id"`)
+			}
+		})
+	}
+}
+
+func (suite *HandlerTestSuite) TestGetEntity_ETag() {
+	entity := &example.Entity{ID: "test-id", Email: "test@example.com", Name: "Test Name", UpdatedAt: time.Date(2024, 5, 1, 12, 30, 45, 0, time.UTC)}
+	etag, err := response.WeakETag(entity)
+	require.NoError(suite.T(), err)
+	changed, err := response.WeakETag(&example.Entity{ID: "test-id", Email: "test@example.com", Name: "Old Name"})
+	require.NoError(suite.T(), err)
+
+	tests := []struct {
+		name            string
+		ifNoneMatch     string
+		ifModifiedSince string
+		expectedStatus  int
+	}{
+		{
+			name:           "first request",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "matching tag",
+			ifNoneMatch:    etag,
+			expectedStatus: http.StatusNotModified,
+		},
+		{
+			name:           "stale tag",
+			ifNoneMatch:    changed,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:            "stale tag wins over If-Modified-Since",
+			ifNoneMatch:     changed,
+			ifModifiedSince: "Wed, 01 May 2024 13:00:00 GMT",
+			expectedStatus:  http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			suite.mockManager.EXPECT().
+				GetEntity(mock.Anything, "test-id").
+				Return(entity, nil).
+				Once()
+
+			req := httptest.NewRequest(http.MethodGet, "/entities/test-id", nil)
+			req = req.WithContext(logger.WithLogger(req.Context(), logger.NewNop()))
+			if tt.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			if tt.ifModifiedSince != "" {
+				req.Header.Set("If-Modified-Since", tt.ifModifiedSince)
+			}
+			w := httptest.NewRecorder()
+
+			suite.router.ServeHTTP(w, req)
+
+			assert.Equal(suite.T(), tt.expectedStatus, w.Code)
+			assert.Equal(suite.T(), etag, w.Header().Get("ETag"))
+			if tt.expectedStatus == http.StatusNotModified {
+				assert.Empty(suite.T(), w.Body.String())
+			} else {
+				assert.Contains(suite.T(), w.Body.String(), `"test-id"`)
+			}
+		})
+	}
+}
+
+func (suite *HandlerTestSuite) TestGetEntity_NoLastModifiedWithoutTimestamp() {
+	suite.mockManager.EXPECT().
+		GetEntity(mock.Anything, "test-id").
+		Return(&example.Entity{ID: "test-id", Email: "test@example.com", Name: "Test Name"}, nil).
+		Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/entities/test-id", nil)
+	req = req.WithContext(logger.WithLogger(req.Context(), logger.NewNop()))
+	req.Header.Set("If-Modified-Since", "Wed, 01 May 2024 13:00:00 GMT")
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Empty(suite.T(), w.Header().Get("Last-Modified"))
+}
+
 func (suite *HandlerTestSuite) TestGetEntity_NotFound() {
 	suite.mockManager.EXPECT().
 		GetEntity(mock.Anything, "nonexistent-id").
@@ -121,6 +337,89 @@ func (suite *HandlerTestSuite) TestGetEntity_InvalidEntityID() {
 	assert.JSONEq(suite.T(), `{"error":"Invalid entity ID"}`, w.Body.String())
 }
 
+func (suite *HandlerTestSuite) TestExportEntities() {
+	updatedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.FixedZone("CET", 3600))
+	suite.mockManager.EXPECT().
+		ListEntities(mock.Anything).
+		Return([]*example.Entity{{ID: "test-id", Email: "test@example.com", Name: "Doe, Jane", UpdatedAt: updatedAt}}, nil).
+		Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/entities/export", nil)
+	w := httptest.NewRecorder()
+
+	suite.Require().NoError(suite.handler.ExportEntities(w, req))
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Equal(suite.T(), "id,email,name,updated_at\ntest-id,test@example.com,\"Doe, Jane\",2024-01-02T02:04:05Z\n", w.Body.String())
+	assert.NotEmpty(suite.T(), w.Header().Get("ETag"))
+}
+
+func (suite *HandlerTestSuite) TestExportEntities_Error() {
+	suite.mockManager.EXPECT().
+		ListEntities(mock.Anything).
+		Return(nil, errors.New("database unavailable")).
+		Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/entities/export", nil)
+	w := httptest.NewRecorder()
+
+	err := suite.handler.ExportEntities(w, req)
+
+	assert.EqualError(suite.T(), err, "database unavailable")
+	assert.Empty(suite.T(), w.Body.String())
+}
+
+func (suite *HandlerTestSuite) TestFindEntity() {
+	entity := &example.Entity{ID: "test-id", Email: "a+b@example.com", Name: "Test Name"}
+	suite.mockManager.EXPECT().
+		GetEntityByEmail(mock.Anything, "a+b@example.com").
+		Return(entity, nil).
+		Once()
+	suite.mockManager.EXPECT().
+		GetEntityByEmail(mock.Anything, "missing@example.com").
+		Return(nil, example.ErrEntityNotFound).
+		Once()
+
+	tests := []struct {
+		name         string
+		query        string
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:         "found",
+			query:        "?email=a%2Bb%40example.com",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"ID":"test-id","Email":"a+b@example.com","Name":"Test Name","UpdatedAt":"0001-01-01T00:00:00Z"}`,
+		},
+		{
+			name:         "not_found",
+			query:        "?email=missing@example.com",
+			expectedCode: http.StatusNotFound,
+			expectedBody: `{"error":"Entity not found"}`,
+		},
+		{
+			name:         "missing_email",
+			query:        "",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"error":"email query parameter is required"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			req := httptest.NewRequest(http.MethodGet, "/entities"+tt.query, nil)
+			req = req.WithContext(logger.WithLogger(req.Context(), logger.NewNop()))
+			w := httptest.NewRecorder()
+
+			suite.router.ServeHTTP(w, req)
+
+			assert.Equal(suite.T(), tt.expectedCode, w.Code)
+			assert.JSONEq(suite.T(), tt.expectedBody, w.Body.String())
+		})
+	}
+}
+
 func (suite *HandlerTestSuite) TestCreateEntity_Success() {
 	request := CreateEntityRequest{
 		ID:    "test-id",
@@ -171,7 +470,74 @@ func (suite *HandlerTestSuite) TestCreateEntity_InvalidJSON() {
 	suite.router.ServeHTTP(w, req)
 
 	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
-	assert.JSONEq(suite.T(), `{"error":"invalid request payload"}`, w.Body.String())
+	assert.JSONEq(suite.T(), `{"error":"malformed JSON at offset 1"}`, w.Body.String())
+}
+
+func (suite *HandlerTestSuite) TestCreateEntity_DecodeErrors() {
+	tests := []struct {
+		name         string
+		body         string
+		opts         []Option
+		expectedBody string
+	}{
+		{name: "empty_body", body: "", expectedBody: `{"error":"request body is empty"}`},
+		{name: "syntax_error", body: `{"id":"test-id",}`, expectedBody: `{"error":"malformed JSON at offset 17"}`},
+		{name: "truncated", body: `{"id":"test-id"`, expectedBody: `{"error":"malformed JSON: unexpected end of input"}`},
+		{name: "wrong_field_type", body: `{"id":"test-id","email":42}`, expectedBody: `{"error":"field \"email\" must be a string"}`},
+		{name: "wrong_body_type", body: `["test-id"]`, expectedBody: `{"error":"request body must be an object"}`},
+		{name: "unknown_field", body: `{"id":"test-id","nickname":"x"}`, opts: []Option{WithDisallowUnknownFields()}, expectedBody: `{"error":"unknown field \"nickname\""}`},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			handler := NewHandler(suite.mockManager, suite.mockValidator, 100, tt.opts...)
+			req := httptest.NewRequest(http.MethodPost, "/entities", bytes.NewBufferString(tt.body))
+			req = req.WithContext(logger.WithLogger(req.Context(), logger.NewNop()))
+			w := httptest.NewRecorder()
+
+			require.NoError(suite.T(), handler.CreateEntity(w, req))
+
+			assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+			assert.JSONEq(suite.T(), tt.expectedBody, w.Body.String())
+		})
+	}
+}
+
+func (suite *HandlerTestSuite) TestCreateEntity_UnknownFieldsIgnoredByDefault() {
+	suite.mockValidator.EXPECT().Validate(mock.Anything).Return(nil).Once()
+	suite.mockManager.EXPECT().CreateEntity(mock.Anything, "test-id", "test@example.com", "Test").
+		Return(&example.Entity{ID: "test-id"}, nil).Once()
+
+	req := httptest.NewRequest(http.MethodPost, "/entities", bytes.NewBufferString(`{"id":"test-id","email":"test@example.com","name":"Test","nickname":"x"}`))
+	req = req.WithContext(logger.WithLogger(req.Context(), logger.NewNop()))
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusCreated, w.Code)
+}
+
+func (suite *HandlerTestSuite) TestCreateEntity_BodyTooLarge() {
+	body := `{"id":"test-id","email":"test@example.com","name":"` + strings.Repeat("a", 64) + `"}`
+	req := httptest.NewRequest(http.MethodPost, "/entities", bytes.NewBufferString(body))
+	req = req.WithContext(logger.WithLogger(req.Context(), logger.NewNop()))
+	w := httptest.NewRecorder()
+
+	platformMiddleware.MaxBodySize(int64(len(body)-1))(suite.router).ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusRequestEntityTooLarge, w.Code)
+	assert.JSONEq(suite.T(), `{"error":"request body too large"}`, w.Body.String())
+}
+
+func (suite *HandlerTestSuite) TestCreateEntity_NestedTooDeeply() {
+	body := `{"id":"test-id","email":"test@example.com","name":[[[[{"a":[]}]]]]}`
+	req := httptest.NewRequest(http.MethodPost, "/entities", bytes.NewBufferString(body))
+	req = req.WithContext(logger.WithLogger(req.Context(), logger.NewNop()))
+	w := httptest.NewRecorder()
+
+	platformMiddleware.MaxJSONDepth(4)(suite.router).ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	assert.JSONEq(suite.T(), `{"error":"request body is nested too deeply"}`, w.Body.String())
 }
 
 func (suite *HandlerTestSuite) TestCreateEntity_ValidationError() {
@@ -301,6 +667,442 @@ func (suite *HandlerTestSuite) TestCreateEntity_ReservedName() {
 	assert.JSONEq(suite.T(), `{"error":"Name is reserved"}`, w.Body.String())
 }
 
+func (suite *HandlerTestSuite) TestPatchEntity_Success() {
+	name := "New Name"
+	expectedEntity := &example.Entity{
+		ID:    "test-id",
+		Email: "test@example.com",
+		Name:  name,
+	}
+
+	suite.mockValidator.EXPECT().
+		Validate(PatchEntityRequest{Name: &name}).
+		Return(nil).
+		Once()
+
+	suite.mockManager.EXPECT().
+		UpdateEntity(mock.Anything, "test-id", (*string)(nil), &name).
+		Return(expectedEntity, nil).
+		Once()
+
+	req := httptest.NewRequest(http.MethodPatch, "/entities/test-id", bytes.NewBufferString(`{"name":"New Name"}`))
+	req = req.WithContext(logger.WithLogger(req.Context(), logger.NewNop()))
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+
+	var responseEntity example.Entity
+	err := json.Unmarshal(w.Body.Bytes(), &responseEntity)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), *expectedEntity, responseEntity)
+}
+
+func (suite *HandlerTestSuite) TestPatchEntity_NotFound() {
+	email := "new@example.com"
+
+	suite.mockValidator.EXPECT().
+		Validate(PatchEntityRequest{Email: &email}).
+		Return(nil).
+		Once()
+
+	suite.mockManager.EXPECT().
+		UpdateEntity(mock.Anything, "missing-id", &email, (*string)(nil)).
+		Return(nil, example.ErrEntityNotFound).
+		Once()
+
+	req := httptest.NewRequest(http.MethodPatch, "/entities/missing-id", bytes.NewBufferString(`{"email":"new@example.com"}`))
+	req = req.WithContext(logger.WithLogger(req.Context(), logger.NewNop()))
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+	assert.JSONEq(suite.T(), `{"error":"Entity not found"}`, w.Body.String())
+}
+
+func (suite *HandlerTestSuite) TestRestoreEntity() {
+	restored := &example.Entity{ID: "test-id", Email: "test@example.com", Name: "Test User"}
+	restoredJSON, err := json.Marshal(restored)
+	require.NoError(suite.T(), err)
+
+	tests := []struct {
+		name           string
+		entity         *example.Entity
+		err            error
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "restored",
+			entity:         restored,
+			expectedStatus: http.StatusOK,
+			expectedBody:   string(restoredJSON),
+		},
+		{
+			name:           "not found",
+			err:            example.ErrEntityNotFound,
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error":"Entity not found"}`,
+		},
+		{
+			name:           "not deleted",
+			err:            example.ErrEntityNotDeleted,
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"error":"Entity is not deleted"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			suite.mockManager.EXPECT().
+				RestoreEntity(mock.Anything, "test-id").
+				Return(tt.entity, tt.err).
+				Once()
+
+			req := httptest.NewRequest(http.MethodPost, "/entities/test-id/restore", nil)
+			w := httptest.NewRecorder()
+
+			suite.router.ServeHTTP(w, req)
+
+			assert.Equal(suite.T(), tt.expectedStatus, w.Code)
+			assert.JSONEq(suite.T(), tt.expectedBody, w.Body.String())
+		})
+	}
+}
+
+func (suite *HandlerTestSuite) TestPatchEntity_InvalidJSON() {
+	req := httptest.NewRequest(http.MethodPatch, "/entities/test-id", bytes.NewBufferString("invalid json"))
+	req = req.WithContext(logger.WithLogger(req.Context(), logger.NewNop()))
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	assert.JSONEq(suite.T(), `{"error":"malformed JSON at offset 1"}`, w.Body.String())
+}
+
+func (suite *HandlerTestSuite) TestPatchEntity_BodyTooLarge() {
+	body := `{"name":"` + strings.Repeat("a", 64) + `"}`
+	req := httptest.NewRequest(http.MethodPatch, "/entities/test-id", bytes.NewBufferString(body))
+	req = req.WithContext(logger.WithLogger(req.Context(), logger.NewNop()))
+	w := httptest.NewRecorder()
+
+	platformMiddleware.MaxBodySize(int64(len(body)-1))(suite.router).ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusRequestEntityTooLarge, w.Code)
+	assert.JSONEq(suite.T(), `{"error":"request body too large"}`, w.Body.String())
+}
+
+func (suite *HandlerTestSuite) TestPatchEntity_OmittedVersusEmptyFields() {
+	handler := NewHandler(suite.mockManager, validatorAdapter.NewPlaygroundAdapter(), 100)
+	router := chi.NewRouter()
+	router.Patch("/entities/{id}", func(w http.ResponseWriter, r *http.Request) {
+		_ = handler.PatchEntity(w, r)
+	})
+
+	unchanged := &example.Entity{ID: "test-id", Email: "test@example.com", Name: "Test Name"}
+	suite.mockManager.EXPECT().
+		UpdateEntity(mock.Anything, "test-id", (*string)(nil), (*string)(nil)).
+		Return(unchanged, nil).
+		Once()
+
+	req := httptest.NewRequest(http.MethodPatch, "/entities/test-id", bytes.NewBufferString(`{}`))
+	req = req.WithContext(logger.WithLogger(req.Context(), logger.NewNop()))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+
+	req = httptest.NewRequest(http.MethodPatch, "/entities/test-id", bytes.NewBufferString(`{"name":""}`))
+	req = req.WithContext(logger.WithLogger(req.Context(), logger.NewNop()))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+
+	var validationResponse validator.ValidationError
+	err := json.Unmarshal(w.Body.Bytes(), &validationResponse)
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), validationResponse.Errors, 1)
+	assert.Equal(suite.T(), "name", validationResponse.Errors[0].Field)
+}
+
+func (suite *HandlerTestSuite) TestCreateEntity_ValidationFieldsMatchJSONKeys() {
+	handler := NewHandler(suite.mockManager, validatorAdapter.NewPlaygroundAdapter(), 100)
+
+	req := httptest.NewRequest(http.MethodPost, "/entities", bytes.NewBufferString(`{"email":"not-an-email"}`))
+	req = req.WithContext(logger.WithLogger(req.Context(), logger.NewNop()))
+	w := httptest.NewRecorder()
+	require.NoError(suite.T(), handler.CreateEntity(w, req))
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+
+	var validationResponse validator.ValidationError
+	require.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &validationResponse))
+	fields := make([]string, len(validationResponse.Errors))
+	for i, fieldErr := range validationResponse.Errors {
+		fields[i] = fieldErr.Field
+	}
+	assert.Equal(suite.T(), []string{"id", "email", "name"}, fields)
+}
+
+func (suite *HandlerTestSuite) TestCreateEntity_GeneratedIDs() {
+	handler := NewHandler(suite.mockManager, validatorAdapter.NewPlaygroundAdapter(), 100, WithGeneratedIDs())
+	suite.mockManager.EXPECT().CreateEntity(mock.Anything, "", "test@example.com", "Test User").
+		Return(&example.Entity{ID: "gen-1", Email: "test@example.com", Name: "Test User"}, nil).Once()
+
+	req := httptest.NewRequest(http.MethodPost, "/entities", bytes.NewBufferString(`{"email":"test@example.com","name":"Test User"}`))
+	req = req.WithContext(logger.WithLogger(req.Context(), logger.NewNop()))
+	w := httptest.NewRecorder()
+	require.NoError(suite.T(), handler.CreateEntity(w, req))
+
+	assert.Equal(suite.T(), http.StatusCreated, w.Code)
+	var entity example.Entity
+	require.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &entity))
+	assert.Equal(suite.T(), "gen-1", entity.ID)
+}
+
+func (suite *HandlerTestSuite) TestCreateEntities_GeneratedIDs() {
+	handler := NewHandler(suite.mockManager, validatorAdapter.NewPlaygroundAdapter(), 100, WithGeneratedIDs())
+	suite.mockManager.EXPECT().CreateEntities(mock.Anything, []example.CreateEntityParams{
+		{Email: "one@example.com", Name: "One"},
+	}, false).Return([]example.CreateEntityResult{
+		{Entity: &example.Entity{ID: "gen-1", Email: "one@example.com", Name: "One"}},
+	}, nil).Once()
+
+	w := suite.serveBatch(handler, "", strings.NewReader(`[{"email":"one@example.com","name":"One"}]`))
+
+	assert.Equal(suite.T(), http.StatusCreated, w.Code)
+	var results []BatchItemResult
+	require.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &results))
+	require.Len(suite.T(), results, 1)
+	assert.Equal(suite.T(), "gen-1", results[0].Entity.ID)
+}
+
+func (suite *HandlerTestSuite) serveBatch(handler *Handler, query string, body io.Reader) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/entities/batch"+query, body)
+	req = req.WithContext(logger.WithLogger(req.Context(), logger.NewNop()))
+	w := httptest.NewRecorder()
+
+	err := handler.CreateEntities(w, req)
+	if err != nil {
+		var httpErr *httpErrors.Error
+		if errors.As(err, &httpErr) {
+			response.RespondError(w, httpErr.StatusCode, httpErr)
+		} else {
+			response.RespondError(w, http.StatusInternalServerError, err)
+		}
+	}
+
+	return w
+}
+
+func batchBody(n int) string {
+	items := make([]string, n)
+	for i := range items {
+		items[i] = fmt.Sprintf(`{"id":"id-%d","email":"user%d@example.com","name":"User %d"}`, i, i, i)
+	}
+	return "[" + strings.Join(items, ",") + "]"
+}
+
+func (suite *HandlerTestSuite) TestCreateEntities_AllCreated() {
+	handler := NewHandler(suite.mockManager, validatorAdapter.NewPlaygroundAdapter(), 10)
+	params := []example.CreateEntityParams{
+		{ID: "id-0", Email: "user0@example.com", Name: "User 0"},
+		{ID: "id-1", Email: "user1@example.com", Name: "User 1"},
+	}
+	suite.mockManager.EXPECT().
+		CreateEntities(mock.Anything, params, false).
+		Return([]example.CreateEntityResult{
+			{Entity: &example.Entity{ID: "id-0", Email: "user0@example.com", Name: "User 0"}},
+			{Entity: &example.Entity{ID: "id-1", Email: "user1@example.com", Name: "User 1"}},
+		}, nil).
+		Once()
+
+	w := suite.serveBatch(handler, "", strings.NewReader(batchBody(2)))
+
+	assert.Equal(suite.T(), http.StatusCreated, w.Code)
+	var results []BatchItemResult
+	require.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &results))
+	require.Len(suite.T(), results, 2)
+	for i, result := range results {
+		assert.Equal(suite.T(), i, result.Index)
+		assert.Equal(suite.T(), http.StatusCreated, result.Status)
+		assert.Equal(suite.T(), fmt.Sprintf("id-%d", i), result.Entity.ID)
+		assert.Empty(suite.T(), result.Error)
+	}
+}
+
+func (suite *HandlerTestSuite) TestCreateEntities_PartialFailure() {
+	handler := NewHandler(suite.mockManager, validatorAdapter.NewPlaygroundAdapter(), 10)
+	body := `[
+		{"id":"id-0","email":"user0@example.com","name":"User 0"},
+		{"id":"id-1","email":"not-an-email","name":"User 1"},
+		{"id":"id-2","email":"user2@example.com","name":"User 2"}
+	]`
+	suite.mockManager.EXPECT().
+		CreateEntities(mock.Anything, []example.CreateEntityParams{
+			{ID: "id-0", Email: "user0@example.com", Name: "User 0"},
+			{ID: "id-2", Email: "user2@example.com", Name: "User 2"},
+		}, false).
+		Return([]example.CreateEntityResult{
+			{Entity: &example.Entity{ID: "id-0", Email: "user0@example.com", Name: "User 0"}},
+			{Err: &example.AlreadyExistsError{ID: "id-2"}},
+		}, nil).
+		Once()
+
+	w := suite.serveBatch(handler, "", strings.NewReader(body))
+
+	assert.Equal(suite.T(), http.StatusMultiStatus, w.Code)
+	var results []BatchItemResult
+	require.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &results))
+	require.Len(suite.T(), results, 3)
+	assert.Equal(suite.T(), http.StatusCreated, results[0].Status)
+	assert.Equal(suite.T(), http.StatusBadRequest, results[1].Status)
+	assert.Contains(suite.T(), results[1].Error, "validation failed")
+	assert.Nil(suite.T(), results[1].Entity)
+	assert.Equal(suite.T(), http.StatusConflict, results[2].Status)
+	assert.Equal(suite.T(), "Entity already exists", results[2].Error)
+}
+
+func (suite *HandlerTestSuite) TestCreateEntities_AtomicAbortsOnValidationFailure() {
+	handler := NewHandler(suite.mockManager, validatorAdapter.NewPlaygroundAdapter(), 10)
+	body := `[
+		{"id":"id-0","email":"user0@example.com","name":"User 0"},
+		{"id":"id-1","email":"","name":"User 1"}
+	]`
+
+	w := suite.serveBatch(handler, "?atomic=true", strings.NewReader(body))
+
+	assert.Equal(suite.T(), http.StatusMultiStatus, w.Code)
+	var results []BatchItemResult
+	require.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &results))
+	require.Len(suite.T(), results, 2)
+	assert.Equal(suite.T(), http.StatusFailedDependency, results[0].Status)
+	assert.Equal(suite.T(), http.StatusBadRequest, results[1].Status)
+	suite.mockManager.AssertNotCalled(suite.T(), "CreateEntities", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *HandlerTestSuite) TestCreateEntities_AtomicPassedToManager() {
+	handler := NewHandler(suite.mockManager, validatorAdapter.NewPlaygroundAdapter(), 10)
+	suite.mockManager.EXPECT().
+		CreateEntities(mock.Anything, mock.Anything, true).
+		Return([]example.CreateEntityResult{{Err: example.ErrBatchAborted}}, nil).
+		Once()
+
+	w := suite.serveBatch(handler, "?atomic=true", strings.NewReader(batchBody(1)))
+
+	assert.Equal(suite.T(), http.StatusMultiStatus, w.Code)
+}
+
+func (suite *HandlerTestSuite) TestCreateEntities_ManagerError() {
+	handler := NewHandler(suite.mockManager, validatorAdapter.NewPlaygroundAdapter(), 10)
+	suite.mockManager.EXPECT().
+		CreateEntities(mock.Anything, mock.Anything, false).
+		Return(nil, errors.New("connection refused")).
+		Once()
+
+	w := suite.serveBatch(handler, "", strings.NewReader(batchBody(1)))
+
+	assert.Equal(suite.T(), http.StatusInternalServerError, w.Code)
+}
+
+func (suite *HandlerTestSuite) TestCreateEntities_InvalidRequests() {
+	tests := []struct {
+		name           string
+		query          string
+		body           string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "invalid atomic parameter",
+			query:          "?atomic=maybe",
+			body:           batchBody(1),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"Invalid atomic parameter"}`,
+		},
+		{
+			name:           "not an array",
+			body:           `{"id":"id-0"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"batch must be a JSON array"}`,
+		},
+		{
+			name:           "malformed item",
+			body:           `[{"id":`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"malformed JSON: unexpected end of input"}`,
+		},
+		{
+			name:           "empty batch",
+			body:           `[]`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"batch must contain at least one item"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			handler := NewHandler(suite.mockManager, validatorAdapter.NewPlaygroundAdapter(), 10)
+
+			w := suite.serveBatch(handler, tt.query, strings.NewReader(tt.body))
+
+			assert.Equal(suite.T(), tt.expectedStatus, w.Code)
+			assert.JSONEq(suite.T(), tt.expectedBody, w.Body.String())
+		})
+	}
+}
+
+func (suite *HandlerTestSuite) TestCreateEntities_ItemCap() {
+	suite.Run("just under the cap", func() {
+		handler := NewHandler(suite.mockManager, validatorAdapter.NewPlaygroundAdapter(), 3)
+		suite.mockManager.EXPECT().
+			CreateEntities(mock.Anything, mock.Anything, false).
+			RunAndReturn(func(_ context.Context, params []example.CreateEntityParams, _ bool) ([]example.CreateEntityResult, error) {
+				results := make([]example.CreateEntityResult, len(params))
+				for i, p := range params {
+					results[i].Entity = &example.Entity{ID: p.ID, Email: p.Email, Name: p.Name}
+				}
+				return results, nil
+			}).
+			Once()
+
+		w := suite.serveBatch(handler, "", strings.NewReader(batchBody(3)))
+
+		assert.Equal(suite.T(), http.StatusCreated, w.Code)
+	})
+
+	suite.Run("just over the cap", func() {
+		handler := NewHandler(suite.mockManager, validatorAdapter.NewPlaygroundAdapter(), 3)
+
+		w := suite.serveBatch(handler, "", strings.NewReader(batchBody(4)))
+
+		assert.Equal(suite.T(), http.StatusRequestEntityTooLarge, w.Code)
+		assert.JSONEq(suite.T(), `{"error":"batch exceeds the maximum of 3 items"}`, w.Body.String())
+	})
+
+	suite.Run("stops reading once the cap is exceeded", func() {
+		handler := NewHandler(suite.mockManager, validatorAdapter.NewPlaygroundAdapter(), 3)
+		body := &countingReader{r: strings.NewReader(batchBody(100000))}
+
+		w := suite.serveBatch(handler, "", body)
+
+		assert.Equal(suite.T(), http.StatusRequestEntityTooLarge, w.Code)
+		assert.Less(suite.T(), body.n, 64*1024)
+	})
+}
+
+type countingReader struct {
+	r io.Reader
+	n int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += n
+	return n, err
+}
+
 func (suite *HandlerTestSuite) TestMapDomainError() {
 	tests := []struct {
 		name           string
@@ -344,6 +1146,18 @@ func (suite *HandlerTestSuite) TestMapDomainError() {
 			expectedStatus: http.StatusConflict,
 			expectedMsg:    "Entity already exists",
 		},
+		{
+			name:           "not deleted error",
+			inputError:     example.ErrEntityNotDeleted,
+			expectedStatus: http.StatusConflict,
+			expectedMsg:    "Entity is not deleted",
+		},
+		{
+			name:           "batch aborted error",
+			inputError:     example.ErrBatchAborted,
+			expectedStatus: http.StatusFailedDependency,
+			expectedMsg:    "Aborted because another item in the batch failed",
+		},
 	}
 
 	for _, tt := range tests {
@@ -366,11 +1180,12 @@ func (suite *HandlerTestSuite) TestMapDomainError_UnknownError() {
 }
 
 func (suite *HandlerTestSuite) TestNewHandler() {
-	handler := NewHandler(suite.mockManager, suite.mockValidator)
+	handler := NewHandler(suite.mockManager, suite.mockValidator, 100)
 
 	assert.NotNil(suite.T(), handler)
 	assert.Equal(suite.T(), suite.mockManager, handler.manager)
 	assert.Equal(suite.T(), suite.mockValidator, handler.validate)
+	assert.Equal(suite.T(), 100, handler.maxBatchItems)
 }
 
 func TestHandlerTestSuite(t *testing.T) {
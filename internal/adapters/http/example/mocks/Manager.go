@@ -38,6 +38,80 @@ func (_m *MockManager) EXPECT() *MockManager_Expecter {
 	return &MockManager_Expecter{mock: &_m.Mock}
 }
 
+// CreateEntities provides a mock function for the type MockManager
+func (_mock *MockManager) CreateEntities(ctx context.Context, params []example.CreateEntityParams, atomic bool) ([]example.CreateEntityResult, error) {
+	ret := _mock.Called(ctx, params, atomic)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateEntities")
+	}
+
+	var r0 []example.CreateEntityResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []example.CreateEntityParams, bool) ([]example.CreateEntityResult, error)); ok {
+		return returnFunc(ctx, params, atomic)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []example.CreateEntityParams, bool) []example.CreateEntityResult); ok {
+		r0 = returnFunc(ctx, params, atomic)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]example.CreateEntityResult)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []example.CreateEntityParams, bool) error); ok {
+		r1 = returnFunc(ctx, params, atomic)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockManager_CreateEntities_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateEntities'
+type MockManager_CreateEntities_Call struct {
+	*mock.Call
+}
+
+// CreateEntities is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params []example.CreateEntityParams
+//   - atomic bool
+func (_e *MockManager_Expecter) CreateEntities(ctx interface{}, params interface{}, atomic interface{}) *MockManager_CreateEntities_Call {
+	return &MockManager_CreateEntities_Call{Call: _e.mock.On("CreateEntities", ctx, params, atomic)}
+}
+
+func (_c *MockManager_CreateEntities_Call) Run(run func(ctx context.Context, params []example.CreateEntityParams, atomic bool)) *MockManager_CreateEntities_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []example.CreateEntityParams
+		if args[1] != nil {
+			arg1 = args[1].([]example.CreateEntityParams)
+		}
+		var arg2 bool
+		if args[2] != nil {
+			arg2 = args[2].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockManager_CreateEntities_Call) Return(createEntityResults []example.CreateEntityResult, err error) *MockManager_CreateEntities_Call {
+	_c.Call.Return(createEntityResults, err)
+	return _c
+}
+
+func (_c *MockManager_CreateEntities_Call) RunAndReturn(run func(ctx context.Context, params []example.CreateEntityParams, atomic bool) ([]example.CreateEntityResult, error)) *MockManager_CreateEntities_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateEntity provides a mock function for the type MockManager
 func (_mock *MockManager) CreateEntity(ctx context.Context, id string, email string, name string) (*example.Entity, error) {
 	ret := _mock.Called(ctx, id, email, name)
@@ -185,3 +259,281 @@ func (_c *MockManager_GetEntity_Call) RunAndReturn(run func(ctx context.Context,
 	_c.Call.Return(run)
 	return _c
 }
+
+// GetEntityByEmail provides a mock function for the type MockManager
+func (_mock *MockManager) GetEntityByEmail(ctx context.Context, email string) (*example.Entity, error) {
+	ret := _mock.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEntityByEmail")
+	}
+
+	var r0 *example.Entity
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*example.Entity, error)); ok {
+		return returnFunc(ctx, email)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *example.Entity); ok {
+		r0 = returnFunc(ctx, email)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*example.Entity)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockManager_GetEntityByEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEntityByEmail'
+type MockManager_GetEntityByEmail_Call struct {
+	*mock.Call
+}
+
+// GetEntityByEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+func (_e *MockManager_Expecter) GetEntityByEmail(ctx interface{}, email interface{}) *MockManager_GetEntityByEmail_Call {
+	return &MockManager_GetEntityByEmail_Call{Call: _e.mock.On("GetEntityByEmail", ctx, email)}
+}
+
+func (_c *MockManager_GetEntityByEmail_Call) Run(run func(ctx context.Context, email string)) *MockManager_GetEntityByEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockManager_GetEntityByEmail_Call) Return(entity *example.Entity, err error) *MockManager_GetEntityByEmail_Call {
+	_c.Call.Return(entity, err)
+	return _c
+}
+
+func (_c *MockManager_GetEntityByEmail_Call) RunAndReturn(run func(ctx context.Context, email string) (*example.Entity, error)) *MockManager_GetEntityByEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListEntities provides a mock function for the type MockManager
+func (_mock *MockManager) ListEntities(ctx context.Context) ([]*example.Entity, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListEntities")
+	}
+
+	var r0 []*example.Entity
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]*example.Entity, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []*example.Entity); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*example.Entity)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockManager_ListEntities_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListEntities'
+type MockManager_ListEntities_Call struct {
+	*mock.Call
+}
+
+// ListEntities is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockManager_Expecter) ListEntities(ctx interface{}) *MockManager_ListEntities_Call {
+	return &MockManager_ListEntities_Call{Call: _e.mock.On("ListEntities", ctx)}
+}
+
+func (_c *MockManager_ListEntities_Call) Run(run func(ctx context.Context)) *MockManager_ListEntities_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockManager_ListEntities_Call) Return(entitys []*example.Entity, err error) *MockManager_ListEntities_Call {
+	_c.Call.Return(entitys, err)
+	return _c
+}
+
+func (_c *MockManager_ListEntities_Call) RunAndReturn(run func(ctx context.Context) ([]*example.Entity, error)) *MockManager_ListEntities_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreEntity provides a mock function for the type MockManager
+func (_mock *MockManager) RestoreEntity(ctx context.Context, id string) (*example.Entity, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreEntity")
+	}
+
+	var r0 *example.Entity
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*example.Entity, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *example.Entity); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*example.Entity)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockManager_RestoreEntity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreEntity'
+type MockManager_RestoreEntity_Call struct {
+	*mock.Call
+}
+
+// RestoreEntity is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockManager_Expecter) RestoreEntity(ctx interface{}, id interface{}) *MockManager_RestoreEntity_Call {
+	return &MockManager_RestoreEntity_Call{Call: _e.mock.On("RestoreEntity", ctx, id)}
+}
+
+func (_c *MockManager_RestoreEntity_Call) Run(run func(ctx context.Context, id string)) *MockManager_RestoreEntity_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockManager_RestoreEntity_Call) Return(entity *example.Entity, err error) *MockManager_RestoreEntity_Call {
+	_c.Call.Return(entity, err)
+	return _c
+}
+
+func (_c *MockManager_RestoreEntity_Call) RunAndReturn(run func(ctx context.Context, id string) (*example.Entity, error)) *MockManager_RestoreEntity_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateEntity provides a mock function for the type MockManager
+func (_mock *MockManager) UpdateEntity(ctx context.Context, id string, email *string, name *string) (*example.Entity, error) {
+	ret := _mock.Called(ctx, id, email, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateEntity")
+	}
+
+	var r0 *example.Entity
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *string, *string) (*example.Entity, error)); ok {
+		return returnFunc(ctx, id, email, name)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *string, *string) *example.Entity); ok {
+		r0 = returnFunc(ctx, id, email, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*example.Entity)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *string, *string) error); ok {
+		r1 = returnFunc(ctx, id, email, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockManager_UpdateEntity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateEntity'
+type MockManager_UpdateEntity_Call struct {
+	*mock.Call
+}
+
+// UpdateEntity is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - email *string
+//   - name *string
+func (_e *MockManager_Expecter) UpdateEntity(ctx interface{}, id interface{}, email interface{}, name interface{}) *MockManager_UpdateEntity_Call {
+	return &MockManager_UpdateEntity_Call{Call: _e.mock.On("UpdateEntity", ctx, id, email, name)}
+}
+
+func (_c *MockManager_UpdateEntity_Call) Run(run func(ctx context.Context, id string, email *string, name *string)) *MockManager_UpdateEntity_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *string
+		if args[2] != nil {
+			arg2 = args[2].(*string)
+		}
+		var arg3 *string
+		if args[3] != nil {
+			arg3 = args[3].(*string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockManager_UpdateEntity_Call) Return(entity *example.Entity, err error) *MockManager_UpdateEntity_Call {
+	_c.Call.Return(entity, err)
+	return _c
+}
+
+func (_c *MockManager_UpdateEntity_Call) RunAndReturn(run func(ctx context.Context, id string, email *string, name *string) (*example.Entity, error)) *MockManager_UpdateEntity_Call {
+	_c.Call.Return(run)
+	return _c
+}
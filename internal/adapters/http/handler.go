@@ -1,6 +1,7 @@
 package http
 
 import (
+	"context"
 	"errors"
 	httpErrors "microservice/internal/platform/http"
 	"microservice/internal/platform/logger"
@@ -26,6 +27,36 @@ func ErrorHandler(next HandlerFunc) http.HandlerFunc {
 			return
 		}
 
+		// The request context ended before the work finished: the client went
+		// away or the deadline passed. Neither is a server failure.
+		switch r.Context().Err() {
+		case context.Canceled:
+			contextLogger.Info("Request cancelled by client",
+				logger.String("method", r.Method),
+				logger.String("path", r.URL.Path),
+				logger.Error(err))
+			response.RespondError(w, httpErrors.StatusClientClosedRequest, errors.New("request cancelled"))
+			return
+		case context.DeadlineExceeded:
+			contextLogger.Warn("Request timed out",
+				logger.String("method", r.Method),
+				logger.String("path", r.URL.Path),
+				logger.Error(err))
+			response.RespondError(w, http.StatusRequestTimeout, errors.New("request timed out"))
+			return
+		}
+
+		// A deadline of our own, such as the database query timeout, expired
+		// while the request was still live. The server was too slow.
+		if errors.Is(err, context.DeadlineExceeded) {
+			contextLogger.Error("Operation timed out",
+				logger.String("method", r.Method),
+				logger.String("path", r.URL.Path),
+				logger.Error(err))
+			response.RespondError(w, http.StatusGatewayTimeout, errors.New("operation timed out"))
+			return
+		}
+
 		contextLogger.Error("Unexpected server error",
 			logger.String("method", r.Method),
 			logger.String("path", r.URL.Path),
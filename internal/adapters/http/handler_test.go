@@ -1,15 +1,28 @@
 package http
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	platformPostgres "microservice/internal/platform/database/postgres"
 	httpErrors "microservice/internal/platform/http"
 	"microservice/internal/platform/logger"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-chi/chi/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"microservice/internal/adapters/database"
+	"microservice/internal/adapters/http/example"
+	"microservice/internal/adapters/repository/postgres"
+	"microservice/internal/config"
+	exampleDomain "microservice/internal/core/domain/example"
+	exampleUseCase "microservice/internal/core/usecase/example"
 )
 
 func TestErrorHandler_Success(t *testing.T) {
@@ -112,6 +125,104 @@ func TestErrorHandler_UnknownError(t *testing.T) {
 	assert.JSONEq(t, `{"error":"internal server error"}`, w.Body.String())
 }
 
+func TestErrorHandler_ContextErrors(t *testing.T) {
+	cancelled := func(ctx context.Context) (context.Context, context.CancelFunc) {
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+		return ctx, cancel
+	}
+	expired := func(ctx context.Context) (context.Context, context.CancelFunc) {
+		return context.WithDeadline(ctx, time.Now().Add(-time.Second))
+	}
+	live := func(ctx context.Context) (context.Context, context.CancelFunc) {
+		return ctx, func() {}
+	}
+
+	tests := []struct {
+		name           string
+		requestContext func(context.Context) (context.Context, context.CancelFunc)
+		err            error
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "cancelled",
+			requestContext: cancelled,
+			err:            fmt.Errorf("get entity: %w", context.Canceled),
+			expectedStatus: httpErrors.StatusClientClosedRequest,
+			expectedBody:   `{"error":"request cancelled"}`,
+		},
+		{
+			name:           "deadline_exceeded",
+			requestContext: expired,
+			err:            fmt.Errorf("get entity: %w", context.DeadlineExceeded),
+			expectedStatus: http.StatusRequestTimeout,
+			expectedBody:   `{"error":"request timed out"}`,
+		},
+		{
+			name:           "own_deadline_exceeded",
+			requestContext: live,
+			err:            fmt.Errorf("get entity: %w", context.DeadlineExceeded),
+			expectedStatus: http.StatusGatewayTimeout,
+			expectedBody:   `{"error":"operation timed out"}`,
+		},
+		{
+			name:           "own_context_cancelled",
+			requestContext: live,
+			err:            fmt.Errorf("get entity: %w", context.Canceled),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"error":"internal server error"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handlerFunc := func(w http.ResponseWriter, r *http.Request) error {
+				return tt.err
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			ctx, cancel := tt.requestContext(logger.WithLogger(req.Context(), logger.NewNop()))
+			defer cancel()
+			req = req.WithContext(ctx)
+			w := httptest.NewRecorder()
+
+			ErrorHandler(handlerFunc)(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+		})
+	}
+}
+
+// A query timeout in the repository is the server being slow, not the client
+// giving up, so it must not be answered with 408.
+func TestErrorHandler_QueryTimeoutWithLiveRequest(t *testing.T) {
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	dbMock.ExpectQuery("SELECT id, email, name, updated_at, deleted_at FROM examples").
+		WillDelayFor(time.Second).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "email", "name", "updated_at", "deleted_at"}))
+
+	dbCfg := &config.DatabaseConfig{Postgres: config.PostgresConfig{QueryTimeout: 20 * time.Millisecond}}
+	lifecycle := database.NewLifecycleWithConnection(dbCfg, logger.NewNop(), &platformPostgres.DB{DB: db})
+	usecase := exampleUseCase.NewUsecase(postgres.NewRepository(lifecycle), exampleDomain.NewService())
+	handler := example.NewHandler(usecase, nil, 100)
+
+	router := chi.NewRouter()
+	router.Get("/api/examples/{id}", ErrorHandler(handler.GetEntity))
+	req := httptest.NewRequest(http.MethodGet, "/api/examples/slow-id", nil)
+	req = req.WithContext(logger.WithLogger(req.Context(), logger.NewNop()))
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	require.NoError(t, req.Context().Err())
+	assert.Equal(t, http.StatusGatewayTimeout, w.Code)
+	assert.JSONEq(t, `{"error":"operation timed out"}`, w.Body.String())
+}
+
 func TestErrorHandler_MultipleRequestTypes(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -1,20 +1,45 @@
 package health
 
 import (
+	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"microservice/internal/adapters/http/response"
 )
 
+// Watchdog reports whether a loop the process depends on is still making
+// progress. platform/health.Watchdog implements it.
+type Watchdog interface {
+	Name() string
+	Healthy() bool
+}
+
 type LivenessHandler struct {
-	version string
+	version   string
+	watchdogs []Watchdog
 }
 
-func NewLivenessHandler(version string) *LivenessHandler {
-	return &LivenessHandler{
+type LivenessOption func(*LivenessHandler)
+
+// WithWatchdog fails the liveness probe while watchdog is not kicked, so a
+// wedged loop gets the process restarted. Without watchdogs the probe always
+// passes.
+func WithWatchdog(watchdog Watchdog) LivenessOption {
+	return func(h *LivenessHandler) {
+		h.watchdogs = append(h.watchdogs, watchdog)
+	}
+}
+
+func NewLivenessHandler(version string, opts ...LivenessOption) *LivenessHandler {
+	h := &LivenessHandler{
 		version: version,
 	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
 }
 
 func (h *LivenessHandler) Check(w http.ResponseWriter, r *http.Request) {
@@ -30,6 +55,23 @@ func (h *LivenessHandler) Check(w http.ResponseWriter, r *http.Request) {
 			Timestamp: time.Now(),
 			Version:   h.version,
 		}
-		response.RespondJSON(w, http.StatusOK, livenessResponse)
+
+		statusCode := http.StatusOK
+		if stalled := h.stalledWatchdogs(); len(stalled) > 0 {
+			livenessResponse.Status = StatusFail
+			livenessResponse.Output = fmt.Sprintf("watchdog not kicked in time: %s", strings.Join(stalled, ", "))
+			statusCode = http.StatusServiceUnavailable
+		}
+		response.RespondJSON(w, statusCode, livenessResponse)
+	}
+}
+
+func (h *LivenessHandler) stalledWatchdogs() []string {
+	var stalled []string
+	for _, watchdog := range h.watchdogs {
+		if !watchdog.Healthy() {
+			stalled = append(stalled, watchdog.Name())
+		}
 	}
+	return stalled
 }
@@ -98,3 +98,40 @@ func TestLivenessResponse_JSONFields(t *testing.T) {
 	assert.Equal(t, "v1.0.0", unmarshaled.Version)
 	assert.WithinDuration(t, timestamp, unmarshaled.Timestamp, time.Millisecond)
 }
+
+type stubWatchdog struct {
+	name    string
+	healthy bool
+}
+
+func (w *stubWatchdog) Name() string  { return w.name }
+func (w *stubWatchdog) Healthy() bool { return w.healthy }
+
+func TestLivenessHandler_Check_Watchdogs(t *testing.T) {
+	eventLoop := &stubWatchdog{name: "event-loop", healthy: true}
+	worker := &stubWatchdog{name: "worker", healthy: true}
+	handler := NewLivenessHandler("v1.0.0", WithWatchdog(eventLoop), WithWatchdog(worker))
+
+	check := func() (int, LivenessResponse) {
+		w := httptest.NewRecorder()
+		handler.Check(w, httptest.NewRequest(http.MethodGet, "/health/live", nil))
+		var response LivenessResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return w.Code, response
+	}
+
+	code, response := check()
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, StatusPass, response.Status)
+	assert.Empty(t, response.Output)
+
+	worker.healthy = false
+	code, response = check()
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, StatusFail, response.Status)
+	assert.Equal(t, "watchdog not kicked in time: worker", response.Output)
+
+	worker.healthy = true
+	code, _ = check()
+	assert.Equal(t, http.StatusOK, code)
+}
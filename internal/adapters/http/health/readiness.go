@@ -4,26 +4,65 @@ import (
 	"context"
 	"microservice/internal/platform/health"
 	"microservice/internal/platform/logger"
+	"microservice/internal/platform/metrics"
 	"net/http"
+	"sort"
+	"sync/atomic"
 	"time"
 
 	"microservice/internal/adapters/http/response"
 )
 
+// DegradationRecorder counts responses served in a degraded state.
+type DegradationRecorder interface {
+	RecordDegraded(ctx context.Context, reason string)
+}
+
+// defaultReadinessTimeout bounds a readiness check when no timeout is given.
+const defaultReadinessTimeout = 5 * time.Second
+
 type ReadinessHandler struct {
 	version       string
 	healthManager health.ManagerInterface
+	degradation   DegradationRecorder
+	timeout       time.Duration
+	shuttingDown  atomic.Bool
 }
 
-func NewReadinessHandler(version string, healthManager health.ManagerInterface) *ReadinessHandler {
+// NewReadinessHandler creates a readiness handler. degradation may be nil, in
+// which case warn responses are not counted. timeout bounds how long the
+// checks may run; checks still running then are reported as warn. A
+// non-positive timeout uses the default of 5 seconds.
+func NewReadinessHandler(version string, healthManager health.ManagerInterface, degradation DegradationRecorder, timeout time.Duration) *ReadinessHandler {
+	if timeout <= 0 {
+		timeout = defaultReadinessTimeout
+	}
 	return &ReadinessHandler{
 		version:       version,
 		healthManager: healthManager,
+		degradation:   degradation,
+		timeout:       timeout,
 	}
 }
 
+// MarkShuttingDown makes every later check fail with 503 without running the
+// dependency checks, so load balancers stop routing traffic here before the
+// server starts draining.
+func (h *ReadinessHandler) MarkShuttingDown() {
+	h.shuttingDown.Store(true)
+}
+
 func (h *ReadinessHandler) Check(w http.ResponseWriter, r *http.Request) {
-	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	if h.shuttingDown.Load() {
+		response.RespondJSON(w, http.StatusServiceUnavailable, ReadinessResponse{
+			Status:  StatusFail,
+			Version: h.version,
+			Notes:   []string{"Service is shutting down"},
+		})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeout)
 	defer cancel()
 
 	log := logger.FromContext(ctx)
@@ -38,6 +77,13 @@ func (h *ReadinessHandler) Check(w http.ResponseWriter, r *http.Request) {
 		case health.StatusHealthy:
 			status = StatusPass
 		case health.StatusUnhealthy:
+			if result.Optional {
+				status = StatusWarn
+				if overallStatus == StatusPass {
+					overallStatus = StatusWarn
+				}
+				break
+			}
 			status = StatusFail
 			overallStatus = StatusFail
 		default:
@@ -47,9 +93,14 @@ func (h *ReadinessHandler) Check(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 
+		componentType := "dependency"
+		if result.Group != "" {
+			componentType = result.Group
+		}
+
 		checkDetail := CheckDetail{
 			ComponentId:   name,
-			ComponentType: "dependency",
+			ComponentType: componentType,
 			Status:        status,
 			Time:          time.Now(),
 			Output:        result.Message,
@@ -59,10 +110,13 @@ func (h *ReadinessHandler) Check(w http.ResponseWriter, r *http.Request) {
 			checkDetail.Output = result.Error
 		}
 
-		checks[name] = []CheckDetail{checkDetail}
+		checks[name] = append([]CheckDetail{checkDetail}, componentDetails(result.Components)...)
 
-		if status == StatusFail {
+		switch {
+		case status == StatusFail:
 			notes = append(notes, "Dependency "+name+" is unavailable")
+		case result.Optional && result.Status == health.StatusUnhealthy:
+			notes = append(notes, "Optional dependency "+name+" is unavailable")
 		}
 	}
 
@@ -74,10 +128,52 @@ func (h *ReadinessHandler) Check(w http.ResponseWriter, r *http.Request) {
 	}
 
 	statusCode := http.StatusOK
-	if overallStatus == StatusFail {
+	switch overallStatus {
+	case StatusFail:
 		statusCode = http.StatusServiceUnavailable
 		log.Warn("Readiness check failed", logger.String("status", string(overallStatus)))
+	case StatusWarn:
+		if h.degradation != nil {
+			h.degradation.RecordDegraded(ctx, metrics.DegradedReasonDependencyWarn)
+		}
 	}
 
 	response.RespondJSON(w, statusCode, readinessResponse)
 }
+
+// componentDetails lists the dependencies behind a rolled-up check after the
+// check's own entry, sorted by name.
+func componentDetails(components map[string]health.CheckResult) []CheckDetail {
+	if len(components) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(components))
+	for name := range components {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	details := make([]CheckDetail, 0, len(names))
+	for _, name := range names {
+		component := components[name]
+		status := StatusWarn
+		switch {
+		case component.Status == health.StatusHealthy:
+			status = StatusPass
+		case component.Status == health.StatusUnhealthy && !component.Optional:
+			status = StatusFail
+		}
+		output := component.Message
+		if component.Error != "" {
+			output = component.Error
+		}
+		details = append(details, CheckDetail{
+			ComponentId:   name,
+			ComponentType: "component",
+			Status:        status,
+			Time:          time.Now(),
+			Output:        output,
+		})
+	}
+	return details
+}
@@ -1,10 +1,12 @@
 package health
 
 import (
+	"context"
 	"encoding/json"
 	"microservice/internal/platform/health"
 	"microservice/internal/platform/health/mocks"
 	"microservice/internal/platform/logger"
+	"microservice/internal/platform/metrics"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -19,7 +21,7 @@ func TestNewReadinessHandler(t *testing.T) {
 	version := "v1.0.0"
 	mockManager := mocks.NewMockManagerInterface(t)
 
-	handler := NewReadinessHandler(version, mockManager)
+	handler := NewReadinessHandler(version, mockManager, nil, 0)
 
 	assert.NotNil(t, handler)
 	assert.Equal(t, version, handler.version)
@@ -41,7 +43,7 @@ func TestReadinessHandler_Check_AllHealthy(t *testing.T) {
 	}
 	mockManager.EXPECT().CheckAll(mock.Anything).Return(checkResults).Once()
 
-	handler := NewReadinessHandler(version, mockManager)
+	handler := NewReadinessHandler(version, mockManager, nil, 0)
 	req := httptest.NewRequest(http.MethodGet, "/health/readiness", nil)
 	req = req.WithContext(logger.WithLogger(req.Context(), logger.NewNop()))
 	w := httptest.NewRecorder()
@@ -81,7 +83,7 @@ func TestReadinessHandler_Check_WithUnhealthyDependency(t *testing.T) {
 	}
 	mockManager.EXPECT().CheckAll(mock.Anything).Return(checkResults).Once()
 
-	handler := NewReadinessHandler("v1.0.0", mockManager)
+	handler := NewReadinessHandler("v1.0.0", mockManager, nil, 0)
 	req := httptest.NewRequest(http.MethodGet, "/health/readiness", nil)
 	req = req.WithContext(logger.WithLogger(req.Context(), logger.NewNop()))
 	w := httptest.NewRecorder()
@@ -117,7 +119,7 @@ func TestReadinessHandler_Check_WithWarningDependency(t *testing.T) {
 	}
 	mockManager.EXPECT().CheckAll(mock.Anything).Return(checkResults).Once()
 
-	handler := NewReadinessHandler("v1.0.0", mockManager)
+	handler := NewReadinessHandler("v1.0.0", mockManager, nil, 0)
 	req := httptest.NewRequest(http.MethodGet, "/health/readiness", nil)
 	req = req.WithContext(logger.WithLogger(req.Context(), logger.NewNop()))
 	w := httptest.NewRecorder()
@@ -138,12 +140,80 @@ func TestReadinessHandler_Check_WithWarningDependency(t *testing.T) {
 	assert.Equal(t, "High latency detected", apiCheck.Output)
 }
 
+func TestReadinessHandler_Check_DegradedDependencyWarns(t *testing.T) {
+	mockManager := mocks.NewMockManagerInterface(t)
+	mockManager.EXPECT().CheckAll(mock.Anything).Return(map[string]health.CheckResult{
+		"certificates": {
+			Status:  health.StatusDegraded,
+			Message: "certificate server.pem expires at 2026-01-01T00:00:00Z",
+		},
+	}).Once()
+
+	handler := NewReadinessHandler("v1.0.0", mockManager, nil, 0)
+	req := httptest.NewRequest(http.MethodGet, "/health/readiness", nil)
+	req = req.WithContext(logger.WithLogger(req.Context(), logger.NewNop()))
+	w := httptest.NewRecorder()
+
+	handler.Check(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response ReadinessResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, StatusWarn, response.Status)
+	assert.Equal(t, StatusWarn, response.Checks["certificates"][0].Status)
+}
+
+func TestReadinessHandler_Check_RolledUpComponents(t *testing.T) {
+	mockManager := mocks.NewMockManagerInterface(t)
+	mockManager.EXPECT().CheckAll(mock.Anything).Return(map[string]health.CheckResult{
+		"services": {
+			Status:  health.StatusUnhealthy,
+			Message: "1 of 3 dependencies healthy",
+			Error:   "unhealthy: search",
+			Components: map[string]health.CheckResult{
+				"search":  {Status: health.StatusUnhealthy, Message: "api returned status 503"},
+				"billing": {Status: health.StatusHealthy, Message: "api responding with status 200"},
+				"mailer":  {Status: health.StatusUnhealthy, Optional: true, Error: "connection refused"},
+			},
+		},
+	}).Once()
+
+	handler := NewReadinessHandler("v1.0.0", mockManager, nil, 0)
+	req := httptest.NewRequest(http.MethodGet, "/health/readiness", nil)
+	req = req.WithContext(logger.WithLogger(req.Context(), logger.NewNop()))
+	w := httptest.NewRecorder()
+
+	handler.Check(w, req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+	var response ReadinessResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	details := response.Checks["services"]
+	require.Len(t, details, 4)
+	assert.Equal(t, "services", details[0].ComponentId)
+	assert.Equal(t, StatusFail, details[0].Status)
+	assert.Equal(t, "unhealthy: search", details[0].Output)
+
+	components := make(map[string]CheckDetail)
+	for _, detail := range details[1:] {
+		assert.Equal(t, "component", detail.ComponentType)
+		components[detail.ComponentId] = detail
+	}
+	assert.Equal(t, []string{"billing", "mailer", "search"}, []string{details[1].ComponentId, details[2].ComponentId, details[3].ComponentId})
+	assert.Equal(t, StatusPass, components["billing"].Status)
+	assert.Equal(t, StatusWarn, components["mailer"].Status)
+	assert.Equal(t, "connection refused", components["mailer"].Output)
+	assert.Equal(t, StatusFail, components["search"].Status)
+}
+
 func TestReadinessHandler_Check_NoHealthChecks(t *testing.T) {
 	mockManager := mocks.NewMockManagerInterface(t)
 	checkResults := map[string]health.CheckResult{}
 	mockManager.EXPECT().CheckAll(mock.Anything).Return(checkResults).Once()
 
-	handler := NewReadinessHandler("v1.0.0", mockManager)
+	handler := NewReadinessHandler("v1.0.0", mockManager, nil, 0)
 	req := httptest.NewRequest(http.MethodGet, "/health/readiness", nil)
 	req = req.WithContext(logger.WithLogger(req.Context(), logger.NewNop()))
 	w := httptest.NewRecorder()
@@ -179,7 +249,7 @@ func TestReadinessHandler_Check_MixedStatuses(t *testing.T) {
 	}
 	mockManager.EXPECT().CheckAll(mock.Anything).Return(checkResults).Once()
 
-	handler := NewReadinessHandler("v1.0.0", mockManager)
+	handler := NewReadinessHandler("v1.0.0", mockManager, nil, 0)
 	req := httptest.NewRequest(http.MethodGet, "/health/readiness", nil)
 	req = req.WithContext(logger.WithLogger(req.Context(), logger.NewNop()))
 	w := httptest.NewRecorder()
@@ -196,6 +266,177 @@ func TestReadinessHandler_Check_MixedStatuses(t *testing.T) {
 	assert.Len(t, response.Checks, 3)
 }
 
+func TestReadinessHandler_Check_WithGroupedCheckers(t *testing.T) {
+	manager := health.NewManager()
+	manager.Register(health.WithGroup(&stubChecker{name: "database", result: health.CheckResult{Status: health.StatusHealthy}}, health.GroupCore))
+	manager.Register(health.WithGroup(&stubChecker{name: "cache", result: health.CheckResult{Status: health.StatusHealthy}}, health.GroupOptional))
+	manager.Register(health.WithGroup(&stubChecker{name: "payments_api", result: health.CheckResult{Status: health.StatusHealthy}}, health.GroupExternal))
+	manager.Register(&stubChecker{name: "memory", result: health.CheckResult{Status: health.StatusHealthy}})
+
+	handler := NewReadinessHandler("v1.0.0", manager, nil, 0)
+	req := httptest.NewRequest(http.MethodGet, "/health/readiness", nil)
+	req = req.WithContext(logger.WithLogger(req.Context(), logger.NewNop()))
+	w := httptest.NewRecorder()
+
+	handler.Check(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response ReadinessResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+
+	require.Len(t, response.Checks, 4)
+	assert.Equal(t, health.GroupCore, response.Checks["database"][0].ComponentType)
+	assert.Equal(t, health.GroupOptional, response.Checks["cache"][0].ComponentType)
+	assert.Equal(t, health.GroupExternal, response.Checks["payments_api"][0].ComponentType)
+	assert.Equal(t, "dependency", response.Checks["memory"][0].ComponentType)
+}
+
+func TestReadinessHandler_Check_OptionalDependency(t *testing.T) {
+	tests := []struct {
+		name           string
+		databaseStatus health.Status
+		cacheStatus    health.Status
+		expectedCode   int
+		expectedStatus Status
+		expectedCache  Status
+		expectedNote   string
+	}{
+		{
+			name:           "optional_healthy",
+			databaseStatus: health.StatusHealthy,
+			cacheStatus:    health.StatusHealthy,
+			expectedCode:   http.StatusOK,
+			expectedStatus: StatusPass,
+			expectedCache:  StatusPass,
+		},
+		{
+			name:           "optional_unhealthy_warns",
+			databaseStatus: health.StatusHealthy,
+			cacheStatus:    health.StatusUnhealthy,
+			expectedCode:   http.StatusOK,
+			expectedStatus: StatusWarn,
+			expectedCache:  StatusWarn,
+			expectedNote:   "Optional dependency cache is unavailable",
+		},
+		{
+			name:           "critical_unhealthy_still_fails",
+			databaseStatus: health.StatusUnhealthy,
+			cacheStatus:    health.StatusUnhealthy,
+			expectedCode:   http.StatusServiceUnavailable,
+			expectedStatus: StatusFail,
+			expectedCache:  StatusWarn,
+			expectedNote:   "Dependency database is unavailable",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager := health.NewManager()
+			manager.Register(&stubChecker{name: "database", result: health.CheckResult{Status: tt.databaseStatus}})
+			manager.RegisterOptional(&stubChecker{name: "cache", result: health.CheckResult{Status: tt.cacheStatus}})
+
+			handler := NewReadinessHandler("v1.0.0", manager, nil, 0)
+			req := httptest.NewRequest(http.MethodGet, "/health/readiness", nil)
+			req = req.WithContext(logger.WithLogger(req.Context(), logger.NewNop()))
+			w := httptest.NewRecorder()
+
+			handler.Check(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+
+			var response ReadinessResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.expectedStatus, response.Status)
+			assert.Equal(t, tt.expectedCache, response.Checks["cache"][0].Status)
+			if tt.expectedNote == "" {
+				assert.Empty(t, response.Notes)
+			} else {
+				assert.Contains(t, response.Notes, tt.expectedNote)
+			}
+		})
+	}
+}
+
+func TestReadinessHandler_Check_OptionalFailureRecordsDegradation(t *testing.T) {
+	manager := health.NewManager()
+	manager.RegisterOptional(&stubChecker{name: "cache", result: health.CheckResult{Status: health.StatusUnhealthy}})
+	recorder := &recordingDegradation{}
+
+	handler := NewReadinessHandler("v1.0.0", manager, recorder, 0)
+	req := httptest.NewRequest(http.MethodGet, "/health/readiness", nil)
+	req = req.WithContext(logger.WithLogger(req.Context(), logger.NewNop()))
+
+	handler.Check(httptest.NewRecorder(), req)
+
+	assert.Equal(t, []string{metrics.DegradedReasonDependencyWarn}, recorder.reasons)
+}
+
+type recordingDegradation struct {
+	reasons []string
+}
+
+func (r *recordingDegradation) RecordDegraded(_ context.Context, reason string) {
+	r.reasons = append(r.reasons, reason)
+}
+
+func TestReadinessHandler_Check_RecordsDegradation(t *testing.T) {
+	tests := []struct {
+		name            string
+		status          health.Status
+		expectedReasons []string
+	}{
+		{
+			name:   "healthy",
+			status: health.StatusHealthy,
+		},
+		{
+			name:            "warn",
+			status:          "degraded",
+			expectedReasons: []string{metrics.DegradedReasonDependencyWarn},
+		},
+		{
+			name:   "unhealthy",
+			status: health.StatusUnhealthy,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager := health.NewManager()
+			manager.Register(&stubChecker{name: "cache", result: health.CheckResult{Status: tt.status}})
+			recorder := &recordingDegradation{}
+
+			handler := NewReadinessHandler("v1.0.0", manager, recorder, 0)
+			req := httptest.NewRequest(http.MethodGet, "/health/readiness", nil)
+			req = req.WithContext(logger.WithLogger(req.Context(), logger.NewNop()))
+
+			handler.Check(httptest.NewRecorder(), req)
+
+			assert.Equal(t, tt.expectedReasons, recorder.reasons)
+		})
+	}
+}
+
+func TestReadinessHandler_Check_DegradedMetric(t *testing.T) {
+	provider, err := metrics.NewProvider()
+	require.NoError(t, err)
+
+	manager := health.NewManager()
+	manager.Register(&stubChecker{name: "cache", result: health.CheckResult{Status: "degraded"}})
+
+	handler := NewReadinessHandler("v1.0.0", manager, provider, 0)
+	req := httptest.NewRequest(http.MethodGet, "/health/readiness", nil)
+	req = req.WithContext(logger.WithLogger(req.Context(), logger.NewNop()))
+
+	handler.Check(httptest.NewRecorder(), req)
+
+	w := httptest.NewRecorder()
+	provider.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Regexp(t, `service_degraded_responses_total\{[^}]*reason="dependency_warn"[^}]*\} 1`, w.Body.String())
+}
+
 func TestCheckDetail_JSONSerialization(t *testing.T) {
 	detail := CheckDetail{
 		ComponentId:   "test-component",
@@ -224,3 +465,66 @@ func TestStatus_Constants(t *testing.T) {
 	assert.Equal(t, Status("fail"), StatusFail)
 	assert.Equal(t, Status("warn"), StatusWarn)
 }
+
+type stubChecker struct {
+	name   string
+	result health.CheckResult
+	delay  time.Duration
+}
+
+func (c *stubChecker) Name() string {
+	return c.name
+}
+
+func (c *stubChecker) Check(context.Context) health.CheckResult {
+	time.Sleep(c.delay)
+	return c.result
+}
+
+func TestReadinessHandler_Check_SlowCheckersReportedWithinTimeout(t *testing.T) {
+	manager := health.NewManager()
+	manager.Register(&stubChecker{name: "memory", result: health.CheckResult{Status: health.StatusHealthy}})
+	for _, name := range []string{"database", "cache", "payments_api"} {
+		manager.Register(&stubChecker{name: name, result: health.CheckResult{Status: health.StatusHealthy}, delay: time.Second})
+	}
+
+	handler := NewReadinessHandler("v1.0.0", manager, nil, 50*time.Millisecond)
+	req := httptest.NewRequest(http.MethodGet, "/health/readiness", nil)
+	req = req.WithContext(logger.WithLogger(req.Context(), logger.NewNop()))
+	w := httptest.NewRecorder()
+
+	start := time.Now()
+	handler.Check(w, req)
+
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response ReadinessResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+
+	assert.Equal(t, StatusWarn, response.Status)
+	require.Len(t, response.Checks, 4)
+	assert.Equal(t, StatusPass, response.Checks["memory"][0].Status)
+	for _, name := range []string{"database", "cache", "payments_api"} {
+		assert.Equal(t, StatusWarn, response.Checks[name][0].Status, name)
+	}
+}
+
+func TestReadinessHandler_Check_ShuttingDown(t *testing.T) {
+	mockManager := mocks.NewMockManagerInterface(t)
+	handler := NewReadinessHandler("v1.0.0", mockManager, nil, 0)
+	handler.MarkShuttingDown()
+
+	req := httptest.NewRequest(http.MethodGet, "/health/readiness", nil)
+	w := httptest.NewRecorder()
+
+	handler.Check(w, req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+	var response ReadinessResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, StatusFail, response.Status)
+	assert.Equal(t, []string{"Service is shutting down"}, response.Notes)
+	mockManager.AssertNotCalled(t, "CheckAll", mock.Anything)
+}
@@ -0,0 +1,45 @@
+package health
+
+import (
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"microservice/internal/adapters/http/response"
+)
+
+// StartupHandler answers the startup probe. It fails until MarkReady is called
+// once initialization has finished and passes from then on. Unlike readiness
+// it does not re-check dependencies, so it only guards the initial start.
+type StartupHandler struct {
+	version string
+	ready   atomic.Bool
+}
+
+func NewStartupHandler(version string) *StartupHandler {
+	return &StartupHandler{
+		version: version,
+	}
+}
+
+// MarkReady records that startup has completed. It is safe to call more than
+// once.
+func (h *StartupHandler) MarkReady() {
+	h.ready.Store(true)
+}
+
+func (h *StartupHandler) Check(w http.ResponseWriter, r *http.Request) {
+	startupResponse := StartupResponse{
+		Status:    StatusPass,
+		Timestamp: time.Now(),
+		Version:   h.version,
+	}
+
+	statusCode := http.StatusOK
+	if !h.ready.Load() {
+		startupResponse.Status = StatusFail
+		statusCode = http.StatusServiceUnavailable
+	}
+
+	response.RespondJSON(w, statusCode, startupResponse)
+}
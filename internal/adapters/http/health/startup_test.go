@@ -0,0 +1,96 @@
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewStartupHandler(t *testing.T) {
+	handler := NewStartupHandler("v1.0.0")
+
+	assert.NotNil(t, handler)
+	assert.Equal(t, "v1.0.0", handler.version)
+	assert.False(t, handler.ready.Load())
+}
+
+func TestStartupHandler_Check(t *testing.T) {
+	tests := []struct {
+		name           string
+		markReady      bool
+		expectedCode   int
+		expectedStatus Status
+	}{
+		{
+			name:           "before_mark_ready",
+			expectedCode:   http.StatusServiceUnavailable,
+			expectedStatus: StatusFail,
+		},
+		{
+			name:           "after_mark_ready",
+			markReady:      true,
+			expectedCode:   http.StatusOK,
+			expectedStatus: StatusPass,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewStartupHandler("v1.2.3")
+			if tt.markReady {
+				handler.MarkReady()
+			}
+			w := httptest.NewRecorder()
+
+			handler.Check(w, httptest.NewRequest(http.MethodGet, "/health/startup", nil))
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+			var response StartupResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.expectedStatus, response.Status)
+			assert.Equal(t, "v1.2.3", response.Version)
+			assert.False(t, response.Timestamp.IsZero())
+		})
+	}
+}
+
+func TestStartupHandler_StaysReady(t *testing.T) {
+	handler := NewStartupHandler("v1.0.0")
+	handler.MarkReady()
+	handler.MarkReady()
+
+	for i := 0; i < 3; i++ {
+		w := httptest.NewRecorder()
+		handler.Check(w, httptest.NewRequest(http.MethodGet, "/health/startup", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+	}
+}
+
+func TestStartupHandler_ConcurrentMarkReady(t *testing.T) {
+	handler := NewStartupHandler("v1.0.0")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			handler.MarkReady()
+		}()
+		go func() {
+			defer wg.Done()
+			handler.Check(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health/startup", nil))
+		}()
+	}
+	wg.Wait()
+
+	w := httptest.NewRecorder()
+	handler.Check(w, httptest.NewRequest(http.MethodGet, "/health/startup", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+}
@@ -14,6 +14,13 @@ type LivenessResponse struct {
 	Status    Status    `json:"status"`
 	Timestamp time.Time `json:"timestamp"`
 	Version   string    `json:"version,omitempty"`
+	Output    string    `json:"output,omitempty"`
+}
+
+type StartupResponse struct {
+	Status    Status    `json:"status"`
+	Timestamp time.Time `json:"timestamp"`
+	Version   string    `json:"version,omitempty"`
 }
 
 type ReadinessResponse struct {
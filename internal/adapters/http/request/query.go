@@ -0,0 +1,212 @@
+package request
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	httpErrors "microservice/internal/platform/http"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidTarget   = errors.New("bind target must be a non-nil pointer to a struct")
+	errUnsupportedType = errors.New("unsupported field type")
+	errInvalidTag      = errors.New("invalid tag")
+	durationType       = reflect.TypeOf(time.Duration(0))
+)
+
+// BindQuery fills the fields of the struct out points to from the query
+// string. Fields are matched by their query tag; a default tag supplies the
+// value when the parameter is absent or empty, and required:"true" rejects
+// requests without it. String, bool, int, uint, float and time.Duration fields
+// are supported. Numeric fields may set min and max tags, for example
+// min:"0" on an offset. Missing, malformed or out-of-range parameters yield a
+// 400 error. A repeated parameter is bound from its first value, or rejected
+// with a 400 when the request went through StrictQuery.
+func BindQuery(r *http.Request, out interface{}) error {
+	target := reflect.ValueOf(out)
+	if target.Kind() != reflect.Pointer || target.IsNil() || target.Elem().Kind() != reflect.Struct {
+		return ErrInvalidTarget
+	}
+
+	query := r.URL.Query()
+	strict := isStrictQuery(r.Context())
+	elem := target.Elem()
+	for i := 0; i < elem.NumField(); i++ {
+		field := elem.Type().Field(i)
+		name := field.Tag.Get("query")
+		if name == "" || name == "-" || !field.IsExported() {
+			continue
+		}
+
+		if strict && len(query[name]) > 1 {
+			return httpErrors.NewBadRequest(fmt.Sprintf("Duplicate %s parameter", name), nil)
+		}
+		value := query.Get(name)
+		if value == "" {
+			if field.Tag.Get("required") == "true" {
+				return httpErrors.NewBadRequest(fmt.Sprintf("Missing %s parameter", name), nil)
+			}
+			def, ok := field.Tag.Lookup("default")
+			if !ok {
+				continue
+			}
+			value = def
+		}
+
+		err := setField(elem.Field(i), value)
+		if err == nil {
+			err = checkBounds(field, elem.Field(i))
+		}
+		if err != nil {
+			var bound *boundError
+			switch {
+			case errors.Is(err, errUnsupportedType), errors.Is(err, errInvalidTag):
+				return fmt.Errorf("bind query parameter %s: %w", name, err)
+			case errors.As(err, &bound):
+				return httpErrors.NewBadRequest(fmt.Sprintf("Invalid %s parameter: must be %s", name, bound), err)
+			case errors.Is(err, strconv.ErrRange):
+				return httpErrors.NewBadRequest(fmt.Sprintf("Invalid %s parameter: out of range", name), err)
+			}
+			return httpErrors.NewBadRequest(fmt.Sprintf("Invalid %s parameter", name), err)
+		}
+	}
+
+	return nil
+}
+
+type strictQueryKey struct{}
+
+// StrictQuery makes BindQuery reject requests that repeat a parameter it
+// binds, such as ?limit=10&limit=20, instead of silently using the first
+// value. Parameters no struct field binds may still repeat.
+func StrictQuery(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), strictQueryKey{}, true)))
+	})
+}
+
+func isStrictQuery(ctx context.Context) bool {
+	strict, _ := ctx.Value(strictQueryKey{}).(bool)
+	return strict
+}
+
+func setField(field reflect.Value, value string) error {
+	if field.Type() == durationType {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(d))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if strings.HasPrefix(value, "-") {
+			// ParseUint reports a syntax error; name the actual problem.
+			return &boundError{bound: "at least", limit: "0"}
+		}
+		n, err := strconv.ParseUint(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+	default:
+		return fmt.Errorf("%w %s", errUnsupportedType, field.Type())
+	}
+	return nil
+}
+
+// boundError reports a value outside the range allowed for its field.
+type boundError struct {
+	bound string
+	limit string
+}
+
+func (e *boundError) Error() string {
+	return e.bound + " " + e.limit
+}
+
+func checkBounds(field reflect.StructField, value reflect.Value) error {
+	for _, b := range []struct {
+		tag   string
+		bound string
+		sign  int
+	}{
+		{tag: "min", bound: "at least", sign: -1},
+		{tag: "max", bound: "at most", sign: 1},
+	} {
+		limit, ok := field.Tag.Lookup(b.tag)
+		if !ok {
+			continue
+		}
+		c, err := compare(value, limit)
+		if err != nil {
+			return fmt.Errorf("%w %s:%q: %v", errInvalidTag, b.tag, limit, err)
+		}
+		if c == b.sign {
+			return &boundError{bound: b.bound, limit: limit}
+		}
+	}
+	return nil
+}
+
+// compare returns -1, 0 or 1 as value is less than, equal to or greater than
+// limit.
+func compare(value reflect.Value, limit string) (int, error) {
+	if value.Type() == durationType {
+		l, err := time.ParseDuration(limit)
+		if err != nil {
+			return 0, err
+		}
+		return cmp.Compare(value.Int(), int64(l)), nil
+	}
+
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		l, err := strconv.ParseInt(limit, 10, 64)
+		if err != nil {
+			return 0, err
+		}
+		return cmp.Compare(value.Int(), l), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		l, err := strconv.ParseUint(limit, 10, 64)
+		if err != nil {
+			return 0, err
+		}
+		return cmp.Compare(value.Uint(), l), nil
+	case reflect.Float32, reflect.Float64:
+		l, err := strconv.ParseFloat(limit, 64)
+		if err != nil {
+			return 0, err
+		}
+		return cmp.Compare(value.Float(), l), nil
+	}
+	return 0, fmt.Errorf("%w %s", errUnsupportedType, value.Type())
+}
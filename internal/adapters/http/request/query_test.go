@@ -0,0 +1,272 @@
+package request
+
+import (
+	"errors"
+	httpErrors "microservice/internal/platform/http"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type listQuery struct {
+	Limit    int           `query:"limit" default:"20"`
+	Cursor   string        `query:"cursor"`
+	Verbose  bool          `query:"verbose"`
+	MinScore float64       `query:"min_score"`
+	MaxAge   time.Duration `query:"max_age" default:"1h"`
+	Offset   uint          `query:"offset"`
+	Ignored  string
+}
+
+func newRequest(rawQuery string) *http.Request {
+	return httptest.NewRequest(http.MethodGet, "/examples?"+rawQuery, nil)
+}
+
+func TestBindQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		expected listQuery
+	}{
+		{
+			name:     "defaults",
+			query:    "",
+			expected: listQuery{Limit: 20, MaxAge: time.Hour},
+		},
+		{
+			name:  "all_types",
+			query: "limit=5&cursor=abc&verbose=true&min_score=0.75&max_age=90s&offset=3&Ignored=x",
+			expected: listQuery{
+				Limit:    5,
+				Cursor:   "abc",
+				Verbose:  true,
+				MinScore: 0.75,
+				MaxAge:   90 * time.Second,
+				Offset:   3,
+			},
+		},
+		{
+			name:     "empty_value_uses_default",
+			query:    "limit=&max_age=",
+			expected: listQuery{Limit: 20, MaxAge: time.Hour},
+		},
+		{
+			name:     "first_value_wins",
+			query:    "limit=1&limit=2",
+			expected: listQuery{Limit: 1, MaxAge: time.Hour},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out listQuery
+
+			err := BindQuery(newRequest(tt.query), &out)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, out)
+		})
+	}
+}
+
+func TestBindQuery_StrictQuery(t *testing.T) {
+	bind := func(rawQuery string) (listQuery, error) {
+		var out listQuery
+		var err error
+		StrictQuery(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+			err = BindQuery(r, &out)
+		})).ServeHTTP(httptest.NewRecorder(), newRequest(rawQuery))
+		return out, err
+	}
+
+	t.Run("single_params", func(t *testing.T) {
+		out, err := bind("limit=5&cursor=abc")
+
+		require.NoError(t, err)
+		assert.Equal(t, listQuery{Limit: 5, Cursor: "abc", MaxAge: time.Hour}, out)
+	})
+
+	t.Run("duplicate_param", func(t *testing.T) {
+		_, err := bind("limit=10&limit=20")
+
+		var httpErr *httpErrors.Error
+		require.ErrorAs(t, err, &httpErr)
+		assert.Equal(t, http.StatusBadRequest, httpErr.StatusCode)
+		assert.Equal(t, "Duplicate limit parameter", httpErr.Error())
+	})
+
+	t.Run("duplicate_empty_param", func(t *testing.T) {
+		_, err := bind("cursor=&cursor=abc")
+
+		require.Error(t, err)
+	})
+
+	t.Run("unbound_param_may_repeat", func(t *testing.T) {
+		out, err := bind("limit=5&tag=a&tag=b")
+
+		require.NoError(t, err)
+		assert.Equal(t, 5, out.Limit)
+	})
+}
+
+func TestBindQuery_TypeMismatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		message string
+	}{
+		{name: "int", query: "limit=ten", message: "Invalid limit parameter"},
+		{name: "int_overflow", query: "limit=99999999999999999999", message: "Invalid limit parameter: out of range"},
+		{name: "bool", query: "verbose=maybe", message: "Invalid verbose parameter"},
+		{name: "float", query: "min_score=high", message: "Invalid min_score parameter"},
+		{name: "duration", query: "max_age=forever", message: "Invalid max_age parameter"},
+		{name: "negative_uint", query: "offset=-1", message: "Invalid offset parameter: must be at least 0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out listQuery
+
+			err := BindQuery(newRequest(tt.query), &out)
+
+			var httpErr *httpErrors.Error
+			require.ErrorAs(t, err, &httpErr)
+			assert.Equal(t, http.StatusBadRequest, httpErr.StatusCode)
+			assert.Equal(t, tt.message, httpErr.Error())
+			assert.NotNil(t, errors.Unwrap(httpErr))
+		})
+	}
+}
+
+type pageQuery struct {
+	Offset int    `query:"offset" min:"0"`
+	Limit  int    `query:"limit" default:"20" min:"1" max:"100"`
+	Page   int32  `query:"page"`
+	Size   uint8  `query:"size"`
+	Skip   uint64 `query:"skip"`
+}
+
+func TestBindQuery_Range(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		message string
+	}{
+		{name: "negative_offset", query: "offset=-1", message: "Invalid offset parameter: must be at least 0"},
+		{name: "limit_below_min", query: "limit=0", message: "Invalid limit parameter: must be at least 1"},
+		{name: "limit_above_max", query: "limit=101", message: "Invalid limit parameter: must be at most 100"},
+		{name: "offset_exceeds_int64", query: "offset=99999999999999999999", message: "Invalid offset parameter: out of range"},
+		{name: "offset_below_int64", query: "offset=-99999999999999999999", message: "Invalid offset parameter: out of range"},
+		{name: "page_exceeds_int32", query: "page=2147483648", message: "Invalid page parameter: out of range"},
+		{name: "size_exceeds_uint8", query: "size=256", message: "Invalid size parameter: out of range"},
+		{name: "skip_exceeds_uint64", query: "skip=18446744073709551616", message: "Invalid skip parameter: out of range"},
+		{name: "negative_uint", query: "skip=-5", message: "Invalid skip parameter: must be at least 0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out pageQuery
+
+			err := BindQuery(newRequest(tt.query), &out)
+
+			var httpErr *httpErrors.Error
+			require.ErrorAs(t, err, &httpErr)
+			assert.Equal(t, http.StatusBadRequest, httpErr.StatusCode)
+			assert.Equal(t, tt.message, httpErr.Error())
+		})
+	}
+
+	var out pageQuery
+	require.NoError(t, BindQuery(newRequest("offset=0&limit=100&page=2147483647&size=255&skip=18446744073709551615"), &out))
+	assert.Equal(t, pageQuery{Offset: 0, Limit: 100, Page: 2147483647, Size: 255, Skip: 18446744073709551615}, out)
+}
+
+func TestBindQuery_InvalidBoundTag(t *testing.T) {
+	var out struct {
+		Limit int `query:"limit" max:"lots"`
+	}
+
+	err := BindQuery(newRequest("limit=5"), &out)
+
+	require.ErrorIs(t, err, errInvalidTag)
+	var httpErr *httpErrors.Error
+	assert.False(t, errors.As(err, &httpErr), "invalid tags are a programming error, not a bad request")
+}
+
+func TestBindQuery_InvalidDefault(t *testing.T) {
+	var out struct {
+		Limit int `query:"limit" default:"many"`
+	}
+
+	err := BindQuery(newRequest(""), &out)
+
+	var httpErr *httpErrors.Error
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusBadRequest, httpErr.StatusCode)
+}
+
+func TestBindQuery_MissingRequired(t *testing.T) {
+	type requiredQuery struct {
+		ID    string `query:"id" required:"true"`
+		Limit int    `query:"limit" required:"true" default:"10"`
+	}
+
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{name: "absent", query: ""},
+		{name: "empty", query: "id="},
+		{name: "default_does_not_satisfy_required", query: "id=abc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out requiredQuery
+
+			err := BindQuery(newRequest(tt.query), &out)
+
+			var httpErr *httpErrors.Error
+			require.ErrorAs(t, err, &httpErr)
+			assert.Equal(t, http.StatusBadRequest, httpErr.StatusCode)
+			assert.Contains(t, httpErr.Error(), "Missing")
+		})
+	}
+
+	var out requiredQuery
+	require.NoError(t, BindQuery(newRequest("id=abc&limit=5"), &out))
+	assert.Equal(t, requiredQuery{ID: "abc", Limit: 5}, out)
+}
+
+func TestBindQuery_InvalidTarget(t *testing.T) {
+	var nilPtr *listQuery
+	value := listQuery{}
+	number := 0
+
+	for name, target := range map[string]interface{}{
+		"nil":         nil,
+		"nil_pointer": nilPtr,
+		"non_pointer": value,
+		"non_struct":  &number,
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.ErrorIs(t, BindQuery(newRequest(""), target), ErrInvalidTarget)
+		})
+	}
+}
+
+func TestBindQuery_UnsupportedFieldType(t *testing.T) {
+	var out struct {
+		Tags []string `query:"tags"`
+	}
+
+	err := BindQuery(newRequest("tags=a"), &out)
+
+	require.Error(t, err)
+	var httpErr *httpErrors.Error
+	assert.False(t, errors.As(err, &httpErr), "unsupported fields are a programming error, not a bad request")
+}
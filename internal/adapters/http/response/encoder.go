@@ -0,0 +1,63 @@
+package response
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sync/atomic"
+)
+
+// Encoder holds the JSON settings shared by RespondJSON and RespondError.
+// Configure it once at startup with SetEncoder; the zero value writes compact
+// JSON without HTML escaping.
+type Encoder struct {
+	// Indent pretty-prints responses, one level per Indent. Empty keeps them
+	// compact.
+	Indent string
+	// EscapeHTML replaces <, > and & with \u escapes. It is off by default so
+	// URLs in payloads come out as written.
+	EscapeHTML bool
+	// OmitNull writes an empty body instead of null for nil payloads.
+	OmitNull bool
+}
+
+var encoder atomic.Pointer[Encoder]
+
+// SetEncoder replaces the settings used by every later response.
+func SetEncoder(e Encoder) {
+	encoder.Store(&e)
+}
+
+// CurrentEncoder returns the settings set by SetEncoder, or the zero Encoder.
+func CurrentEncoder() Encoder {
+	if e := encoder.Load(); e != nil {
+		return *e
+	}
+	return Encoder{}
+}
+
+// Encode writes payload to buf, followed by a newline unless OmitNull dropped
+// it.
+func (e Encoder) Encode(buf *bytes.Buffer, payload interface{}) error {
+	if e.OmitNull && isNil(payload) {
+		return nil
+	}
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(e.EscapeHTML)
+	if e.Indent != "" {
+		enc.SetIndent("", e.Indent)
+	}
+	return enc.Encode(payload)
+}
+
+func isNil(payload interface{}) bool {
+	if payload == nil {
+		return true
+	}
+	v := reflect.ValueOf(payload)
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Interface:
+		return v.IsNil()
+	}
+	return false
+}
@@ -0,0 +1,66 @@
+package response
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func withEncoder(t *testing.T, e Encoder) {
+	previous := CurrentEncoder()
+	SetEncoder(e)
+	t.Cleanup(func() { SetEncoder(previous) })
+}
+
+func TestRespondJSON_DoesNotEscapeHTML(t *testing.T) {
+	w := httptest.NewRecorder()
+
+	RespondJSON(w, http.StatusOK, map[string]string{"next": "/api/examples?limit=10&cursor=<abc>"})
+
+	assert.Equal(t, "{\"next\":\"/api/examples?limit=10&cursor=<abc>\"}\n", w.Body.String())
+}
+
+func TestRespondJSON_EncoderSettings(t *testing.T) {
+	type entity struct {
+		ID   string `json:"id"`
+		Tags []int  `json:"tags"`
+	}
+	var nilEntity *entity
+
+	tests := []struct {
+		name     string
+		encoder  Encoder
+		payload  interface{}
+		expected string
+	}{
+		{name: "indent", encoder: Encoder{Indent: "  "}, payload: entity{ID: "1", Tags: []int{2}}, expected: "{\n  \"id\": \"1\",\n  \"tags\": [\n    2\n  ]\n}\n"},
+		{name: "escape_html", encoder: Encoder{EscapeHTML: true}, payload: "a&b", expected: "\"a\\u0026b\"\n"},
+		{name: "omit_null_nil", encoder: Encoder{OmitNull: true}, payload: nil, expected: ""},
+		{name: "omit_null_typed_nil", encoder: Encoder{OmitNull: true}, payload: nilEntity, expected: ""},
+		{name: "omit_null_keeps_values", encoder: Encoder{OmitNull: true}, payload: []int{}, expected: "[]\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withEncoder(t, tt.encoder)
+			w := httptest.NewRecorder()
+
+			RespondJSON(w, http.StatusOK, tt.payload)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, ContentTypeJSON, w.Header().Get("Content-Type"))
+			assert.Equal(t, tt.expected, w.Body.String())
+		})
+	}
+}
+
+func TestRespondError_UsesEncoder(t *testing.T) {
+	withEncoder(t, Encoder{Indent: "\t"})
+	w := httptest.NewRecorder()
+
+	RespondError(w, http.StatusBadRequest, assert.AnError)
+
+	assert.Equal(t, "{\n\t\"error\": \""+assert.AnError.Error()+"\"\n}\n", w.Body.String())
+}
@@ -0,0 +1,50 @@
+package response
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWeakETag(t *testing.T) {
+	first, err := WeakETag(map[string]string{"id": "1", "name": "Before"})
+	require.NoError(t, err)
+	same, err := WeakETag(map[string]string{"id": "1", "name": "Before"})
+	require.NoError(t, err)
+	changed, err := WeakETag(map[string]string{"id": "1", "name": "After"})
+	require.NoError(t, err)
+
+	assert.Regexp(t, `^W/"[0-9a-f]{32}"$`, first)
+	assert.Equal(t, first, same)
+	assert.NotEqual(t, first, changed)
+
+	_, err = WeakETag(func() {})
+	assert.Error(t, err)
+}
+
+func TestETagMatches(t *testing.T) {
+	etag := `W/"abc"`
+
+	tests := []struct {
+		name        string
+		ifNoneMatch string
+		expected    bool
+	}{
+		{name: "same_weak_tag", ifNoneMatch: `W/"abc"`, expected: true},
+		{name: "strong_form", ifNoneMatch: `"abc"`, expected: true},
+		{name: "in_list", ifNoneMatch: `"other", W/"abc"`, expected: true},
+		{name: "wildcard", ifNoneMatch: `*`, expected: true},
+		{name: "different_tag", ifNoneMatch: `W/"abd"`, expected: false},
+		{name: "unquoted", ifNoneMatch: `abc`, expected: false},
+		{name: "empty", ifNoneMatch: ``, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ETagMatches(tt.ifNoneMatch, etag))
+		})
+	}
+
+	assert.False(t, ETagMatches("*", ""))
+}
@@ -0,0 +1,85 @@
+package response
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const exportCSV = "id,email,name\n1,a@example.com,Alice\n2,b@example.com,Bob\n"
+
+var exportModTime = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func serveExport(headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/api/examples/export", nil)
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	w := httptest.NewRecorder()
+
+	RespondExport(w, req, "examples.csv", "text/csv; charset=utf-8", exportModTime, []byte(exportCSV))
+
+	return w
+}
+
+func TestRespondExport_FullContent(t *testing.T) {
+	w := serveExport(nil)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, exportCSV, w.Body.String())
+	assert.Equal(t, "bytes", w.Header().Get("Accept-Ranges"))
+	assert.Equal(t, strconv.Itoa(len(exportCSV)), w.Header().Get("Content-Length"))
+	assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename=examples.csv`, w.Header().Get("Content-Disposition"))
+	assert.NotEmpty(t, w.Header().Get("ETag"))
+}
+
+func TestRespondExport_Range(t *testing.T) {
+	size := len(exportCSV)
+
+	tests := []struct {
+		name          string
+		rangeHeader   string
+		expectedRange string
+		expectedBody  string
+	}{
+		{name: "first_bytes", rangeHeader: "bytes=0-13", expectedRange: "bytes 0-13/" + strconv.Itoa(size), expectedBody: exportCSV[0:14]},
+		{name: "middle", rangeHeader: "bytes=14-32", expectedRange: "bytes 14-32/" + strconv.Itoa(size), expectedBody: exportCSV[14:33]},
+		{name: "resume_from_offset", rangeHeader: "bytes=34-", expectedRange: "bytes 34-" + strconv.Itoa(size-1) + "/" + strconv.Itoa(size), expectedBody: exportCSV[34:]},
+		{name: "suffix", rangeHeader: "bytes=-4", expectedRange: "bytes " + strconv.Itoa(size-4) + "-" + strconv.Itoa(size-1) + "/" + strconv.Itoa(size), expectedBody: exportCSV[size-4:]},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serveExport(map[string]string{"Range": tt.rangeHeader})
+
+			assert.Equal(t, http.StatusPartialContent, w.Code)
+			assert.Equal(t, tt.expectedRange, w.Header().Get("Content-Range"))
+			assert.Equal(t, tt.expectedBody, w.Body.String())
+			assert.Equal(t, strconv.Itoa(len(tt.expectedBody)), w.Header().Get("Content-Length"))
+		})
+	}
+}
+
+func TestRespondExport_UnsatisfiableRange(t *testing.T) {
+	w := serveExport(map[string]string{"Range": "bytes=1000-2000"})
+
+	assert.Equal(t, http.StatusRequestedRangeNotSatisfiable, w.Code)
+	assert.Equal(t, "bytes */"+strconv.Itoa(len(exportCSV)), w.Header().Get("Content-Range"))
+}
+
+func TestRespondExport_IfRange(t *testing.T) {
+	etag := serveExport(nil).Header().Get("ETag")
+
+	matching := serveExport(map[string]string{"Range": "bytes=0-1", "If-Range": etag})
+	assert.Equal(t, http.StatusPartialContent, matching.Code)
+	assert.Equal(t, exportCSV[:2], matching.Body.String())
+
+	changed := serveExport(map[string]string{"Range": "bytes=0-1", "If-Range": `"stale"`})
+	assert.Equal(t, http.StatusOK, changed.Code)
+	assert.Equal(t, exportCSV, changed.Body.String())
+}
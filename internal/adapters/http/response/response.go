@@ -1,10 +1,31 @@
 package response
 
 import (
-	"encoding/json"
+	"bytes"
+	"microservice/internal/platform/servertiming"
+	"mime"
 	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+const (
+	ContentTypeJSON    = "application/json"
+	ContentTypeMsgPack = "application/msgpack"
 )
 
+// maxPooledBufferSize keeps buffers grown by unusually large responses from
+// pinning memory in the pool.
+const maxPooledBufferSize = 64 << 10
+
+var bufferPool = sync.Pool{
+	New: func() interface{} { return new(bytes.Buffer) },
+}
+
 type FieldError struct {
 	Field   string `json:"field"`
 	Message string `json:"message"`
@@ -14,12 +35,90 @@ type ValidationErrorResponse struct {
 	Errors []FieldError `json:"errors"`
 }
 
+// Respond encodes payload as MessagePack when the Accept header prefers
+// application/msgpack over application/json, and as JSON otherwise, including
+// for missing or unknown Accept values. MessagePack uses the json struct tags,
+// so both encodings share field names.
+func Respond(w http.ResponseWriter, r *http.Request, status int, payload interface{}) {
+	w.Header().Add("Vary", "Accept")
+	if !prefersMsgPack(r.Header.Get("Accept")) {
+		RespondJSON(w, status, payload)
+		return
+	}
+
+	buf := bufferPool.Get().(*bytes.Buffer)
+	defer releaseBuffer(buf)
+
+	writeMsgPack(w, status, payload, buf)
+}
+
+// RespondJSON encodes payload into a pooled buffer before writing anything, so
+// an encoding failure still produces a clean 500 instead of a partial body.
+// Encoding follows the settings given to SetEncoder.
 func RespondJSON(w http.ResponseWriter, status int, payload interface{}) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	if err := json.NewEncoder(w).Encode(payload); err != nil {
+	buf := bufferPool.Get().(*bytes.Buffer)
+	defer releaseBuffer(buf)
+
+	writeJSON(w, status, payload, buf)
+}
+
+func releaseBuffer(buf *bytes.Buffer) {
+	if buf.Cap() <= maxPooledBufferSize {
+		bufferPool.Put(buf)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, payload interface{}, buf *bytes.Buffer) {
+	write(w, status, ContentTypeJSON, buf, func() error {
+		return CurrentEncoder().Encode(buf, payload)
+	})
+}
+
+func writeMsgPack(w http.ResponseWriter, status int, payload interface{}, buf *bytes.Buffer) {
+	write(w, status, ContentTypeMsgPack, buf, func() error {
+		enc := msgpack.GetEncoder()
+		defer msgpack.PutEncoder(enc)
+		enc.Reset(buf)
+		enc.SetCustomStructTag("json")
+		return enc.Encode(payload)
+	})
+}
+
+func write(w http.ResponseWriter, status int, contentType string, buf *bytes.Buffer, encode func() error) {
+	buf.Reset()
+	start := time.Now()
+	if err := encode(); err != nil {
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	servertiming.FromWriter(w).Add("encode", time.Since(start))
+
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(status)
+	_, _ = w.Write(buf.Bytes())
+}
+
+// prefersMsgPack reports whether accept ranks application/msgpack above
+// application/json. Ties go to whichever is listed first; wildcards and other
+// types are ignored, which leaves JSON as the default.
+func prefersMsgPack(accept string) bool {
+	best, bestQ := "", 0.0
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil || (mediaType != ContentTypeMsgPack && mediaType != ContentTypeJSON) {
+			continue
+		}
+		q := 1.0
+		if value, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(value, 64); err != nil {
+				continue
+			}
+		}
+		if q > bestQ {
+			best, bestQ = mediaType, q
+		}
 	}
+	return best == ContentTypeMsgPack
 }
 
 func RespondError(w http.ResponseWriter, status int, err error) {
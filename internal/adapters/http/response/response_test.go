@@ -1,14 +1,19 @@
 package response
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/vmihailenco/msgpack/v5"
 )
 
 func TestRespondJSON_Success(t *testing.T) {
@@ -193,3 +198,140 @@ func TestRespondJSON_HeadersNotOverwritten(t *testing.T) {
 	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
 	assert.Equal(t, "custom-value", w.Header().Get("X-Custom-Header"))
 }
+
+func TestRespondJSON_UnencodablePayloadWritesNothingElse(t *testing.T) {
+	w := httptest.NewRecorder()
+
+	RespondJSON(w, http.StatusOK, map[string]interface{}{"func": func() {}})
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, "Internal server error\n", w.Body.String())
+}
+
+func TestRespondJSON_ConcurrentUse(t *testing.T) {
+	const workers = 64
+	const iterations = 50
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for j := 0; j < iterations; j++ {
+				payload := map[string]string{"id": fmt.Sprintf("%d-%d", worker, j), "padding": strings.Repeat("x", worker*j)}
+				w := httptest.NewRecorder()
+
+				RespondJSON(w, http.StatusOK, payload)
+
+				var decoded map[string]string
+				if !assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &decoded)) {
+					return
+				}
+				assert.Equal(t, payload, decoded)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestRespondJSON_LargeThenSmallPayload(t *testing.T) {
+	large := strings.Repeat("x", 2*maxPooledBufferSize)
+	w := httptest.NewRecorder()
+
+	RespondJSON(w, http.StatusOK, large)
+
+	require.Equal(t, `"`+large+`"`+"\n", w.Body.String())
+
+	small := httptest.NewRecorder()
+	RespondJSON(small, http.StatusOK, "ok")
+	assert.Equal(t, "\"ok\"\n", small.Body.String())
+}
+
+var benchmarkPayload = map[string]interface{}{
+	"id":    "bench-id",
+	"email": "bench@example.com",
+	"name":  strings.Repeat("Bench User ", 20),
+	"tags":  []string{"a", "b", "c", "d"},
+}
+
+func TestRespond_NegotiatesEncoding(t *testing.T) {
+	type payload struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	}
+
+	tests := []struct {
+		name        string
+		accept      string
+		contentType string
+	}{
+		{name: "no_accept", accept: "", contentType: "application/json"},
+		{name: "json", accept: "application/json", contentType: "application/json"},
+		{name: "msgpack", accept: "application/msgpack", contentType: "application/msgpack"},
+		{name: "unknown_type", accept: "application/xml", contentType: "application/json"},
+		{name: "malformed", accept: ";;;", contentType: "application/json"},
+		{name: "wildcard", accept: "*/*", contentType: "application/json"},
+		{name: "msgpack_preferred", accept: "application/json;q=0.5, application/msgpack", contentType: "application/msgpack"},
+		{name: "json_preferred", accept: "application/msgpack;q=0.5, application/json", contentType: "application/json"},
+		{name: "tie_goes_to_first", accept: "application/msgpack, application/json", contentType: "application/msgpack"},
+		{name: "msgpack_refused", accept: "application/msgpack;q=0", contentType: "application/json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			w := httptest.NewRecorder()
+
+			Respond(w, req, http.StatusCreated, payload{ID: "id-1", Name: "Test"})
+
+			assert.Equal(t, http.StatusCreated, w.Code)
+			assert.Equal(t, tt.contentType, w.Header().Get("Content-Type"))
+			assert.Equal(t, "Accept", w.Header().Get("Vary"))
+
+			var decoded payload
+			if tt.contentType == "application/msgpack" {
+				dec := msgpack.NewDecoder(w.Body)
+				dec.SetCustomStructTag("json")
+				require.NoError(t, dec.Decode(&decoded))
+			} else {
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &decoded))
+			}
+			assert.Equal(t, payload{ID: "id-1", Name: "Test"}, decoded)
+		})
+	}
+}
+
+func TestRespond_MsgPackUsesJSONFieldNames(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept", "application/msgpack")
+	w := httptest.NewRecorder()
+
+	Respond(w, req, http.StatusOK, struct {
+		Name string `json:"name"`
+	}{Name: "Test"})
+
+	var decoded map[string]interface{}
+	require.NoError(t, msgpack.Unmarshal(w.Body.Bytes(), &decoded))
+	assert.Equal(t, map[string]interface{}{"name": "Test"}, decoded)
+}
+
+func BenchmarkRespondJSON_Pooled(b *testing.B) {
+	w := httptest.NewRecorder()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		w.Body.Reset()
+		RespondJSON(w, http.StatusOK, benchmarkPayload)
+	}
+}
+
+func BenchmarkRespondJSON_Unpooled(b *testing.B) {
+	w := httptest.NewRecorder()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		w.Body.Reset()
+		writeJSON(w, http.StatusOK, benchmarkPayload, new(bytes.Buffer))
+	}
+}
@@ -5,15 +5,17 @@ import (
 	"microservice/internal/platform/metrics"
 	platformMiddleware "microservice/internal/platform/middleware"
 	"net/http"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
-	"github.com/go-chi/cors"
-	"github.com/go-chi/httprate"
 
+	"microservice/internal/adapters/http/admin"
+	"microservice/internal/adapters/http/events"
 	"microservice/internal/adapters/http/example"
 	"microservice/internal/adapters/http/health"
+	"microservice/internal/adapters/http/request"
 	"microservice/internal/config"
 )
 
@@ -22,8 +24,28 @@ type RouterDependencies struct {
 	Logger           logger.Logger
 	ExampleHandler   *example.Handler
 	LivenessHandler  *health.LivenessHandler
+	StartupHandler   *health.StartupHandler
 	ReadinessHandler *health.ReadinessHandler
 	MetricsProvider  *metrics.Provider
+	LogLevelHandler  *admin.LogLevelHandler
+	// MaintenanceHandler serves /admin/maintenance on the admin listener when set.
+	MaintenanceHandler *admin.MaintenanceHandler
+	// Maintenance pauses /api with 503 while set; nil never pauses it.
+	Maintenance      *atomic.Bool
+	IdempotencyStore platformMiddleware.IdempotencyStore
+	// Deployment is echoed in response headers when Config.DeploymentHeaders is set.
+	Deployment platformMiddleware.DeploymentInfo
+	// EventsHandler serves GET /api/events when set.
+	EventsHandler *events.Handler
+	// Options trims the middleware stack; the zero value builds the full one.
+	Options RouterOptions
+}
+
+// RouterOptions lets tests build a router without middlewares that get in the
+// way of exercising routes and handlers.
+type RouterOptions struct {
+	DisableRateLimit bool
+	DisableCORS      bool
 }
 
 func NewRouter(deps RouterDependencies) http.Handler {
@@ -31,42 +53,200 @@ func NewRouter(deps RouterDependencies) http.Handler {
 	log := deps.Logger
 	r := chi.NewRouter()
 
-	r.Use(middleware.RequestID)
+	r.Use(platformMiddleware.RequestID(cfg.RequestIDMaxLength))
+	r.Use(platformMiddleware.CorrelationID())
+	r.Use(platformMiddleware.LoggerInjector(log))
 	r.Use(middleware.RealIP)
-	r.Use(platformMiddleware.RequestLogger(log))
+	if cfg.MethodOverride {
+		r.Use(platformMiddleware.MethodOverride)
+	}
+	r.Use(platformMiddleware.SecurityHeaders(platformMiddleware.SecurityOptions{
+		FrameOptions:          cfg.Security.FrameOptions,
+		ReferrerPolicy:        cfg.Security.ReferrerPolicy,
+		ContentSecurityPolicy: cfg.Security.ContentSecurityPolicy,
+		HSTS:                  cfg.IsProduction() || cfg.TLS.Enabled,
+		HSTSMaxAge:            time.Duration(cfg.Security.HSTSMaxAge) * time.Second,
+		HSTSIncludeSubdomains: cfg.Security.HSTSIncludeSubdomains,
+	}))
+	if cfg.DeploymentHeaders {
+		r.Use(platformMiddleware.DeploymentHeaders(deps.Deployment))
+	}
+	if cfg.ServerTiming {
+		r.Use(platformMiddleware.ServerTiming(cfg.ServerTimingHeader))
+	}
+	r.Use(platformMiddleware.RequestLogger(log, platformMiddleware.RequestLoggerConfig{
+		ClientFields:   cfg.AccessLog.ClientFields,
+		MaxFieldLength: cfg.AccessLog.MaxFieldLength,
+		SkipPaths:      cfg.AccessLog.SkipPaths,
+		SampleRate:     cfg.AccessLog.SampleRate,
+	}))
 	r.Use(platformMiddleware.MetricsMiddleware(deps.MetricsProvider))
-	r.Use(platformMiddleware.Recovery(log))
+	r.Use(platformMiddleware.Recovery(log, recoveryConfig(cfg)))
 	r.Use(middleware.StripSlashes)
+	r.Use(platformMiddleware.MaxBodySize(cfg.MaxBodySize))
+	r.Use(platformMiddleware.MaxJSONDepth(cfg.MaxJSONDepth))
+	if cfg.StrictQuery {
+		r.Use(request.StrictQuery)
+	}
+
+	// With a separate admin server these endpoints live on NewAdminRouter
+	// instead. Behind a base path they stay at the root unless configured
+	// otherwise, since probes usually hit fixed paths.
+	operational := func(opsRouter chi.Router) {
+		if !deps.Options.DisableCORS {
+			opsRouter.Use(corsFor(cfg.AdminCORS))
+		}
+		mountOperational(opsRouter, deps, false)
+	}
+	basePath := cfg.RoutePrefix()
+	operationalAtRoot := basePath == "" || cfg.OperationalAtRoot
+	if !cfg.Admin.Enabled && operationalAtRoot {
+		r.Group(operational)
+	}
+
+	routes := func(router chi.Router) {
+		if !cfg.Admin.Enabled && !operationalAtRoot {
+			router.Group(operational)
+		}
+		router.Route("/api", func(apiRouter chi.Router) {
+			mountAPI(apiRouter, deps)
+		})
+	}
+	if basePath == "" {
+		routes(r)
+	} else {
+		r.Route(basePath, routes)
+	}
+
+	return r
+}
 
-	r.Use(cors.Handler(cors.Options{
-		AllowedOrigins:   cfg.CORS.AllowedOrigins,
-		AllowedMethods:   cfg.CORS.AllowedMethods,
-		AllowedHeaders:   cfg.CORS.AllowedHeaders,
-		ExposedHeaders:   cfg.CORS.ExposedHeaders,
-		AllowCredentials: cfg.CORS.AllowCredentials,
-		MaxAge:           cfg.CORS.MaxAge,
+// mountAPI registers the /api routes. Only they are rate limited or paused by
+// maintenance mode, so health probes, metrics scrapes and /version keep
+// answering while clients are throttled or turned away. CORS runs first so
+// browsers can read 429 and 503 responses and preflights are never throttled.
+func mountAPI(apiRouter chi.Router, deps RouterDependencies) {
+	cfg := deps.Config
+	if !deps.Options.DisableCORS {
+		apiRouter.Use(corsFor(cfg.CORS))
+	}
+	if deps.Maintenance != nil {
+		apiRouter.Use(platformMiddleware.Maintenance(deps.Maintenance, platformMiddleware.MaintenanceOptions{
+			RetryAfter: time.Duration(cfg.MaintenanceRetryAfter) * time.Second,
+		}))
+	}
+	if !deps.Options.DisableRateLimit {
+		apiRouter.Use(platformMiddleware.RateLimit(deps.MetricsProvider, platformMiddleware.RateLimitOptions{
+			Scope:    platformMiddleware.RateLimitScopeGlobal,
+			Requests: cfg.RateLimit.GlobalRequests,
+			Window:   time.Duration(cfg.RateLimit.GlobalWindow) * time.Second,
+			Shadow:   cfg.RateLimit.GlobalShadow,
+		}))
+		apiRouter.Use(platformMiddleware.RateLimit(deps.MetricsProvider, platformMiddleware.RateLimitOptions{
+			Scope:    platformMiddleware.RateLimitScopeIP,
+			Requests: cfg.RateLimit.RequestsPerIP,
+			Window:   time.Duration(cfg.RateLimit.WindowSeconds) * time.Second,
+			Shadow:   cfg.RateLimit.ShadowPerIP,
+		}))
+	}
+	apiRouter.Use(platformMiddleware.SecurityHeadersFor(platformMiddleware.SecurityOverrides{
+		ContentSecurityPolicy: cfg.Security.APIContentSecurityPolicy,
 	}))
+	apiRouter.Use(platformMiddleware.RequireJSON())
+	if deps.IdempotencyStore != nil {
+		apiRouter.Use(platformMiddleware.Idempotency(deps.IdempotencyStore))
+	}
+
+	// Shared by every bulk endpoint so they draw from one pool of slots.
+	bulkLimit := platformMiddleware.ConcurrencyLimit(deps.MetricsProvider, platformMiddleware.ConcurrencyLimitOptions{
+		Scope: platformMiddleware.RateLimitScopeBulk,
+		Limit: cfg.MaxBulkInFlight,
+	})
+
+	apiRouter.Route("/examples", func(exampleRouter chi.Router) {
+		// Headers every route of the resource needs, plus extra ones on writes.
+		read := platformMiddleware.RequireHeaders(cfg.RequiredHeaders...)
+		write := platformMiddleware.RequireHeaders(append(append([]string{}, cfg.RequiredHeaders...), cfg.RequiredWriteHeaders...)...)
+
+		exampleRouter.With(write).Post("/", ErrorHandler(deps.ExampleHandler.CreateEntity))
+		exampleRouter.With(write, bulkLimit).Post("/batch", ErrorHandler(deps.ExampleHandler.CreateEntities))
+		exampleRouter.With(read).Get("/", ErrorHandler(deps.ExampleHandler.FindEntity))
+		exampleRouter.With(read).Get("/export", ErrorHandler(deps.ExampleHandler.ExportEntities))
+		exampleRouter.With(read).Get("/{id}", ErrorHandler(deps.ExampleHandler.GetEntity))
+		exampleRouter.With(write).Patch("/{id}", ErrorHandler(deps.ExampleHandler.PatchEntity))
+		exampleRouter.With(write).Post("/{id}/restore", ErrorHandler(deps.ExampleHandler.RestoreEntity))
+	})
 
-	r.Use(httprate.LimitAll(
-		cfg.RateLimit.GlobalRequests,
-		time.Duration(cfg.RateLimit.GlobalWindow)*time.Second,
-	))
-	r.Use(httprate.LimitByIP(
-		cfg.RateLimit.RequestsPerIP,
-		time.Duration(cfg.RateLimit.WindowSeconds)*time.Second,
-	))
+	if deps.EventsHandler != nil {
+		apiRouter.Get("/events", deps.EventsHandler.Stream)
+	}
+}
+
+// NewAdminRouter serves health, metrics, pprof and admin endpoints for the
+// separate admin server, keeping them off the public listener and out of the
+// API rate limits.
+func NewAdminRouter(deps RouterDependencies) http.Handler {
+	r := chi.NewRouter()
+
+	r.Use(platformMiddleware.RequestID(deps.Config.RequestIDMaxLength))
+	r.Use(platformMiddleware.CorrelationID())
+	r.Use(platformMiddleware.LoggerInjector(deps.Logger))
+	r.Use(platformMiddleware.Recovery(deps.Logger, recoveryConfig(deps.Config)))
+	if !deps.Options.DisableCORS {
+		r.Use(corsFor(deps.Config.AdminCORS))
+	}
+
+	mountOperational(r, deps, true)
+	r.Mount("/debug", middleware.Profiler())
+
+	return r
+}
 
+// recoveryConfig only puts panic messages in responses outside production.
+func recoveryConfig(cfg *config.HttpConfig) platformMiddleware.RecoveryConfig {
+	return platformMiddleware.RecoveryConfig{
+		ExposePanic:      !cfg.IsProduction(),
+		IncludeRequestID: true,
+	}
+}
+
+func corsFor(c config.CORSConfig) func(http.Handler) http.Handler {
+	return platformMiddleware.CORSFor(platformMiddleware.CORSConfig{
+		AllowedOrigins:   c.AllowedOrigins,
+		AllowedMethods:   c.AllowedMethods,
+		AllowedHeaders:   c.AllowedHeaders,
+		ExposedHeaders:   c.ExposedHeaders,
+		AllowCredentials: c.AllowCredentials,
+		MaxAge:           c.MaxAge,
+	})
+}
+
+// mountOperational registers health, metrics, /version and /admin. adminListener
+// is set for the router of the separate admin server.
+func mountOperational(r chi.Router, deps RouterDependencies, adminListener bool) {
 	r.Get("/health/live", deps.LivenessHandler.Check)
+	r.Get("/health/startup", deps.StartupHandler.Check)
 	r.Get("/health/ready", deps.ReadinessHandler.Check)
+	r.Get("/version", admin.Version)
 
 	r.Handle("/metrics", deps.MetricsProvider.Handler())
 
-	r.Route("/api", func(apiRouter chi.Router) {
-		apiRouter.Route("/examples", func(exampleRouter chi.Router) {
-			exampleRouter.Post("/", ErrorHandler(deps.ExampleHandler.CreateEntity))
-			exampleRouter.Get("/{id}", ErrorHandler(deps.ExampleHandler.GetEntity))
-		})
+	// There is no authentication yet, so the log level is never exposed in
+	// production and maintenance mode is only served by the admin listener,
+	// which stays off the public network, in every environment.
+	logLevel := deps.LogLevelHandler != nil && !deps.Config.IsProduction()
+	maintenance := deps.MaintenanceHandler != nil && adminListener
+	if !logLevel && !maintenance {
+		return
+	}
+	r.Route("/admin", func(adminRouter chi.Router) {
+		if logLevel {
+			adminRouter.Get("/log-level", deps.LogLevelHandler.GetLevel)
+			adminRouter.Put("/log-level", ErrorHandler(deps.LogLevelHandler.SetLevel))
+		}
+		if maintenance {
+			adminRouter.Get("/maintenance", deps.MaintenanceHandler.GetMode)
+			adminRouter.Put("/maintenance", ErrorHandler(deps.MaintenanceHandler.SetMode))
+		}
 	})
-
-	return r
 }
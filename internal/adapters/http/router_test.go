@@ -1,21 +1,37 @@
 package http
 
 import (
+	"bufio"
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"microservice/internal/adapters/http/admin"
+	"microservice/internal/adapters/http/events"
 	"microservice/internal/adapters/http/example"
 	"microservice/internal/adapters/http/health"
 	"microservice/internal/adapters/validator"
 	"microservice/internal/config"
+	exampleDomain "microservice/internal/core/domain/example"
 	platformHealth "microservice/internal/platform/health"
 	"microservice/internal/platform/logger"
 	"microservice/internal/platform/metrics"
+	platformMiddleware "microservice/internal/platform/middleware"
+	"microservice/internal/platform/servertiming"
+	"microservice/internal/version"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
 
 	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"regexp"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -33,6 +49,7 @@ type RouterTestSuite struct {
 	metricsProvider   *metrics.Provider
 	exampleHandler    *example.Handler
 	livenessHandler   *health.LivenessHandler
+	startupHandler    *health.StartupHandler
 	readinessHandler  *health.ReadinessHandler
 	mockHealthManager *healthMocks.MockManagerInterface
 	mockManager       *exampleMocks.MockManager
@@ -72,12 +89,13 @@ func (s *RouterTestSuite) SetupTest() {
 	s.mockManager = exampleMocks.NewMockManager(s.T())
 	validatorAdapter := validator.NewPlaygroundAdapter()
 	s.Require().NoError(err)
-	s.exampleHandler = example.NewHandler(s.mockManager, validatorAdapter)
+	s.exampleHandler = example.NewHandler(s.mockManager, validatorAdapter, 100)
 
 	s.livenessHandler = health.NewLivenessHandler("1.0.0")
+	s.startupHandler = health.NewStartupHandler("1.0.0")
 
 	s.mockHealthManager = healthMocks.NewMockManagerInterface(s.T())
-	s.readinessHandler = health.NewReadinessHandler("1.0.0", s.mockHealthManager)
+	s.readinessHandler = health.NewReadinessHandler("1.0.0", s.mockHealthManager, nil, 0)
 }
 
 func (s *RouterTestSuite) createRouterDependencies(config ...*config.HttpConfig) RouterDependencies {
@@ -91,11 +109,22 @@ func (s *RouterTestSuite) createRouterDependencies(config ...*config.HttpConfig)
 		Logger:           s.logger,
 		ExampleHandler:   s.exampleHandler,
 		LivenessHandler:  s.livenessHandler,
+		StartupHandler:   s.startupHandler,
 		ReadinessHandler: s.readinessHandler,
 		MetricsProvider:  s.metricsProvider,
 	}
 }
 
+// limitedRequest is a GET of an entity the mock manager always finds, for tests
+// that exercise the rate limits on /api routes.
+func (s *RouterTestSuite) limitedRequest(remoteAddr string) *http.Request {
+	s.mockManager.EXPECT().GetEntity(mock.Anything, "limited").
+		Return(&exampleDomain.Entity{ID: "limited"}, nil).Maybe()
+	req := httptest.NewRequest("GET", "/api/examples/limited", nil)
+	req.RemoteAddr = remoteAddr
+	return req
+}
+
 func (s *RouterTestSuite) TestNewRouter_Configuration() {
 	router := NewRouter(s.createRouterDependencies())
 
@@ -121,6 +150,20 @@ func (s *RouterTestSuite) TestRouter_HealthLivenessEndpoint() {
 	s.Assert().NotZero(response.Timestamp)
 }
 
+func (s *RouterTestSuite) TestRouter_HealthStartupEndpoint() {
+	router := NewRouter(s.createRouterDependencies())
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/health/startup", nil))
+	s.Assert().Equal(http.StatusServiceUnavailable, w.Code)
+
+	s.startupHandler.MarkReady()
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/health/startup", nil))
+	s.Assert().Equal(http.StatusOK, w.Code)
+}
+
 func (s *RouterTestSuite) TestRouter_HealthReadinessEndpoint_Success() {
 	s.mockHealthManager.On("CheckAll", mock.AnythingOfType("*context.timerCtx")).Return(map[string]platformHealth.CheckResult{
 		"database": {
@@ -183,6 +226,144 @@ func (s *RouterTestSuite) TestRouter_MetricsEndpoint() {
 	s.Assert().Contains(w.Header().Get("Content-Type"), "text/plain")
 }
 
+func (s *RouterTestSuite) TestRouter_MetricsEndpoint_ServiceLabels() {
+	provider, err := metrics.NewProvider(metrics.WithServiceLabels("microservice", "instance-1"))
+	s.Require().NoError(err)
+
+	deps := s.createRouterDependencies()
+	deps.MetricsProvider = provider
+	router := NewRouter(deps)
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/health/live", nil))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+
+	s.Assert().Equal(http.StatusOK, w.Code)
+	body := w.Body.String()
+	for _, name := range []string{"http_requests_total", "http_request_duration_seconds_count", "http_requests_in_flight"} {
+		s.Assert().Regexp(name+`\{[^}]*host="instance-1"[^}]*service="microservice"[^}]*\}`, body)
+	}
+}
+
+func (s *RouterTestSuite) TestRouter_Metrics_LabelsByRoutePattern() {
+	s.mockManager.EXPECT().
+		GetEntity(mock.Anything, mock.AnythingOfType("string")).
+		RunAndReturn(func(_ context.Context, id string) (*exampleDomain.Entity, error) {
+			return &exampleDomain.Entity{ID: id, Email: "test@example.com", Name: "Test User"}, nil
+		}).
+		Twice()
+	router := NewRouter(s.createRouterDependencies())
+
+	for _, path := range []string{"/api/examples/abc-123", "/api/examples/def-456", "/nonexistent", "/api/nonexistent"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", path, nil))
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+	body := w.Body.String()
+
+	s.Assert().Regexp(`http_requests_total\{[^}]*path="/api/examples/\{id\}"[^}]*status="200"[^}]*\} 2\n`, body)
+	s.Assert().Regexp(`http_requests_total\{[^}]*path="`+platformMiddleware.UnmatchedRoute+`"[^}]*status="404"[^}]*\} 2\n`, body)
+	s.Assert().NotContains(body, "abc-123")
+	s.Assert().NotContains(body, "def-456")
+	s.Assert().NotContains(body, `path="/api/*"`)
+}
+
+func (s *RouterTestSuite) TestRouter_AdminLogLevel() {
+	setter := logger.NewNop().(logger.LevelSetter)
+	deps := s.createRouterDependencies()
+	deps.LogLevelHandler = admin.NewLogLevelHandler(setter)
+	router := NewRouter(deps)
+
+	req := httptest.NewRequest("PUT", "/admin/log-level", strings.NewReader(`{"level":"debug"}`))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	s.Assert().Equal(http.StatusOK, w.Code)
+	s.Assert().Equal(logger.LevelDebug, setter.Level())
+
+	req = httptest.NewRequest("GET", "/admin/log-level", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	s.Assert().Equal(http.StatusOK, w.Code)
+	s.Assert().JSONEq(`{"level":"debug"}`, w.Body.String())
+
+	req = httptest.NewRequest("PUT", "/admin/log-level", strings.NewReader(`{"level":"loud"}`))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	s.Assert().Equal(http.StatusBadRequest, w.Code)
+	s.Assert().Equal(logger.LevelDebug, setter.Level())
+}
+
+func serveRequest(router http.Handler, method, path, body string) *httptest.ResponseRecorder {
+	var reader io.Reader
+	if body != "" {
+		reader = strings.NewReader(body)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(method, path, reader))
+	return w
+}
+
+func (s *RouterTestSuite) TestRouter_Maintenance() {
+	flag := new(atomic.Bool)
+	cfg := s.adminConfig()
+	cfg.Environment = config.EnvProduction
+	cfg.MaintenanceRetryAfter = 60
+	deps := s.createRouterDependencies(cfg)
+	deps.MaintenanceHandler = admin.NewMaintenanceHandler(flag)
+	deps.Maintenance = flag
+	router := NewRouter(deps)
+	adminRouter := NewAdminRouter(deps)
+	s.startupHandler.MarkReady()
+
+	s.Assert().NotEqual(http.StatusServiceUnavailable, serveRequest(router, "GET", "/api/unknown", "").Code)
+
+	w := serveRequest(adminRouter, "PUT", "/admin/maintenance", `{"enabled":true}`)
+	s.Require().Equal(http.StatusOK, w.Code)
+	s.Assert().JSONEq(`{"enabled":true}`, w.Body.String())
+
+	w = serveRequest(router, "GET", "/api/unknown", "")
+	s.Assert().Equal(http.StatusServiceUnavailable, w.Code)
+	s.Assert().Equal("60", w.Header().Get("Retry-After"))
+	s.Assert().JSONEq(`{"error":"`+platformMiddleware.DefaultMaintenanceMessage+`"}`, w.Body.String())
+
+	s.Assert().Equal(http.StatusOK, serveRequest(adminRouter, "GET", "/health/live", "").Code)
+	s.Assert().Equal(http.StatusOK, serveRequest(adminRouter, "GET", "/metrics", "").Code)
+	s.Assert().JSONEq(`{"enabled":true}`, serveRequest(adminRouter, "GET", "/admin/maintenance", "").Body.String())
+
+	s.Require().Equal(http.StatusOK, serveRequest(adminRouter, "PUT", "/admin/maintenance", `{"enabled":false}`).Code)
+	s.Assert().NotEqual(http.StatusServiceUnavailable, serveRequest(router, "GET", "/api/unknown", "").Code)
+}
+
+func (s *RouterTestSuite) TestRouter_Maintenance_NotOnPublicRouter() {
+	deps := s.createRouterDependencies()
+	deps.MaintenanceHandler = admin.NewMaintenanceHandler(new(atomic.Bool))
+	deps.LogLevelHandler = admin.NewLogLevelHandler(logger.NewNop().(logger.LevelSetter))
+	router := NewRouter(deps)
+
+	s.Assert().Equal(http.StatusNotFound, serveRequest(router, "GET", "/admin/maintenance", "").Code)
+	s.Assert().Equal(http.StatusNotFound, serveRequest(router, "PUT", "/admin/maintenance", `{"enabled":true}`).Code)
+	s.Assert().Equal(http.StatusOK, serveRequest(router, "GET", "/admin/log-level", "").Code)
+}
+
+func (s *RouterTestSuite) TestRouter_AdminLogLevel_NotMountedInProduction() {
+	productionConfig := *s.config
+	productionConfig.Environment = config.EnvProduction
+	deps := s.createRouterDependencies(&productionConfig)
+	deps.LogLevelHandler = admin.NewLogLevelHandler(logger.NewNop().(logger.LevelSetter))
+	router := NewRouter(deps)
+
+	req := httptest.NewRequest("GET", "/admin/log-level", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	s.Assert().Equal(http.StatusNotFound, w.Code)
+}
+
 func (s *RouterTestSuite) TestRouter_CORSHeaders() {
 	router := NewRouter(s.createRouterDependencies())
 
@@ -278,6 +459,513 @@ func (s *RouterTestSuite) TestRouter_Middleware_RequestID() {
 	s.Assert().Equal("GET", capturedRequestID)
 }
 
+func (s *RouterTestSuite) TestRouter_Middleware_SecurityHeaders() {
+	router := NewRouter(s.createRouterDependencies())
+
+	req := httptest.NewRequest("GET", "/health/live", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	s.Assert().Equal("nosniff", w.Header().Get("X-Content-Type-Options"))
+	s.Assert().Equal("DENY", w.Header().Get("X-Frame-Options"))
+	s.Assert().Equal("no-referrer", w.Header().Get("Referrer-Policy"))
+	s.Assert().Empty(w.Header().Get("Strict-Transport-Security"))
+	s.Assert().Empty(w.Header().Get("Content-Security-Policy"))
+}
+
+func (s *RouterTestSuite) TestRouter_Middleware_SecurityHeaders_Production() {
+	productionConfig := *s.config
+	productionConfig.Environment = config.EnvProduction
+	productionConfig.Security = config.SecurityConfig{
+		FrameOptions:          "SAMEORIGIN",
+		ReferrerPolicy:        "strict-origin",
+		ContentSecurityPolicy: "default-src 'none'",
+		HSTSMaxAge:            3600,
+		HSTSIncludeSubdomains: true,
+	}
+	router := NewRouter(s.createRouterDependencies(&productionConfig))
+
+	req := httptest.NewRequest("GET", "/health/live", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	s.Assert().Equal("nosniff", w.Header().Get("X-Content-Type-Options"))
+	s.Assert().Equal("SAMEORIGIN", w.Header().Get("X-Frame-Options"))
+	s.Assert().Equal("strict-origin", w.Header().Get("Referrer-Policy"))
+	s.Assert().Equal("max-age=3600; includeSubDomains", w.Header().Get("Strict-Transport-Security"))
+	s.Assert().Equal("default-src 'none'", w.Header().Get("Content-Security-Policy"))
+}
+
+func (s *RouterTestSuite) TestRouter_Middleware_SecurityHeaders_APIGroupOverride() {
+	cfg := *s.config
+	cfg.Security.ContentSecurityPolicy = "default-src 'self'"
+	cfg.Security.APIContentSecurityPolicy = platformMiddleware.StrictContentSecurityPolicy
+	router := NewRouter(s.createRouterDependencies(&cfg))
+
+	tests := []struct {
+		path        string
+		expectedCSP string
+	}{
+		{"/health/live", "default-src 'self'"},
+		{"/api/nonexistent", platformMiddleware.StrictContentSecurityPolicy},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.path, func() {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest("GET", tt.path, nil))
+
+			s.Assert().Equal(tt.expectedCSP, w.Header().Get("Content-Security-Policy"))
+			s.Assert().Equal("nosniff", w.Header().Get("X-Content-Type-Options"))
+			s.Assert().Equal("DENY", w.Header().Get("X-Frame-Options"))
+		})
+	}
+}
+
+func (s *RouterTestSuite) TestSecurityHeadersFor_PerRouteGroup() {
+	r := chi.NewRouter()
+	r.Use(platformMiddleware.SecurityHeaders(platformMiddleware.SecurityOptions{}))
+	r.Route("/api", func(apiRouter chi.Router) {
+		apiRouter.Use(platformMiddleware.SecurityHeadersFor(platformMiddleware.SecurityOverrides{
+			ContentSecurityPolicy: platformMiddleware.StrictContentSecurityPolicy,
+		}))
+		apiRouter.Get("/ping", func(w http.ResponseWriter, r *http.Request) {})
+	})
+	r.Route("/docs", func(docsRouter chi.Router) {
+		docsRouter.Use(platformMiddleware.SecurityHeadersFor(platformMiddleware.SecurityOverrides{
+			FrameOptions:          "SAMEORIGIN",
+			ReferrerPolicy:        "same-origin",
+			ContentSecurityPolicy: platformMiddleware.DocsContentSecurityPolicy,
+		}))
+		docsRouter.Get("/", func(w http.ResponseWriter, r *http.Request) {})
+	})
+
+	api := httptest.NewRecorder()
+	r.ServeHTTP(api, httptest.NewRequest("GET", "/api/ping", nil))
+	docs := httptest.NewRecorder()
+	r.ServeHTTP(docs, httptest.NewRequest("GET", "/docs/", nil))
+
+	s.Assert().Equal(platformMiddleware.StrictContentSecurityPolicy, api.Header().Get("Content-Security-Policy"))
+	s.Assert().Equal("DENY", api.Header().Get("X-Frame-Options"))
+	s.Assert().Equal("no-referrer", api.Header().Get("Referrer-Policy"))
+
+	s.Assert().Equal(platformMiddleware.DocsContentSecurityPolicy, docs.Header().Get("Content-Security-Policy"))
+	s.Assert().Equal("SAMEORIGIN", docs.Header().Get("X-Frame-Options"))
+	s.Assert().Equal("same-origin", docs.Header().Get("Referrer-Policy"))
+
+	for _, w := range []*httptest.ResponseRecorder{api, docs} {
+		s.Assert().Equal("nosniff", w.Header().Get("X-Content-Type-Options"))
+	}
+}
+
+func (s *RouterTestSuite) TestRouter_DeploymentHeaders() {
+	deployment := platformMiddleware.DeploymentInfo{Instance: "instance-1", Version: "1.2.3", Environment: config.EnvStaging}
+
+	tests := []struct {
+		name    string
+		enabled bool
+	}{
+		{"enabled", true},
+		{"disabled", false},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			cfg := *s.config
+			cfg.DeploymentHeaders = tt.enabled
+			deps := s.createRouterDependencies(&cfg)
+			deps.Deployment = deployment
+			router := NewRouter(deps)
+
+			for _, path := range []string{"/health/live", "/api/nonexistent"} {
+				w := httptest.NewRecorder()
+				router.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+
+				if tt.enabled {
+					s.Assert().Equal("instance-1", w.Header().Get(platformMiddleware.ServedByHeader), path)
+					s.Assert().Equal("1.2.3", w.Header().Get(platformMiddleware.AppVersionHeader), path)
+					s.Assert().Equal(config.EnvStaging, w.Header().Get(platformMiddleware.EnvironmentHeader), path)
+				} else {
+					s.Assert().Empty(w.Header().Get(platformMiddleware.ServedByHeader), path)
+					s.Assert().Empty(w.Header().Get(platformMiddleware.AppVersionHeader), path)
+					s.Assert().Empty(w.Header().Get(platformMiddleware.EnvironmentHeader), path)
+				}
+			}
+		})
+	}
+}
+
+func (s *RouterTestSuite) TestRouter_DeploymentHeaders_SkipsEmptyValues() {
+	cfg := *s.config
+	cfg.DeploymentHeaders = true
+	deps := s.createRouterDependencies(&cfg)
+	deps.Deployment = platformMiddleware.DeploymentInfo{Version: "1.2.3"}
+	router := NewRouter(deps)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/health/live", nil))
+
+	s.Assert().Equal("1.2.3", w.Header().Get(platformMiddleware.AppVersionHeader))
+	s.Assert().NotContains(w.Header(), platformMiddleware.ServedByHeader)
+	s.Assert().NotContains(w.Header(), platformMiddleware.EnvironmentHeader)
+}
+
+func (s *RouterTestSuite) TestRouter_MethodOverride() {
+	cfg := *s.config
+	cfg.MethodOverride = true
+	router := NewRouter(s.createRouterDependencies(&cfg))
+
+	s.mockManager.EXPECT().UpdateEntity(mock.Anything, "override-id", mock.Anything, mock.Anything).
+		Return(&exampleDomain.Entity{ID: "override-id", Email: "new@example.com", Name: "New"}, nil).Once()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/examples/override-id", strings.NewReader(`{"email":"new@example.com"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(platformMiddleware.MethodOverrideHeader, "patch")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	s.Assert().Equal(http.StatusOK, w.Code)
+	s.Assert().Contains(w.Body.String(), `"new@example.com"`)
+}
+
+func (s *RouterTestSuite) TestRouter_MethodOverride_Ignored() {
+	enabled := *s.config
+	enabled.MethodOverride = true
+
+	tests := []struct {
+		name           string
+		cfg            *config.HttpConfig
+		method         string
+		override       string
+		expectedStatus int
+	}{
+		{"get_request", &enabled, http.MethodGet, http.MethodPatch, http.StatusOK},
+		{"unsupported_method", &enabled, http.MethodPost, "TRACE", http.StatusMethodNotAllowed},
+		{"safe_method", &enabled, http.MethodPost, http.MethodGet, http.StatusMethodNotAllowed},
+		{"disabled", s.config, http.MethodPost, http.MethodPatch, http.StatusMethodNotAllowed},
+	}
+
+	s.mockManager.EXPECT().GetEntity(mock.Anything, "override-id").
+		Return(&exampleDomain.Entity{ID: "override-id"}, nil).Once()
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			router := NewRouter(s.createRouterDependencies(tt.cfg))
+
+			req := httptest.NewRequest(tt.method, "/api/examples/override-id", strings.NewReader(`{"email":"new@example.com"}`))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set(platformMiddleware.MethodOverrideHeader, tt.override)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			s.Assert().Equal(tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func (s *RouterTestSuite) TestRouter_BasePath() {
+	s.mockManager.EXPECT().GetEntity(mock.Anything, "test-id").
+		Return(&exampleDomain.Entity{ID: "test-id"}, nil).Times(2)
+
+	tests := []struct {
+		name              string
+		operationalAtRoot bool
+		expected          map[string]int
+	}{
+		{
+			name:              "operational_at_root",
+			operationalAtRoot: true,
+			expected: map[string]int{
+				"/svc/examples/api/examples/test-id": http.StatusOK,
+				"/api/examples/test-id":              http.StatusNotFound,
+				"/health/live":                       http.StatusOK,
+				"/svc/examples/health/live":          http.StatusNotFound,
+			},
+		},
+		{
+			name: "operational_under_prefix",
+			expected: map[string]int{
+				"/svc/examples/api/examples/test-id": http.StatusOK,
+				"/api/examples/test-id":              http.StatusNotFound,
+				"/health/live":                       http.StatusNotFound,
+				"/svc/examples/health/live":          http.StatusOK,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			cfg := *s.config
+			cfg.BasePath = "/svc/examples/"
+			cfg.OperationalAtRoot = tt.operationalAtRoot
+			router := NewRouter(s.createRouterDependencies(&cfg))
+
+			for path, status := range tt.expected {
+				w := httptest.NewRecorder()
+				router.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+				s.Assert().Equal(status, w.Code, path)
+			}
+		})
+	}
+}
+
+func (s *RouterTestSuite) TestRouter_RequireJSON() {
+	router := NewRouter(s.createRouterDependencies())
+	body := `{"id":"test-id","email":"test@example.com","name":"Test User"}`
+
+	s.mockManager.EXPECT().CreateEntity(mock.Anything, "test-id", "test@example.com", "Test User").
+		Return(&exampleDomain.Entity{ID: "test-id", Email: "test@example.com", Name: "Test User"}, nil).Once()
+	s.mockManager.EXPECT().RestoreEntity(mock.Anything, "test-id").
+		Return(&exampleDomain.Entity{ID: "test-id"}, nil).Once()
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		contentType    string
+		expectedStatus int
+	}{
+		{name: "text_plain", method: "POST", path: "/api/examples", body: body, contentType: "text/plain", expectedStatus: http.StatusUnsupportedMediaType},
+		{name: "form", method: "PATCH", path: "/api/examples/test-id", body: "name=x", contentType: "application/x-www-form-urlencoded", expectedStatus: http.StatusUnsupportedMediaType},
+		{name: "missing", method: "POST", path: "/api/examples/batch", body: "[]", expectedStatus: http.StatusUnsupportedMediaType},
+		{name: "json_with_charset", method: "POST", path: "/api/examples", body: body, contentType: "application/json; charset=utf-8", expectedStatus: http.StatusCreated},
+		{name: "no_body", method: "POST", path: "/api/examples/test-id/restore", expectedStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			s.Assert().Equal(tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusUnsupportedMediaType {
+				s.Assert().JSONEq(`{"error":"Content-Type must be application/json"}`, w.Body.String())
+			}
+		})
+	}
+}
+
+func (s *RouterTestSuite) TestRouter_RequiredHeaders() {
+	cfg := *s.config
+	cfg.RequiredHeaders = []string{"X-Tenant-ID"}
+	cfg.RequiredWriteHeaders = []string{"Idempotency-Key"}
+	router := NewRouter(s.createRouterDependencies(&cfg))
+
+	s.mockManager.EXPECT().CreateEntity(mock.Anything, "test-id", "test@example.com", "Test User").
+		Return(&exampleDomain.Entity{ID: "test-id", Email: "test@example.com", Name: "Test User"}, nil).Once()
+	s.mockManager.EXPECT().GetEntity(mock.Anything, "test-id").
+		Return(&exampleDomain.Entity{ID: "test-id"}, nil).Once()
+
+	tests := []struct {
+		name            string
+		method          string
+		path            string
+		headers         map[string]string
+		expectedStatus  int
+		expectedMissing []string
+	}{
+		{
+			name:           "write_with_headers",
+			method:         http.MethodPost,
+			path:           "/api/examples",
+			headers:        map[string]string{"X-Tenant-ID": "acme", platformMiddleware.IdempotencyKeyHeader: "key-1"},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:            "write_missing_headers",
+			method:          http.MethodPost,
+			path:            "/api/examples",
+			headers:         map[string]string{"X-Tenant-ID": " "},
+			expectedStatus:  http.StatusBadRequest,
+			expectedMissing: []string{"X-Tenant-Id", "Idempotency-Key"},
+		},
+		{
+			name:            "batch_missing_write_header",
+			method:          http.MethodPost,
+			path:            "/api/examples/batch",
+			headers:         map[string]string{"X-Tenant-ID": "acme"},
+			expectedStatus:  http.StatusBadRequest,
+			expectedMissing: []string{"Idempotency-Key"},
+		},
+		{
+			name:           "read_needs_no_write_headers",
+			method:         http.MethodGet,
+			path:           "/api/examples/test-id",
+			headers:        map[string]string{"X-Tenant-ID": "acme"},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:            "read_missing_header",
+			method:          http.MethodGet,
+			path:            "/api/examples/test-id",
+			expectedStatus:  http.StatusBadRequest,
+			expectedMissing: []string{"X-Tenant-Id"},
+		},
+		{
+			name:           "route_without_requirements",
+			method:         http.MethodGet,
+			path:           "/health/live",
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			body := `{"id":"test-id","email":"test@example.com","name":"Test User"}`
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			for name, value := range tt.headers {
+				req.Header.Set(name, value)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			s.Require().Equal(tt.expectedStatus, w.Code, w.Body.String())
+			if tt.expectedMissing == nil {
+				return
+			}
+			var response struct {
+				Error   string   `json:"error"`
+				Missing []string `json:"missing"`
+			}
+			s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &response))
+			s.Assert().Equal(tt.expectedMissing, response.Missing)
+			s.Assert().Equal("missing required headers: "+strings.Join(tt.expectedMissing, ", "), response.Error)
+			s.Assert().Equal("application/json", w.Header().Get("Content-Type"))
+		})
+	}
+}
+
+func (s *RouterTestSuite) TestRouter_StrictQuery() {
+	tests := []struct {
+		name           string
+		strict         bool
+		query          string
+		expectedStatus int
+		expectedAtomic bool
+	}{
+		{name: "single_param", strict: true, query: "?atomic=true", expectedStatus: http.StatusCreated, expectedAtomic: true},
+		{name: "duplicate_param_strict", strict: true, query: "?atomic=true&atomic=false", expectedStatus: http.StatusBadRequest},
+		{name: "duplicate_param_first_wins", strict: false, query: "?atomic=true&atomic=false", expectedStatus: http.StatusCreated, expectedAtomic: true},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			cfg := *s.config
+			cfg.StrictQuery = tt.strict
+			router := NewRouter(s.createRouterDependencies(&cfg))
+
+			if tt.expectedStatus == http.StatusCreated {
+				s.mockManager.EXPECT().CreateEntities(mock.Anything, mock.Anything, tt.expectedAtomic).
+					Return([]exampleDomain.CreateEntityResult{{Entity: &exampleDomain.Entity{ID: "test-id"}}}, nil).Once()
+			}
+
+			body := `[{"id":"test-id","email":"test@example.com","name":"Test User"}]`
+			req := httptest.NewRequest(http.MethodPost, "/api/examples/batch"+tt.query, strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			s.Require().Equal(tt.expectedStatus, w.Code, w.Body.String())
+			if tt.expectedStatus == http.StatusBadRequest {
+				s.Assert().Contains(w.Body.String(), "Duplicate atomic parameter")
+			}
+		})
+	}
+}
+
+func (s *RouterTestSuite) TestRouter_ServerTiming() {
+	serverTimingEntry := regexp.MustCompile(`^[a-z]+;dur=\d+\.\d{3}$`)
+	metricNames := func(header string) []string {
+		var names []string
+		for _, entry := range strings.Split(header, ", ") {
+			s.Require().Regexp(serverTimingEntry, entry)
+			names = append(names, strings.SplitN(entry, ";", 2)[0])
+		}
+		return names
+	}
+
+	tests := []struct {
+		name            string
+		enabled         bool
+		requestHeader   string
+		sendHeader      bool
+		expectedMetrics []string
+	}{
+		{name: "disabled", enabled: false},
+		{name: "enabled", enabled: true, expectedMetrics: []string{"validate", "db", "encode", "total"}},
+		{name: "header_gated_without_header", enabled: true, requestHeader: "X-Debug-Timing"},
+		{name: "header_gated_with_header", enabled: true, requestHeader: "X-Debug-Timing", sendHeader: true, expectedMetrics: []string{"validate", "db", "encode", "total"}},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			cfg := *s.config
+			cfg.ServerTiming = tt.enabled
+			cfg.ServerTimingHeader = tt.requestHeader
+			router := NewRouter(s.createRouterDependencies(&cfg))
+
+			// Stands in for the repository, which records its query time.
+			s.mockManager.EXPECT().CreateEntity(mock.Anything, "test-id", "test@example.com", "Test User").
+				RunAndReturn(func(ctx context.Context, id, email, name string) (*exampleDomain.Entity, error) {
+					servertiming.Add(ctx, "db", 2*time.Millisecond)
+					return &exampleDomain.Entity{ID: id, Email: email, Name: name}, nil
+				}).Once()
+
+			body := `{"id":"test-id","email":"test@example.com","name":"Test User"}`
+			req := httptest.NewRequest(http.MethodPost, "/api/examples", strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.sendHeader {
+				req.Header.Set(tt.requestHeader, "1")
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			s.Require().Equal(http.StatusCreated, w.Code, w.Body.String())
+			header := w.Header().Get(servertiming.HeaderName)
+			if tt.expectedMetrics == nil {
+				s.Assert().Empty(header)
+				return
+			}
+			s.Assert().Equal(tt.expectedMetrics, metricNames(header))
+			s.Assert().Contains(header, "db;dur=2.000")
+		})
+	}
+}
+
+func (s *RouterTestSuite) TestRouter_ServerTiming_WithoutSubTimings() {
+	cfg := *s.config
+	cfg.ServerTiming = true
+	router := NewRouter(s.createRouterDependencies(&cfg))
+
+	req := httptest.NewRequest(http.MethodGet, "/health/live", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	s.Require().Equal(http.StatusOK, w.Code)
+	s.Assert().Regexp(`^encode;dur=\d+\.\d{3}, total;dur=\d+\.\d{3}$`, w.Header().Get(servertiming.HeaderName))
+}
+
+func (s *RouterTestSuite) TestRouter_Middleware_SecurityHeaders_TLSEnablesHSTS() {
+	tlsConfig := *s.config
+	tlsConfig.TLS.Enabled = true
+	router := NewRouter(s.createRouterDependencies(&tlsConfig))
+
+	req := httptest.NewRequest("GET", "/health/live", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	s.Assert().Equal("max-age=31536000", w.Header().Get("Strict-Transport-Security"))
+}
+
 func (s *RouterTestSuite) TestRouter_Middleware_RealIP() {
 	router := NewRouter(s.createRouterDependencies()).(*chi.Mux)
 
@@ -325,33 +1013,1432 @@ func (s *RouterTestSuite) TestRouter_Middleware_Recoverer_Panic() {
 	s.Assert().Equal(http.StatusInternalServerError, w.Code)
 }
 
-func (s *RouterTestSuite) TestRouter_RateLimit_Integration() {
-	restrictiveConfig := &config.HttpConfig{
-		Server: s.config.Server,
-		CORS:   s.config.CORS,
-		RateLimit: config.RateLimitConfig{
-			GlobalRequests: 2,
-			GlobalWindow:   1,
-			RequestsPerIP:  1,
-			WindowSeconds:  1,
-		},
+func (s *RouterTestSuite) TestRouter_Middleware_Recoverer_Response() {
+	tests := []struct {
+		name        string
+		environment string
+		expectPanic string
+	}{
+		{"development exposes panic", config.EnvDevelopment, "test panic"},
+		{"production hides panic", config.EnvProduction, ""},
 	}
 
-	router := NewRouter(s.createRouterDependencies(restrictiveConfig))
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			recorder := newRecordingLogger()
+			s.logger = recorder
+			cfg := *s.config
+			cfg.Environment = tt.environment
+			router := NewRouter(s.createRouterDependencies(&cfg)).(*chi.Mux)
+			router.Get("/panic", func(w http.ResponseWriter, r *http.Request) {
+				panic("test panic")
+			})
 
-	req1 := httptest.NewRequest("GET", "/health/live", nil)
-	req1.RemoteAddr = "192.168.1.1:12345"
-	w1 := httptest.NewRecorder()
-	router.ServeHTTP(w1, req1)
-	s.Assert().Equal(http.StatusOK, w1.Code)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest("GET", "/panic", nil))
+
+			s.Assert().Equal(http.StatusInternalServerError, w.Code)
+			s.Assert().Equal("application/json", w.Header().Get("Content-Type"))
+
+			var body map[string]string
+			s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &body))
+			s.Assert().Equal("Internal Server Error", body["error"])
+			s.Assert().Equal(tt.expectPanic, body["panic"])
+			s.Assert().NotEmpty(body["request_id"])
+
+			var logged *logEntry
+			for i, entry := range *recorder.entries {
+				if entry.msg == "Panic recovered" {
+					logged = &(*recorder.entries)[i]
+				}
+			}
+			s.Require().NotNil(logged)
+			s.Assert().Equal("test panic", logged.fields["panic"])
+			s.Assert().Contains(logged.fields["stack"], "runtime/debug.Stack")
+			s.Assert().Equal(body["request_id"], logged.fields["request_id"])
+		})
+	}
+}
 
-	req2 := httptest.NewRequest("GET", "/health/live", nil)
-	req2.RemoteAddr = "192.168.1.1:12345"
+func (s *RouterTestSuite) TestMetricsMiddleware_PanicReleasesInFlight() {
+	provider, err := metrics.NewProvider()
+	s.Require().NoError(err)
+	handler := platformMiddleware.MetricsMiddleware(provider)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("test panic")
+	}))
+
+	s.Assert().Panics(func() {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/panic", nil))
+	})
+
+	w := httptest.NewRecorder()
+	provider.Handler().ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+	s.Assert().Regexp(`(?m)^http_requests_in_flight\{[^}]*\} 0$`, w.Body.String())
+}
+
+func (s *RouterTestSuite) TestMetricsMiddleware_RecordsPayloadSizes() {
+	provider, err := metrics.NewProvider()
+	s.Require().NoError(err)
+	router := chi.NewRouter()
+	router.Use(platformMiddleware.MetricsMiddleware(provider))
+	router.Post("/upload", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		_, _ = w.Write([]byte("accepted"))
+	})
+	router.Get("/stream", func(w http.ResponseWriter, r *http.Request) {
+		for i := 0; i < 3; i++ {
+			_, _ = w.Write([]byte(strings.Repeat("x", 100)))
+			w.(http.Flusher).Flush()
+		}
+	})
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", "/upload", strings.NewReader("hello world")))
+	chunked := httptest.NewRequest("POST", "/upload", io.MultiReader(strings.NewReader("0123456789"), strings.NewReader("abcde")))
+	chunked.ContentLength = -1
+	router.ServeHTTP(httptest.NewRecorder(), chunked)
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/stream", nil))
+
+	w := httptest.NewRecorder()
+	provider.Handler().ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+	body := w.Body.String()
+	s.Assert().Regexp(`(?m)^http_request_size_bytes_sum\{[^}]*method="POST"[^}]*path="/upload"[^}]*status="200"[^}]*\} 26$`, body,
+		"the declared length of the first request plus the bytes read from the chunked one")
+	s.Assert().Regexp(`(?m)^http_response_size_bytes_sum\{[^}]*method="POST"[^}]*path="/upload"[^}]*status="200"[^}]*\} 16$`, body)
+	s.Assert().Regexp(`(?m)^http_request_size_bytes_sum\{[^}]*method="GET"[^}]*path="/stream"[^}]*status="200"[^}]*\} 0$`, body)
+	s.Assert().Regexp(`(?m)^http_response_size_bytes_sum\{[^}]*method="GET"[^}]*path="/stream"[^}]*status="200"[^}]*\} 300$`, body)
+	s.Assert().Regexp(`(?m)^http_response_size_bytes_bucket\{[^}]*path="/stream"[^}]*le="256"[^}]*\} 0$`, body)
+}
+
+func (s *RouterTestSuite) TestRouter_Middleware_MaxBodySize() {
+	body := `{"id":"test-id","email":"test@example.com","name":"Test User"}`
+	limitedConfig := *s.config
+	limitedConfig.MaxBodySize = int64(len(body) - 1)
+	router := NewRouter(s.createRouterDependencies(&limitedConfig))
+
+	req := httptest.NewRequest("POST", "/api/examples", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	s.Assert().Equal(http.StatusRequestEntityTooLarge, w.Code)
+	s.Assert().JSONEq(`{"error":"request body too large"}`, w.Body.String())
+}
+
+func (s *RouterTestSuite) TestRouter_Middleware_MaxJSONDepth() {
+	nested := func(depth int) string {
+		return `{"id":"test-id","email":"test@example.com","name":"Test User","extra":` +
+			strings.Repeat("[", depth-1) + strings.Repeat("]", depth-1) + `}`
+	}
+
+	tests := []struct {
+		name         string
+		body         string
+		contentType  string
+		expectedCode int
+	}{
+		{name: "within_limit", body: nested(4), contentType: "application/json", expectedCode: http.StatusCreated},
+		{name: "brackets_inside_strings_ignored", body: `{"id":"test-id","email":"test@example.com","name":"[[[[[[[[[[\\\"[[[["}`, contentType: "application/json", expectedCode: http.StatusCreated},
+		{name: "exceeds_limit", body: nested(6), contentType: "application/json", expectedCode: http.StatusBadRequest},
+		{name: "without_content_type_rejected_first", body: nested(6), expectedCode: http.StatusUnsupportedMediaType},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			cfg := *s.config
+			cfg.MaxJSONDepth = 5
+			router := NewRouter(s.createRouterDependencies(&cfg))
+			if tt.expectedCode == http.StatusCreated {
+				s.mockManager.EXPECT().
+					CreateEntity(mock.Anything, "test-id", "test@example.com", mock.Anything).
+					Return(&exampleDomain.Entity{ID: "test-id", Email: "test@example.com", Name: "Test User"}, nil).
+					Once()
+			}
+
+			req := httptest.NewRequest("POST", "/api/examples", strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			s.Assert().Equal(tt.expectedCode, w.Code)
+			if tt.expectedCode == http.StatusBadRequest {
+				s.Assert().JSONEq(`{"error":"request body is nested too deeply"}`, w.Body.String())
+			}
+		})
+	}
+}
+
+func (s *RouterTestSuite) adminConfig() *config.HttpConfig {
+	cfg := *s.config
+	cfg.Admin = config.AdminServerConfig{Enabled: true, Host: "localhost"}
+	return &cfg
+}
+
+func (s *RouterTestSuite) TestRouter_CORSPerRouteGroup() {
+	cfg := *s.config
+	cfg.CORS = config.CORSConfig{AllowedOrigins: []string{"https://app.example.com"}, AllowedMethods: []string{"GET", "POST"}}
+	cfg.AdminCORS = config.CORSConfig{AllowedOrigins: []string{"https://ops.example.com"}, AllowedMethods: []string{"GET"}}
+	router := NewRouter(s.createRouterDependencies(&cfg))
+
+	tests := []struct {
+		name          string
+		method        string
+		path          string
+		origin        string
+		expectAllowed bool
+	}{
+		{"api allows api origin", "OPTIONS", "/api/examples", "https://app.example.com", true},
+		{"api rejects admin origin", "OPTIONS", "/api/examples", "https://ops.example.com", false},
+		{"health allows admin origin", "GET", "/health/live", "https://ops.example.com", true},
+		{"health rejects api origin", "GET", "/health/live", "https://app.example.com", false},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			req.Header.Set("Origin", tt.origin)
+			if tt.method == "OPTIONS" {
+				req.Header.Set("Access-Control-Request-Method", "POST")
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if tt.expectAllowed {
+				s.Assert().Equal(tt.origin, w.Header().Get("Access-Control-Allow-Origin"))
+			} else {
+				s.Assert().Empty(w.Header().Get("Access-Control-Allow-Origin"))
+			}
+		})
+	}
+}
+
+func (s *RouterTestSuite) TestRouter_AdminServerEnabled_MainServesOnlyAPI() {
+	deps := s.createRouterDependencies(s.adminConfig())
+	deps.LogLevelHandler = admin.NewLogLevelHandler(logger.NewNop().(logger.LevelSetter))
+	router := NewRouter(deps)
+
+	for _, path := range []string{"/health/live", "/health/startup", "/health/ready", "/version", "/metrics", "/debug/pprof/", "/admin/log-level"} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		s.Assert().Equal(http.StatusNotFound, w.Code, path)
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/api/examples/batch", strings.NewReader(`[]`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+	s.Assert().Equal(http.StatusBadRequest, w.Code)
+}
+
+func (s *RouterTestSuite) TestNewAdminRouter() {
+	deps := s.createRouterDependencies(s.adminConfig())
+	deps.LogLevelHandler = admin.NewLogLevelHandler(logger.NewNop().(logger.LevelSetter))
+	router := NewAdminRouter(deps)
+	s.startupHandler.MarkReady()
+
+	s.mockHealthManager.EXPECT().CheckAll(mock.Anything).Return(map[string]platformHealth.CheckResult{
+		"test": {Status: platformHealth.StatusHealthy, Message: "OK"},
+	}).Once()
+
+	for _, path := range []string{"/health/live", "/health/startup", "/health/ready", "/metrics", "/debug/pprof/", "/admin/log-level"} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		s.Assert().Equal(http.StatusOK, w.Code, path)
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/api/examples/batch", strings.NewReader(`[]`)))
+	s.Assert().Equal(http.StatusNotFound, w.Code)
+}
+
+func (s *RouterTestSuite) TestRouter_Version() {
+	cfg := *s.config
+	cfg.Environment = config.EnvProduction
+	router := NewRouter(s.createRouterDependencies(&cfg))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/version", nil))
+
+	s.Require().Equal(http.StatusOK, w.Code)
+	var body admin.VersionResponse
+	s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &body))
+	s.Assert().Equal(version.Info(), body.BuildInfo)
+	s.Assert().NotEmpty(body.GoVersion)
+}
+
+func (s *RouterTestSuite) TestNewAdminRouter_NoLogLevelInProduction() {
+	cfg := s.adminConfig()
+	cfg.Environment = config.EnvProduction
+	deps := s.createRouterDependencies(cfg)
+	deps.LogLevelHandler = admin.NewLogLevelHandler(logger.NewNop().(logger.LevelSetter))
+	router := NewAdminRouter(deps)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/admin/log-level", nil))
+
+	s.Assert().Equal(http.StatusNotFound, w.Code)
+}
+
+func (s *RouterTestSuite) TestRouter_AdminServer_SeparatePorts() {
+	freePort := func() int {
+		listener, err := net.Listen("tcp", "localhost:0")
+		s.Require().NoError(err)
+		defer func() { s.Require().NoError(listener.Close()) }()
+		return listener.Addr().(*net.TCPAddr).Port
+	}
+
+	cfg := s.adminConfig()
+	cfg.Server.Host = "localhost"
+	cfg.Server.Port = freePort()
+	cfg.Admin.Port = freePort()
+	deps := s.createRouterDependencies(cfg)
+
+	ctx := context.Background()
+	mainServer := NewServer(cfg, s.logger, NewRouter(deps))
+	adminServer := NewAdminServer(cfg, s.logger, NewAdminRouter(deps))
+	s.Require().NoError(mainServer.Start(ctx))
+	defer func() { s.Assert().NoError(mainServer.Stop(ctx)) }()
+	s.Require().NoError(adminServer.Start(ctx))
+	defer func() { s.Assert().NoError(adminServer.Stop(ctx)) }()
+
+	get := func(port int, path string) int {
+		resp, err := http.Get(fmt.Sprintf("http://localhost:%d%s", port, path))
+		s.Require().NoError(err)
+		s.Require().NoError(resp.Body.Close())
+		return resp.StatusCode
+	}
+
+	s.Assert().Equal(http.StatusOK, get(cfg.Admin.Port, "/health/live"))
+	s.Assert().Equal(http.StatusOK, get(cfg.Admin.Port, "/metrics"))
+	s.Assert().Equal(http.StatusOK, get(cfg.Admin.Port, "/debug/pprof/"))
+	s.Assert().Equal(http.StatusNotFound, get(cfg.Server.Port, "/health/live"))
+	s.Assert().Equal(http.StatusNotFound, get(cfg.Server.Port, "/metrics"))
+	s.Assert().Equal(http.StatusNotFound, get(cfg.Server.Port, "/debug/pprof/"))
+}
+
+func (s *RouterTestSuite) newIdempotentRouter(ttl time.Duration) http.Handler {
+	deps := s.createRouterDependencies()
+	store := platformMiddleware.NewMemoryIdempotencyStore(ttl)
+	s.T().Cleanup(store.Close)
+	deps.IdempotencyStore = store
+	return NewRouter(deps)
+}
+
+func postExample(router http.Handler, key string) *httptest.ResponseRecorder {
+	return postExampleBody(router, key, `{"id":"test-id","email":"test@example.com","name":"Test User"}`)
+}
+
+func postExampleBody(router http.Handler, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("POST", "/api/examples", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		req.Header.Set(platformMiddleware.IdempotencyKeyHeader, key)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func (s *RouterTestSuite) TestRouter_Idempotency_ReplaysResponse() {
+	router := s.newIdempotentRouter(time.Hour)
+	s.mockManager.EXPECT().
+		CreateEntity(mock.Anything, "test-id", "test@example.com", "Test User").
+		Return(&exampleDomain.Entity{ID: "test-id", Email: "test@example.com", Name: "Test User"}, nil).
+		Once()
+
+	first := postExample(router, "key-1")
+	second := postExample(router, "key-1")
+
+	s.Assert().Equal(http.StatusCreated, first.Code)
+	s.Assert().Empty(first.Header().Get(platformMiddleware.IdempotentReplayedHeader))
+	s.Assert().Equal(http.StatusCreated, second.Code)
+	s.Assert().Equal("true", second.Header().Get(platformMiddleware.IdempotentReplayedHeader))
+	s.Assert().Equal(first.Body.String(), second.Body.String())
+	s.Assert().Equal("application/json", second.Header().Get("Content-Type"))
+}
+
+func (s *RouterTestSuite) TestRouter_Idempotency_DistinctKeys() {
+	router := s.newIdempotentRouter(time.Hour)
+	s.mockManager.EXPECT().
+		CreateEntity(mock.Anything, "test-id", "test@example.com", "Test User").
+		Return(&exampleDomain.Entity{ID: "test-id", Email: "test@example.com", Name: "Test User"}, nil).
+		Once()
+	s.mockManager.EXPECT().
+		CreateEntity(mock.Anything, "test-id", "test@example.com", "Test User").
+		Return(nil, &exampleDomain.AlreadyExistsError{ID: "test-id"}).
+		Twice()
+
+	s.Assert().Equal(http.StatusCreated, postExample(router, "key-1").Code)
+	s.Assert().Equal(http.StatusConflict, postExample(router, "key-2").Code)
+	s.Assert().Equal(http.StatusConflict, postExample(router, "").Code)
+}
+
+func (s *RouterTestSuite) TestRouter_Idempotency_DoesNotCacheServerErrors() {
+	router := s.newIdempotentRouter(time.Hour)
+	s.mockManager.EXPECT().
+		CreateEntity(mock.Anything, "test-id", "test@example.com", "Test User").
+		Return(nil, errors.New("connection refused")).
+		Once()
+	s.mockManager.EXPECT().
+		CreateEntity(mock.Anything, "test-id", "test@example.com", "Test User").
+		Return(&exampleDomain.Entity{ID: "test-id", Email: "test@example.com", Name: "Test User"}, nil).
+		Once()
+
+	first := postExample(router, "key-1")
+	second := postExample(router, "key-1")
+
+	s.Assert().Equal(http.StatusInternalServerError, first.Code)
+	s.Assert().Equal(http.StatusCreated, second.Code)
+	s.Assert().Empty(second.Header().Get(platformMiddleware.IdempotentReplayedHeader))
+}
+
+func (s *RouterTestSuite) TestRouter_Idempotency_ExpiresAfterTTL() {
+	router := s.newIdempotentRouter(20 * time.Millisecond)
+	s.mockManager.EXPECT().
+		CreateEntity(mock.Anything, "test-id", "test@example.com", "Test User").
+		Return(&exampleDomain.Entity{ID: "test-id", Email: "test@example.com", Name: "Test User"}, nil).
+		Twice()
+
+	postExample(router, "key-1")
+	time.Sleep(30 * time.Millisecond)
+	second := postExample(router, "key-1")
+
+	s.Assert().Equal(http.StatusCreated, second.Code)
+	s.Assert().Empty(second.Header().Get(platformMiddleware.IdempotentReplayedHeader))
+}
+
+func (s *RouterTestSuite) TestRouter_Idempotency_SerializesConcurrentRequests() {
+	router := s.newIdempotentRouter(time.Hour)
+	var calls atomic.Int32
+	s.mockManager.EXPECT().
+		CreateEntity(mock.Anything, "test-id", "test@example.com", "Test User").
+		RunAndReturn(func(_ context.Context, id, email, name string) (*exampleDomain.Entity, error) {
+			calls.Add(1)
+			time.Sleep(20 * time.Millisecond)
+			return &exampleDomain.Entity{ID: id, Email: email, Name: name}, nil
+		}).
+		Maybe()
+
+	const requests = 5
+	codes := make([]int, requests)
+	var wg sync.WaitGroup
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			codes[i] = postExample(router, "key-1").Code
+		}(i)
+	}
+	wg.Wait()
+
+	s.Assert().Equal(int32(1), calls.Load())
+	for _, code := range codes {
+		s.Assert().Equal(http.StatusCreated, code)
+	}
+}
+
+func (s *RouterTestSuite) TestRouter_Export_ServesRanges() {
+	updatedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	s.mockManager.EXPECT().ListEntities(mock.Anything).
+		Return([]*exampleDomain.Entity{
+			{ID: "a", Email: "a@example.com", Name: "Alice", UpdatedAt: updatedAt},
+			{ID: "b", Email: "b@example.com", Name: "Bob", UpdatedAt: updatedAt.Add(-time.Hour)},
+		}, nil).
+		Twice()
+	router := NewRouter(s.createRouterDependencies())
+	export := "id,email,name,updated_at\n" +
+		"a,a@example.com,Alice,2024-01-02T03:04:05Z\n" +
+		"b,b@example.com,Bob,2024-01-02T02:04:05Z\n"
+
+	full := httptest.NewRecorder()
+	router.ServeHTTP(full, httptest.NewRequest("GET", "/api/examples/export", nil))
+
+	s.Require().Equal(http.StatusOK, full.Code)
+	s.Assert().Equal(export, full.Body.String())
+	s.Assert().Equal("bytes", full.Header().Get("Accept-Ranges"))
+	s.Assert().Equal("text/csv; charset=utf-8", full.Header().Get("Content-Type"))
+	s.Assert().Equal(`attachment; filename=examples.csv`, full.Header().Get("Content-Disposition"))
+	s.Assert().Equal(updatedAt.Format(http.TimeFormat), full.Header().Get("Last-Modified"))
+
+	req := httptest.NewRequest("GET", "/api/examples/export", nil)
+	req.Header.Set("Range", "bytes=25-")
+	req.Header.Set("If-Range", full.Header().Get("ETag"))
+	partial := httptest.NewRecorder()
+	router.ServeHTTP(partial, req)
+
+	s.Assert().Equal(http.StatusPartialContent, partial.Code)
+	s.Assert().Equal(fmt.Sprintf("bytes 25-%d/%d", len(export)-1, len(export)), partial.Header().Get("Content-Range"))
+	s.Assert().Equal(export[25:], partial.Body.String())
+}
+
+func (s *RouterTestSuite) TestRouter_Idempotency_RejectsLongKey() {
+	router := s.newIdempotentRouter(time.Hour)
+
+	w := postExample(router, strings.Repeat("k", 256))
+
+	s.Assert().Equal(http.StatusBadRequest, w.Code)
+	s.Assert().Equal("application/json", w.Header().Get("Content-Type"))
+	s.Assert().JSONEq(`{"error":"Idempotency-Key must not exceed 255 characters"}`, w.Body.String())
+}
+
+func (s *RouterTestSuite) TestRouter_Idempotency_RejectsDifferentBody() {
+	router := s.newIdempotentRouter(time.Hour)
+	s.mockManager.EXPECT().
+		CreateEntity(mock.Anything, "test-id", "test@example.com", "Test User").
+		Return(&exampleDomain.Entity{ID: "test-id", Email: "test@example.com", Name: "Test User"}, nil).
+		Once()
+
+	s.Require().Equal(http.StatusCreated, postExample(router, "key-1").Code)
+	w := postExampleBody(router, "key-1", `{"id":"other-id","email":"other@example.com","name":"Other"}`)
+
+	s.Assert().Equal(http.StatusUnprocessableEntity, w.Code)
+	s.Assert().Empty(w.Header().Get(platformMiddleware.IdempotentReplayedHeader))
+	s.Assert().JSONEq(`{"error":"Idempotency-Key was already used with a different request body"}`, w.Body.String())
+	s.Assert().Equal("true", postExample(router, "key-1").Header().Get(platformMiddleware.IdempotentReplayedHeader))
+}
+
+func (s *RouterTestSuite) TestRouter_Idempotency_ReplayKeepsRequestHeaders() {
+	router := s.newIdempotentRouter(time.Hour)
+	s.mockManager.EXPECT().
+		CreateEntity(mock.Anything, "test-id", "test@example.com", "Test User").
+		Return(&exampleDomain.Entity{ID: "test-id", Email: "test@example.com", Name: "Test User"}, nil).
+		Once()
+
+	post := func(correlationID string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/api/examples", strings.NewReader(`{"id":"test-id","email":"test@example.com","name":"Test User"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(platformMiddleware.IdempotencyKeyHeader, "key-1")
+		req.Header.Set(platformMiddleware.CorrelationIDHeader, correlationID)
+		req.Header.Set("Origin", "https://example.com")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	first := post("first-request")
+	second := post("second-request")
+
+	s.Require().Equal("true", second.Header().Get(platformMiddleware.IdempotentReplayedHeader))
+	s.Assert().Equal("first-request", first.Header().Get(platformMiddleware.CorrelationIDHeader))
+	s.Assert().Equal("second-request", second.Header().Get(platformMiddleware.CorrelationIDHeader))
+	s.Assert().Equal([]string{"*"}, second.Header().Values("Access-Control-Allow-Origin"))
+	s.Assert().Equal(first.Header().Get("Location"), second.Header().Get("Location"))
+	s.Assert().Equal("application/json", second.Header().Get("Content-Type"))
+}
+
+func (s *RouterTestSuite) TestMemoryIdempotencyStore_EvictsLeastRecentlyUsed() {
+	ctx := context.Background()
+	store := platformMiddleware.NewMemoryIdempotencyStore(time.Hour, platformMiddleware.WithIdempotencyMaxEntries(3))
+	defer store.Close()
+
+	for _, key := range []string{"a", "b", "c"} {
+		store.Set(ctx, key, &platformMiddleware.CachedResponse{StatusCode: http.StatusCreated})
+	}
+	_, ok := store.Get(ctx, "a")
+	s.Require().True(ok)
+
+	store.Set(ctx, "d", &platformMiddleware.CachedResponse{StatusCode: http.StatusCreated})
+	store.Set(ctx, "e", &platformMiddleware.CachedResponse{StatusCode: http.StatusCreated})
+
+	s.Assert().Equal(3, store.Len())
+	for key, kept := range map[string]bool{"a": true, "b": false, "c": false, "d": true, "e": true} {
+		_, ok := store.Get(ctx, key)
+		s.Assert().Equal(kept, ok, key)
+	}
+}
+
+func (s *RouterTestSuite) TestMemoryIdempotencyStore_SweepsExpiredEntries() {
+	ctx := context.Background()
+	store := platformMiddleware.NewMemoryIdempotencyStore(20*time.Millisecond,
+		platformMiddleware.WithIdempotencySweepInterval(10*time.Millisecond))
+	defer store.Close()
+
+	for i := 0; i < 10; i++ {
+		store.Set(ctx, fmt.Sprintf("key-%d", i), &platformMiddleware.CachedResponse{StatusCode: http.StatusCreated})
+	}
+	s.Require().Equal(10, store.Len())
+
+	s.Assert().Eventually(func() bool { return store.Len() == 0 }, time.Second, 5*time.Millisecond)
+}
+
+func (s *RouterTestSuite) TestMemoryIdempotencyStore_Close() {
+	store := platformMiddleware.NewMemoryIdempotencyStore(30*time.Millisecond, platformMiddleware.WithIdempotencySweepInterval(time.Millisecond))
+	store.Set(context.Background(), "key", &platformMiddleware.CachedResponse{StatusCode: http.StatusCreated})
+
+	store.Close()
+	store.Close()
+
+	_, ok := store.Get(context.Background(), "key")
+	s.Assert().True(ok, "closing stops sweeping, not serving")
+	time.Sleep(40 * time.Millisecond)
+	s.Assert().Equal(1, store.Len(), "nothing is swept after Close")
+	_, ok = store.Get(context.Background(), "key")
+	s.Assert().False(ok, "expired entries are still hidden")
+}
+
+type logEntry struct {
+	msg    string
+	fields map[string]interface{}
+}
+
+type recordingLogger struct {
+	entries *[]logEntry
+	fields  []logger.Field
+	mu      *sync.Mutex
+}
+
+func newRecordingLogger() *recordingLogger {
+	return &recordingLogger{entries: &[]logEntry{}, mu: &sync.Mutex{}}
+}
+
+func (l *recordingLogger) record(msg string, fields []logger.Field) {
+	entry := logEntry{msg: msg, fields: make(map[string]interface{})}
+	for _, f := range append(append([]logger.Field{}, l.fields...), fields...) {
+		entry.fields[f.Key] = f.Value
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	*l.entries = append(*l.entries, entry)
+}
+
+func (l *recordingLogger) Info(msg string, fields ...logger.Field)  { l.record(msg, fields) }
+func (l *recordingLogger) Error(msg string, fields ...logger.Field) { l.record(msg, fields) }
+func (l *recordingLogger) Debug(msg string, fields ...logger.Field) { l.record(msg, fields) }
+func (l *recordingLogger) Warn(msg string, fields ...logger.Field)  { l.record(msg, fields) }
+
+func (l *recordingLogger) With(fields ...logger.Field) logger.Logger {
+	return &recordingLogger{entries: l.entries, fields: append(append([]logger.Field{}, l.fields...), fields...), mu: l.mu}
+}
+
+func (l *recordingLogger) accessLog() (logEntry, bool) {
+	return l.entry("HTTP Request")
+}
+
+func (l *recordingLogger) entry(msg string) (logEntry, bool) {
+	for _, entry := range *l.entries {
+		if entry.msg == msg {
+			return entry, true
+		}
+	}
+	return logEntry{}, false
+}
+
+func (s *RouterTestSuite) TestRequestLogger_UsesInjectedLogger() {
+	recorder := newRecordingLogger()
+	r := chi.NewRouter()
+	r.Use(platformMiddleware.RequestID(0))
+	r.Use(platformMiddleware.LoggerInjector(recorder))
+	r.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			tagged := logger.FromContext(r.Context()).With(logger.String("tenant", "acme"))
+			next.ServeHTTP(w, r.WithContext(logger.WithLogger(r.Context(), tagged)))
+		})
+	})
+	r.Use(platformMiddleware.RequestLogger(logger.NewNop(), platformMiddleware.RequestLoggerConfig{}))
+	r.Get("/", func(w http.ResponseWriter, r *http.Request) {})
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+
+	entry, ok := recorder.accessLog()
+	s.Require().True(ok)
+	s.Assert().Equal("acme", entry.fields["tenant"])
+	s.Assert().NotEmpty(entry.fields["request_id"])
+}
+
+func (s *RouterTestSuite) TestRouter_CorrelationID() {
+	tests := []struct {
+		name     string
+		inbound  string
+		expected string
+	}{
+		{name: "propagates_inbound_value", inbound: "order-flow-42", expected: "order-flow-42"},
+		{name: "generates_when_missing"},
+		{name: "replaces_invalid_value", inbound: "bad id\r\n"},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			recorder := newRecordingLogger()
+			s.logger = recorder
+			router := NewRouter(s.createRouterDependencies()).(*chi.Mux)
+			var fromContext string
+			router.Get("/correlated", func(w http.ResponseWriter, r *http.Request) {
+				fromContext = platformMiddleware.CorrelationIDFromContext(r.Context())
+				logger.FromContext(r.Context()).Info("Handling correlated request")
+			})
+
+			req := httptest.NewRequest("GET", "/correlated", nil)
+			if tt.inbound != "" {
+				req.Header.Set(platformMiddleware.CorrelationIDHeader, tt.inbound)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			id := w.Header().Get(platformMiddleware.CorrelationIDHeader)
+			if tt.expected != "" {
+				s.Assert().Equal(tt.expected, id)
+			} else {
+				s.Assert().NotEmpty(id)
+				s.Assert().NotEqual(tt.inbound, id)
+			}
+			s.Assert().Equal(id, fromContext)
+
+			handlerLog, ok := recorder.entry("Handling correlated request")
+			s.Require().True(ok)
+			s.Assert().Equal(id, handlerLog.fields["correlation_id"])
+			accessLog, ok := recorder.accessLog()
+			s.Require().True(ok)
+			s.Assert().Equal(id, accessLog.fields["correlation_id"])
+			s.Assert().NotEqual(id, accessLog.fields["request_id"], "the correlation ID is independent of the request ID")
+		})
+	}
+}
+
+func (s *RouterTestSuite) TestCorrelationTransport_ForwardsID() {
+	var forwarded []string
+	downstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		forwarded = append(forwarded, r.Header.Get(platformMiddleware.CorrelationIDHeader))
+	}))
+	defer downstream.Close()
+	client := &http.Client{Transport: platformMiddleware.CorrelationTransport{}}
+
+	ctx := platformMiddleware.WithCorrelationID(context.Background(), "order-flow-42")
+	req, err := http.NewRequestWithContext(ctx, "GET", downstream.URL, nil)
+	s.Require().NoError(err)
+	resp, err := client.Do(req)
+	s.Require().NoError(err)
+	s.Require().NoError(resp.Body.Close())
+	s.Assert().Empty(req.Header.Get(platformMiddleware.CorrelationIDHeader), "the caller's request is not modified")
+
+	req, err = http.NewRequestWithContext(ctx, "GET", downstream.URL, nil)
+	s.Require().NoError(err)
+	req.Header.Set(platformMiddleware.CorrelationIDHeader, "explicit")
+	resp, err = client.Do(req)
+	s.Require().NoError(err)
+	s.Require().NoError(resp.Body.Close())
+
+	s.Assert().Equal([]string{"order-flow-42", "explicit"}, forwarded)
+}
+
+func (s *RouterTestSuite) TestRouter_AccessLog_ClientFields() {
+	tests := []struct {
+		name              string
+		accessLog         config.AccessLogConfig
+		userAgent         string
+		referer           string
+		expectedUserAgent string
+		expectedReferer   string
+		expectOmitted     bool
+	}{
+		{
+			name:          "disabled_by_default",
+			userAgent:     "curl/8.5.0",
+			referer:       "https://example.com/page",
+			expectOmitted: true,
+		},
+		{
+			name:              "enabled",
+			accessLog:         config.AccessLogConfig{ClientFields: true, MaxFieldLength: 256},
+			userAgent:         "curl/8.5.0",
+			referer:           "https://example.com/page",
+			expectedUserAgent: "curl/8.5.0",
+			expectedReferer:   "https://example.com/page",
+		},
+		{
+			name:              "truncated_when_oversized",
+			accessLog:         config.AccessLogConfig{ClientFields: true, MaxFieldLength: 10},
+			userAgent:         "Mozilla/5.0 (X11; Linux x86_64)",
+			referer:           "https://example.com/a/very/long/path",
+			expectedUserAgent: "Mozilla/5....",
+			expectedReferer:   "https://ex...",
+		},
+		{
+			name:              "truncation_keeps_utf8_valid",
+			accessLog:         config.AccessLogConfig{ClientFields: true, MaxFieldLength: 3},
+			userAgent:         "ab\u00e9",
+			expectedUserAgent: "ab...",
+			expectedReferer:   "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			recorder := newRecordingLogger()
+			s.logger = recorder
+			cfg := *s.config
+			cfg.AccessLog = tt.accessLog
+			router := NewRouter(s.createRouterDependencies(&cfg))
+
+			req := httptest.NewRequest("GET", "/health/live", nil)
+			req.Header.Set("User-Agent", tt.userAgent)
+			if tt.referer != "" {
+				req.Header.Set("Referer", tt.referer)
+			}
+			router.ServeHTTP(httptest.NewRecorder(), req)
+
+			entry, ok := recorder.accessLog()
+			s.Require().True(ok)
+			if tt.expectOmitted {
+				s.Assert().NotContains(entry.fields, "user_agent")
+				s.Assert().NotContains(entry.fields, "referer")
+				return
+			}
+			s.Assert().Equal(tt.expectedUserAgent, entry.fields["user_agent"])
+			s.Assert().Equal(tt.expectedReferer, entry.fields["referer"])
+		})
+	}
+}
+
+func (l *recordingLogger) accessLogCount() int {
+	count := 0
+	for _, entry := range *l.entries {
+		if entry.msg == "HTTP Request" {
+			count++
+		}
+	}
+	return count
+}
+
+func (s *RouterTestSuite) TestRouter_AccessLog_Fields() {
+	recorder := newRecordingLogger()
+	s.logger = recorder
+	router := NewRouter(s.createRouterDependencies())
+	s.mockManager.EXPECT().
+		GetEntity(mock.Anything, "test-id").
+		Return(&exampleDomain.Entity{ID: "test-id", Email: "test@example.com", Name: "Test User"}, nil)
+
+	req := httptest.NewRequest("GET", "/api/examples/test-id", nil)
+	req.RemoteAddr = "192.0.2.10:54321"
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	entry, ok := recorder.accessLog()
+	s.Require().True(ok)
+	s.Assert().Equal("GET", entry.fields["method"])
+	s.Assert().Equal("/api/examples/test-id", entry.fields["path"])
+	s.Assert().Equal("/api/examples/{id}", entry.fields["route"])
+	s.Assert().Equal(http.StatusOK, entry.fields["status"])
+	s.Assert().Equal(w.Body.Len(), entry.fields["bytes"])
+	s.Assert().Equal("192.0.2.10", entry.fields["remote_ip"])
+	s.Assert().NotEmpty(entry.fields["request_id"])
+	s.Assert().NotEmpty(entry.fields["duration"])
+}
+
+func (s *RouterTestSuite) TestRouter_RequestID_Validation() {
+	tests := []struct {
+		name   string
+		id     string
+		reused bool
+	}{
+		{name: "valid_reused", id: "3f2b8c1e-9a7d-4c52-b1e0-6d8f4a2c9e17", reused: true},
+		{name: "at_cap_reused", id: strings.Repeat("a", 40), reused: true},
+		{name: "over_long_regenerated", id: strings.Repeat("a", 41)},
+		{name: "newline_regenerated", id: "req-1\nlevel=error msg=forged"},
+		{name: "control_character_regenerated", id: "req-1\x1b[31m"},
+		{name: "space_regenerated", id: "req 1"},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			recorder := newRecordingLogger()
+			s.logger = recorder
+			cfg := *s.config
+			cfg.RequestIDMaxLength = 40
+			router := NewRouter(s.createRouterDependencies(&cfg))
+
+			req := httptest.NewRequest("GET", "/health/live", nil)
+			req.Header.Set(middleware.RequestIDHeader, tt.id)
+			router.ServeHTTP(httptest.NewRecorder(), req)
+
+			entry, ok := recorder.accessLog()
+			s.Require().True(ok)
+			requestID, _ := entry.fields["request_id"].(string)
+			s.Require().NotEmpty(requestID)
+			if tt.reused {
+				s.Assert().Equal(tt.id, requestID)
+			} else {
+				s.Assert().NotEqual(tt.id, requestID)
+				s.Assert().True(platformMiddleware.ValidRequestID(requestID, platformMiddleware.DefaultRequestIDMaxLength), requestID)
+			}
+		})
+	}
+}
+
+func (s *RouterTestSuite) TestRouter_HandlerLogsCarryRequestID() {
+	recorder := newRecordingLogger()
+	s.logger = recorder
+	router := NewRouter(s.createRouterDependencies())
+
+	req := httptest.NewRequest("POST", "/api/examples", strings.NewReader("{"))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(middleware.RequestIDHeader, "handler-log-1")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	entry, ok := recorder.entry("Failed to decode request body")
+	s.Require().True(ok)
+	s.Assert().Equal("handler-log-1", entry.fields["request_id"])
+}
+
+func (s *RouterTestSuite) TestNewAdminRouter_HandlerLogsCarryRequestID() {
+	recorder := newRecordingLogger()
+	s.logger = recorder
+	deps := s.createRouterDependencies(s.adminConfig())
+	deps.LogLevelHandler = admin.NewLogLevelHandler(logger.NewNop().(logger.LevelSetter))
+	router := NewAdminRouter(deps)
+
+	req := httptest.NewRequest("PUT", "/admin/log-level", strings.NewReader(`{"level":"debug"}`))
+	req.Header.Set(middleware.RequestIDHeader, "admin-log-1")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	s.Require().Equal(http.StatusOK, w.Code)
+
+	entry, ok := recorder.entry("Log level changed")
+	s.Require().True(ok)
+	s.Assert().Equal("admin-log-1", entry.fields["request_id"])
+}
+
+func (s *RouterTestSuite) TestRouter_AccessLog_SkipPaths() {
+	recorder := newRecordingLogger()
+	s.logger = recorder
+	cfg := *s.config
+	cfg.AccessLog.SkipPaths = []string{"/health/live", "/metrics"}
+	router := NewRouter(s.createRouterDependencies(&cfg))
+
+	for _, path := range []string{"/health/live", "/metrics"} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		s.Require().Equal(http.StatusOK, w.Code)
+	}
+	s.Assert().Zero(recorder.accessLogCount())
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/api/examples/missing/extra", nil))
+	s.Assert().Equal(1, recorder.accessLogCount())
+}
+
+func (s *RouterTestSuite) TestRouter_AccessLog_Sampling() {
+	const requests = 400
+
+	tests := []struct {
+		name       string
+		sampleRate float64
+		min, max   int
+	}{
+		{name: "unset_logs_everything", sampleRate: 0, min: requests, max: requests},
+		{name: "full_rate_logs_everything", sampleRate: 1, min: requests, max: requests},
+		{name: "half_rate", sampleRate: 0.5, min: requests / 4, max: requests * 3 / 4},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			recorder := newRecordingLogger()
+			s.logger = recorder
+			cfg := *s.config
+			cfg.RateLimit.RequestsPerIP = requests * 2
+			cfg.AccessLog.SampleRate = tt.sampleRate
+			router := NewRouter(s.createRouterDependencies(&cfg))
+
+			for i := 0; i < requests; i++ {
+				router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/health/live", nil))
+			}
+
+			s.Assert().GreaterOrEqual(recorder.accessLogCount(), tt.min)
+			s.Assert().LessOrEqual(recorder.accessLogCount(), tt.max)
+		})
+	}
+}
+
+func (s *RouterTestSuite) TestRouter_AccessLog_SamplingKeepsServerErrors() {
+	recorder := newRecordingLogger()
+	s.logger = recorder
+	cfg := *s.config
+	cfg.AccessLog.SampleRate = 0.000001
+	router := NewRouter(s.createRouterDependencies(&cfg))
+	s.mockManager.EXPECT().
+		GetEntity(mock.Anything, "test-id").
+		Return(nil, errors.New("database unavailable"))
+
+	for i := 0; i < 10; i++ {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/api/examples/test-id", nil))
+		s.Require().Equal(http.StatusInternalServerError, w.Code)
+	}
+
+	s.Assert().Equal(10, recorder.accessLogCount())
+}
+
+func (s *RouterTestSuite) TestRouter_ExampleBatchRoute() {
+	router := NewRouter(s.createRouterDependencies())
+
+	req := httptest.NewRequest("POST", "/api/examples/batch", strings.NewReader(`[]`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	s.Assert().Equal(http.StatusBadRequest, w.Code)
+	s.Assert().JSONEq(`{"error":"batch must contain at least one item"}`, w.Body.String())
+}
+
+func (s *RouterTestSuite) TestRouter_RateLimit_Integration() {
+	restrictiveConfig := &config.HttpConfig{
+		Server: s.config.Server,
+		CORS:   s.config.CORS,
+		RateLimit: config.RateLimitConfig{
+			GlobalRequests: 2,
+			GlobalWindow:   1,
+			RequestsPerIP:  1,
+			WindowSeconds:  1,
+		},
+	}
+
+	router := NewRouter(s.createRouterDependencies(restrictiveConfig))
+
+	req1 := s.limitedRequest("192.168.1.1:12345")
+	w1 := httptest.NewRecorder()
+	router.ServeHTTP(w1, req1)
+	s.Assert().Equal(http.StatusOK, w1.Code)
+
+	req2 := s.limitedRequest("192.168.1.1:12345")
 	w2 := httptest.NewRecorder()
 	router.ServeHTTP(w2, req2)
 	s.Assert().True(w2.Code == http.StatusOK || w2.Code == http.StatusTooManyRequests)
 }
 
+func (s *RouterTestSuite) TestRouter_RateLimit_JSONResponse() {
+	cfg := *s.config
+	cfg.RateLimit = config.RateLimitConfig{GlobalRequests: 100, GlobalWindow: 60, RequestsPerIP: 1, WindowSeconds: 60}
+	router := NewRouter(s.createRouterDependencies(&cfg))
+
+	first := httptest.NewRecorder()
+	router.ServeHTTP(first, s.limitedRequest("192.0.2.30:1000"))
+	s.Require().Equal(http.StatusOK, first.Code)
+	s.Assert().Equal("1", first.Header().Get("X-RateLimit-Limit"))
+	s.Assert().Equal("0", first.Header().Get("X-RateLimit-Remaining"))
+	s.Assert().NotEmpty(first.Header().Get("X-RateLimit-Reset"))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, s.limitedRequest("192.0.2.30:1000"))
+
+	s.Require().Equal(http.StatusTooManyRequests, w.Code)
+	s.Assert().Equal("application/json", w.Header().Get("Content-Type"))
+	s.Assert().JSONEq(`{"error":"rate limit exceeded"}`, w.Body.String())
+	retryAfter, err := strconv.Atoi(w.Header().Get("Retry-After"))
+	s.Require().NoError(err)
+	s.Assert().GreaterOrEqual(retryAfter, 1)
+	s.Assert().LessOrEqual(retryAfter, 60)
+	s.Assert().Equal("1", w.Header().Get("X-RateLimit-Limit"))
+	s.Assert().Equal("0", w.Header().Get("X-RateLimit-Remaining"))
+	reset, err := strconv.ParseInt(w.Header().Get("X-RateLimit-Reset"), 10, 64)
+	s.Require().NoError(err)
+	s.Assert().Greater(reset, time.Now().Unix()-1)
+}
+
+func (s *RouterTestSuite) TestRouter_CORSOnRejectedRequests() {
+	const origin = "https://app.example.com"
+	cfg := *s.config
+	cfg.RateLimit = config.RateLimitConfig{GlobalRequests: 100, GlobalWindow: 60, RequestsPerIP: 1, WindowSeconds: 60}
+	cfg.CORS.AllowedOrigins = []string{origin}
+	cfg.MaintenanceRetryAfter = 60
+	flag := new(atomic.Bool)
+	deps := s.createRouterDependencies(&cfg)
+	deps.Maintenance = flag
+	router := NewRouter(deps)
+
+	get := func() *httptest.ResponseRecorder {
+		req := s.limitedRequest("192.0.2.40:1000")
+		req.Header.Set("Origin", origin)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	s.Require().Equal(http.StatusOK, get().Code)
+
+	w := get()
+	s.Require().Equal(http.StatusTooManyRequests, w.Code)
+	s.Assert().Equal(origin, w.Header().Get("Access-Control-Allow-Origin"))
+
+	for i := 0; i < 3; i++ {
+		req := httptest.NewRequest("OPTIONS", "/api/examples", nil)
+		req.RemoteAddr = "192.0.2.40:1000"
+		req.Header.Set("Origin", origin)
+		req.Header.Set("Access-Control-Request-Method", "POST")
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		s.Assert().Equal(http.StatusOK, w.Code)
+		s.Assert().Equal(origin, w.Header().Get("Access-Control-Allow-Origin"))
+	}
+
+	flag.Store(true)
+	w = get()
+	s.Require().Equal(http.StatusServiceUnavailable, w.Code)
+	s.Assert().Equal(origin, w.Header().Get("Access-Control-Allow-Origin"))
+	s.Assert().NotEmpty(w.Header().Get("Retry-After"))
+}
+
+func (s *RouterTestSuite) TestRouter_RateLimit_OperationalEndpointsUnlimited() {
+	s.mockHealthManager.EXPECT().CheckAll(mock.Anything).Return(map[string]platformHealth.CheckResult{}).Maybe()
+
+	limits := []config.RateLimitConfig{
+		{GlobalRequests: 1, GlobalWindow: 60, RequestsPerIP: 100, WindowSeconds: 60},
+		{GlobalRequests: 100, GlobalWindow: 60, RequestsPerIP: 1, WindowSeconds: 60},
+		{},
+	}
+	paths := []string{"/health/live", "/health/ready", "/health/startup", "/metrics", "/version"}
+
+	for _, limit := range limits {
+		cfg := *s.config
+		cfg.RateLimit = limit
+		router := NewRouter(s.createRouterDependencies(&cfg))
+
+		for _, path := range paths {
+			for i := 0; i < 5; i++ {
+				w := httptest.NewRecorder()
+				req := httptest.NewRequest("GET", path, nil)
+				req.RemoteAddr = "192.0.2.40:1000"
+				router.ServeHTTP(w, req)
+				s.Require().NotEqual(http.StatusTooManyRequests, w.Code, "%s with %+v", path, limit)
+				s.Assert().Empty(w.Header().Get("X-RateLimit-Limit"), path)
+			}
+		}
+	}
+
+	cfg := *s.config
+	cfg.RateLimit = config.RateLimitConfig{GlobalRequests: 1, GlobalWindow: 60, RequestsPerIP: 1, WindowSeconds: 60}
+	router := NewRouter(s.createRouterDependencies(&cfg))
+	for i := 0; i < 5; i++ {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/health/live", nil))
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, s.limitedRequest("192.0.2.40:1000"))
+	s.Assert().Equal(http.StatusOK, w.Code, "probes are not counted against the API limit")
+	s.Assert().Equal("1", w.Header().Get("X-RateLimit-Limit"))
+}
+
+func (s *RouterTestSuite) TestRouter_RateLimit_ReportsViolations() {
+	tests := []struct {
+		name      string
+		rateLimit config.RateLimitConfig
+		secondIP  string
+		scope     string
+	}{
+		{
+			name:      "per_ip",
+			rateLimit: config.RateLimitConfig{GlobalRequests: 100, GlobalWindow: 60, RequestsPerIP: 1, WindowSeconds: 60},
+			secondIP:  "192.0.2.1",
+			scope:     platformMiddleware.RateLimitScopeIP,
+		},
+		{
+			name:      "global",
+			rateLimit: config.RateLimitConfig{GlobalRequests: 1, GlobalWindow: 60, RequestsPerIP: 100, WindowSeconds: 60},
+			secondIP:  "192.0.2.2",
+			scope:     platformMiddleware.RateLimitScopeGlobal,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			recorder := newRecordingLogger()
+			s.logger = recorder
+			provider, err := metrics.NewProvider()
+			s.Require().NoError(err)
+
+			cfg := *s.config
+			cfg.RateLimit = tt.rateLimit
+			deps := s.createRouterDependencies(&cfg)
+			deps.MetricsProvider = provider
+			router := NewRouter(deps)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, s.limitedRequest("192.0.2.1:1000"))
+			s.Require().Equal(http.StatusOK, w.Code)
+
+			w = httptest.NewRecorder()
+			router.ServeHTTP(w, s.limitedRequest(tt.secondIP+":2000"))
+			s.Require().Equal(http.StatusTooManyRequests, w.Code)
+
+			scrape := httptest.NewRecorder()
+			provider.Handler().ServeHTTP(scrape, httptest.NewRequest("GET", "/metrics", nil))
+			s.Assert().Regexp(`rate_limit_exceeded_total\{[^}]*scope="`+tt.scope+`"[^}]*\} 1\n`, scrape.Body.String())
+
+			var violation *logEntry
+			for i, entry := range *recorder.entries {
+				if entry.msg == "Rate limit exceeded" {
+					violation = &(*recorder.entries)[i]
+				}
+			}
+			s.Require().NotNil(violation)
+			s.Assert().Equal(tt.scope, violation.fields["scope"])
+			s.Assert().Equal(tt.secondIP, violation.fields["remote_ip"])
+			s.Assert().Equal("GET", violation.fields["method"])
+			s.Assert().Equal("/api/examples/{id}", violation.fields["route"])
+			s.Assert().NotEmpty(violation.fields["request_id"])
+		})
+	}
+}
+
+func (s *RouterTestSuite) TestRouter_RateLimit_Shadow() {
+	tests := []struct {
+		name      string
+		rateLimit config.RateLimitConfig
+		scope     string
+	}{
+		{
+			name:      "per_ip",
+			rateLimit: config.RateLimitConfig{GlobalRequests: 100, GlobalWindow: 60, RequestsPerIP: 1, WindowSeconds: 60, ShadowPerIP: true},
+			scope:     platformMiddleware.RateLimitScopeIP,
+		},
+		{
+			name:      "global",
+			rateLimit: config.RateLimitConfig{GlobalRequests: 1, GlobalWindow: 60, RequestsPerIP: 100, WindowSeconds: 60, GlobalShadow: true},
+			scope:     platformMiddleware.RateLimitScopeGlobal,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			recorder := newRecordingLogger()
+			s.logger = recorder
+			provider, err := metrics.NewProvider()
+			s.Require().NoError(err)
+
+			cfg := *s.config
+			cfg.RateLimit = tt.rateLimit
+			deps := s.createRouterDependencies(&cfg)
+			deps.MetricsProvider = provider
+			router := NewRouter(deps)
+
+			for i := 0; i < 3; i++ {
+				w := httptest.NewRecorder()
+				router.ServeHTTP(w, s.limitedRequest("192.0.2.50:1000"))
+				s.Require().Equal(http.StatusOK, w.Code)
+				s.Assert().Empty(w.Header().Get("Retry-After"))
+			}
+
+			scrape := httptest.NewRecorder()
+			provider.Handler().ServeHTTP(scrape, httptest.NewRequest("GET", "/metrics", nil))
+			s.Assert().Regexp(`rate_limit_would_exceed_total\{[^}]*scope="`+tt.scope+`"[^}]*\} 2\n`, scrape.Body.String())
+			s.Assert().NotContains(scrape.Body.String(), "rate_limit_exceeded_total")
+
+			warnings := 0
+			for _, entry := range *recorder.entries {
+				if entry.msg == "Rate limit would be exceeded" {
+					warnings++
+					s.Assert().Equal(tt.scope, entry.fields["scope"])
+					s.Assert().Equal("/api/examples/{id}", entry.fields["route"])
+				}
+			}
+			s.Assert().Equal(2, warnings)
+		})
+	}
+}
+
+func (s *RouterTestSuite) TestRouter_Options_ReducedMiddleware() {
+	tests := []struct {
+		name            string
+		options         RouterOptions
+		expectRateLimit bool
+		expectCORS      bool
+	}{
+		{name: "default", expectRateLimit: true, expectCORS: true},
+		{name: "without_rate_limit", options: RouterOptions{DisableRateLimit: true}, expectCORS: true},
+		{name: "without_cors", options: RouterOptions{DisableCORS: true}, expectRateLimit: true},
+		{name: "without_both", options: RouterOptions{DisableRateLimit: true, DisableCORS: true}},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			cfg := *s.config
+			cfg.RateLimit.RequestsPerIP = 1
+			cfg.AdminCORS = cfg.CORS
+			deps := s.createRouterDependencies(&cfg)
+			deps.Options = tt.options
+			router := NewRouter(deps)
+
+			codes := make([]int, 2)
+			for i := range codes {
+				w := httptest.NewRecorder()
+				router.ServeHTTP(w, s.limitedRequest("192.0.2.10:1000"))
+				codes[i] = w.Code
+			}
+			s.Assert().Equal(http.StatusOK, codes[0])
+			if tt.expectRateLimit {
+				s.Assert().Equal(http.StatusTooManyRequests, codes[1])
+			} else {
+				s.Assert().Equal(http.StatusOK, codes[1])
+			}
+
+			for i, path := range []string{"/api/examples", "/health/live"} {
+				req := httptest.NewRequest("GET", path, nil)
+				req.RemoteAddr = fmt.Sprintf("192.0.2.%d:1000", 20+i)
+				req.Header.Set("Origin", "https://example.com")
+				w := httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+
+				if tt.expectCORS {
+					s.Assert().Equal("*", w.Header().Get("Access-Control-Allow-Origin"), path)
+				} else {
+					s.Assert().Empty(w.Header().Get("Access-Control-Allow-Origin"), path)
+				}
+			}
+		})
+	}
+}
+
+func (s *RouterTestSuite) TestNewAdminRouter_OptionsDisableCORS() {
+	cfg := s.adminConfig()
+	cfg.AdminCORS = cfg.CORS
+	deps := s.createRouterDependencies(cfg)
+	deps.Options = RouterOptions{DisableCORS: true}
+	router := NewAdminRouter(deps)
+
+	req := httptest.NewRequest("GET", "/health/live", nil)
+	req.Header.Set("Origin", "https://example.com")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	s.Assert().Equal(http.StatusOK, w.Code)
+	s.Assert().Empty(w.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func (s *RouterTestSuite) TestRouter_Events() {
+	broadcaster := events.NewBroadcaster(4)
+	deps := s.createRouterDependencies()
+	deps.EventsHandler = events.NewHandler(broadcaster, time.Minute)
+	server := httptest.NewServer(NewRouter(deps))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, "GET", server.URL+"/api/events", nil)
+	s.Require().NoError(err)
+	resp, err := http.DefaultClient.Do(req)
+	s.Require().NoError(err)
+	defer func() { _ = resp.Body.Close() }()
+
+	s.Assert().Equal(http.StatusOK, resp.StatusCode)
+	s.Assert().Equal("text/event-stream", resp.Header.Get("Content-Type"))
+
+	reader := bufio.NewReader(resp.Body)
+	line, err := reader.ReadString('\n')
+	s.Require().NoError(err)
+	s.Require().Equal(": connected\n", line)
+	s.Require().Eventually(func() bool { return broadcaster.Subscribers() == 1 }, time.Second, 5*time.Millisecond)
+
+	broadcaster.Publish(events.Event{Type: "ping", Data: "1"})
+
+	_, err = reader.ReadString('\n')
+	s.Require().NoError(err)
+	line, err = reader.ReadString('\n')
+	s.Require().NoError(err)
+	s.Assert().Equal("event: ping\n", line)
+	cancel()
+}
+
+func (s *RouterTestSuite) TestRouter_Events_NotMountedWithoutHandler() {
+	router := NewRouter(s.createRouterDependencies())
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/events", nil))
+
+	s.Assert().Equal(http.StatusNotFound, w.Code)
+}
+
+func postBatch(router http.Handler) *httptest.ResponseRecorder {
+	body := `[{"id":"test-id","email":"test@example.com","name":"Test User"}]`
+	req := httptest.NewRequest("POST", "/api/examples/batch", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func (s *RouterTestSuite) TestRouter_BulkConcurrencyLimit() {
+	recorder := newRecordingLogger()
+	s.logger = recorder
+	provider, err := metrics.NewProvider()
+	s.Require().NoError(err)
+
+	cfg := *s.config
+	cfg.MaxBulkInFlight = 2
+	deps := s.createRouterDependencies(&cfg)
+	deps.MetricsProvider = provider
+	router := NewRouter(deps)
+
+	started := make(chan struct{}, cfg.MaxBulkInFlight)
+	release := make(chan struct{})
+	s.mockManager.EXPECT().
+		CreateEntities(mock.Anything, mock.Anything, false).
+		RunAndReturn(func(_ context.Context, params []exampleDomain.CreateEntityParams, _ bool) ([]exampleDomain.CreateEntityResult, error) {
+			started <- struct{}{}
+			<-release
+			return []exampleDomain.CreateEntityResult{{Entity: &exampleDomain.Entity{ID: params[0].ID}}}, nil
+		}).
+		Times(cfg.MaxBulkInFlight)
+
+	admitted := make([]int, cfg.MaxBulkInFlight)
+	var wg sync.WaitGroup
+	for i := range admitted {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			admitted[i] = postBatch(router).Code
+		}(i)
+	}
+	for range admitted {
+		<-started
+	}
+
+	const excess = 3
+	var rejected []*httptest.ResponseRecorder
+	for i := 0; i < excess; i++ {
+		rejected = append(rejected, postBatch(router))
+	}
+
+	// Other endpoints are not affected by the bulk cap.
+	s.mockManager.EXPECT().
+		CreateEntity(mock.Anything, "test-id", "test@example.com", "Test User").
+		Return(&exampleDomain.Entity{ID: "test-id"}, nil).
+		Once()
+	s.Assert().Equal(http.StatusCreated, postExample(router, "").Code)
+
+	close(release)
+	wg.Wait()
+
+	for _, code := range admitted {
+		s.Assert().Equal(http.StatusCreated, code)
+	}
+	for _, w := range rejected {
+		s.Assert().Equal(http.StatusServiceUnavailable, w.Code)
+		s.Assert().Equal("1", w.Header().Get("Retry-After"))
+		s.Assert().Equal("application/json", w.Header().Get("Content-Type"))
+		s.Assert().JSONEq(`{"error":"too many bulk requests in progress"}`, w.Body.String())
+	}
+
+	scrape := httptest.NewRecorder()
+	provider.Handler().ServeHTTP(scrape, httptest.NewRequest("GET", "/metrics", nil))
+	s.Assert().Regexp(`rate_limit_exceeded_total\{[^}]*scope="bulk"[^}]*\} 3\n`, scrape.Body.String())
+
+	violations := 0
+	for _, entry := range *recorder.entries {
+		if entry.msg == "Concurrency limit exceeded" {
+			violations++
+			s.Assert().Equal(platformMiddleware.RateLimitScopeBulk, entry.fields["scope"])
+			s.Assert().Equal("/api/examples/batch", entry.fields["route"])
+		}
+	}
+	s.Assert().Equal(excess, violations)
+
+	// Slots are released once the admitted requests finish.
+	s.mockManager.EXPECT().
+		CreateEntities(mock.Anything, mock.Anything, false).
+		Return([]exampleDomain.CreateEntityResult{{Entity: &exampleDomain.Entity{ID: "test-id"}}}, nil).
+		Once()
+	s.Assert().Equal(http.StatusCreated, postBatch(router).Code)
+}
+
 func (s *RouterTestSuite) TestRouter_AllMiddleware_Integration() {
 	router := NewRouter(s.createRouterDependencies())
 
@@ -461,10 +2548,10 @@ func BenchmarkRouter_HealthLiveness(b *testing.B) {
 
 	mockManager := exampleMocks.NewMockManager(b)
 	validatorAdapter := validator.NewPlaygroundAdapter()
-	exampleHandler := example.NewHandler(mockManager, validatorAdapter)
+	exampleHandler := example.NewHandler(mockManager, validatorAdapter, 100)
 
 	mockHealthManager := healthMocks.NewMockManagerInterface(b)
-	readinessHandler := health.NewReadinessHandler("1.0.0", mockHealthManager)
+	readinessHandler := health.NewReadinessHandler("1.0.0", mockHealthManager, nil, 0)
 
 	deps := RouterDependencies{
 		Config:           httpConfig,
@@ -512,10 +2599,10 @@ func BenchmarkRouter_Metrics(b *testing.B) {
 
 	mockManager := exampleMocks.NewMockManager(b)
 	validatorAdapter := validator.NewPlaygroundAdapter()
-	exampleHandler := example.NewHandler(mockManager, validatorAdapter)
+	exampleHandler := example.NewHandler(mockManager, validatorAdapter, 100)
 
 	mockHealthManager := healthMocks.NewMockManagerInterface(b)
-	readinessHandler := health.NewReadinessHandler("1.0.0", mockHealthManager)
+	readinessHandler := health.NewReadinessHandler("1.0.0", mockHealthManager, nil, 0)
 
 	deps := RouterDependencies{
 		Config:           httpConfig,
@@ -2,6 +2,7 @@ package http
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"microservice/internal/platform/logger"
@@ -12,36 +13,82 @@ import (
 	"microservice/internal/config"
 )
 
+const defaultShutdownTimeout = 30 * time.Second
+
+// defaultReadHeaderTimeout applies when no header timeout is configured, as
+// net/http would otherwise fall back to ReadTimeout and wait forever when that
+// is 0 too.
+const defaultReadHeaderTimeout = 5 * time.Second
+
 type Server struct {
 	server *http.Server
+	tls    config.TLSConfig
 	logger logger.Logger
 }
 
 func NewServer(cfg *config.HttpConfig, log logger.Logger, handler http.Handler) *Server {
 	return &Server{
 		server: &http.Server{
-			Addr:         fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.Port),
-			Handler:      handler,
-			ReadTimeout:  time.Duration(cfg.Server.ReadTimeout) * time.Second,
-			WriteTimeout: time.Duration(cfg.Server.WriteTimeout) * time.Second,
-			IdleTimeout:  time.Duration(cfg.Server.IdleTimeout) * time.Second,
+			Addr:              fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.Port),
+			Handler:           handler,
+			ReadTimeout:       time.Duration(cfg.Server.ReadTimeout) * time.Second,
+			ReadHeaderTimeout: readHeaderTimeout(cfg.Server),
+			WriteTimeout:      time.Duration(cfg.Server.WriteTimeout) * time.Second,
+			IdleTimeout:       time.Duration(cfg.Server.IdleTimeout) * time.Second,
+			MaxHeaderBytes:    cfg.Server.MaxHeaderBytes,
 		},
+		tls:    cfg.TLS,
 		logger: log,
 	}
 }
 
+// NewAdminServer creates the plain HTTP server for the admin listener. It has
+// no write timeout because CPU profiles and traces stream for longer than an
+// API response is allowed to take.
+func NewAdminServer(cfg *config.HttpConfig, log logger.Logger, handler http.Handler) *Server {
+	return &Server{
+		server: &http.Server{
+			Addr:              fmt.Sprintf("%s:%d", cfg.Admin.Host, cfg.Admin.Port),
+			Handler:           handler,
+			ReadTimeout:       time.Duration(cfg.Server.ReadTimeout) * time.Second,
+			ReadHeaderTimeout: readHeaderTimeout(cfg.Server),
+			IdleTimeout:       time.Duration(cfg.Server.IdleTimeout) * time.Second,
+			MaxHeaderBytes:    cfg.Server.MaxHeaderBytes,
+		},
+		logger: log.With(logger.String("server", "admin")),
+	}
+}
+
+func readHeaderTimeout(cfg config.HttpServerConfig) time.Duration {
+	if cfg.ReadHeaderTimeout <= 0 {
+		return defaultReadHeaderTimeout
+	}
+	return time.Duration(cfg.ReadHeaderTimeout) * time.Second
+}
+
 func (s *Server) Start(ctx context.Context) error {
+	scheme := "http"
+	if s.tls.Enabled {
+		scheme = "https"
+		tlsConfig, err := newTLSConfig(s.tls)
+		if err != nil {
+			s.logger.Error("failed to configure TLS", logger.Error(err))
+			return err
+		}
+		s.server.TLSConfig = tlsConfig
+	}
+
 	ln, err := net.Listen("tcp", s.server.Addr)
 	if err != nil {
 		s.logger.Error("failed to listen", logger.Error(err))
 		return err
 	}
 
-	s.logger.Info("Starting HTTP server", logger.String("addr", s.server.Addr))
+	s.logger.Info("Starting HTTP server", logger.String("addr", s.server.Addr), logger.String("scheme", scheme))
 
 	errChan := make(chan error, 1)
 	go func() {
-		if err := s.server.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		if err := s.serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
 			s.logger.Error("failed to serve", logger.Error(err))
 			errChan <- err
 		}
@@ -58,6 +105,39 @@ func (s *Server) Start(ctx context.Context) error {
 	}
 }
 
+func (s *Server) serve(ln net.Listener) error {
+	if s.tls.Enabled {
+		return s.server.ServeTLS(ln, "", "")
+	}
+	return s.server.Serve(ln)
+}
+
+func newTLSConfig(cfg config.TLSConfig) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load TLS certificate %q with key %q: %w", cfg.CertFile, cfg.KeyFile, err)
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+		CipherSuites: []uint16{
+			tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+			tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+			tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+			tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+			tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305,
+			tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305,
+		},
+	}, nil
+}
+
+// OnShutdown registers f to run when shutdown starts. Long-lived handlers such
+// as event streams use it to finish, since Shutdown waits for them.
+func (s *Server) OnShutdown(f func()) {
+	s.server.RegisterOnShutdown(f)
+}
+
 func (s *Server) Stop(ctx context.Context) error {
 	if s.server == nil {
 		return nil
@@ -65,8 +145,18 @@ func (s *Server) Stop(ctx context.Context) error {
 
 	s.logger.Info("Shutting down HTTP server")
 
-	shutdownCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
-	defer cancel()
+	// The caller's deadline is shared with the hooks that stop after the
+	// server, so it is only replaced when there is none.
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, defaultShutdownTimeout)
+		defer cancel()
+	}
 
-	return s.server.Shutdown(shutdownCtx)
+	err := s.server.Shutdown(ctx)
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		s.logger.Warn("HTTP server did not drain before the shutdown deadline, closing open connections")
+		return errors.Join(err, s.server.Close())
+	}
+	return err
 }
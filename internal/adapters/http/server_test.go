@@ -1,12 +1,25 @@
 package http
 
 import (
+	"bufio"
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"fmt"
+	"io"
+	"math/big"
+	"microservice/internal/adapters/http/events"
 	"microservice/internal/config"
 	"microservice/internal/platform/logger"
 	"net"
 	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -69,6 +82,57 @@ func (s *ServerTestSuite) TestNewServer_DefaultHost() {
 	s.Assert().Equal(60*time.Second, server.server.IdleTimeout)
 }
 
+func (s *ServerTestSuite) TestNewAdminServer() {
+	cfg := &config.HttpConfig{
+		Server: config.HttpServerConfig{
+			ReadTimeout:  10,
+			WriteTimeout: 15,
+			IdleTimeout:  60,
+		},
+		Admin: config.AdminServerConfig{Host: "127.0.0.1", Port: 8081},
+		TLS:   config.TLSConfig{Enabled: true},
+	}
+
+	handler := http.NewServeMux()
+
+	server := NewAdminServer(cfg, s.logger, handler)
+
+	s.Assert().Equal("127.0.0.1:8081", server.server.Addr)
+	s.Assert().Equal(handler, server.server.Handler)
+	s.Assert().Equal(10*time.Second, server.server.ReadTimeout)
+	s.Assert().Zero(server.server.WriteTimeout)
+	s.Assert().Equal(60*time.Second, server.server.IdleTimeout)
+	s.Assert().Equal(defaultReadHeaderTimeout, server.server.ReadHeaderTimeout)
+	s.Assert().False(server.tls.Enabled)
+}
+
+func (s *ServerTestSuite) TestNewServer_HeaderLimits() {
+	cfg := &config.HttpConfig{
+		Server: config.HttpServerConfig{
+			Port:              8080,
+			ReadHeaderTimeout: 2,
+			MaxHeaderBytes:    16 << 10,
+		},
+	}
+
+	server := NewServer(cfg, s.logger, http.NewServeMux())
+	admin := NewAdminServer(cfg, s.logger, http.NewServeMux())
+
+	s.Assert().Equal(2*time.Second, server.server.ReadHeaderTimeout)
+	s.Assert().Equal(16<<10, server.server.MaxHeaderBytes)
+	s.Assert().Equal(2*time.Second, admin.server.ReadHeaderTimeout)
+	s.Assert().Equal(16<<10, admin.server.MaxHeaderBytes)
+}
+
+func (s *ServerTestSuite) TestNewServer_DefaultsReadHeaderTimeoutWithoutTimeouts() {
+	cfg := &config.HttpConfig{Server: config.HttpServerConfig{Port: 8080}}
+
+	server := NewServer(cfg, s.logger, http.NewServeMux())
+
+	s.Assert().Zero(server.server.ReadTimeout)
+	s.Assert().Equal(5*time.Second, server.server.ReadHeaderTimeout)
+}
+
 func (s *ServerTestSuite) TestServer_Start_Success() {
 	listener, err := net.Listen("tcp", ":0")
 	s.Require().NoError(err)
@@ -123,6 +187,102 @@ func (s *ServerTestSuite) TestServer_Start_InvalidPort() {
 	s.Assert().Error(err)
 }
 
+func (s *ServerTestSuite) writeSelfSignedCert() (certFile, keyFile string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s.Require().NoError(err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	s.Require().NoError(err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	s.Require().NoError(err)
+
+	dir := s.T().TempDir()
+	certFile = filepath.Join(dir, "cert.pem")
+	keyFile = filepath.Join(dir, "key.pem")
+	s.Require().NoError(os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	s.Require().NoError(os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+
+	return certFile, keyFile
+}
+
+func (s *ServerTestSuite) TestServer_Start_TLS() {
+	listener, err := net.Listen("tcp", ":0")
+	s.Require().NoError(err)
+	port := listener.Addr().(*net.TCPAddr).Port
+	s.Require().NoError(listener.Close())
+
+	certFile, keyFile := s.writeSelfSignedCert()
+	cfg := &config.HttpConfig{
+		Server: config.HttpServerConfig{
+			Host:         "localhost",
+			Port:         port,
+			ReadTimeout:  5,
+			WriteTimeout: 5,
+			IdleTimeout:  10,
+		},
+		TLS: config.TLSConfig{
+			Enabled:  true,
+			CertFile: certFile,
+			KeyFile:  keyFile,
+		},
+	}
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	server := NewServer(cfg, s.logger, handler)
+
+	ctx := context.Background()
+	s.Require().NoError(server.Start(ctx))
+	defer func() { s.Assert().NoError(server.Stop(ctx)) }()
+
+	s.Assert().Equal(uint16(tls.VersionTLS12), server.server.TLSConfig.MinVersion)
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig:   &tls.Config{InsecureSkipVerify: true}, //nolint:gosec // self-signed test certificate
+			DisableKeepAlives: true,
+		},
+	}
+	resp, err := client.Get(fmt.Sprintf("https://localhost:%d/", port))
+	s.Require().NoError(err)
+	s.Assert().Equal(http.StatusOK, resp.StatusCode)
+	s.Assert().NotNil(resp.TLS)
+	s.Require().NoError(resp.Body.Close())
+}
+
+func (s *ServerTestSuite) TestServer_Start_TLS_InvalidCertificate() {
+	cfg := &config.HttpConfig{
+		Server: config.HttpServerConfig{
+			Host: "localhost",
+			Port: 0,
+		},
+		TLS: config.TLSConfig{
+			Enabled:  true,
+			CertFile: filepath.Join(s.T().TempDir(), "missing-cert.pem"),
+			KeyFile:  filepath.Join(s.T().TempDir(), "missing-key.pem"),
+		},
+	}
+
+	server := NewServer(cfg, s.logger, http.NewServeMux())
+
+	err := server.Start(context.Background())
+	s.Require().Error(err)
+	s.Assert().Contains(err.Error(), "failed to load TLS certificate")
+	s.Assert().ErrorIs(err, os.ErrNotExist)
+}
+
 func (s *ServerTestSuite) TestServer_Stop_Success() {
 	listener, err := net.Listen("tcp", ":0")
 	s.Require().NoError(err)
@@ -198,6 +358,59 @@ func (s *ServerTestSuite) TestServer_Stop_WithTimeout() {
 	s.Assert().NoError(err)
 }
 
+func (s *ServerTestSuite) TestServer_Stop_RespectsCallerDeadline() {
+	listener, err := net.Listen("tcp", ":0")
+	s.Require().NoError(err)
+	port := listener.Addr().(*net.TCPAddr).Port
+	s.Require().NoError(listener.Close())
+
+	cfg := &config.HttpConfig{
+		Server: config.HttpServerConfig{
+			Host: "localhost",
+			Port: port,
+		},
+	}
+
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(entered)
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	})
+
+	server := NewServer(cfg, s.logger, handler)
+	s.Require().NoError(server.Start(context.Background()))
+
+	requestDone := make(chan error, 1)
+	go func() {
+		resp, err := http.Get(fmt.Sprintf("http://localhost:%d/", port))
+		if err == nil {
+			_ = resp.Body.Close()
+		}
+		requestDone <- err
+	}()
+	<-entered
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err = server.Stop(ctx)
+	s.Assert().ErrorIs(err, context.DeadlineExceeded)
+	s.Assert().Less(time.Since(start), time.Second)
+
+	select {
+	case err := <-requestDone:
+		s.Assert().Error(err, "in-flight connection should be closed once the deadline passes")
+	case <-time.After(time.Second):
+		s.Fail("in-flight request was not interrupted")
+	}
+}
+
 func (s *ServerTestSuite) TestServer_StartStop_Multiple() {
 	listener, err := net.Listen("tcp", ":0")
 	s.Require().NoError(err)
@@ -290,6 +503,54 @@ func (s *ServerTestSuite) TestServer_Integration() {
 	s.Assert().NoError(err)
 }
 
+func (s *ServerTestSuite) TestServer_Stop_EndsEventStreams() {
+	listener, err := net.Listen("tcp", ":0")
+	s.Require().NoError(err)
+	port := listener.Addr().(*net.TCPAddr).Port
+	s.Require().NoError(listener.Close())
+
+	cfg := &config.HttpConfig{
+		Server: config.HttpServerConfig{
+			Host:         "localhost",
+			Port:         port,
+			ReadTimeout:  1,
+			WriteTimeout: 1,
+			IdleTimeout:  2,
+		},
+	}
+
+	broadcaster := events.NewBroadcaster(4)
+	server := NewServer(cfg, s.logger, http.HandlerFunc(events.NewHandler(broadcaster, time.Minute).Stream))
+	server.OnShutdown(broadcaster.Close)
+	s.Require().NoError(server.Start(context.Background()))
+
+	resp, err := http.Get(fmt.Sprintf("http://localhost:%d/", port))
+	s.Require().NoError(err)
+	defer func() { _ = resp.Body.Close() }()
+	reader := bufio.NewReader(resp.Body)
+	line, err := reader.ReadString('\n')
+	s.Require().NoError(err)
+	s.Require().Equal(": connected\n", line)
+
+	// The stream outlives the server's one second write timeout.
+	time.Sleep(1500 * time.Millisecond)
+	broadcaster.Publish(events.Event{Data: "still here"})
+	_, err = reader.ReadString('\n')
+	s.Require().NoError(err)
+	line, err = reader.ReadString('\n')
+	s.Require().NoError(err)
+	s.Require().Equal("data: still here\n", line)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	start := time.Now()
+	s.Require().NoError(server.Stop(ctx))
+	s.Assert().Less(time.Since(start), time.Second)
+
+	_, err = io.ReadAll(reader)
+	s.Assert().NoError(err)
+}
+
 func (s *ServerTestSuite) TestServer_Performance() {
 	listener, err := net.Listen("tcp", ":0")
 	s.Require().NoError(err)
@@ -0,0 +1,23 @@
+package messaging
+
+import "context"
+
+// Message is a record received from a broker.
+type Message struct {
+	Topic   string
+	Key     string
+	Payload []byte
+	Headers map[string]string
+}
+
+// Handler processes one message. What happens to a message whose handler
+// returned an error, such as redelivery, is up to the Consumer.
+type Handler func(ctx context.Context, msg Message) error
+
+// Consumer receives messages from a broker. Subscribe blocks, calling handler
+// for every message on topic, until ctx is done or consuming fails. A message
+// that is being handled when ctx ends is finished before Subscribe returns.
+// Kafka or NATS clients implement it to replace MemoryBroker.
+type Consumer interface {
+	Subscribe(ctx context.Context, topic string, handler Handler) error
+}
@@ -0,0 +1,57 @@
+package example
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"microservice/internal/adapters/messaging"
+	"microservice/internal/core/domain/example"
+	"microservice/internal/platform/logger"
+)
+
+// TopicCreateEntity carries requests to create an entity, as JSON with id,
+// email and name.
+const TopicCreateEntity = "example.create"
+
+type Manager interface {
+	CreateEntity(ctx context.Context, id, email, name string) (*example.Entity, error)
+}
+
+type createEntityMessage struct {
+	ID    string `json:"id"`
+	Email string `json:"email"`
+	Name  string `json:"name"`
+}
+
+type Handler struct {
+	manager Manager
+}
+
+func NewHandler(manager Manager) *Handler {
+	return &Handler{manager: manager}
+}
+
+// Register subscribes the handler's topics on lifecycle.
+func (h *Handler) Register(lifecycle *messaging.Lifecycle) {
+	lifecycle.Handle(TopicCreateEntity, h.CreateEntity)
+}
+
+// CreateEntity creates the entity described by msg. An entity that already
+// exists counts as handled, so a redelivered message is not reported as a
+// failure.
+func (h *Handler) CreateEntity(ctx context.Context, msg messaging.Message) error {
+	var body createEntityMessage
+	if err := json.Unmarshal(msg.Payload, &body); err != nil {
+		return fmt.Errorf("decode %s message: %w", TopicCreateEntity, err)
+	}
+
+	_, err := h.manager.CreateEntity(ctx, body.ID, body.Email, body.Name)
+	var exists *example.AlreadyExistsError
+	if errors.As(err, &exists) {
+		logger.FromContext(ctx).Debug("Entity already exists, skipping message", logger.String("entity_id", exists.ID))
+		return nil
+	}
+	return err
+}
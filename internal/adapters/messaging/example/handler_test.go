@@ -0,0 +1,90 @@
+package example
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"microservice/internal/adapters/messaging"
+	"microservice/internal/core/domain/example"
+	"microservice/internal/platform/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeManager struct {
+	mu      sync.Mutex
+	created []example.Entity
+	err     error
+}
+
+func (m *fakeManager) CreateEntity(_ context.Context, id, email, name string) (*example.Entity, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entity := example.Entity{ID: id, Email: email, Name: name}
+	m.created = append(m.created, entity)
+	return &entity, nil
+}
+
+func (m *fakeManager) createdCount() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.created)
+}
+
+func createMessage(payload string) messaging.Message {
+	return messaging.Message{Topic: TopicCreateEntity, Payload: []byte(payload)}
+}
+
+func TestHandler_CreateEntity(t *testing.T) {
+	manager := &fakeManager{}
+
+	err := NewHandler(manager).CreateEntity(context.Background(),
+		createMessage(`{"id":"id-1","email":"one@example.com","name":"One"}`))
+
+	require.NoError(t, err)
+	assert.Equal(t, []example.Entity{{ID: "id-1", Email: "one@example.com", Name: "One"}}, manager.created)
+}
+
+func TestHandler_CreateEntity_IgnoresRedeliveredMessages(t *testing.T) {
+	manager := &fakeManager{err: &example.AlreadyExistsError{ID: "id-1"}}
+
+	err := NewHandler(manager).CreateEntity(context.Background(), createMessage(`{"id":"id-1"}`))
+
+	assert.NoError(t, err)
+}
+
+func TestHandler_CreateEntity_InvalidPayload(t *testing.T) {
+	manager := &fakeManager{}
+
+	err := NewHandler(manager).CreateEntity(context.Background(), createMessage(`{"id":`))
+
+	assert.ErrorContains(t, err, "decode example.create message")
+	assert.Empty(t, manager.created)
+}
+
+func TestHandler_CreateEntity_ReturnsManagerErrors(t *testing.T) {
+	manager := &fakeManager{err: example.ErrInvalidEmail}
+
+	err := NewHandler(manager).CreateEntity(context.Background(), createMessage(`{"id":"id-1","email":"nope"}`))
+
+	assert.ErrorIs(t, err, example.ErrInvalidEmail)
+}
+
+func TestHandler_Register(t *testing.T) {
+	broker := messaging.NewMemoryBroker()
+	manager := &fakeManager{}
+	lifecycle := messaging.NewLifecycle(broker, logger.NewNop())
+	NewHandler(manager).Register(lifecycle)
+	require.NoError(t, lifecycle.Start(context.Background()))
+	defer func() { _ = lifecycle.Stop(context.Background()) }()
+
+	require.NoError(t, broker.Publish(context.Background(), createMessage(`{"id":"id-1","email":"one@example.com","name":"One"}`)))
+
+	assert.Eventually(t, func() bool { return manager.createdCount() == 1 }, time.Second, 5*time.Millisecond)
+}
@@ -0,0 +1,134 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sync"
+
+	"microservice/internal/platform/logger"
+)
+
+// ErrHandlerPanicked is returned to the Consumer for a message whose handler
+// panicked.
+var ErrHandlerPanicked = errors.New("message handler panicked")
+
+type subscription struct {
+	topic   string
+	handler Handler
+}
+
+// Lifecycle subscribes the registered handlers on Start and stops consuming on
+// Stop. Handler panics are recovered and logged, and every handler gets a
+// context carrying a logger with the message topic and key.
+type Lifecycle struct {
+	consumer      Consumer
+	logger        logger.Logger
+	subscriptions []subscription
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func NewLifecycle(consumer Consumer, log logger.Logger) *Lifecycle {
+	return &Lifecycle{
+		consumer: consumer,
+		logger:   log,
+	}
+}
+
+// Handle registers handler for messages on topic. Handlers registered after
+// Start are subscribed on the next Start.
+func (l *Lifecycle) Handle(topic string, handler Handler) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.subscriptions = append(l.subscriptions, subscription{topic: topic, handler: handler})
+}
+
+// Start subscribes every registered handler in the background and returns at
+// once. Calling it again while consuming does nothing.
+func (l *Lifecycle) Start(context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.cancel != nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	for _, s := range l.subscriptions {
+		wg.Add(1)
+		go func(s subscription) {
+			defer wg.Done()
+			l.subscribe(ctx, s)
+		}(s)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	l.cancel, l.done = cancel, done
+	l.logger.Info("Started message consumer", logger.Int("subscriptions", len(l.subscriptions)))
+	return nil
+}
+
+func (l *Lifecycle) subscribe(ctx context.Context, s subscription) {
+	err := l.consumer.Subscribe(ctx, s.topic, l.recoverer(s.topic, s.handler))
+	if err != nil && ctx.Err() == nil {
+		l.logger.Error("Message subscription ended", logger.String("topic", s.topic), logger.Error(err))
+	}
+}
+
+// Stop ends every subscription and waits until the messages being handled are
+// finished or ctx is done.
+func (l *Lifecycle) Stop(ctx context.Context) error {
+	l.mu.Lock()
+	cancel, done := l.cancel, l.done
+	l.cancel, l.done = nil, nil
+	l.mu.Unlock()
+
+	if cancel == nil {
+		return nil
+	}
+
+	l.logger.Info("Stopping message consumer")
+	cancel()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		l.logger.Warn("Message consumer did not stop before the shutdown deadline")
+		return ctx.Err()
+	}
+}
+
+// recoverer wraps handler so that a panic is logged and reported to the
+// Consumer as an error instead of crashing the process.
+func (l *Lifecycle) recoverer(topic string, handler Handler) Handler {
+	return func(ctx context.Context, msg Message) (err error) {
+		log := l.logger.With(logger.String("topic", topic), logger.String("key", msg.Key))
+		ctx = logger.WithLogger(ctx, log)
+
+		defer func() {
+			if p := recover(); p != nil {
+				log.Error("Panic recovered",
+					logger.String("panic", fmt.Sprintf("%v", p)),
+					logger.String("stack", string(debug.Stack())),
+				)
+				err = fmt.Errorf("%w: %v", ErrHandlerPanicked, p)
+			}
+		}()
+
+		if err := handler(ctx, msg); err != nil {
+			log.Warn("Failed to handle message", logger.Error(err))
+			return err
+		}
+		return nil
+	}
+}
@@ -0,0 +1,165 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"microservice/internal/platform/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingLogger keeps the messages logged at error and warn level, including
+// those of loggers derived with With.
+type recordingLogger struct {
+	logger.Logger
+	mu       *sync.Mutex
+	messages *[]string
+}
+
+func newRecordingLogger() recordingLogger {
+	return recordingLogger{Logger: logger.NewNop(), mu: &sync.Mutex{}, messages: &[]string{}}
+}
+
+func (r recordingLogger) record(msg string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	*r.messages = append(*r.messages, msg)
+}
+
+func (r recordingLogger) Error(msg string, _ ...logger.Field) { r.record(msg) }
+func (r recordingLogger) Warn(msg string, _ ...logger.Field)  { r.record(msg) }
+func (r recordingLogger) With(...logger.Field) logger.Logger  { return r }
+
+func (r recordingLogger) logged() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), *r.messages...)
+}
+
+func startLifecycle(t *testing.T, consumer Consumer, log logger.Logger, topic string, handler Handler) *Lifecycle {
+	t.Helper()
+	lifecycle := NewLifecycle(consumer, log)
+	lifecycle.Handle(topic, handler)
+	require.NoError(t, lifecycle.Start(context.Background()))
+	t.Cleanup(func() { _ = lifecycle.Stop(context.Background()) })
+	return lifecycle
+}
+
+func TestLifecycle_DeliversMessages(t *testing.T) {
+	broker := NewMemoryBroker()
+	received := make(chan Message, 2)
+	startLifecycle(t, broker, logger.NewNop(), "orders", func(ctx context.Context, msg Message) error {
+		assert.NotNil(t, logger.FromContext(ctx))
+		received <- msg
+		return nil
+	})
+
+	require.NoError(t, broker.Publish(context.Background(), Message{Topic: "orders", Key: "1"}))
+	require.NoError(t, broker.Publish(context.Background(), Message{Topic: "other", Key: "2"}))
+	require.NoError(t, broker.Publish(context.Background(), Message{Topic: "orders", Key: "3"}))
+
+	assert.Equal(t, "1", (<-received).Key)
+	assert.Equal(t, "3", (<-received).Key)
+}
+
+func TestLifecycle_RecoversHandlerPanics(t *testing.T) {
+	broker := NewMemoryBroker()
+	log := newRecordingLogger()
+	handled := make(chan string, 1)
+	startLifecycle(t, broker, log, "orders", func(_ context.Context, msg Message) error {
+		if msg.Key == "bad" {
+			panic("nil order")
+		}
+		handled <- msg.Key
+		return nil
+	})
+
+	require.NoError(t, broker.Publish(context.Background(), Message{Topic: "orders", Key: "bad"}))
+	require.NoError(t, broker.Publish(context.Background(), Message{Topic: "orders", Key: "good"}))
+
+	assert.Equal(t, "good", <-handled, "consuming continues after a panic")
+	assert.Contains(t, log.logged(), "Panic recovered")
+}
+
+func TestLifecycle_RecovererReturnsPanicAsError(t *testing.T) {
+	lifecycle := NewLifecycle(NewMemoryBroker(), logger.NewNop())
+	handler := lifecycle.recoverer("orders", func(context.Context, Message) error {
+		panic("boom")
+	})
+
+	err := handler(context.Background(), Message{Topic: "orders"})
+
+	assert.ErrorIs(t, err, ErrHandlerPanicked)
+	assert.ErrorContains(t, err, "boom")
+}
+
+func TestLifecycle_StopFinishesMessageInProgress(t *testing.T) {
+	broker := NewMemoryBroker()
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var mu sync.Mutex
+	var handled []string
+	lifecycle := startLifecycle(t, broker, logger.NewNop(), "orders", func(ctx context.Context, msg Message) error {
+		close(started)
+		<-release
+		mu.Lock()
+		defer mu.Unlock()
+		handled = append(handled, msg.Key)
+		return ctx.Err()
+	})
+
+	require.NoError(t, broker.Publish(context.Background(), Message{Topic: "orders", Key: "1"}))
+	<-started
+	require.NoError(t, broker.Publish(context.Background(), Message{Topic: "orders", Key: "2"}))
+
+	stopped := make(chan error, 1)
+	go func() { stopped <- lifecycle.Stop(context.Background()) }()
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+
+	require.NoError(t, <-stopped)
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"1"}, handled, "the message in progress finishes with a live context and no new one starts")
+}
+
+func TestLifecycle_StopHonorsDeadline(t *testing.T) {
+	broker := NewMemoryBroker()
+	started := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+	lifecycle := startLifecycle(t, broker, logger.NewNop(), "orders", func(context.Context, Message) error {
+		close(started)
+		<-release
+		return nil
+	})
+	require.NoError(t, broker.Publish(context.Background(), Message{Topic: "orders"}))
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	assert.ErrorIs(t, lifecycle.Stop(ctx), context.DeadlineExceeded)
+}
+
+type failingConsumer struct{ err error }
+
+func (c failingConsumer) Subscribe(context.Context, string, Handler) error { return c.err }
+
+func TestLifecycle_LogsFailedSubscriptions(t *testing.T) {
+	log := newRecordingLogger()
+	startLifecycle(t, failingConsumer{err: errors.New("broker unreachable")}, log, "orders",
+		func(context.Context, Message) error { return nil })
+
+	assert.Eventually(t, func() bool { return len(log.logged()) == 1 }, time.Second, 5*time.Millisecond)
+	assert.Equal(t, []string{"Message subscription ended"}, log.logged())
+}
+
+func TestLifecycle_StopWithoutStart(t *testing.T) {
+	assert.NoError(t, NewLifecycle(NewMemoryBroker(), logger.NewNop()).Stop(context.Background()))
+}
@@ -0,0 +1,58 @@
+package messaging
+
+import (
+	"context"
+	"sync"
+)
+
+const memoryTopicBuffer = 64
+
+// MemoryBroker is an in-process Consumer for tests and local development.
+// Messages are queued per topic and each one is delivered to a single
+// subscriber. Failed messages are not redelivered.
+type MemoryBroker struct {
+	mu     sync.Mutex
+	topics map[string]chan Message
+}
+
+func NewMemoryBroker() *MemoryBroker {
+	return &MemoryBroker{topics: make(map[string]chan Message)}
+}
+
+func (b *MemoryBroker) topic(name string) chan Message {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ch, ok := b.topics[name]
+	if !ok {
+		ch = make(chan Message, memoryTopicBuffer)
+		b.topics[name] = ch
+	}
+	return ch
+}
+
+// Publish queues msg on its topic. It blocks while the queue is full, until
+// ctx is done.
+func (b *MemoryBroker) Publish(ctx context.Context, msg Message) error {
+	select {
+	case b.topic(msg.Topic) <- msg:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Subscribe hands queued messages on topic to handler until ctx is done. The
+// handler context is not cancelled with ctx, so a message in progress is
+// finished.
+func (b *MemoryBroker) Subscribe(ctx context.Context, topic string, handler Handler) error {
+	messages := b.topic(topic)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case msg := <-messages:
+			_ = handler(context.WithoutCancel(ctx), msg)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package outbox
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"microservice/internal/core/ports"
+	"microservice/internal/platform/logger"
+)
+
+const (
+	defaultPollInterval = time.Second
+	defaultBatchSize    = 100
+)
+
+// Store is the outbox the relay drains. postgres.Outbox implements it.
+type Store interface {
+	PublishPending(ctx context.Context, limit int, publish func(context.Context, ports.OutboxMessage) error) (int, error)
+}
+
+// Relay polls the outbox in the background and hands pending messages to a
+// Publisher. A message is marked published only after Publish returned nil, so
+// delivery is at least once: a crash or shutdown between the two sends it
+// again.
+type Relay struct {
+	store     Store
+	publisher ports.Publisher
+	logger    logger.Logger
+	interval  time.Duration
+	batchSize int
+
+	mu      sync.Mutex
+	cancel  context.CancelFunc
+	stopped chan struct{}
+}
+
+type Option func(*Relay)
+
+// WithPollInterval sets how long the relay waits after draining the outbox
+// before it looks again. Non-positive values keep the default of one second.
+func WithPollInterval(interval time.Duration) Option {
+	return func(r *Relay) {
+		if interval > 0 {
+			r.interval = interval
+		}
+	}
+}
+
+// WithBatchSize sets how many messages are claimed per transaction.
+// Non-positive values keep the default of 100.
+func WithBatchSize(size int) Option {
+	return func(r *Relay) {
+		if size > 0 {
+			r.batchSize = size
+		}
+	}
+}
+
+func NewRelay(store Store, publisher ports.Publisher, log logger.Logger, opts ...Option) *Relay {
+	r := &Relay{
+		store:     store,
+		publisher: publisher,
+		logger:    log,
+		interval:  defaultPollInterval,
+		batchSize: defaultBatchSize,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Start launches the polling loop and returns at once. Calling it again while
+// the relay runs does nothing.
+func (r *Relay) Start(context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.cancel != nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+	r.stopped = make(chan struct{})
+	go r.run(ctx, r.stopped)
+	return nil
+}
+
+// Stop cancels the polling loop, including a publish in progress, and waits
+// until it has exited or ctx is done. Messages whose publish was cancelled
+// stay pending and are published after the next start.
+func (r *Relay) Stop(ctx context.Context) error {
+	r.mu.Lock()
+	cancel, stopped := r.cancel, r.stopped
+	r.cancel = nil
+	r.mu.Unlock()
+
+	if cancel == nil {
+		return nil
+	}
+
+	cancel()
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (r *Relay) run(ctx context.Context, stopped chan struct{}) {
+	defer close(stopped)
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		r.drain(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// drain publishes batches until the outbox is empty, a publish fails or ctx
+// ends. A failed batch is retried on the next tick.
+func (r *Relay) drain(ctx context.Context) {
+	for ctx.Err() == nil {
+		published, err := r.store.PublishPending(ctx, r.batchSize, r.publisher.Publish)
+		if err != nil {
+			if ctx.Err() == nil {
+				r.logger.Warn("Failed to publish outbox messages",
+					logger.Int("published", published),
+					logger.Error(err),
+				)
+			}
+			return
+		}
+		if published < r.batchSize {
+			return
+		}
+	}
+}
+
+// NopPublisher accepts every message without sending it anywhere, so the relay
+// marks them published and they are lost. It is meant for tests only.
+type NopPublisher struct{}
+
+func (NopPublisher) Publish(context.Context, ports.OutboxMessage) error {
+	return nil
+}
@@ -0,0 +1,162 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"microservice/internal/core/ports"
+	"microservice/internal/platform/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryStore mimics postgres.Outbox: messages accepted by publish are marked
+// published and the batch stops at the first error.
+type memoryStore struct {
+	mu        sync.Mutex
+	messages  []ports.OutboxMessage
+	published map[int64]bool
+}
+
+func newMemoryStore(count int) *memoryStore {
+	s := &memoryStore{published: make(map[int64]bool)}
+	for i := 1; i <= count; i++ {
+		s.messages = append(s.messages, ports.OutboxMessage{ID: int64(i), Topic: "example.created"})
+	}
+	return s
+}
+
+func (s *memoryStore) PublishPending(ctx context.Context, limit int, publish func(context.Context, ports.OutboxMessage) error) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	published := 0
+	for _, message := range s.messages {
+		if published == limit {
+			break
+		}
+		if s.published[message.ID] {
+			continue
+		}
+		if err := publish(ctx, message); err != nil {
+			return published, err
+		}
+		s.published[message.ID] = true
+		published++
+	}
+	return published, nil
+}
+
+func (s *memoryStore) pending() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.messages) - len(s.published)
+}
+
+type recordingPublisher struct {
+	mu      sync.Mutex
+	ids     []int64
+	publish func(ctx context.Context, message ports.OutboxMessage) error
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, message ports.OutboxMessage) error {
+	if p.publish != nil {
+		if err := p.publish(ctx, message); err != nil {
+			return err
+		}
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.ids = append(p.ids, message.ID)
+	return nil
+}
+
+func (p *recordingPublisher) published() []int64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]int64(nil), p.ids...)
+}
+
+func TestRelay_PublishesPendingMessagesInOrder(t *testing.T) {
+	store := newMemoryStore(5)
+	publisher := &recordingPublisher{}
+	relay := NewRelay(store, publisher, logger.NewNop(), WithBatchSize(2), WithPollInterval(time.Hour))
+
+	require.NoError(t, relay.Start(context.Background()))
+	t.Cleanup(func() { _ = relay.Stop(context.Background()) })
+
+	assert.Eventually(t, func() bool { return store.pending() == 0 }, time.Second, 5*time.Millisecond,
+		"full batches are drained without waiting for the next poll")
+	assert.Equal(t, []int64{1, 2, 3, 4, 5}, publisher.published())
+}
+
+func TestRelay_RetriesFailedMessages(t *testing.T) {
+	store := newMemoryStore(3)
+	failures := 2
+	publisher := &recordingPublisher{publish: func(_ context.Context, message ports.OutboxMessage) error {
+		if message.ID == 2 && failures > 0 {
+			failures--
+			return errors.New("broker unavailable")
+		}
+		return nil
+	}}
+	relay := NewRelay(store, publisher, logger.NewNop(), WithPollInterval(10*time.Millisecond))
+
+	require.NoError(t, relay.Start(context.Background()))
+	t.Cleanup(func() { _ = relay.Stop(context.Background()) })
+
+	assert.Eventually(t, func() bool { return store.pending() == 0 }, time.Second, 5*time.Millisecond)
+	assert.Equal(t, []int64{1, 2, 3}, publisher.published())
+}
+
+func TestRelay_StopCancelsPublishInProgress(t *testing.T) {
+	store := newMemoryStore(1)
+	publishing := make(chan struct{})
+	publisher := &recordingPublisher{publish: func(ctx context.Context, _ ports.OutboxMessage) error {
+		close(publishing)
+		<-ctx.Done()
+		return ctx.Err()
+	}}
+	relay := NewRelay(store, publisher, logger.NewNop())
+
+	require.NoError(t, relay.Start(context.Background()))
+	<-publishing
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, relay.Stop(ctx))
+
+	assert.Equal(t, 1, store.pending(), "a cancelled publish leaves the message pending")
+	assert.Empty(t, publisher.published())
+}
+
+func TestRelay_StopHonorsDeadline(t *testing.T) {
+	store := newMemoryStore(1)
+	publishing := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+	publisher := &recordingPublisher{publish: func(context.Context, ports.OutboxMessage) error {
+		close(publishing)
+		<-release
+		return nil
+	}}
+	relay := NewRelay(store, publisher, logger.NewNop())
+
+	require.NoError(t, relay.Start(context.Background()))
+	<-publishing
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	assert.ErrorIs(t, relay.Stop(ctx), context.DeadlineExceeded)
+}
+
+func TestRelay_StopWithoutStart(t *testing.T) {
+	relay := NewRelay(newMemoryStore(0), NopPublisher{}, logger.NewNop())
+
+	assert.NoError(t, relay.Stop(context.Background()))
+}
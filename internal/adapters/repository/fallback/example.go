@@ -0,0 +1,66 @@
+package fallback
+
+import (
+	"context"
+	"microservice/internal/adapters/repository/memory"
+	"microservice/internal/core/domain/example"
+	"microservice/internal/core/ports"
+)
+
+// Mode reports whether the database fell back to in-memory mode at startup.
+// database.Lifecycle implements it.
+type Mode interface {
+	InMemory() bool
+}
+
+// Repository serves entities from the primary repository, or from memory while
+// mode reports that the database is unavailable. Entities saved in memory are
+// lost on restart.
+type Repository struct {
+	mode    Mode
+	primary ports.ExampleRepository
+	memory  *memory.Repository
+}
+
+func NewRepository(mode Mode, primary ports.ExampleRepository, fallback *memory.Repository) *Repository {
+	return &Repository{
+		mode:    mode,
+		primary: primary,
+		memory:  fallback,
+	}
+}
+
+func (r *Repository) current() ports.ExampleRepository {
+	if r.mode.InMemory() {
+		return r.memory
+	}
+	return r.primary
+}
+
+func (r *Repository) Save(ctx context.Context, entity *example.Entity) error {
+	return r.current().Save(ctx, entity)
+}
+
+func (r *Repository) GetByID(ctx context.Context, id string) (*example.Entity, error) {
+	return r.current().GetByID(ctx, id)
+}
+
+func (r *Repository) GetByEmail(ctx context.Context, email string) (*example.Entity, error) {
+	return r.current().GetByEmail(ctx, email)
+}
+
+func (r *Repository) List(ctx context.Context) ([]*example.Entity, error) {
+	return r.current().List(ctx)
+}
+
+func (r *Repository) Update(ctx context.Context, entity *example.Entity) error {
+	return r.current().Update(ctx, entity)
+}
+
+func (r *Repository) Restore(ctx context.Context, id string) error {
+	return r.current().Restore(ctx, id)
+}
+
+func (r *Repository) SaveBatch(ctx context.Context, entities []*example.Entity, atomic bool) ([]error, error) {
+	return r.current().SaveBatch(ctx, entities, atomic)
+}
@@ -0,0 +1,64 @@
+package fallback
+
+import (
+	"context"
+	"microservice/internal/adapters/repository/memory"
+	"microservice/internal/core/domain/example"
+	portsMocks "microservice/internal/core/ports/mocks"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type staticMode bool
+
+func (m staticMode) InMemory() bool { return bool(m) }
+
+func TestRepository_UsesPrimaryWhenConnected(t *testing.T) {
+	primary := portsMocks.NewMockExampleRepository(t)
+	entity := &example.Entity{ID: "primary-id"}
+	primary.EXPECT().Save(mock.Anything, entity).Return(nil).Once()
+	primary.EXPECT().GetByID(mock.Anything, "primary-id").Return(entity, nil).Once()
+
+	fallback := memory.NewRepository()
+	repo := NewRepository(staticMode(false), primary, fallback)
+
+	require.NoError(t, repo.Save(context.Background(), entity))
+	got, err := repo.GetByID(context.Background(), "primary-id")
+	require.NoError(t, err)
+	assert.Same(t, entity, got)
+
+	_, err = fallback.GetByID(context.Background(), "primary-id")
+	assert.ErrorIs(t, err, example.ErrEntityNotFound)
+}
+
+func TestRepository_UsesMemoryInFallbackMode(t *testing.T) {
+	primary := portsMocks.NewMockExampleRepository(t)
+	repo := NewRepository(staticMode(true), primary, memory.NewRepository())
+	ctx := context.Background()
+
+	require.NoError(t, repo.Save(ctx, &example.Entity{ID: "mem-id", Name: "Before"}))
+	require.NoError(t, repo.Update(ctx, &example.Entity{ID: "mem-id", Name: "After"}))
+
+	errs, err := repo.SaveBatch(ctx, []*example.Entity{{ID: "batch-1", Email: "batch@example.com"}, {ID: "mem-id"}}, false)
+	require.NoError(t, err)
+	assert.NoError(t, errs[0])
+	var exists *example.AlreadyExistsError
+	assert.ErrorAs(t, errs[1], &exists)
+
+	got, err := repo.GetByID(ctx, "mem-id")
+	require.NoError(t, err)
+	assert.Equal(t, "After", got.Name)
+
+	got, err = repo.GetByEmail(ctx, "batch@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "batch-1", got.ID)
+
+	entities, err := repo.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, entities, 2)
+	assert.Equal(t, "batch-1", entities[0].ID)
+	assert.Equal(t, "mem-id", entities[1].ID)
+}
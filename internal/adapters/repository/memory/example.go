@@ -4,6 +4,9 @@ import (
 	"context"
 	"errors"
 	memoryPlatform "microservice/internal/platform/repository/memory"
+	"sort"
+	"strings"
+	"time"
 
 	"microservice/internal/core/domain/example"
 )
@@ -12,12 +15,18 @@ type Repository struct {
 	*memoryPlatform.Repository[*example.Entity]
 }
 
-func NewRepository() *Repository {
+func NewRepository(opts ...memoryPlatform.Option) *Repository {
 	return &Repository{
-		Repository: memoryPlatform.New[*example.Entity](),
+		Repository: memoryPlatform.New[*example.Entity](opts...),
 	}
 }
 
+// WithCaseInsensitiveIDs stores entities under their lower-cased ID, so
+// lookups match regardless of casing and IDs differing only in case collide.
+func WithCaseInsensitiveIDs() memoryPlatform.Option {
+	return memoryPlatform.WithKeyNormalizer(strings.ToLower)
+}
+
 func (r *Repository) GetByID(ctx context.Context, id string) (*example.Entity, error) {
 	entity, err := r.Repository.GetByID(ctx, id)
 	if err != nil {
@@ -29,7 +38,75 @@ func (r *Repository) GetByID(ctx context.Context, id string) (*example.Entity, e
 	return entity, nil
 }
 
+// GetByEmail returns the live entity whose email matches regardless of case,
+// or the one with the lowest ID when several share it.
+func (r *Repository) GetByEmail(ctx context.Context, email string) (*example.Entity, error) {
+	entity, err := r.Repository.Find(ctx, func(e *example.Entity) bool {
+		return strings.EqualFold(e.Email, email)
+	})
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return entity, nil
+}
+
+// List returns every live entity ordered by ID.
+func (r *Repository) List(ctx context.Context) ([]*example.Entity, error) {
+	entities, err := r.Repository.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(entities, func(i, j int) bool { return entities[i].ID < entities[j].ID })
+	return entities, nil
+}
+
+func (r *Repository) Update(ctx context.Context, entity *example.Entity) error {
+	entity.UpdatedAt = time.Now().UTC()
+	err := r.Repository.Update(ctx, entity)
+	if err != nil {
+		if errors.Is(err, memoryPlatform.ErrNotFound) {
+			return example.ErrEntityNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *Repository) GetByIDIncludingDeleted(ctx context.Context, id string) (*example.Entity, *time.Time, error) {
+	entity, deletedAt, err := r.Repository.GetByIDIncludingDeleted(ctx, id)
+	if err != nil {
+		return nil, nil, notFound(err)
+	}
+	return entity, deletedAt, nil
+}
+
+// SoftDelete hides the entity but keeps its ID taken, mirroring the postgres
+// repository: saving it again fails until it is restored or deleted.
+func (r *Repository) SoftDelete(ctx context.Context, id string) error {
+	return notFound(r.Repository.SoftDelete(ctx, id))
+}
+
+func (r *Repository) Restore(ctx context.Context, id string) error {
+	err := r.Repository.Restore(ctx, id)
+	if errors.Is(err, memoryPlatform.ErrNotDeleted) {
+		return example.ErrEntityNotDeleted
+	}
+	return notFound(err)
+}
+
+func (r *Repository) Delete(ctx context.Context, id string) error {
+	return notFound(r.Repository.Delete(ctx, id))
+}
+
+func notFound(err error) error {
+	if errors.Is(err, memoryPlatform.ErrNotFound) {
+		return example.ErrEntityNotFound
+	}
+	return err
+}
+
 func (r *Repository) Save(ctx context.Context, entity *example.Entity) error {
+	entity.UpdatedAt = time.Now().UTC()
 	err := r.Repository.Save(ctx, entity)
 	if err != nil {
 		if errors.Is(err, memoryPlatform.ErrAlreadyExists) {
@@ -39,3 +116,17 @@ func (r *Repository) Save(ctx context.Context, entity *example.Entity) error {
 	}
 	return nil
 }
+
+func (r *Repository) SaveBatch(ctx context.Context, entities []*example.Entity, atomic bool) ([]error, error) {
+	now := time.Now().UTC()
+	for _, entity := range entities {
+		entity.UpdatedAt = now
+	}
+	errs := r.Repository.SaveAll(ctx, entities, atomic)
+	for i, err := range errs {
+		if errors.Is(err, memoryPlatform.ErrAlreadyExists) {
+			errs[i] = &example.AlreadyExistsError{ID: entities[i].ID}
+		}
+	}
+	return errs, nil
+}
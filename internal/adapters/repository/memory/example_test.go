@@ -143,3 +143,143 @@ func TestRepository_GetByID(t *testing.T) {
 		})
 	}
 }
+
+func TestRepository_GetByID_Casing(t *testing.T) {
+	tests := []struct {
+		name          string
+		repo          *Repository
+		expectedError error
+	}{
+		{
+			name:          "case_sensitive_by_default",
+			repo:          NewRepository(),
+			expectedError: example.ErrEntityNotFound,
+		},
+		{
+			name: "case_insensitive",
+			repo: NewRepository(WithCaseInsensitiveIDs()),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			require.NoError(t, tt.repo.Save(ctx, &example.Entity{ID: "Test-ID", Email: "test@example.com", Name: "Test User"}))
+
+			entity, err := tt.repo.GetByID(ctx, "test-id")
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, entity)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "Test-ID", entity.ID)
+		})
+	}
+}
+
+func TestRepository_Update(t *testing.T) {
+	ctx := context.Background()
+	repo := NewRepository()
+	saved := &example.Entity{ID: "test-id", Email: "test@example.com", Name: "Test User"}
+	require.NoError(t, repo.Save(ctx, saved))
+	assert.False(t, saved.UpdatedAt.IsZero())
+
+	err := repo.Update(ctx, &example.Entity{ID: "test-id", Email: "new@example.com", Name: "New Name"})
+	require.NoError(t, err)
+
+	entity, err := repo.GetByID(ctx, "test-id")
+	require.NoError(t, err)
+	assert.Equal(t, "new@example.com", entity.Email)
+	assert.Equal(t, "New Name", entity.Name)
+	assert.False(t, entity.UpdatedAt.Before(saved.UpdatedAt))
+
+	err = repo.Update(ctx, &example.Entity{ID: "missing-id", Email: "new@example.com", Name: "New Name"})
+	assert.ErrorIs(t, err, example.ErrEntityNotFound)
+}
+
+func TestRepository_SaveBatch(t *testing.T) {
+	ctx := context.Background()
+	repo := NewRepository()
+	require.NoError(t, repo.Save(ctx, &example.Entity{ID: "existing-id", Email: "test@example.com", Name: "Test User"}))
+
+	errs, err := repo.SaveBatch(ctx, []*example.Entity{
+		{ID: "new-id", Email: "new@example.com", Name: "New User"},
+		{ID: "existing-id", Email: "dup@example.com", Name: "Dup User"},
+	}, false)
+
+	require.NoError(t, err)
+	require.Len(t, errs, 2)
+	assert.NoError(t, errs[0])
+	var alreadyExistsErr *example.AlreadyExistsError
+	require.ErrorAs(t, errs[1], &alreadyExistsErr)
+	assert.Equal(t, "existing-id", alreadyExistsErr.ID)
+
+	_, err = repo.GetByID(ctx, "new-id")
+	assert.NoError(t, err)
+}
+
+func TestRepository_SoftDelete(t *testing.T) {
+	ctx := context.Background()
+	repo := NewRepository()
+	require.NoError(t, repo.Save(ctx, &example.Entity{ID: "test-id", Email: "test@example.com", Name: "Test User"}))
+
+	require.NoError(t, repo.SoftDelete(ctx, "test-id"))
+
+	_, err := repo.GetByID(ctx, "test-id")
+	assert.ErrorIs(t, err, example.ErrEntityNotFound)
+	entity, deletedAt, err := repo.GetByIDIncludingDeleted(ctx, "test-id")
+	require.NoError(t, err)
+	assert.Equal(t, "Test User", entity.Name)
+	assert.NotNil(t, deletedAt)
+
+	var alreadyExistsErr *example.AlreadyExistsError
+	assert.ErrorAs(t, repo.Save(ctx, &example.Entity{ID: "test-id"}), &alreadyExistsErr)
+
+	require.NoError(t, repo.Restore(ctx, "test-id"))
+	_, err = repo.GetByID(ctx, "test-id")
+	assert.NoError(t, err)
+	assert.ErrorIs(t, repo.Restore(ctx, "test-id"), example.ErrEntityNotDeleted)
+
+	require.NoError(t, repo.Delete(ctx, "test-id"))
+	assert.NoError(t, repo.Save(ctx, &example.Entity{ID: "test-id"}))
+
+	assert.ErrorIs(t, repo.SoftDelete(ctx, "missing-id"), example.ErrEntityNotFound)
+	assert.ErrorIs(t, repo.Restore(ctx, "missing-id"), example.ErrEntityNotFound)
+	assert.ErrorIs(t, repo.Delete(ctx, "missing-id"), example.ErrEntityNotFound)
+	_, _, err = repo.GetByIDIncludingDeleted(ctx, "missing-id")
+	assert.ErrorIs(t, err, example.ErrEntityNotFound)
+}
+
+func TestRepository_GetByEmail(t *testing.T) {
+	ctx := context.Background()
+	repo := NewRepository()
+	require.NoError(t, repo.Save(ctx, &example.Entity{ID: "c-id", Email: "shared@example.com", Name: "Third"}))
+	require.NoError(t, repo.Save(ctx, &example.Entity{ID: "b-id", Email: "Shared@Example.com", Name: "Second"}))
+	require.NoError(t, repo.Save(ctx, &example.Entity{ID: "a-id", Email: "shared@example.com", Name: "Deleted"}))
+	require.NoError(t, repo.SoftDelete(ctx, "a-id"))
+
+	entity, err := repo.GetByEmail(ctx, "SHARED@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "b-id", entity.ID)
+
+	_, err = repo.GetByEmail(ctx, "missing@example.com")
+	assert.ErrorIs(t, err, example.ErrEntityNotFound)
+}
+
+func TestRepository_List(t *testing.T) {
+	ctx := context.Background()
+	repo := NewRepository()
+	require.NoError(t, repo.Save(ctx, &example.Entity{ID: "c-id", Email: "c@example.com", Name: "Third"}))
+	require.NoError(t, repo.Save(ctx, &example.Entity{ID: "a-id", Email: "a@example.com", Name: "First"}))
+	require.NoError(t, repo.Save(ctx, &example.Entity{ID: "b-id", Email: "b@example.com", Name: "Deleted"}))
+	require.NoError(t, repo.SoftDelete(ctx, "b-id"))
+
+	entities, err := repo.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, entities, 2)
+	assert.Equal(t, "a-id", entities[0].ID)
+	assert.Equal(t, "c-id", entities[1].ID)
+}
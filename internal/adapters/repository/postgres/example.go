@@ -4,53 +4,360 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"time"
 
 	"microservice/internal/adapters/database"
 	"microservice/internal/core/domain/example"
+	"microservice/internal/platform/logger"
 
 	"github.com/lib/pq"
 )
 
+var ErrResetNotAllowed = errors.New("reset is not allowed in production environment")
+
+// Repository runs its hot queries as prepared statements that are cached per
+// connection, so Close should be called before the database is stopped. Calls
+// made with a context from database.Lifecycle.InTx join that transaction.
 type Repository struct {
-	db *database.Lifecycle
+	db     *database.Lifecycle
+	reads  statementCache
+	writes statementCache
 }
 
 func NewRepository(db *database.Lifecycle) *Repository {
 	return &Repository{db: db}
 }
 
+// queryRow runs query in the transaction carried by ctx when there is one.
+// Otherwise it uses the cached prepared statement on the primary, or on the
+// replica when read is set.
+func (r *Repository) queryRow(ctx context.Context, read bool, query string, args ...any) *sql.Row {
+	if tx, ok := database.TxFromContext(ctx); ok {
+		return tx.QueryRowContext(ctx, query, args...)
+	}
+	if read {
+		return r.reads.queryRow(ctx, r.db.ReadConnection().DB, query, args...)
+	}
+	return r.writes.queryRow(ctx, r.db.Connection().DB, query, args...)
+}
+
+// queryContext bounds ctx by the configured query timeout unless the caller
+// already set a deadline.
+func (r *Repository) queryContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return withQueryTimeout(ctx, r.db.Config().Postgres.QueryTimeout)
+}
+
+// GetByID returns the entity unless it does not exist or was soft-deleted.
 func (r *Repository) GetByID(ctx context.Context, id string) (*example.Entity, error) {
-	query := `SELECT id, email, name FROM examples WHERE id = $1`
+	entity, deletedAt, err := r.getByID(ctx, id, "get_by_id")
+	if err != nil {
+		return nil, err
+	}
+	if deletedAt != nil {
+		return nil, example.ErrEntityNotFound
+	}
+	return entity, nil
+}
+
+// GetByEmail returns the live entity with the email, compared regardless of
+// case, or the one with the lowest ID when several share it.
+func (r *Repository) GetByEmail(ctx context.Context, email string) (*example.Entity, error) {
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	var rows int64
+	defer r.logQuery(ctx, "get_by_email", time.Now(), &rows)
+
+	query := `SELECT id, email, name, updated_at FROM examples WHERE LOWER(email) = LOWER($1) AND deleted_at IS NULL ORDER BY id LIMIT 1`
 
 	var entity example.Entity
-	err := r.db.Connection().QueryRowContext(ctx, query, id).Scan(
+	err := r.queryRow(ctx, true, query, email).Scan(
 		&entity.ID,
 		&entity.Email,
 		&entity.Name,
+		&entity.UpdatedAt,
 	)
-
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, example.ErrEntityNotFound
 		}
-		return nil, err
+		return nil, contextError(ctx, err)
 	}
 
+	rows = 1
+	r.checkOnRead(ctx, &entity)
 	return &entity, nil
 }
 
+// List returns every live entity ordered by ID. Outside a transaction it reads
+// from the replica when one is configured.
+func (r *Repository) List(ctx context.Context) ([]*example.Entity, error) {
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	var count int64
+	defer r.logQuery(ctx, "list", time.Now(), &count)
+
+	rows, err := database.Executor(ctx, r.db.ReadConnection().DB).QueryContext(ctx,
+		`SELECT id, email, name, updated_at FROM examples WHERE deleted_at IS NULL ORDER BY id`)
+	if err != nil {
+		return nil, contextError(ctx, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var entities []*example.Entity
+	for rows.Next() {
+		var entity example.Entity
+		if err := rows.Scan(&entity.ID, &entity.Email, &entity.Name, &entity.UpdatedAt); err != nil {
+			return nil, contextError(ctx, err)
+		}
+		r.checkOnRead(ctx, &entity)
+		entities = append(entities, &entity)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, contextError(ctx, err)
+	}
+
+	count = int64(len(entities))
+	return entities, nil
+}
+
+// GetByIDIncludingDeleted also returns soft-deleted entities, together with
+// the time they were deleted, or nil for live ones. It is meant for admin use.
+func (r *Repository) GetByIDIncludingDeleted(ctx context.Context, id string) (*example.Entity, *time.Time, error) {
+	return r.getByID(ctx, id, "get_by_id_including_deleted")
+}
+
+func (r *Repository) getByID(ctx context.Context, id, operation string) (*example.Entity, *time.Time, error) {
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	var rows int64
+	defer r.logQuery(ctx, operation, time.Now(), &rows)
+
+	query := `SELECT id, email, name, updated_at, deleted_at FROM examples WHERE id = $1`
+	if r.db.Config().CaseInsensitiveIDs {
+		// Prefer an exact match when several IDs differ only in case.
+		query = `SELECT id, email, name, updated_at, deleted_at FROM examples WHERE LOWER(id) = LOWER($1) ORDER BY id = $1 DESC, id LIMIT 1`
+	}
+
+	var (
+		entity    example.Entity
+		deletedAt sql.NullTime
+	)
+	err := r.queryRow(ctx, true, query, id).Scan(
+		&entity.ID,
+		&entity.Email,
+		&entity.Name,
+		&entity.UpdatedAt,
+		&deletedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, example.ErrEntityNotFound
+		}
+		return nil, nil, contextError(ctx, err)
+	}
+
+	rows = 1
+	r.checkOnRead(ctx, &entity)
+
+	if deletedAt.Valid {
+		return &entity, &deletedAt.Time, nil
+	}
+	return &entity, nil, nil
+}
+
+// checkOnRead logs stored entities that no longer pass domain validation,
+// such as rows written before a rule existed. They are still returned.
+func (r *Repository) checkOnRead(ctx context.Context, entity *example.Entity) {
+	if !r.db.Config().ValidateOnRead {
+		return
+	}
+	if err := entity.Validate(); err != nil {
+		logger.FromContext(ctx).Warn("Stored entity failed validation",
+			logger.String("entity_id", entity.ID),
+			logger.Error(err),
+		)
+	}
+}
+
 func (r *Repository) Save(ctx context.Context, entity *example.Entity) error {
-	query := `INSERT INTO examples (id, email, name) VALUES ($1, $2, $3)`
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	var rows int64
+	defer r.logQuery(ctx, "save", time.Now(), &rows)
+
+	query := `INSERT INTO examples (id, email, name) VALUES ($1, $2, $3) RETURNING updated_at`
 
-	_, err := r.db.Connection().ExecContext(ctx, query, entity.ID, entity.Email, entity.Name)
+	err := r.queryRow(ctx, false, query, entity.ID, entity.Email, entity.Name).Scan(&entity.UpdatedAt)
 	if err != nil {
 		var pqErr *pq.Error
 		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
 			return &example.AlreadyExistsError{ID: entity.ID}
 		}
+		return contextError(ctx, err)
+	}
+
+	rows = 1
+	return nil
+}
+
+// SaveBatch inserts the entities in one transaction. In atomic mode the first
+// failure rolls back the whole batch; otherwise each insert runs under its own
+// savepoint so a failing row does not abort the rest. When ctx carries a
+// transaction the batch joins it under a savepoint instead of committing.
+func (r *Repository) SaveBatch(ctx context.Context, entities []*example.Entity, atomic bool) ([]error, error) {
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	var rows int64
+	defer r.logQuery(ctx, "save_batch", time.Now(), &rows)
+
+	query := `INSERT INTO examples (id, email, name) VALUES ($1, $2, $3) RETURNING updated_at`
+
+	db := r.db.Connection().DB
+	tx, joined := database.TxFromContext(ctx)
+	if joined {
+		if _, err := tx.ExecContext(ctx, `SAVEPOINT save_batch`); err != nil {
+			return nil, contextError(ctx, err)
+		}
+	} else {
+		var err error
+		if tx, err = db.BeginTx(ctx, nil); err != nil {
+			return nil, contextError(ctx, err)
+		}
+		defer func() { _ = tx.Rollback() }()
+	}
+
+	insert := func(entity *example.Entity) *sql.Row {
+		return tx.QueryRowContext(ctx, query, entity.ID, entity.Email, entity.Name)
+	}
+	if stmt, err := r.writes.prepare(ctx, db, query); err == nil {
+		txStmt := tx.StmtContext(ctx, stmt)
+		insert = func(entity *example.Entity) *sql.Row {
+			return txStmt.QueryRowContext(ctx, entity.ID, entity.Email, entity.Name)
+		}
+	}
+
+	errs := make([]error, len(entities))
+	for i, entity := range entities {
+		if !atomic {
+			if _, err := tx.ExecContext(ctx, `SAVEPOINT batch_item`); err != nil {
+				return nil, contextError(ctx, err)
+			}
+		}
+
+		err := insert(entity).Scan(&entity.UpdatedAt)
+		if err != nil {
+			// A cancelled query also surfaces as a *pq.Error; it fails the
+			// whole batch rather than this item.
+			var pqErr *pq.Error
+			if !errors.As(err, &pqErr) || ctx.Err() != nil {
+				return nil, contextError(ctx, err)
+			}
+			if pqErr.Code == "23505" {
+				err = &example.AlreadyExistsError{ID: entity.ID}
+			}
+			errs[i] = err
+
+			if atomic {
+				if joined {
+					if _, err := tx.ExecContext(ctx, `ROLLBACK TO SAVEPOINT save_batch`); err != nil {
+						return nil, contextError(ctx, err)
+					}
+				}
+				rows = 0
+				return errs, nil
+			}
+			if _, err := tx.ExecContext(ctx, `ROLLBACK TO SAVEPOINT batch_item`); err != nil {
+				return nil, contextError(ctx, err)
+			}
+			continue
+		}
+
+		if !atomic {
+			if _, err := tx.ExecContext(ctx, `RELEASE SAVEPOINT batch_item`); err != nil {
+				return nil, contextError(ctx, err)
+			}
+		}
+		rows++
+	}
+
+	if joined {
+		if _, err := tx.ExecContext(ctx, `RELEASE SAVEPOINT save_batch`); err != nil {
+			return nil, contextError(ctx, err)
+		}
+		return errs, nil
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, contextError(ctx, err)
+	}
+
+	return errs, nil
+}
+
+func (r *Repository) Update(ctx context.Context, entity *example.Entity) error {
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	var rows int64
+	defer r.logQuery(ctx, "update", time.Now(), &rows)
+
+	query := `UPDATE examples SET email = $2, name = $3, updated_at = CURRENT_TIMESTAMP WHERE id = $1 AND deleted_at IS NULL RETURNING updated_at`
+
+	err := r.queryRow(ctx, false, query, entity.ID, entity.Email, entity.Name).Scan(&entity.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return example.ErrEntityNotFound
+		}
+		return contextError(ctx, err)
+	}
+
+	rows = 1
+	return nil
+}
+
+// SoftDelete marks a live entity as deleted. It disappears from GetByID but
+// keeps its row, so its ID stays taken: saving the same ID again fails with
+// AlreadyExistsError until the entity is restored with Restore or removed for
+// good with Delete. This keeps a deleted entity's history from being silently
+// replaced by a new one.
+func (r *Repository) SoftDelete(ctx context.Context, id string) error {
+	return r.execByID(ctx, "soft_delete", `UPDATE examples SET deleted_at = CURRENT_TIMESTAMP WHERE id = $1 AND deleted_at IS NULL RETURNING id`, id)
+}
+
+// Restore undoes SoftDelete. It fails with ErrEntityNotFound when no entity
+// has the ID and with ErrEntityNotDeleted when the entity is live.
+func (r *Repository) Restore(ctx context.Context, id string) error {
+	err := r.execByID(ctx, "restore", `UPDATE examples SET deleted_at = NULL WHERE id = $1 AND deleted_at IS NOT NULL RETURNING id`, id)
+	if !errors.Is(err, example.ErrEntityNotFound) {
 		return err
 	}
+	if _, deletedAt, getErr := r.GetByIDIncludingDeleted(ctx, id); getErr == nil && deletedAt == nil {
+		return example.ErrEntityNotDeleted
+	}
+	return err
+}
 
+// Delete removes the entity permanently, whether or not it is soft-deleted,
+// which frees its ID.
+func (r *Repository) Delete(ctx context.Context, id string) error {
+	return r.execByID(ctx, "delete", `DELETE FROM examples WHERE id = $1 RETURNING id`, id)
+}
+
+// execByID runs a write that returns the affected ID, mapping no affected row
+// to ErrEntityNotFound.
+func (r *Repository) execByID(ctx context.Context, operation, query, id string) error {
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	var rows int64
+	defer r.logQuery(ctx, operation, time.Now(), &rows)
+
+	var affected string
+	if err := r.queryRow(ctx, false, query, id).Scan(&affected); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return example.ErrEntityNotFound
+		}
+		return contextError(ctx, err)
+	}
+	rows = 1
 	return nil
 }
 
@@ -61,10 +368,28 @@ func (r *Repository) CreateTable(ctx context.Context) error {
 			email VARCHAR(255) NOT NULL,
 			name VARCHAR(255) NOT NULL,
 			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			deleted_at TIMESTAMP
 		)
 	`
 
-	_, err := r.db.Connection().ExecContext(ctx, query)
+	_, err := database.Executor(ctx, r.db.Connection().DB).ExecContext(ctx, query)
 	return err
 }
+
+// Reset removes all rows from the examples table. It is intended for test
+// setup and refuses to run when the service is configured for production.
+func (r *Repository) Reset(ctx context.Context) error {
+	if r.db.Config().IsProduction() {
+		return ErrResetNotAllowed
+	}
+
+	_, err := database.Executor(ctx, r.db.Connection().DB).ExecContext(ctx, `TRUNCATE TABLE examples`)
+	return err
+}
+
+// Close releases the cached prepared statements. The repository can still be
+// used afterwards; statements are prepared again on demand.
+func (r *Repository) Close() error {
+	return errors.Join(r.reads.close(), r.writes.close())
+}
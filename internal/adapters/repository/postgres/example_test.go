@@ -5,14 +5,17 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"microservice/internal/adapters/database"
 	"microservice/internal/config"
 	"microservice/internal/core/domain/example"
+	platformPostgres "microservice/internal/platform/database/postgres"
 	"microservice/internal/platform/logger"
 
+	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/suite"
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/modules/postgres"
@@ -48,6 +51,7 @@ func (s *RepositoryTestSuite) SetupSuite() {
 	s.Require().NoError(err)
 
 	dbConfig := &config.DatabaseConfig{
+		BaseConfig: config.BaseConfig{Environment: config.EnvTest},
 		Postgres: config.PostgresConfig{
 			Host:     host,
 			Port:     port.Int(),
@@ -70,8 +74,7 @@ func (s *RepositoryTestSuite) SetupSuite() {
 }
 
 func (s *RepositoryTestSuite) SetupTest() {
-	ctx := context.Background()
-	_, err := s.db.Connection().ExecContext(ctx, "TRUNCATE TABLE examples")
+	err := s.repository.Reset(context.Background())
 	s.Require().NoError(err)
 }
 
@@ -111,6 +114,116 @@ func (s *RepositoryTestSuite) TestGetByID_NotFound() {
 	s.True(errors.Is(err, example.ErrEntityNotFound))
 }
 
+func (s *RepositoryTestSuite) TestGetByID_CaseSensitiveByDefault() {
+	ctx := context.Background()
+	s.Require().NoError(s.repository.Save(ctx, &example.Entity{ID: "Case-ID", Email: "case@example.com", Name: "Case"}))
+
+	retrieved, err := s.repository.GetByID(ctx, "case-id")
+	s.Require().ErrorIs(err, example.ErrEntityNotFound)
+	s.Require().Nil(retrieved)
+}
+
+func (s *RepositoryTestSuite) TestGetByID_CaseInsensitive() {
+	ctx := context.Background()
+	s.db.Config().CaseInsensitiveIDs = true
+	defer func() { s.db.Config().CaseInsensitiveIDs = false }()
+
+	s.Require().NoError(s.repository.Save(ctx, &example.Entity{ID: "Case-ID", Email: "case@example.com", Name: "Case"}))
+
+	retrieved, err := s.repository.GetByID(ctx, "case-id")
+	s.Require().NoError(err)
+	s.Equal("Case-ID", retrieved.ID)
+
+	s.Require().NoError(s.repository.Save(ctx, &example.Entity{ID: "CASE-ID", Email: "upper@example.com", Name: "Upper"}))
+
+	retrieved, err = s.repository.GetByID(ctx, "CASE-ID")
+	s.Require().NoError(err)
+	s.Equal("CASE-ID", retrieved.ID)
+
+	retrieved, err = s.repository.GetByID(ctx, "Case-ID")
+	s.Require().NoError(err)
+	s.Equal("Case-ID", retrieved.ID)
+}
+
+func (s *RepositoryTestSuite) TestGetByEmail() {
+	ctx := context.Background()
+	s.Require().NoError(s.repository.Save(ctx, &example.Entity{ID: "c-id", Email: "shared@example.com", Name: "Third"}))
+	s.Require().NoError(s.repository.Save(ctx, &example.Entity{ID: "b-id", Email: "Shared@Example.com", Name: "Second"}))
+	s.Require().NoError(s.repository.Save(ctx, &example.Entity{ID: "a-id", Email: "shared@example.com", Name: "Deleted"}))
+	s.Require().NoError(s.repository.SoftDelete(ctx, "a-id"))
+
+	retrieved, err := s.repository.GetByEmail(ctx, "SHARED@example.com")
+	s.Require().NoError(err)
+	s.Equal("b-id", retrieved.ID)
+	s.Equal("Shared@Example.com", retrieved.Email)
+
+	_, err = s.repository.GetByEmail(ctx, "missing@example.com")
+	s.ErrorIs(err, example.ErrEntityNotFound)
+}
+
+func (s *RepositoryTestSuite) TestList() {
+	ctx := context.Background()
+	s.Require().NoError(s.repository.Save(ctx, &example.Entity{ID: "c-id", Email: "c@example.com", Name: "Third"}))
+	s.Require().NoError(s.repository.Save(ctx, &example.Entity{ID: "a-id", Email: "a@example.com", Name: "First"}))
+	s.Require().NoError(s.repository.Save(ctx, &example.Entity{ID: "b-id", Email: "b@example.com", Name: "Deleted"}))
+	s.Require().NoError(s.repository.SoftDelete(ctx, "b-id"))
+
+	entities, err := s.repository.List(ctx)
+	s.Require().NoError(err)
+	s.Require().Len(entities, 2)
+	s.Equal("a-id", entities[0].ID)
+	s.Equal("c-id", entities[1].ID)
+	s.False(entities[0].UpdatedAt.IsZero())
+}
+
+func (s *RepositoryTestSuite) TestQueryTimeout() {
+	s.Require().NoError(s.repository.Save(context.Background(), &example.Entity{ID: "timeout-id", Email: "timeout@example.com", Name: "Timeout"}))
+
+	original := s.db.Config().Postgres.QueryTimeout
+	s.db.Config().Postgres.QueryTimeout = time.Nanosecond
+	defer func() { s.db.Config().Postgres.QueryTimeout = original }()
+
+	_, err := s.repository.GetByID(context.Background(), "timeout-id")
+	s.Require().ErrorIs(err, context.DeadlineExceeded)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	retrieved, err := s.repository.GetByID(ctx, "timeout-id")
+	s.Require().NoError(err)
+	s.Equal("timeout-id", retrieved.ID)
+}
+
+func (s *RepositoryTestSuite) TestGetByID_ValidateOnRead() {
+	_, err := s.db.Connection().ExecContext(context.Background(),
+		`INSERT INTO examples (id, email, name) VALUES ($1, $2, $3)`, "legacy-id", "not-an-email", "Legacy")
+	s.Require().NoError(err)
+
+	tests := []struct {
+		name        string
+		enabled     bool
+		expectWarns int
+	}{
+		{name: "disabled", enabled: false, expectWarns: 0},
+		{name: "enabled", enabled: true, expectWarns: 1},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.db.Config().ValidateOnRead = tt.enabled
+			defer func() { s.db.Config().ValidateOnRead = false }()
+			log := newRecordingLogger()
+			ctx := logger.WithLogger(context.Background(), log)
+
+			retrieved, err := s.repository.GetByID(ctx, "legacy-id")
+
+			s.Require().NoError(err)
+			s.Equal("not-an-email", retrieved.Email)
+			s.Len(log.warnings(), tt.expectWarns)
+		})
+	}
+}
+
 func (s *RepositoryTestSuite) TestSave_AlreadyExists() {
 	ctx := context.Background()
 	entity := &example.Entity{
@@ -130,6 +243,48 @@ func (s *RepositoryTestSuite) TestSave_AlreadyExists() {
 	s.Equal(entity.ID, alreadyExistsErr.ID)
 }
 
+func (s *RepositoryTestSuite) TestSaveBatch_NonAtomic() {
+	ctx := context.Background()
+	s.Require().NoError(s.repository.Save(ctx, &example.Entity{ID: "batch-existing", Email: "existing@example.com", Name: "Existing"}))
+
+	errs, err := s.repository.SaveBatch(ctx, []*example.Entity{
+		{ID: "batch-1", Email: "one@example.com", Name: "One"},
+		{ID: "batch-existing", Email: "dup@example.com", Name: "Dup"},
+		{ID: "batch-2", Email: "two@example.com", Name: "Two"},
+	}, false)
+
+	s.Require().NoError(err)
+	s.Require().Len(errs, 3)
+	s.NoError(errs[0])
+	var alreadyExistsErr *example.AlreadyExistsError
+	s.Require().ErrorAs(errs[1], &alreadyExistsErr)
+	s.Equal("batch-existing", alreadyExistsErr.ID)
+	s.NoError(errs[2])
+
+	_, err = s.repository.GetByID(ctx, "batch-1")
+	s.NoError(err)
+	_, err = s.repository.GetByID(ctx, "batch-2")
+	s.NoError(err)
+}
+
+func (s *RepositoryTestSuite) TestSaveBatch_Atomic() {
+	ctx := context.Background()
+	s.Require().NoError(s.repository.Save(ctx, &example.Entity{ID: "atomic-existing", Email: "existing@example.com", Name: "Existing"}))
+
+	errs, err := s.repository.SaveBatch(ctx, []*example.Entity{
+		{ID: "atomic-1", Email: "one@example.com", Name: "One"},
+		{ID: "atomic-existing", Email: "dup@example.com", Name: "Dup"},
+	}, true)
+
+	s.Require().NoError(err)
+	s.Require().Len(errs, 2)
+	var alreadyExistsErr *example.AlreadyExistsError
+	s.Require().ErrorAs(errs[1], &alreadyExistsErr)
+
+	_, err = s.repository.GetByID(ctx, "atomic-1")
+	s.ErrorIs(err, example.ErrEntityNotFound)
+}
+
 func (s *RepositoryTestSuite) TestSave_MaxLengthFields() {
 	ctx := context.Background()
 
@@ -188,6 +343,328 @@ func (s *RepositoryTestSuite) TestSave_SQLInjectionPrevention() {
 	s.GreaterOrEqual(count, 1)
 }
 
+func (s *RepositoryTestSuite) TestUpdate() {
+	ctx := context.Background()
+	entity := &example.Entity{
+		ID:    "update-id",
+		Email: "before@example.com",
+		Name:  "Before",
+	}
+	s.Require().NoError(s.repository.Save(ctx, entity))
+	s.False(entity.UpdatedAt.IsZero())
+	createdAt := entity.UpdatedAt
+
+	entity.Email = "after@example.com"
+	entity.Name = "After"
+	err := s.repository.Update(ctx, entity)
+	s.Require().NoError(err)
+	s.False(entity.UpdatedAt.Before(createdAt))
+
+	retrieved, err := s.repository.GetByID(ctx, entity.ID)
+	s.Require().NoError(err)
+	s.Equal("after@example.com", retrieved.Email)
+	s.Equal("After", retrieved.Name)
+	s.True(retrieved.UpdatedAt.Equal(entity.UpdatedAt))
+}
+
+func (s *RepositoryTestSuite) TestUpdate_NotFound() {
+	err := s.repository.Update(context.Background(), &example.Entity{
+		ID:    "missing-id",
+		Email: "missing@example.com",
+		Name:  "Missing",
+	})
+	s.True(errors.Is(err, example.ErrEntityNotFound))
+}
+
+func (s *RepositoryTestSuite) TestSoftDelete_HidesEntity() {
+	ctx := context.Background()
+	entity := &example.Entity{ID: "soft-id", Email: "soft@example.com", Name: "Soft"}
+	s.Require().NoError(s.repository.Save(ctx, entity))
+
+	s.Require().NoError(s.repository.SoftDelete(ctx, entity.ID))
+
+	_, err := s.repository.GetByID(ctx, entity.ID)
+	s.True(errors.Is(err, example.ErrEntityNotFound))
+	s.True(errors.Is(s.repository.Update(ctx, entity), example.ErrEntityNotFound))
+	s.True(errors.Is(s.repository.SoftDelete(ctx, entity.ID), example.ErrEntityNotFound))
+
+	retrieved, deletedAt, err := s.repository.GetByIDIncludingDeleted(ctx, entity.ID)
+	s.Require().NoError(err)
+	s.Equal("Soft", retrieved.Name)
+	s.NotNil(deletedAt)
+}
+
+func (s *RepositoryTestSuite) TestSoftDelete_RecreateRequiresRestoreOrDelete() {
+	ctx := context.Background()
+	entity := &example.Entity{ID: "recreate-id", Email: "recreate@example.com", Name: "Recreate"}
+	s.Require().NoError(s.repository.Save(ctx, entity))
+	s.Require().NoError(s.repository.SoftDelete(ctx, entity.ID))
+
+	var exists *example.AlreadyExistsError
+	s.ErrorAs(s.repository.Save(ctx, entity), &exists)
+
+	s.Require().NoError(s.repository.Restore(ctx, entity.ID))
+	_, err := s.repository.GetByID(ctx, entity.ID)
+	s.Require().NoError(err)
+	s.True(errors.Is(s.repository.Restore(ctx, entity.ID), example.ErrEntityNotDeleted))
+
+	s.Require().NoError(s.repository.SoftDelete(ctx, entity.ID))
+	s.Require().NoError(s.repository.Delete(ctx, entity.ID))
+	s.Require().NoError(s.repository.Save(ctx, entity))
+}
+
+func (s *RepositoryTestSuite) TestRestore_RoundTrip() {
+	ctx := context.Background()
+	entity := &example.Entity{ID: "restore-id", Email: "restore@example.com", Name: "Restore"}
+	s.Require().NoError(s.repository.Save(ctx, entity))
+
+	s.Require().NoError(s.repository.SoftDelete(ctx, entity.ID))
+	s.Require().NoError(s.repository.Restore(ctx, entity.ID))
+
+	retrieved, err := s.repository.GetByID(ctx, entity.ID)
+	s.Require().NoError(err)
+	s.Equal("restore@example.com", retrieved.Email)
+	s.Equal("Restore", retrieved.Name)
+	_, deletedAt, err := s.repository.GetByIDIncludingDeleted(ctx, entity.ID)
+	s.Require().NoError(err)
+	s.Nil(deletedAt)
+}
+
+func (s *RepositoryTestSuite) TestRestore_NotFound() {
+	err := s.repository.Restore(context.Background(), "missing-id")
+	s.True(errors.Is(err, example.ErrEntityNotFound))
+}
+
+func (s *RepositoryTestSuite) TestDelete_NotFound() {
+	err := s.repository.Delete(context.Background(), "missing-id")
+	s.True(errors.Is(err, example.ErrEntityNotFound))
+}
+
+func (s *RepositoryTestSuite) TestReset_ClearsData() {
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		err := s.repository.Save(ctx, &example.Entity{
+			ID:    fmt.Sprintf("reset-id-%d", i),
+			Email: fmt.Sprintf("reset%d@example.com", i),
+			Name:  "Reset User",
+		})
+		s.Require().NoError(err)
+	}
+
+	err := s.repository.Reset(ctx)
+	s.Require().NoError(err)
+
+	var count int
+	err = s.db.Connection().QueryRowContext(ctx, "SELECT COUNT(*) FROM examples").Scan(&count)
+	s.Require().NoError(err)
+	s.Equal(0, count)
+}
+
+func TestRepository_Reset_RefusedInProduction(t *testing.T) {
+	cfg := &config.DatabaseConfig{
+		BaseConfig: config.BaseConfig{Environment: config.EnvProduction},
+	}
+	repository := NewRepository(database.NewDatabaseLifecycle(cfg, logger.NewNop()))
+
+	err := repository.Reset(context.Background())
+
+	if !errors.Is(err, ErrResetNotAllowed) {
+		t.Fatalf("expected ErrResetNotAllowed, got %v", err)
+	}
+}
+
+type logEntry struct {
+	level  string
+	msg    string
+	fields map[string]interface{}
+}
+
+// recordingLogger records every entry together with the fields attached via
+// With. Loggers derived with With share the recorded entries.
+type recordingLogger struct {
+	fields  []logger.Field
+	entries *[]logEntry
+	mu      *sync.Mutex
+}
+
+func newRecordingLogger() *recordingLogger {
+	return &recordingLogger{entries: &[]logEntry{}, mu: &sync.Mutex{}}
+}
+
+func (l *recordingLogger) record(level, msg string, fields []logger.Field) {
+	entry := logEntry{level: level, msg: msg, fields: make(map[string]interface{})}
+	for _, f := range append(append([]logger.Field{}, l.fields...), fields...) {
+		entry.fields[f.Key] = f.Value
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	*l.entries = append(*l.entries, entry)
+}
+
+func (l *recordingLogger) Info(msg string, fields ...logger.Field)  { l.record("info", msg, fields) }
+func (l *recordingLogger) Error(msg string, fields ...logger.Field) { l.record("error", msg, fields) }
+func (l *recordingLogger) Debug(msg string, fields ...logger.Field) { l.record("debug", msg, fields) }
+func (l *recordingLogger) Warn(msg string, fields ...logger.Field)  { l.record("warn", msg, fields) }
+
+func (l *recordingLogger) With(fields ...logger.Field) logger.Logger {
+	return &recordingLogger{entries: l.entries, fields: append(append([]logger.Field{}, l.fields...), fields...), mu: l.mu}
+}
+
+func (l *recordingLogger) at(level string) []logEntry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var entries []logEntry
+	for _, entry := range *l.entries {
+		if entry.level == level {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+func (l *recordingLogger) warnings() []logEntry { return l.at("warn") }
+func (l *recordingLogger) debugs() []logEntry   { return l.at("debug") }
+
+func TestRepository_CheckOnRead(t *testing.T) {
+	tests := []struct {
+		name        string
+		enabled     bool
+		entity      example.Entity
+		expectWarns int
+	}{
+		{name: "disabled_invalid", entity: example.Entity{ID: "id", Email: "bad", Name: "Name"}},
+		{name: "enabled_valid", enabled: true, entity: example.Entity{ID: "id", Email: "ok@example.com", Name: "Name"}},
+		{name: "enabled_invalid", enabled: true, entity: example.Entity{ID: "id", Email: "bad", Name: "Name"}, expectWarns: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.DatabaseConfig{ValidateOnRead: tt.enabled}
+			repository := NewRepository(database.NewDatabaseLifecycle(cfg, logger.NewNop()))
+			log := newRecordingLogger()
+
+			repository.checkOnRead(logger.WithLogger(context.Background(), log), &tt.entity)
+
+			if len(log.warnings()) != tt.expectWarns {
+				t.Fatalf("expected %d warnings, got %v", tt.expectWarns, log.warnings())
+			}
+		})
+	}
+}
+
+// newMockRepository returns a repository configured with pgCfg on top of a
+// sqlmock connection.
+func newMockRepository(t *testing.T, pgCfg config.PostgresConfig) (*Repository, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	cfg := &config.DatabaseConfig{Postgres: pgCfg}
+	lifecycle := database.NewLifecycleWithConnection(cfg, logger.NewNop(), &platformPostgres.DB{DB: db})
+	return NewRepository(lifecycle), mock
+}
+
+func TestRepository_ContextEndsMidQuery(t *testing.T) {
+	entity := func() *example.Entity {
+		return &example.Entity{ID: "id", Email: "test@example.com", Name: "Name"}
+	}
+	operations := map[string]struct {
+		expect func(sqlmock.Sqlmock)
+		call   func(context.Context, *Repository) error
+	}{
+		"get_by_id": {
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT id, email, name, updated_at, deleted_at FROM examples").
+					WillDelayFor(time.Second).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "email", "name", "updated_at", "deleted_at"}))
+			},
+			call: func(ctx context.Context, r *Repository) error {
+				_, err := r.GetByID(ctx, "id")
+				return err
+			},
+		},
+		"list": {
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT id, email, name, updated_at FROM examples").
+					WillDelayFor(time.Second).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "email", "name", "updated_at"}))
+			},
+			call: func(ctx context.Context, r *Repository) error {
+				_, err := r.List(ctx)
+				return err
+			},
+		},
+		"save": {
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("INSERT INTO examples").
+					WillDelayFor(time.Second).
+					WillReturnRows(sqlmock.NewRows([]string{"updated_at"}))
+			},
+			call: func(ctx context.Context, r *Repository) error {
+				return r.Save(ctx, entity())
+			},
+		},
+	}
+	endings := map[string]struct {
+		newContext func() (context.Context, context.CancelFunc)
+		expected   error
+	}{
+		"cancelled": {
+			newContext: func() (context.Context, context.CancelFunc) {
+				ctx, cancel := context.WithCancel(context.Background())
+				time.AfterFunc(20*time.Millisecond, cancel)
+				return ctx, cancel
+			},
+			expected: context.Canceled,
+		},
+		"deadline_exceeded": {
+			newContext: func() (context.Context, context.CancelFunc) {
+				return context.WithTimeout(context.Background(), 20*time.Millisecond)
+			},
+			expected: context.DeadlineExceeded,
+		},
+	}
+
+	for opName, op := range operations {
+		for endName, end := range endings {
+			t.Run(opName+"_"+endName, func(t *testing.T) {
+				repository, mock := newMockRepository(t, config.PostgresConfig{})
+				op.expect(mock)
+				ctx, cancel := end.newContext()
+				defer cancel()
+
+				start := time.Now()
+				err := op.call(ctx, repository)
+
+				if !errors.Is(err, end.expected) {
+					t.Fatalf("expected %v, got %v", end.expected, err)
+				}
+				if time.Since(start) >= time.Second {
+					t.Fatalf("query was not interrupted")
+				}
+			})
+		}
+	}
+}
+
+func TestRepository_QueryErrorWithoutCancellation(t *testing.T) {
+	repository, mock := newMockRepository(t, config.PostgresConfig{})
+	queryErr := errors.New("connection reset")
+	mock.ExpectQuery("SELECT id, email, name, updated_at, deleted_at FROM examples").WillReturnError(queryErr)
+
+	_, err := repository.GetByID(context.Background(), "id")
+
+	if !errors.Is(err, queryErr) {
+		t.Fatalf("expected %v, got %v", queryErr, err)
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("unexpected context error in %v", err)
+	}
+}
+
 func TestRepositoryTestSuite(t *testing.T) {
 	suite.Run(t, new(RepositoryTestSuite))
 }
@@ -0,0 +1,118 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"microservice/internal/adapters/database"
+	"microservice/internal/core/ports"
+
+	"github.com/lib/pq"
+)
+
+// Outbox stores messages in the outbox table. Messages added with a context
+// from database.Lifecycle.InTx are committed or rolled back together with the
+// rest of that transaction.
+type Outbox struct {
+	db *database.Lifecycle
+}
+
+func NewOutbox(db *database.Lifecycle) *Outbox {
+	return &Outbox{db: db}
+}
+
+// Add stores message in the transaction carried by ctx, or on its own when
+// there is none. In in-memory mode the message is dropped, like the data it
+// describes.
+func (o *Outbox) Add(ctx context.Context, message ports.OutboxMessage) error {
+	if o.db.InMemory() {
+		return nil
+	}
+	conn := o.db.Connection()
+	if conn == nil {
+		return database.ErrNotConnected
+	}
+
+	_, err := database.Executor(ctx, conn.DB).ExecContext(ctx,
+		`INSERT INTO outbox (topic, key, payload) VALUES ($1, $2, $3)`,
+		message.Topic, message.Key, message.Payload,
+	)
+	if err != nil {
+		return fmt.Errorf("add outbox message: %w", err)
+	}
+	return nil
+}
+
+// PublishPending passes up to limit unpublished messages, oldest first, to
+// publish and marks the ones it accepted as published, in one transaction.
+// It stops at the first publish error, so the remaining messages keep their
+// order and are offered again on the next call. Rows are claimed with SKIP
+// LOCKED, so several replicas can drain the same table. It returns how many
+// messages were published.
+func (o *Outbox) PublishPending(ctx context.Context, limit int, publish func(context.Context, ports.OutboxMessage) error) (int, error) {
+	if o.db.InMemory() {
+		return 0, nil
+	}
+
+	var (
+		published  []int64
+		publishErr error
+	)
+	err := o.db.WithTx(ctx, func(tx *sql.Tx) error {
+		messages, err := pendingMessages(ctx, tx, limit)
+		if err != nil {
+			return err
+		}
+
+		for _, message := range messages {
+			if err := publish(ctx, message); err != nil {
+				publishErr = fmt.Errorf("publish outbox message %d: %w", message.ID, err)
+				break
+			}
+			published = append(published, message.ID)
+		}
+		if len(published) == 0 {
+			return nil
+		}
+
+		_, err = tx.ExecContext(ctx,
+			`UPDATE outbox SET published_at = CURRENT_TIMESTAMP WHERE id = ANY($1)`,
+			pq.Array(published),
+		)
+		if err != nil {
+			return fmt.Errorf("mark outbox messages published: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		// Nothing was marked, so every message is offered again.
+		return 0, errors.Join(publishErr, err)
+	}
+	return len(published), publishErr
+}
+
+func pendingMessages(ctx context.Context, tx *sql.Tx, limit int) ([]ports.OutboxMessage, error) {
+	rows, err := tx.QueryContext(ctx,
+		`SELECT id, topic, key, payload FROM outbox WHERE published_at IS NULL ORDER BY id LIMIT $1 FOR UPDATE SKIP LOCKED`,
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("select outbox messages: %w", err)
+	}
+	defer rows.Close()
+
+	var messages []ports.OutboxMessage
+	for rows.Next() {
+		var message ports.OutboxMessage
+		if err := rows.Scan(&message.ID, &message.Topic, &message.Key, &message.Payload); err != nil {
+			return nil, fmt.Errorf("scan outbox message: %w", err)
+		}
+		messages = append(messages, message)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("select outbox messages: %w", err)
+	}
+	return messages, nil
+}
@@ -0,0 +1,113 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"microservice/internal/config"
+	"microservice/internal/core/domain/example"
+	"microservice/internal/core/ports"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMockOutbox(t *testing.T) (*Outbox, sqlmock.Sqlmock) {
+	t.Helper()
+	repository, mock := newMockRepository(t, config.PostgresConfig{})
+	return NewOutbox(repository.db), mock
+}
+
+func TestOutbox_AddJoinsTransactionFromContext(t *testing.T) {
+	repository, mock := newMockRepository(t, config.PostgresConfig{})
+	outbox := NewOutbox(repository.db)
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO examples").
+		WillReturnRows(sqlmock.NewRows([]string{"updated_at"}).AddRow(time.Now()))
+	mock.ExpectExec("INSERT INTO outbox").WithArgs("example.created", "id", []byte(`{"id":"id"}`)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectRollback()
+	writeErr := errors.New("later write failed")
+
+	err := repository.db.InTx(context.Background(), func(ctx context.Context) error {
+		require.NoError(t, repository.Save(ctx, &example.Entity{ID: "id", Email: "id@example.com", Name: "Name"}))
+		require.NoError(t, outbox.Add(ctx, ports.OutboxMessage{Topic: "example.created", Key: "id", Payload: []byte(`{"id":"id"}`)}))
+		return writeErr
+	})
+
+	assert.ErrorIs(t, err, writeErr)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOutbox_PublishPendingMarksPublishedMessages(t *testing.T) {
+	outbox, mock := newMockOutbox(t)
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT id, topic, key, payload FROM outbox WHERE published_at IS NULL ORDER BY id LIMIT \$1 FOR UPDATE SKIP LOCKED`).
+		WithArgs(10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "topic", "key", "payload"}).
+			AddRow(int64(1), "example.created", "a", []byte("1")).
+			AddRow(int64(2), "example.updated", "a", []byte("2")))
+	mock.ExpectExec(`UPDATE outbox SET published_at = CURRENT_TIMESTAMP WHERE id = ANY\(\$1\)`).
+		WithArgs(pq.Array([]int64{1, 2})).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	var topics []string
+	published, err := outbox.PublishPending(context.Background(), 10, func(_ context.Context, message ports.OutboxMessage) error {
+		topics = append(topics, message.Topic)
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, published)
+	assert.Equal(t, []string{"example.created", "example.updated"}, topics)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOutbox_PublishPendingKeepsMessagesAfterFailure(t *testing.T) {
+	outbox, mock := newMockOutbox(t)
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id, topic, key, payload FROM outbox").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "topic", "key", "payload"}).
+			AddRow(int64(1), "example.created", "a", []byte("1")).
+			AddRow(int64(2), "example.created", "b", []byte("2")).
+			AddRow(int64(3), "example.created", "c", []byte("3")))
+	mock.ExpectExec("UPDATE outbox SET published_at").
+		WithArgs(pq.Array([]int64{1})).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	brokerErr := errors.New("broker unavailable")
+
+	published, err := outbox.PublishPending(context.Background(), 10, func(_ context.Context, message ports.OutboxMessage) error {
+		if message.ID == 2 {
+			return brokerErr
+		}
+		return nil
+	})
+
+	assert.ErrorIs(t, err, brokerErr)
+	assert.Equal(t, 1, published, "only the message before the failure is marked")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOutbox_PublishPendingRollsBackWhenMarkingFails(t *testing.T) {
+	outbox, mock := newMockOutbox(t)
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id, topic, key, payload FROM outbox").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "topic", "key", "payload"}).
+			AddRow(int64(1), "example.created", "a", []byte("1")))
+	mock.ExpectExec("UPDATE outbox SET published_at").WillReturnError(errors.New("connection reset"))
+	mock.ExpectRollback()
+
+	published, err := outbox.PublishPending(context.Background(), 10, func(context.Context, ports.OutboxMessage) error {
+		return nil
+	})
+
+	assert.Error(t, err)
+	assert.Zero(t, published, "unmarked messages are published again")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,38 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"microservice/internal/platform/logger"
+	"microservice/internal/platform/servertiming"
+)
+
+// logQuery reports an operation started at start that affected *rows rows.
+// It warns when the operation ran longer than the configured threshold and,
+// with POSTGRES_LOG_QUERIES set, logs every operation at debug level. Both go
+// through the context logger, so they carry the request_id and fields of the
+// HTTP request that caused them. Only the operation name is logged, never the
+// SQL or its arguments, which may hold personal data. Every operation's
+// duration is also added to the request's db server timing.
+func (r *Repository) logQuery(ctx context.Context, operation string, start time.Time, rows *int64) {
+	cfg := r.db.Config().Postgres
+	elapsed := time.Since(start)
+	servertiming.Add(ctx, "db", elapsed)
+
+	slow := cfg.SlowQueryThreshold > 0 && elapsed >= cfg.SlowQueryThreshold
+	if !slow && !cfg.LogQueries {
+		return
+	}
+
+	fields := []logger.Field{
+		logger.String("operation", operation),
+		logger.String("duration", elapsed.String()),
+		logger.Int("rows", int(*rows)),
+	}
+	if slow {
+		logger.FromContext(ctx).Warn("Slow query", append(fields, logger.String("threshold", cfg.SlowQueryThreshold.String()))...)
+		return
+	}
+	logger.FromContext(ctx).Debug("Query finished", fields...)
+}
@@ -0,0 +1,125 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"microservice/internal/config"
+	exampleDomain "microservice/internal/core/domain/example"
+	exampleUseCase "microservice/internal/core/usecase/example"
+	"microservice/internal/platform/logger"
+	platformMiddleware "microservice/internal/platform/middleware"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_LogQuery_SlowQueryWarns(t *testing.T) {
+	tests := []struct {
+		name      string
+		threshold time.Duration
+		elapsed   time.Duration
+		expectLog bool
+	}{
+		{name: "disabled", threshold: 0, elapsed: time.Hour},
+		{name: "fast", threshold: time.Second, elapsed: time.Millisecond},
+		{name: "slow", threshold: time.Second, elapsed: 2 * time.Second, expectLog: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repository, _ := newMockRepository(t, config.PostgresConfig{SlowQueryThreshold: tt.threshold})
+			log := newRecordingLogger()
+
+			rows := int64(1)
+			repository.logQuery(logger.WithLogger(context.Background(), log), "get_by_id", time.Now().Add(-tt.elapsed), &rows)
+
+			if !tt.expectLog {
+				assert.Empty(t, log.warnings())
+				return
+			}
+			require.Len(t, log.warnings(), 1)
+			warning := log.warnings()[0]
+			assert.Equal(t, "Slow query", warning.msg)
+			assert.Equal(t, "get_by_id", warning.fields["operation"])
+			assert.Equal(t, tt.threshold.String(), warning.fields["threshold"])
+			assert.Equal(t, 1, warning.fields["rows"])
+		})
+	}
+}
+
+func TestRepository_LogQuery_Debug(t *testing.T) {
+	repository, mock := newMockRepository(t, config.PostgresConfig{LogQueries: true})
+	mock.ExpectQuery("UPDATE examples SET deleted_at = CURRENT_TIMESTAMP").
+		WithArgs("secret@example.com").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("secret@example.com"))
+	log := newRecordingLogger()
+
+	require.NoError(t, repository.SoftDelete(logger.WithLogger(context.Background(), log), "secret@example.com"))
+
+	assert.Empty(t, log.warnings())
+	require.Len(t, log.debugs(), 1)
+	entry := log.debugs()[0]
+	assert.Equal(t, "Query finished", entry.msg)
+	assert.Equal(t, "soft_delete", entry.fields["operation"])
+	assert.Equal(t, 1, entry.fields["rows"])
+	assert.NotEmpty(t, entry.fields["duration"])
+	for _, value := range entry.fields {
+		assert.NotContains(t, fmt.Sprint(value), "secret@example.com", "arguments are never logged")
+		assert.NotContains(t, fmt.Sprint(value), "UPDATE", "SQL is never logged")
+	}
+}
+
+func TestRepository_LogQuery_DisabledByDefault(t *testing.T) {
+	repository, _ := newMockRepository(t, config.PostgresConfig{})
+	log := newRecordingLogger()
+	rows := int64(0)
+
+	repository.logQuery(logger.WithLogger(context.Background(), log), "get_by_id", time.Now(), &rows)
+
+	assert.Empty(t, log.warnings())
+	assert.Empty(t, log.debugs())
+}
+
+// A warning logged deep in the repository must carry the request ID set by
+// the HTTP middleware, with the usecase passing the request context through.
+func TestRepository_SlowQueryLogCarriesRequestID(t *testing.T) {
+	repository, mock := newMockRepository(t, config.PostgresConfig{SlowQueryThreshold: 5 * time.Millisecond})
+	mock.ExpectQuery("SELECT id, email, name, updated_at, deleted_at FROM examples").
+		WithArgs("slow-id").
+		WillDelayFor(20 * time.Millisecond).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "email", "name", "updated_at", "deleted_at"}).
+			AddRow("slow-id", "slow@example.com", "Slow", time.Now(), nil))
+
+	usecase := exampleUseCase.NewUsecase(repository, exampleDomain.NewService())
+	log := newRecordingLogger()
+
+	router := chi.NewRouter()
+	router.Use(middleware.RequestID)
+	router.Use(platformMiddleware.RequestLogger(log, platformMiddleware.RequestLoggerConfig{}))
+	router.Get("/examples/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if _, err := usecase.GetEntity(r.Context(), chi.URLParam(r, "id")); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/examples/slow-id", nil)
+	req.Header.Set(middleware.RequestIDHeader, "req-slow-123")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, mock.ExpectationsWereMet())
+	require.Len(t, log.warnings(), 1)
+	warning := log.warnings()[0]
+	assert.Equal(t, "Slow query", warning.msg)
+	assert.Equal(t, "req-slow-123", warning.fields["request_id"])
+	assert.Equal(t, "get_by_id", warning.fields["operation"])
+}
@@ -0,0 +1,89 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+)
+
+var errStatementClosed = errors.New("prepared statement closed")
+
+// statementCache prepares each query once per connection and reuses the
+// statement across calls. When Lifecycle.Start replaces the connection, the
+// new *sql.DB no longer matches, so the old statements are closed and the
+// queries are prepared again against the new one.
+type statementCache struct {
+	mu    sync.Mutex
+	db    *sql.DB
+	stmts map[string]*cachedStatement
+}
+
+type cachedStatement struct {
+	once sync.Once
+	stmt *sql.Stmt
+	err  error
+}
+
+// prepare returns the cached statement for query on db, preparing it on first
+// use. A failed prepare is not cached, so the next call tries again.
+func (c *statementCache) prepare(ctx context.Context, db *sql.DB, query string) (*sql.Stmt, error) {
+	c.mu.Lock()
+	if c.db != db {
+		_ = c.closeLocked()
+		c.db = db
+	}
+	if c.stmts == nil {
+		c.stmts = make(map[string]*cachedStatement)
+	}
+	cached, ok := c.stmts[query]
+	if !ok {
+		cached = &cachedStatement{}
+		c.stmts[query] = cached
+	}
+	c.mu.Unlock()
+
+	cached.once.Do(func() {
+		cached.stmt, cached.err = db.PrepareContext(ctx, query)
+	})
+	if cached.err != nil {
+		c.mu.Lock()
+		if c.stmts[query] == cached {
+			delete(c.stmts, query)
+		}
+		c.mu.Unlock()
+		return nil, cached.err
+	}
+	return cached.stmt, nil
+}
+
+// queryRow runs query through its prepared statement, falling back to a plain
+// query when the statement cannot be prepared.
+func (c *statementCache) queryRow(ctx context.Context, db *sql.DB, query string, args ...any) *sql.Row {
+	stmt, err := c.prepare(ctx, db, query)
+	if err != nil {
+		return db.QueryRowContext(ctx, query, args...)
+	}
+	return stmt.QueryRowContext(ctx, args...)
+}
+
+func (c *statementCache) close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.closeLocked()
+}
+
+// closeLocked closes every cached statement, waiting for any prepare still in
+// flight. Callers must hold the lock.
+func (c *statementCache) closeLocked() error {
+	var errs []error
+	for _, cached := range c.stmts {
+		cached.once.Do(func() { cached.err = errStatementClosed })
+		if cached.stmt != nil {
+			errs = append(errs, cached.stmt.Close())
+		}
+	}
+	c.stmts = nil
+	c.db = nil
+	return errors.Join(errs...)
+}
@@ -0,0 +1,168 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"microservice/internal/adapters/database"
+	"microservice/internal/config"
+	"microservice/internal/core/domain/example"
+	"microservice/internal/platform/logger"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const getByIDQuery = `SELECT id, email, name, updated_at, deleted_at FROM examples WHERE id = $1`
+
+func entityRows(id string) *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"id", "email", "name", "updated_at", "deleted_at"}).
+		AddRow(id, id+"@example.com", "Name", time.Now(), nil)
+}
+
+func TestRepository_PreparesStatementsOnce(t *testing.T) {
+	repository, mock := newMockRepository(t, config.PostgresConfig{})
+	prepared := mock.ExpectPrepare("SELECT id, email, name, updated_at, deleted_at FROM examples")
+	prepared.ExpectQuery().WithArgs("first").WillReturnRows(entityRows("first"))
+	prepared.ExpectQuery().WithArgs("second").WillReturnRows(entityRows("second"))
+	update := mock.ExpectPrepare("UPDATE examples")
+	update.ExpectQuery().WithArgs("first", "new@example.com", "New").
+		WillReturnRows(sqlmock.NewRows([]string{"updated_at"}).AddRow(time.Now()))
+
+	_, err := repository.GetByID(context.Background(), "first")
+	require.NoError(t, err)
+	_, err = repository.GetByID(context.Background(), "second")
+	require.NoError(t, err)
+	require.NoError(t, repository.Update(context.Background(), &example.Entity{ID: "first", Email: "new@example.com", Name: "New"}))
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_PrepareFailureFallsBackAndRetries(t *testing.T) {
+	repository, mock := newMockRepository(t, config.PostgresConfig{})
+	mock.ExpectPrepare("SELECT id, email, name, updated_at, deleted_at FROM examples").WillReturnError(errors.New("prepare failed"))
+	mock.ExpectQuery("SELECT id, email, name, updated_at, deleted_at FROM examples").WithArgs("first").WillReturnRows(entityRows("first"))
+	mock.ExpectPrepare("SELECT id, email, name, updated_at, deleted_at FROM examples").
+		ExpectQuery().WithArgs("second").WillReturnRows(entityRows("second"))
+
+	entity, err := repository.GetByID(context.Background(), "first")
+	require.NoError(t, err)
+	assert.Equal(t, "first", entity.ID)
+	entity, err = repository.GetByID(context.Background(), "second")
+	require.NoError(t, err)
+	assert.Equal(t, "second", entity.ID)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_CloseReleasesStatements(t *testing.T) {
+	repository, mock := newMockRepository(t, config.PostgresConfig{})
+	prepared := mock.ExpectPrepare("SELECT id, email, name, updated_at, deleted_at FROM examples").WillBeClosed()
+	prepared.ExpectQuery().WithArgs("id").WillReturnRows(entityRows("id"))
+
+	_, err := repository.GetByID(context.Background(), "id")
+	require.NoError(t, err)
+	require.NoError(t, repository.Close())
+	require.NoError(t, repository.Close())
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStatementCache_ReplacedConnectionIsPreparedAgain(t *testing.T) {
+	oldDB, oldMock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = oldDB.Close() })
+	newDB, newMock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = newDB.Close() })
+
+	oldMock.ExpectPrepare("SELECT").WillBeClosed().ExpectQuery().WillReturnRows(entityRows("id"))
+	newMock.ExpectPrepare("SELECT").ExpectQuery().WillReturnRows(entityRows("id"))
+
+	var cache statementCache
+	var id string
+	require.NoError(t, cache.queryRow(context.Background(), oldDB, getByIDQuery, "id").Scan(&id, new(string), new(string), new(time.Time), new(sql.NullTime)))
+	require.NoError(t, cache.queryRow(context.Background(), newDB, getByIDQuery, "id").Scan(&id, new(string), new(string), new(time.Time), new(sql.NullTime)))
+
+	assert.NoError(t, oldMock.ExpectationsWereMet())
+	assert.NoError(t, newMock.ExpectationsWereMet())
+}
+
+// A Lifecycle restart swaps the underlying *sql.DB; statements prepared on the
+// old connection must not be reused.
+func (s *RepositoryTestSuite) TestPreparedStatementsSurviveRestart() {
+	ctx := context.Background()
+	entity := &example.Entity{ID: "restart", Email: "restart@example.com", Name: "Restart"}
+	s.Require().NoError(s.repository.Save(ctx, entity))
+	_, err := s.repository.GetByID(ctx, entity.ID)
+	s.Require().NoError(err)
+
+	s.Require().NoError(s.db.Stop(ctx))
+	s.Require().NoError(s.db.Start(ctx))
+
+	found, err := s.repository.GetByID(ctx, entity.ID)
+	s.Require().NoError(err)
+	s.Assert().Equal(entity.Email, found.Email)
+	s.Require().NoError(s.repository.Update(ctx, entity))
+}
+
+// BenchmarkRepository_GetByID compares the cached prepared statement with
+// re-parsing the query on every call. It needs Docker for the database.
+func BenchmarkRepository_GetByID(b *testing.B) {
+	ctx := context.Background()
+	pg, err := postgres.Run(ctx,
+		"postgres:15.3-alpine",
+		postgres.WithDatabase("bench-db"),
+		postgres.WithUsername("postgres"),
+		postgres.WithPassword("postgres"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(30*time.Second)),
+	)
+	if err != nil {
+		b.Skipf("postgres container unavailable: %v", err)
+	}
+	b.Cleanup(func() { _ = pg.Terminate(ctx) })
+
+	host, err := pg.Host(ctx)
+	require.NoError(b, err)
+	port, err := pg.MappedPort(ctx, "5432")
+	require.NoError(b, err)
+
+	cfg := &config.DatabaseConfig{Postgres: config.PostgresConfig{
+		Host: host, Port: port.Int(), User: "postgres", Password: "postgres", Database: "bench-db", SSLMode: "disable",
+	}}
+	db := database.NewDatabaseLifecycle(cfg, logger.NewNop())
+	require.NoError(b, db.Start(ctx))
+	b.Cleanup(func() { _ = db.Stop(ctx) })
+
+	repository := NewRepository(db)
+	b.Cleanup(func() { _ = repository.Close() })
+	require.NoError(b, repository.CreateTable(ctx))
+	require.NoError(b, repository.Save(ctx, &example.Entity{ID: "bench", Email: "bench@example.com", Name: "Bench"}))
+
+	b.Run("prepared", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := repository.GetByID(ctx, "bench"); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("reparsed", func(b *testing.B) {
+		conn := db.ReadConnection()
+		for i := 0; i < b.N; i++ {
+			var entity example.Entity
+			if err := conn.QueryRowContext(ctx, getByIDQuery, "bench").Scan(&entity.ID, &entity.Email, &entity.Name, &entity.UpdatedAt, new(sql.NullTime)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,27 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// withQueryTimeout bounds ctx by timeout when the caller has not set a
+// deadline, so a slow query cannot run unbounded. Existing deadlines are kept
+// as they are and a non-positive timeout disables the safeguard.
+func withQueryTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok || timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// contextError marks err with the context error when ctx ended while the query
+// ran, so callers can tell an abandoned request from a database failure with
+// errors.Is(err, context.Canceled) or context.DeadlineExceeded.
+func contextError(ctx context.Context, err error) error {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return fmt.Errorf("%w: %w", ctxErr, err)
+	}
+	return err
+}
@@ -0,0 +1,76 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"microservice/internal/adapters/database"
+	"microservice/internal/config"
+	"microservice/internal/platform/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithQueryTimeout_AppliesTimeoutWithoutDeadline(t *testing.T) {
+	start := time.Now()
+
+	ctx, cancel := withQueryTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	deadline, ok := ctx.Deadline()
+	require.True(t, ok)
+	assert.WithinDuration(t, start.Add(2*time.Second), deadline, 100*time.Millisecond)
+}
+
+func TestWithQueryTimeout_PreservesExistingDeadline(t *testing.T) {
+	tests := []struct {
+		name   string
+		parent time.Duration
+	}{
+		{name: "shorter", parent: 100 * time.Millisecond},
+		{name: "longer", parent: time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parent, parentCancel := context.WithTimeout(context.Background(), tt.parent)
+			defer parentCancel()
+			want, _ := parent.Deadline()
+
+			ctx, cancel := withQueryTimeout(parent, 2*time.Second)
+			defer cancel()
+
+			deadline, ok := ctx.Deadline()
+			require.True(t, ok)
+			assert.Equal(t, want, deadline)
+		})
+	}
+}
+
+func TestWithQueryTimeout_DisabledWhenNotPositive(t *testing.T) {
+	for _, timeout := range []time.Duration{0, -time.Second} {
+		ctx, cancel := withQueryTimeout(context.Background(), timeout)
+		cancel()
+
+		_, ok := ctx.Deadline()
+		assert.False(t, ok)
+		assert.NoError(t, ctx.Err())
+	}
+}
+
+func TestRepository_QueryContextUsesConfiguredTimeout(t *testing.T) {
+	cfg := &config.DatabaseConfig{
+		Postgres: config.PostgresConfig{QueryTimeout: 3 * time.Second},
+	}
+	repository := NewRepository(database.NewDatabaseLifecycle(cfg, logger.NewNop()))
+	start := time.Now()
+
+	ctx, cancel := repository.queryContext(context.Background())
+	defer cancel()
+
+	deadline, ok := ctx.Deadline()
+	require.True(t, ok)
+	assert.WithinDuration(t, start.Add(3*time.Second), deadline, 100*time.Millisecond)
+}
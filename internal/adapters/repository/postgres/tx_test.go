@@ -0,0 +1,103 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"microservice/internal/config"
+	"microservice/internal/core/domain/example"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_JoinsTransactionFromContext(t *testing.T) {
+	repository, mock := newMockRepository(t, config.PostgresConfig{})
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO examples").WithArgs("first", "first@example.com", "First").
+		WillReturnRows(sqlmock.NewRows([]string{"updated_at"}).AddRow(time.Now()))
+	mock.ExpectQuery("UPDATE examples").WithArgs("first", "second@example.com", "First").
+		WillReturnRows(sqlmock.NewRows([]string{"updated_at"}).AddRow(time.Now()))
+	mock.ExpectRollback()
+	auditErr := errors.New("audit write failed")
+
+	err := repository.db.InTx(context.Background(), func(ctx context.Context) error {
+		entity := &example.Entity{ID: "first", Email: "first@example.com", Name: "First"}
+		require.NoError(t, repository.Save(ctx, entity))
+		entity.Email = "second@example.com"
+		require.NoError(t, repository.Update(ctx, entity))
+		return auditErr
+	})
+
+	assert.ErrorIs(t, err, auditErr)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func (s *RepositoryTestSuite) TestInTx_RollbackLeavesNoRows() {
+	ctx := context.Background()
+	auditErr := errors.New("audit write failed")
+
+	err := s.db.InTx(ctx, func(ctx context.Context) error {
+		s.Require().NoError(s.repository.Save(ctx, &example.Entity{ID: "tx-1", Email: "tx1@example.com", Name: "One"}))
+		errs, err := s.repository.SaveBatch(ctx, []*example.Entity{
+			{ID: "tx-2", Email: "tx2@example.com", Name: "Two"},
+			{ID: "tx-3", Email: "tx3@example.com", Name: "Three"},
+		}, true)
+		s.Require().NoError(err)
+		s.Require().Equal([]error{nil, nil}, errs)
+
+		found, err := s.repository.GetByID(ctx, "tx-2")
+		s.Require().NoError(err, "reads inside the transaction see its writes")
+		s.Assert().Equal("tx2@example.com", found.Email)
+		return auditErr
+	})
+	s.Require().ErrorIs(err, auditErr)
+
+	for _, id := range []string{"tx-1", "tx-2", "tx-3"} {
+		_, err := s.repository.GetByID(ctx, id)
+		s.Assert().ErrorIs(err, example.ErrEntityNotFound, id)
+	}
+}
+
+func (s *RepositoryTestSuite) TestInTx_CommitsAllWrites() {
+	ctx := context.Background()
+
+	err := s.db.InTx(ctx, func(ctx context.Context) error {
+		if err := s.repository.Save(ctx, &example.Entity{ID: "tx-a", Email: "a@example.com", Name: "A"}); err != nil {
+			return err
+		}
+		return s.repository.Save(ctx, &example.Entity{ID: "tx-b", Email: "b@example.com", Name: "B"})
+	})
+	s.Require().NoError(err)
+
+	for _, id := range []string{"tx-a", "tx-b"} {
+		_, err := s.repository.GetByID(ctx, id)
+		s.Assert().NoError(err, id)
+	}
+}
+
+// An atomic batch that fails inside a caller's transaction is undone on its
+// own, leaving earlier writes in that transaction intact.
+func (s *RepositoryTestSuite) TestInTx_AtomicBatchFailureKeepsTransactionUsable() {
+	ctx := context.Background()
+
+	err := s.db.InTx(ctx, func(ctx context.Context) error {
+		s.Require().NoError(s.repository.Save(ctx, &example.Entity{ID: "tx-kept", Email: "kept@example.com", Name: "Kept"}))
+		errs, err := s.repository.SaveBatch(ctx, []*example.Entity{
+			{ID: "tx-new", Email: "new@example.com", Name: "New"},
+			{ID: "tx-kept", Email: "dup@example.com", Name: "Duplicate"},
+		}, true)
+		s.Require().NoError(err)
+		s.Require().Error(errs[1])
+		return nil
+	})
+	s.Require().NoError(err)
+
+	_, err = s.repository.GetByID(ctx, "tx-kept")
+	s.Assert().NoError(err)
+	_, err = s.repository.GetByID(ctx, "tx-new")
+	s.Assert().ErrorIs(err, example.ErrEntityNotFound)
+}
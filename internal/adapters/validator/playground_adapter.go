@@ -4,30 +4,70 @@ import (
 	"errors"
 	"fmt"
 	validatorPLatform "microservice/internal/platform/validator"
+	"reflect"
 	"strings"
 
 	"github.com/go-playground/validator/v10"
 )
 
-type playgroundValidator struct {
+type PlaygroundValidator struct {
 	validate *validator.Validate
+	messages map[string]string
 }
 
-func NewPlaygroundAdapter() validatorPLatform.Validator {
-	return &playgroundValidator{
-		validate: validator.New(),
+func NewPlaygroundAdapter() *PlaygroundValidator {
+	validate := validator.New()
+	validate.RegisterTagNameFunc(jsonFieldName)
+
+	return &PlaygroundValidator{
+		validate: validate,
+		messages: make(map[string]string),
+	}
+}
+
+// jsonFieldName names fields after their json tag so errors point at the keys
+// the client sent. Fields without a usable tag, including json:"-", fall back
+// to the lowercased Go field name.
+func jsonFieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" || name == "-" {
+		return strings.ToLower(field.Name)
+	}
+	return name
+}
+
+// fieldPath is the dotted path of the failing field from the validated
+// struct, such as address.city or items[0].name.
+func fieldPath(e validator.FieldError) string {
+	_, path, ok := strings.Cut(e.Namespace(), ".")
+	if !ok {
+		return e.Field()
 	}
+	return path
 }
 
-func (v *playgroundValidator) Validate(s interface{}) error {
+// RegisterValidation adds a custom tag, such as a domain rule, that struct
+// fields can then use. Like RegisterTranslation it must be called before the
+// validator is used.
+func (v *PlaygroundValidator) RegisterValidation(tag string, fn validator.Func) error {
+	return v.validate.RegisterValidation(tag, fn)
+}
+
+// RegisterTranslation sets the message reported for fields failing tag. It
+// also replaces the default message of a built-in tag.
+func (v *PlaygroundValidator) RegisterTranslation(tag, msg string) {
+	v.messages[tag] = msg
+}
+
+func (v *PlaygroundValidator) Validate(s interface{}) error {
 	if err := v.validate.Struct(s); err != nil {
 		var validationErrors validator.ValidationErrors
 		if errors.As(err, &validationErrors) {
 			outErrors := make([]validatorPLatform.FieldError, len(validationErrors))
 			for i, fe := range validationErrors {
 				outErrors[i] = validatorPLatform.FieldError{
-					Field:   strings.ToLower(fe.Field()),
-					Message: getValidationErrorMessage(fe),
+					Field:   fieldPath(fe),
+					Message: v.message(fe),
 				}
 			}
 			return validatorPLatform.ValidationError{Errors: outErrors}
@@ -37,6 +77,13 @@ func (v *playgroundValidator) Validate(s interface{}) error {
 	return nil
 }
 
+func (v *PlaygroundValidator) message(e validator.FieldError) string {
+	if msg, ok := v.messages[e.Tag()]; ok {
+		return msg
+	}
+	return getValidationErrorMessage(e)
+}
+
 func getValidationErrorMessage(e validator.FieldError) string {
 	switch e.Tag() {
 	case "required":
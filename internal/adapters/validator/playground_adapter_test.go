@@ -2,8 +2,10 @@ package validator
 
 import (
 	"errors"
+	"strings"
 	"testing"
 
+	playground "github.com/go-playground/validator/v10"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -229,3 +231,90 @@ func BenchmarkPlaygroundValidator_Validate_WithErrors(b *testing.B) {
 		_ = validator.Validate(user)
 	}
 }
+
+type TestAccount struct {
+	Name string `validate:"required,reserved_name"`
+}
+
+func TestPlaygroundValidator_RegisterValidation(t *testing.T) {
+	validator := NewPlaygroundAdapter()
+	require.NoError(t, validator.RegisterValidation("reserved_name", func(fl playground.FieldLevel) bool {
+		return !strings.EqualFold(fl.Field().String(), "admin")
+	}))
+	validator.RegisterTranslation("reserved_name", "This name is reserved")
+
+	require.NoError(t, validator.Validate(TestAccount{Name: "John"}))
+
+	err := validator.Validate(TestAccount{Name: "Admin"})
+
+	var validationErr validatorPlatform.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	require.Len(t, validationErr.Errors, 1)
+	assert.Equal(t, "name", validationErr.Errors[0].Field)
+	assert.Equal(t, "This name is reserved", validationErr.Errors[0].Message)
+}
+
+func TestPlaygroundValidator_RegisterValidation_WithoutTranslation(t *testing.T) {
+	validator := NewPlaygroundAdapter()
+	require.NoError(t, validator.RegisterValidation("reserved_name", func(fl playground.FieldLevel) bool {
+		return false
+	}))
+
+	err := validator.Validate(TestAccount{Name: "John"})
+
+	var validationErr validatorPlatform.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "This field failed on the 'reserved_name' tag", validationErr.Errors[0].Message)
+}
+
+func TestPlaygroundValidator_RegisterValidation_InvalidTag(t *testing.T) {
+	validator := NewPlaygroundAdapter()
+
+	err := validator.RegisterValidation("", func(playground.FieldLevel) bool { return true })
+
+	assert.Error(t, err)
+}
+
+func TestPlaygroundValidator_RegisterTranslation_OverridesDefault(t *testing.T) {
+	validator := NewPlaygroundAdapter()
+	validator.RegisterTranslation("required", "Please fill in this field")
+
+	err := validator.Validate(TestUser{Email: "john@example.com", Age: 25})
+
+	var validationErr validatorPlatform.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "Please fill in this field", validationErr.Errors[0].Message)
+}
+
+type TestAddress struct {
+	City   string `json:"city" validate:"required"`
+	Street string `validate:"required"`
+}
+
+type TestProfile struct {
+	DisplayName string        `json:"displayName,omitempty" validate:"required"`
+	Secret      string        `json:"-" validate:"required"`
+	Address     TestAddress   `json:"home_address"`
+	Aliases     []TestAddress `json:"aliases" validate:"dive"`
+}
+
+func TestPlaygroundValidator_Validate_JSONFieldNames(t *testing.T) {
+	validator := NewPlaygroundAdapter()
+
+	err := validator.Validate(TestProfile{Aliases: []TestAddress{{City: "Oslo"}}})
+
+	var validationErr validatorPlatform.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+
+	fields := make([]string, len(validationErr.Errors))
+	for i, fieldErr := range validationErr.Errors {
+		fields[i] = fieldErr.Field
+	}
+	assert.Equal(t, []string{
+		"displayName",
+		"secret",
+		"home_address.city",
+		"home_address.street",
+		"aliases[0].street",
+	}, fields)
+}
@@ -0,0 +1,116 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"go.uber.org/fx"
+)
+
+// service is a component with its own start and stop hooks.
+type service interface {
+	Start(ctx context.Context) error
+	Stop(ctx context.Context) error
+}
+
+// component is one step in the ordered lifecycle. Either hook may be nil.
+type component struct {
+	name  string
+	start func(context.Context) error
+	stop  func(context.Context) error
+}
+
+func serviceComponent(name string, s service) component {
+	return component{name: name, start: s.Start, stop: s.Stop}
+}
+
+// serviceComponents lists the long-lived components in start order. They stop
+// in reverse, so the HTTP servers drain before the outbox relay, the statement
+// cache and the database connection are released. relay and admin are nil
+// when the outbox and the admin listener are disabled.
+func serviceComponents(db, dbStats service, repo io.Closer, relay, srv, admin service) []component {
+	components := []component{
+		serviceComponent("database", db),
+		{name: "repository", stop: func(context.Context) error { return repo.Close() }},
+		serviceComponent("db_stats", dbStats),
+	}
+	if relay != nil {
+		components = append(components, serviceComponent("outbox_relay", relay))
+	}
+	components = append(components, serviceComponent("http_server", srv))
+	if admin != nil {
+		components = append(components, serviceComponent("admin_server", admin))
+	}
+	return components
+}
+
+// shutdownMarker fails readiness once shutdown starts.
+// *healthHttp.ReadinessHandler implements it.
+type shutdownMarker interface {
+	MarkShuttingDown()
+}
+
+// preStopComponent fails readiness and then waits delay before the components
+// listed ahead of it stop. Listed last, it stops first, which gives load
+// balancers time to see the failing probe and stop sending requests before the
+// servers drain. The wait ends early when the stop deadline passes.
+func preStopComponent(readiness shutdownMarker, delay time.Duration) component {
+	return component{
+		name: "pre_stop",
+		stop: func(ctx context.Context) error {
+			readiness.MarkShuttingDown()
+			if delay <= 0 {
+				return nil
+			}
+			timer := time.NewTimer(delay)
+			defer timer.Stop()
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+			}
+			return nil
+		},
+	}
+}
+
+// appendOrdered registers components as a single fx hook. They start in the
+// given order and stop in reverse, independent of the order in which fx runs
+// the Invoke calls that build them. Every stop shares the deadline of the stop
+// context, set by fx.StopTimeout, and a failed stop does not skip the rest.
+// If a start fails, the components that already started are stopped again.
+func appendOrdered(lc fx.Lifecycle, components ...component) {
+	var started []component
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			for _, c := range components {
+				if c.start != nil {
+					if err := c.start(ctx); err != nil {
+						return errors.Join(fmt.Errorf("start %s: %w", c.name, err), stopAll(ctx, started))
+					}
+				}
+				started = append(started, c)
+			}
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			return stopAll(ctx, started)
+		},
+	})
+}
+
+func stopAll(ctx context.Context, components []component) error {
+	var errs []error
+	for i := len(components) - 1; i >= 0; i-- {
+		c := components[i]
+		if c.stop == nil {
+			continue
+		}
+		if err := c.stop(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("stop %s: %w", c.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,244 @@
+package app
+
+import (
+	"context"
+	"errors"
+	healthHttp "microservice/internal/adapters/http/health"
+	platformHealth "microservice/internal/platform/health"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+)
+
+type lifecycleRecorder struct {
+	mu        sync.Mutex
+	events    []string
+	deadlines []time.Time
+}
+
+func (r *lifecycleRecorder) record(event string, ctx context.Context) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+	if deadline, ok := ctx.Deadline(); ok {
+		r.deadlines = append(r.deadlines, deadline)
+	}
+}
+
+type fakeService struct {
+	name     string
+	recorder *lifecycleRecorder
+	startErr error
+}
+
+func (f *fakeService) Start(ctx context.Context) error {
+	f.recorder.record("start "+f.name, ctx)
+	return f.startErr
+}
+
+func (f *fakeService) Stop(ctx context.Context) error {
+	f.recorder.record("stop "+f.name, ctx)
+	return nil
+}
+
+type fakeCloser struct {
+	name     string
+	recorder *lifecycleRecorder
+}
+
+func (f *fakeCloser) Close() error {
+	f.recorder.record("close "+f.name, context.Background())
+	return nil
+}
+
+type testComponents struct {
+	db, dbStats, relay, srv, admin *fakeService
+	repo                           *fakeCloser
+}
+
+func newTestComponents(recorder *lifecycleRecorder) testComponents {
+	return testComponents{
+		db:      &fakeService{name: "database", recorder: recorder},
+		dbStats: &fakeService{name: "db_stats", recorder: recorder},
+		relay:   &fakeService{name: "outbox_relay", recorder: recorder},
+		srv:     &fakeService{name: "http_server", recorder: recorder},
+		admin:   &fakeService{name: "admin_server", recorder: recorder},
+		repo:    &fakeCloser{name: "repository", recorder: recorder},
+	}
+}
+
+// newTestApp builds an fx app that registers the service components in the
+// same way as Lifecycle, with the given stop timeout.
+func newTestApp(t *testing.T, c testComponents, stopTimeout time.Duration) *fxtest.App {
+	t.Helper()
+	var relay, admin service
+	if c.relay != nil {
+		relay = c.relay
+	}
+	if c.admin != nil {
+		admin = c.admin
+	}
+	return fxtest.New(t,
+		fx.NopLogger,
+		fx.StopTimeout(stopTimeout),
+		fx.Invoke(func(lc fx.Lifecycle) {
+			appendOrdered(lc, serviceComponents(c.db, c.dbStats, c.repo, relay, c.srv, admin)...)
+		}),
+	)
+}
+
+func TestLifecycle_StartStopOrder(t *testing.T) {
+	recorder := &lifecycleRecorder{}
+	app := newTestApp(t, newTestComponents(recorder), time.Second)
+
+	app.RequireStart()
+	app.RequireStop()
+
+	assert.Equal(t, []string{
+		"start database",
+		"start db_stats",
+		"start outbox_relay",
+		"start http_server",
+		"start admin_server",
+		"stop admin_server",
+		"stop http_server",
+		"stop outbox_relay",
+		"stop db_stats",
+		"close repository",
+		"stop database",
+	}, recorder.events)
+}
+
+func TestLifecycle_AdminDisabled(t *testing.T) {
+	recorder := &lifecycleRecorder{}
+	c := newTestComponents(recorder)
+	c.admin = nil
+	app := newTestApp(t, c, time.Second)
+
+	app.RequireStart()
+	app.RequireStop()
+
+	assert.NotContains(t, recorder.events, "start admin_server")
+	assert.NotContains(t, recorder.events, "stop admin_server")
+}
+
+func TestLifecycle_OutboxDisabled(t *testing.T) {
+	recorder := &lifecycleRecorder{}
+	c := newTestComponents(recorder)
+	c.relay = nil
+	app := newTestApp(t, c, time.Second)
+
+	app.RequireStart()
+	app.RequireStop()
+
+	assert.NotContains(t, recorder.events, "start outbox_relay")
+	assert.NotContains(t, recorder.events, "stop outbox_relay")
+}
+
+func TestLifecycle_StopsShareDeadline(t *testing.T) {
+	recorder := &lifecycleRecorder{}
+	app := newTestApp(t, newTestComponents(recorder), 5*time.Second)
+
+	app.RequireStart()
+	recorder.deadlines = nil
+	before := time.Now()
+	app.RequireStop()
+
+	// The database, db_stats, the outbox relay and both servers receive the
+	// stop context.
+	require.Len(t, recorder.deadlines, 5)
+	for _, deadline := range recorder.deadlines {
+		assert.Equal(t, recorder.deadlines[0], deadline)
+	}
+	assert.WithinDuration(t, before.Add(5*time.Second), recorder.deadlines[0], time.Second)
+}
+
+func TestLifecycle_StartFailureStopsStartedComponents(t *testing.T) {
+	recorder := &lifecycleRecorder{}
+	c := newTestComponents(recorder)
+	c.srv.startErr = errors.New("listen failed")
+	app := newTestApp(t, c, time.Second)
+
+	err := app.Start(context.Background())
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, c.srv.startErr)
+	assert.Equal(t, []string{
+		"start database",
+		"start db_stats",
+		"start outbox_relay",
+		"start http_server",
+		"stop outbox_relay",
+		"stop db_stats",
+		"close repository",
+		"stop database",
+	}, recorder.events)
+}
+
+func (r *lifecycleRecorder) snapshot() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.events...)
+}
+
+func probeStatus(handler http.HandlerFunc) int {
+	w := httptest.NewRecorder()
+	handler(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+	return w.Code
+}
+
+func TestLifecycle_PreStopDelayFailsReadinessBeforeDraining(t *testing.T) {
+	const delay = 200 * time.Millisecond
+	recorder := &lifecycleRecorder{}
+	c := newTestComponents(recorder)
+	readiness := healthHttp.NewReadinessHandler("v1.0.0", platformHealth.NewManager(), nil, 0)
+	liveness := healthHttp.NewLivenessHandler("v1.0.0")
+	app := fxtest.New(t,
+		fx.NopLogger,
+		fx.StopTimeout(5*time.Second),
+		fx.Invoke(func(lc fx.Lifecycle) {
+			components := serviceComponents(c.db, c.dbStats, c.repo, c.relay, c.srv, c.admin)
+			appendOrdered(lc, append(components, preStopComponent(readiness, delay))...)
+		}),
+	)
+
+	app.RequireStart()
+	require.Equal(t, http.StatusOK, probeStatus(readiness.Check))
+
+	stopped := make(chan error, 1)
+	before := time.Now()
+	go func() {
+		stopped <- app.Stop(context.Background())
+	}()
+
+	require.Eventually(t, func() bool {
+		return probeStatus(readiness.Check) == http.StatusServiceUnavailable
+	}, delay/2, 5*time.Millisecond)
+	assert.Equal(t, http.StatusOK, probeStatus(liveness.Check))
+	assert.NotContains(t, recorder.snapshot(), "stop http_server")
+
+	require.NoError(t, <-stopped)
+	assert.GreaterOrEqual(t, time.Since(before), delay)
+	assert.Contains(t, recorder.snapshot(), "stop http_server")
+	assert.Equal(t, http.StatusServiceUnavailable, probeStatus(readiness.Check))
+	assert.Equal(t, http.StatusOK, probeStatus(liveness.Check))
+}
+
+func TestPreStopComponent_EndsAtStopDeadline(t *testing.T) {
+	readiness := healthHttp.NewReadinessHandler("v1.0.0", platformHealth.NewManager(), nil, 0)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	before := time.Now()
+	require.NoError(t, preStopComponent(readiness, time.Minute).stop(ctx))
+
+	assert.Less(t, time.Since(before), time.Second)
+	assert.Equal(t, http.StatusServiceUnavailable, probeStatus(readiness.Check))
+}
@@ -0,0 +1,378 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"microservice/internal/adapters/database"
+	"microservice/internal/adapters/health"
+	httpAdapter "microservice/internal/adapters/http"
+	adminHttp "microservice/internal/adapters/http/admin"
+	eventsHttp "microservice/internal/adapters/http/events"
+	exampleHandler "microservice/internal/adapters/http/example"
+	healthHttp "microservice/internal/adapters/http/health"
+	"microservice/internal/adapters/http/response"
+	outboxAdapter "microservice/internal/adapters/outbox"
+	fallbackRepo "microservice/internal/adapters/repository/fallback"
+	memoryRepo "microservice/internal/adapters/repository/memory"
+	exampleRepo "microservice/internal/adapters/repository/postgres"
+	"microservice/internal/adapters/validator"
+	"microservice/internal/config"
+	exampleDomain "microservice/internal/core/domain/example"
+	"microservice/internal/core/ports"
+	exampleUseCase "microservice/internal/core/usecase/example"
+	"microservice/internal/platform/database/postgres"
+	platformHealth "microservice/internal/platform/health"
+	"microservice/internal/platform/idgen"
+	"microservice/internal/platform/logger"
+	"microservice/internal/platform/maxprocs"
+	"microservice/internal/platform/metrics"
+	"microservice/internal/platform/middleware"
+	memoryPlatform "microservice/internal/platform/repository/memory"
+	validatorPlatform "microservice/internal/platform/validator"
+	"microservice/internal/version"
+	"microservice/migrations"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/fx"
+)
+
+// Module wires the HTTP service: configuration, logging, the database, health
+// checks, both HTTP servers, the example domain and the lifecycle hooks that
+// start and stop them in order. Compose it with your own modules:
+//
+//	fx.New(app.Module, myModule).Run()
+//
+// Providers in other modules can use anything Module provides, add readiness
+// checks with HealthChecker and liveness watchdogs with Watchdog, and swap a
+// provided value at the root with fx.Replace or fx.Decorate. With the outbox
+// enabled another module must provide the ports.Publisher that delivers its
+// messages. The startup probe passes once
+// Module's own start hooks have run, so start hooks registered by modules
+// listed after it may still be running.
+var Module = fx.Module("app",
+	Platform,
+	Health,
+	HTTP,
+	Domain,
+	// Kept last so the startup probe is marked ready after the other hooks.
+	Lifecycle,
+)
+
+const (
+	healthCheckersTag        = `group:"health_checkers"`
+	healthCheckersFlattenTag = `group:"health_checkers,flatten"`
+	watchdogsTag             = `group:"watchdogs"`
+)
+
+// HealthChecker provides the result of constructor, which must implement
+// platformHealth.Checker, as a readiness check registered by Health.
+func HealthChecker(constructor any) fx.Option {
+	return fx.Provide(fx.Annotate(constructor, fx.As(new(platformHealth.Checker)), fx.ResultTags(healthCheckersTag)))
+}
+
+// Watchdog provides the *platformHealth.Watchdog returned by constructor to the
+// liveness probe, which fails once the watchdog goes unkicked for too long.
+func Watchdog(constructor any) fx.Option {
+	return fx.Provide(fx.Annotate(constructor, fx.ResultTags(watchdogsTag)))
+}
+
+// Platform loads configuration and provides logging, validation and the
+// database connection.
+var Platform = fx.Module("platform",
+	fx.Provide(config.LoadBase),
+	fx.Provide(config.LoadHttp),
+	fx.Provide(config.LoadDatabase),
+	fx.Provide(func(cfg *config.BaseConfig) logger.Config {
+		return logger.Config{
+			Environment: cfg.Environment,
+			Level:       cfg.Logger.Level,
+			Format:      cfg.Logger.Format,
+		}
+	}),
+	fx.Provide(logger.NewZapLogger),
+	fx.Invoke(func(cfg *config.BaseConfig, log logger.Logger) {
+		maxprocs.Set(log, cfg.AutoMaxProcs, maxprocs.CgroupQuota)
+	}),
+	fx.Invoke(func(cfg *config.HttpConfig) {
+		encoder := response.Encoder{OmitNull: cfg.JSONOmitNull}
+		if cfg.PrettyJSON() {
+			encoder.Indent = "  "
+		}
+		response.SetEncoder(encoder)
+	}),
+	fx.Invoke(func(log logger.Logger, provider *metrics.Provider) {
+		config.WarnDeprecatedKeys(log, func(key config.DeprecatedKey) {
+			provider.RecordDeprecatedConfigKey(context.Background(), key.Key, key.Replacement)
+		})
+	}),
+	fx.Provide(fx.Annotate(validator.NewPlaygroundAdapter, fx.As(new(validatorPlatform.Validator)))),
+	fx.Provide(postgres.New),
+	fx.Provide(fx.Annotate(database.NewDatabaseLifecycle, fx.As(fx.Self()), fx.As(new(ports.Transactor)))),
+)
+
+// Health provides the built-in readiness checks and the health manager that
+// runs every check in the health_checkers group.
+var Health = fx.Module("health",
+	HealthChecker(health.NewMemoryChecker),
+	fx.Provide(fx.Annotate(
+		func(db *database.Lifecycle) platformHealth.Checker {
+			return platformHealth.WithGroup(health.NewDatabaseChecker(db, "postgres"), platformHealth.GroupCore)
+		},
+		fx.ResultTags(healthCheckersTag),
+	)),
+	fx.Provide(fx.Annotate(
+		func(db *database.Lifecycle) (platformHealth.Checker, error) {
+			version, err := migrations.LatestVersion()
+			if err != nil {
+				return nil, err
+			}
+			return platformHealth.WithGroup(health.NewMigrationChecker(db, version, "migrations"), platformHealth.GroupCore), nil
+		},
+		fx.ResultTags(healthCheckersTag),
+	)),
+	fx.Provide(fx.Annotate(
+		func(cfg *config.HttpConfig) []platformHealth.Checker {
+			files := cfg.CertFiles
+			if cfg.TLS.Enabled && cfg.TLS.CertFile != "" {
+				files = append([]string{cfg.TLS.CertFile}, files...)
+			}
+			if len(files) == 0 {
+				return nil
+			}
+			warnBefore := time.Duration(cfg.CertExpiryWarnDays) * 24 * time.Hour
+			return []platformHealth.Checker{health.NewCertificateChecker("certificates", files, warnBefore)}
+		},
+		fx.ResultTags(healthCheckersFlattenTag),
+	)),
+	fx.Provide(fx.Annotate(
+		func(cfg *config.HttpConfig) []platformHealth.Checker {
+			if cfg.DiskPath == "" {
+				return nil
+			}
+			return []platformHealth.Checker{health.NewDiskChecker(cfg.DiskPath, cfg.DiskMinFreeBytes, "disk")}
+		},
+		fx.ResultTags(healthCheckersFlattenTag),
+	)),
+	fx.Provide(fx.Annotate(
+		func(cfg *config.HttpConfig, log logger.Logger, checkers []platformHealth.Checker) *platformHealth.Manager {
+			m := platformHealth.NewManager(
+				platformHealth.WithCacheTTL(time.Duration(cfg.HealthCacheTTL)*time.Second),
+				platformHealth.WithConcurrencyLimit(cfg.HealthCheckConcurrency),
+			)
+			for _, checker := range checkers {
+				if name := m.RegisterUnique(checker); name != checker.Name() {
+					log.Warn("Health checker name already registered",
+						logger.String("checker", checker.Name()),
+						logger.String("registered_as", name))
+				}
+			}
+			return m
+		},
+		fx.ParamTags(``, ``, healthCheckersTag),
+		fx.As(new(platformHealth.ManagerInterface)),
+	)),
+)
+
+// HTTP provides metrics, handlers and the API and admin servers.
+var HTTP = fx.Module("http",
+	fx.Provide(func(cfg *config.HttpConfig) (*metrics.Provider, error) {
+		return metrics.NewProvider(
+			metrics.WithServiceLabels(cfg.Metrics.ServiceName, cfg.Metrics.Instance),
+			metrics.WithNamespace(cfg.Metrics.Namespace),
+			metrics.WithDurationBuckets(cfg.Metrics.DurationBuckets...),
+		)
+	}),
+	fx.Provide(func(provider *metrics.Provider, db *database.Lifecycle) (*metrics.DBStatsCollector, error) {
+		return metrics.NewDBStatsCollector(provider, db)
+	}),
+	fx.Provide(httpAdapter.NewServer),
+	fx.Provide(httpAdapter.NewRouter),
+	fx.Provide(fx.Annotate(httpAdapter.NewAdminRouter, fx.ResultTags(`name:"admin"`))),
+	fx.Provide(fx.Annotate(
+		httpAdapter.NewAdminServer,
+		fx.ParamTags(``, ``, `name:"admin"`),
+		fx.ResultTags(`name:"admin"`),
+	)),
+	fx.Provide(func(cfg *config.HttpConfig, manager exampleHandler.Manager, validate validatorPlatform.Validator) *exampleHandler.Handler {
+		var opts []exampleHandler.Option
+		if cfg.GeneratesIDs() {
+			opts = append(opts, exampleHandler.WithGeneratedIDs())
+		}
+		if cfg.DisallowUnknownFields {
+			opts = append(opts, exampleHandler.WithDisallowUnknownFields())
+		}
+		return exampleHandler.NewHandler(manager, validate, cfg.MaxBatchItems, opts...)
+	}),
+	// Loops that must keep running provide a *platformHealth.Watchdog in the
+	// "watchdogs" group and kick it; liveness fails while one is stalled.
+	fx.Provide(fx.Annotate(
+		func(watchdogs []*platformHealth.Watchdog) *healthHttp.LivenessHandler {
+			var opts []healthHttp.LivenessOption
+			for _, watchdog := range watchdogs {
+				opts = append(opts, healthHttp.WithWatchdog(watchdog))
+			}
+			return healthHttp.NewLivenessHandler(version.Get(), opts...)
+		},
+		fx.ParamTags(watchdogsTag),
+	)),
+	fx.Provide(func() *healthHttp.StartupHandler {
+		return healthHttp.NewStartupHandler(version.Get())
+	}),
+	fx.Provide(func(cfg *config.HttpConfig, hm platformHealth.ManagerInterface, provider *metrics.Provider) *healthHttp.ReadinessHandler {
+		return healthHttp.NewReadinessHandler(version.Get(), hm, provider, time.Duration(cfg.HealthCheckTimeout)*time.Second)
+	}),
+	fx.Provide(func(log logger.Logger) *adminHttp.LogLevelHandler {
+		setter, ok := log.(logger.LevelSetter)
+		if !ok {
+			return nil
+		}
+		return adminHttp.NewLogLevelHandler(setter)
+	}),
+	fx.Provide(func(cfg *config.HttpConfig) *adminHttp.MaintenanceHandler {
+		flag := new(atomic.Bool)
+		flag.Store(cfg.MaintenanceMode)
+		return adminHttp.NewMaintenanceHandler(flag)
+	}),
+	fx.Provide(func(cfg *config.HttpConfig) *eventsHttp.Broadcaster {
+		return eventsHttp.NewBroadcaster(cfg.EventsBuffer)
+	}),
+	fx.Provide(func(cfg *config.HttpConfig, broadcaster *eventsHttp.Broadcaster) *eventsHttp.Handler {
+		return eventsHttp.NewHandler(broadcaster, time.Duration(cfg.EventsHeartbeat)*time.Second)
+	}),
+	fx.Provide(func(lc fx.Lifecycle, cfg *config.HttpConfig) middleware.IdempotencyStore {
+		store := middleware.NewMemoryIdempotencyStore(
+			time.Duration(cfg.Idempotency.TTL)*time.Second,
+			middleware.WithIdempotencyMaxEntries(cfg.Idempotency.MaxEntries),
+			middleware.WithIdempotencySweepInterval(time.Duration(cfg.Idempotency.SweepInterval)*time.Second),
+		)
+		lc.Append(fx.Hook{
+			OnStop: func(context.Context) error {
+				store.Close()
+				return nil
+			},
+		})
+		return store
+	}),
+	fx.Provide(func(cfg *config.HttpConfig, log logger.Logger, example *exampleHandler.Handler, liveness *healthHttp.LivenessHandler, startup *healthHttp.StartupHandler, readiness *healthHttp.ReadinessHandler, metrics *metrics.Provider, logLevel *adminHttp.LogLevelHandler, maintenance *adminHttp.MaintenanceHandler, idempotency middleware.IdempotencyStore, events *eventsHttp.Handler) httpAdapter.RouterDependencies {
+		return httpAdapter.RouterDependencies{
+			Config:             cfg,
+			Logger:             log,
+			ExampleHandler:     example,
+			LivenessHandler:    liveness,
+			StartupHandler:     startup,
+			ReadinessHandler:   readiness,
+			MetricsProvider:    metrics,
+			LogLevelHandler:    logLevel,
+			IdempotencyStore:   idempotency,
+			MaintenanceHandler: maintenance,
+			Maintenance:        maintenance.Flag(),
+			Deployment: middleware.DeploymentInfo{
+				Instance:    instanceName(cfg),
+				Version:     version.Get(),
+				Environment: cfg.Environment,
+			},
+			EventsHandler: events,
+		}
+	}),
+)
+
+// errNoPublisher stops the app when the outbox is enabled without a publisher,
+// rather than relaying messages nowhere and marking them as published.
+var errNoPublisher = errors.New("OUTBOX_ENABLED is set but no ports.Publisher is provided")
+
+// Domain provides the example repository, outbox relay and use case. The relay
+// is nil while the outbox is disabled.
+var Domain = fx.Module("domain",
+	fx.Provide(exampleRepo.NewRepository),
+	fx.Provide(exampleRepo.NewOutbox),
+	// There is no default publisher: a module composed with Module provides a
+	// Kafka or NATS one when the outbox is enabled.
+	fx.Provide(fx.Annotate(
+		func(cfg *config.DatabaseConfig, store *exampleRepo.Outbox, log logger.Logger, publisher ports.Publisher) (*outboxAdapter.Relay, error) {
+			if !cfg.Outbox.Enabled {
+				return nil, nil
+			}
+			if publisher == nil {
+				return nil, errNoPublisher
+			}
+			return outboxAdapter.NewRelay(store, publisher, log,
+				outboxAdapter.WithPollInterval(cfg.Outbox.PollInterval),
+				outboxAdapter.WithBatchSize(cfg.Outbox.BatchSize),
+			), nil
+		},
+		fx.ParamTags(``, ``, ``, `optional:"true"`),
+	)),
+	fx.Provide(func(cfg *config.DatabaseConfig, db *database.Lifecycle, repo *exampleRepo.Repository) ports.ExampleRepository {
+		if !cfg.MemoryFallback {
+			return repo
+		}
+		var opts []memoryPlatform.Option
+		if cfg.CaseInsensitiveIDs {
+			opts = append(opts, memoryRepo.WithCaseInsensitiveIDs())
+		}
+		return fallbackRepo.NewRepository(db, repo, memoryRepo.NewRepository(opts...))
+	}),
+	fx.Provide(fx.Annotate(exampleDomain.NewService, fx.As(new(exampleUseCase.EntityChecker)))),
+	fx.Provide(fx.Annotate(idgen.NewUUID, fx.As(new(ports.IDGenerator)))),
+	fx.Provide(fx.Annotate(
+		func(cfg *config.HttpConfig, dbCfg *config.DatabaseConfig, repo ports.ExampleRepository, checker exampleUseCase.EntityChecker, ids ports.IDGenerator, tx ports.Transactor, outbox *exampleRepo.Outbox) *exampleUseCase.Usecase {
+			var opts []exampleUseCase.Option
+			if cfg.GeneratesIDs() {
+				opts = append(opts, exampleUseCase.WithIDGenerator(ids))
+			}
+			if dbCfg.Outbox.Enabled {
+				opts = append(opts, exampleUseCase.WithOutbox(tx, outbox))
+			}
+			return exampleUseCase.NewUsecase(repo, checker, opts...)
+		},
+		fx.As(new(exampleHandler.Manager)),
+	)),
+)
+
+// Lifecycle starts and stops the servers, the database and the outbox relay in
+// order, and marks the startup probe ready once they are up. On stop it fails
+// readiness and waits SHUTDOWN_PRE_STOP_DELAY before the servers drain.
+var Lifecycle = fx.Module("lifecycle",
+	// Open event streams never go idle, so they are ended as soon as shutdown
+	// starts to let the server drain.
+	fx.Invoke(func(srv *httpAdapter.Server, broadcaster *eventsHttp.Broadcaster) {
+		srv.OnShutdown(broadcaster.Close)
+	}),
+	fx.Invoke(fx.Annotate(
+		func(lc fx.Lifecycle, cfg *config.HttpConfig, dbCfg *config.DatabaseConfig, db *database.Lifecycle, repo *exampleRepo.Repository, dbStats *metrics.DBStatsCollector, outboxRelay *outboxAdapter.Relay, srv *httpAdapter.Server, adminSrv *httpAdapter.Server, readiness *healthHttp.ReadinessHandler) {
+			var relay, admin service
+			if dbCfg.Outbox.Enabled {
+				relay = outboxRelay
+			}
+			if cfg.Admin.Enabled {
+				admin = adminSrv
+			}
+			components := serviceComponents(db, dbStats, repo, relay, srv, admin)
+			components = append(components, preStopComponent(readiness, time.Duration(cfg.ShutdownPreStopDelay)*time.Second))
+			appendOrdered(lc, components...)
+		},
+		fx.ParamTags(``, ``, ``, ``, ``, ``, ``, ``, `name:"admin"`),
+	)),
+	// Registered last so the startup probe passes only after every other start
+	// hook has completed.
+	fx.Invoke(func(lc fx.Lifecycle, startup *healthHttp.StartupHandler) {
+		lc.Append(fx.Hook{
+			OnStart: func(context.Context) error {
+				startup.MarkReady()
+				return nil
+			},
+		})
+	}),
+)
+
+// instanceName identifies this process in deployment headers, falling back to
+// the hostname.
+func instanceName(cfg *config.HttpConfig) string {
+	if cfg.Metrics.Instance != "" {
+		return cfg.Metrics.Instance
+	}
+	hostname, _ := os.Hostname()
+	return hostname
+}
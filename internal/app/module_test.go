@@ -0,0 +1,76 @@
+package app
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+
+	healthHttp "microservice/internal/adapters/http/health"
+	outboxAdapter "microservice/internal/adapters/outbox"
+	"microservice/internal/core/ports"
+	platformHealth "microservice/internal/platform/health"
+)
+
+func TestModule_Validates(t *testing.T) {
+	require.NoError(t, fx.ValidateApp(Module, fx.NopLogger))
+}
+
+type staticChecker struct{}
+
+func (staticChecker) Name() string { return "consumer" }
+
+func (staticChecker) Check(context.Context) platformHealth.CheckResult {
+	return platformHealth.CheckResult{Status: platformHealth.StatusHealthy}
+}
+
+func TestModule_ComposesWithConsumerModule(t *testing.T) {
+	var (
+		manager  platformHealth.ManagerInterface
+		liveness *healthHttp.LivenessHandler
+	)
+	consumer := fx.Module("consumer",
+		HealthChecker(func() staticChecker { return staticChecker{} }),
+		Watchdog(func() *platformHealth.Watchdog { return platformHealth.NewWatchdog("worker", time.Nanosecond) }),
+	)
+
+	fxtest.New(t, Module, consumer, fx.NopLogger, fx.Populate(&manager, &liveness))
+
+	results := manager.CheckAll(context.Background())
+	assert.Contains(t, results, "consumer")
+	assert.Contains(t, results, "postgres")
+	assert.Contains(t, results, "memory_storage")
+
+	// The watchdog is never kicked, so liveness fails naming it.
+	w := httptest.NewRecorder()
+	liveness.Check(w, httptest.NewRequest(http.MethodGet, "/health/live", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Contains(t, w.Body.String(), "worker")
+}
+
+func TestModule_OutboxRequiresPublisher(t *testing.T) {
+	t.Setenv("OUTBOX_ENABLED", "true")
+
+	err := fx.New(Module, fx.NopLogger).Err()
+	require.Error(t, err)
+	assert.ErrorIs(t, err, errNoPublisher)
+
+	var relay *outboxAdapter.Relay
+	publisher := fx.Provide(func() ports.Publisher { return outboxAdapter.NopPublisher{} })
+	fxtest.New(t, Module, publisher, fx.NopLogger, fx.Populate(&relay))
+	assert.NotNil(t, relay)
+}
+
+func TestModule_OutboxDisabledWithoutPublisher(t *testing.T) {
+	t.Setenv("OUTBOX_ENABLED", "false")
+
+	var relay *outboxAdapter.Relay
+	fxtest.New(t, Module, fx.NopLogger, fx.Populate(&relay))
+	assert.Nil(t, relay)
+}
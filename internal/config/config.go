@@ -3,8 +3,6 @@ package config
 import (
 	"microservice/internal/platform/logger"
 	"strings"
-
-	"github.com/kelseyhightower/envconfig"
 )
 
 const (
@@ -17,6 +15,14 @@ const (
 type BaseConfig struct {
 	Environment string       `envconfig:"ENV" default:"development" validate:"oneof=development staging production test"`
 	Logger      LoggerConfig `envconfig:"LOGGER"`
+
+	AutoMaxProcs bool `envconfig:"AUTO_MAXPROCS" default:"true"`
+
+	// ShutdownTimeout is the deadline in seconds shared by every stop hook.
+	ShutdownTimeout int `envconfig:"SHUTDOWN_TIMEOUT" default:"30"`
+	// ShutdownPreStopDelay is how long in seconds readiness fails before the
+	// servers start draining. It is added to ShutdownTimeout.
+	ShutdownPreStopDelay int `envconfig:"SHUTDOWN_PRE_STOP_DELAY" default:"0"`
 }
 
 type LoggerConfig struct {
@@ -26,12 +32,33 @@ type LoggerConfig struct {
 
 func LoadBase() (*BaseConfig, error) {
 	var cfg BaseConfig
-	if err := envconfig.Process("", &cfg); err != nil {
+	if err := process(&cfg); err != nil {
+		return nil, err
+	}
+	if err := cfg.Validate(); err != nil {
 		return nil, err
 	}
 	return &cfg, nil
 }
 
+// Validate reports an unknown ENV or a negative SHUTDOWN_TIMEOUT or
+// SHUTDOWN_PRE_STOP_DELAY.
+func (c *BaseConfig) Validate() error {
+	var p problems
+	c.check(&p)
+	return p.err("base")
+}
+
+func (c *BaseConfig) check(p *problems) {
+	switch strings.ToLower(c.Environment) {
+	case EnvDevelopment, EnvStaging, EnvProduction, EnvTest:
+	default:
+		p.add("ENV must be one of %s, %s, %s or %s, got %q", EnvDevelopment, EnvStaging, EnvProduction, EnvTest, c.Environment)
+	}
+	p.nonNegative("SHUTDOWN_TIMEOUT", int64(c.ShutdownTimeout))
+	p.nonNegative("SHUTDOWN_PRE_STOP_DELAY", int64(c.ShutdownPreStopDelay))
+}
+
 func (c *BaseConfig) IsDevelopment() bool {
 	return strings.ToLower(c.Environment) == EnvDevelopment
 }
@@ -16,7 +16,8 @@ type ConfigTestSuite struct {
 func (s *ConfigTestSuite) SetupTest() {
 	s.originalEnv = make(map[string]string)
 	envVars := []string{
-		"ENV", "LOGGER_LEVEL", "LOGGER_FORMAT",
+		"ENV", "LOGGER_LEVEL", "LOGGER_FORMAT", "AUTO_MAXPROCS", "SHUTDOWN_TIMEOUT",
+		"SHUTDOWN_PRE_STOP_DELAY",
 	}
 
 	for _, env := range envVars {
@@ -29,7 +30,8 @@ func (s *ConfigTestSuite) SetupTest() {
 
 func (s *ConfigTestSuite) TearDownTest() {
 	envVars := []string{
-		"ENV", "LOGGER_LEVEL", "LOGGER_FORMAT",
+		"ENV", "LOGGER_LEVEL", "LOGGER_FORMAT", "AUTO_MAXPROCS", "SHUTDOWN_TIMEOUT",
+		"SHUTDOWN_PRE_STOP_DELAY",
 	}
 
 	for _, env := range envVars {
@@ -49,6 +51,33 @@ func (s *ConfigTestSuite) TestLoadBase_DefaultValues() {
 	s.Assert().Equal(EnvDevelopment, cfg.Environment)
 	s.Assert().Equal(logger.LevelInfo, cfg.Logger.Level)
 	s.Assert().Equal(logger.FormatJSON, cfg.Logger.Format)
+	s.Assert().True(cfg.AutoMaxProcs)
+	s.Assert().Equal(30, cfg.ShutdownTimeout)
+	s.Assert().Equal(0, cfg.ShutdownPreStopDelay)
+}
+
+func (s *ConfigTestSuite) TestLoadBase_ShutdownTimeout() {
+	s.Require().NoError(os.Setenv("SHUTDOWN_TIMEOUT", "45"))
+
+	cfg, err := LoadBase()
+	s.Require().NoError(err)
+	s.Assert().Equal(45, cfg.ShutdownTimeout)
+}
+
+func (s *ConfigTestSuite) TestLoadBase_ShutdownPreStopDelay() {
+	s.Require().NoError(os.Setenv("SHUTDOWN_PRE_STOP_DELAY", "5"))
+
+	cfg, err := LoadBase()
+	s.Require().NoError(err)
+	s.Assert().Equal(5, cfg.ShutdownPreStopDelay)
+}
+
+func (s *ConfigTestSuite) TestLoadBase_AutoMaxProcsDisabled() {
+	s.Require().NoError(os.Setenv("AUTO_MAXPROCS", "false"))
+
+	cfg, err := LoadBase()
+	s.Require().NoError(err)
+	s.Assert().False(cfg.AutoMaxProcs)
 }
 
 func (s *ConfigTestSuite) TestLoadBase_WithEnvironmentVariables() {
@@ -2,14 +2,34 @@ package config
 
 import (
 	"fmt"
+	"strings"
 	"time"
-
-	"github.com/kelseyhightower/envconfig"
 )
 
 type DatabaseConfig struct {
 	BaseConfig
 	Postgres PostgresConfig `envconfig:"POSTGRES"`
+
+	CaseInsensitiveIDs bool `envconfig:"CASE_INSENSITIVE_IDS" default:"false"`
+	ValidateOnRead     bool `envconfig:"VALIDATE_ON_READ" default:"false"`
+
+	// MemoryFallback serves data from memory when the database cannot be
+	// reached at startup. It is meant for local development and never engages
+	// in production.
+	MemoryFallback bool `envconfig:"DATABASE_MEMORY_FALLBACK" default:"false"`
+
+	Outbox OutboxConfig `envconfig:"OUTBOX"`
+}
+
+// MaxConnectRetryBackoff caps the wait between two connection attempts.
+const MaxConnectRetryBackoff = 10 * time.Second
+
+// OutboxConfig enables recording entity changes in the outbox table and the
+// relay that publishes them.
+type OutboxConfig struct {
+	Enabled      bool          `envconfig:"ENABLED" default:"false"`
+	PollInterval time.Duration `envconfig:"POLL_INTERVAL" default:"1s"`
+	BatchSize    int           `envconfig:"BATCH_SIZE" default:"100"`
 }
 
 type PostgresConfig struct {
@@ -23,6 +43,33 @@ type PostgresConfig struct {
 	MaxIdleConns    int           `envconfig:"MAX_IDLE_CONNS" default:"5"`
 	ConnMaxLifetime time.Duration `envconfig:"CONN_MAX_LIFETIME" default:"5m"`
 	ConnMaxIdleTime time.Duration `envconfig:"CONN_MAX_IDLE_TIME" default:"5m"`
+	QueryTimeout    time.Duration `envconfig:"QUERY_TIMEOUT" default:"5s"`
+
+	// ConnectRetries failed connection attempts are retried at startup, waiting
+	// ConnectRetryBackoff before the first retry and twice as long before each
+	// later one, up to MaxConnectRetryBackoff.
+	ConnectRetries      int           `envconfig:"CONNECT_RETRIES" default:"5"`
+	ConnectRetryBackoff time.Duration `envconfig:"CONNECT_RETRY_BACKOFF" default:"1s"`
+
+	// MinIdleConns connections are opened and pinged during startup so the
+	// first requests find them idle. It is capped at MaxIdleConns; zero turns
+	// warm-up off.
+	MinIdleConns int `envconfig:"MIN_IDLE_CONNS" default:"0"`
+
+	// ReplicaHost enables a read replica. The other replica fields fall back
+	// to the primary's values when empty.
+	ReplicaHost     string `envconfig:"REPLICA_HOST" default:""`
+	ReplicaPort     int    `envconfig:"REPLICA_PORT" default:"0"`
+	ReplicaUser     string `envconfig:"REPLICA_USER" default:""`
+	ReplicaPassword string `envconfig:"REPLICA_PASSWORD" default:""`
+	ReplicaDatabase string `envconfig:"REPLICA_DB" default:""`
+
+	// SlowQueryThreshold logs a warning for queries that take longer; zero
+	// disables the warning.
+	SlowQueryThreshold time.Duration `envconfig:"SLOW_QUERY_THRESHOLD" default:"500ms"`
+	// LogQueries logs the name, duration and row count of every repository
+	// operation at debug level.
+	LogQueries bool `envconfig:"LOG_QUERIES" default:"false"`
 }
 
 func (c *PostgresConfig) DSN() string {
@@ -30,6 +77,60 @@ func (c *PostgresConfig) DSN() string {
 		c.Host, c.Port, c.User, c.Password, c.Database, c.SSLMode)
 }
 
+// ReplicaConfig returns the connection settings for the read replica, or false
+// when no replica is configured. Pool and timeout settings are shared with the
+// primary.
+func (c *PostgresConfig) ReplicaConfig() (*PostgresConfig, bool) {
+	if c.ReplicaHost == "" {
+		return nil, false
+	}
+
+	replica := *c
+	replica.Host = c.ReplicaHost
+	if c.ReplicaPort != 0 {
+		replica.Port = c.ReplicaPort
+	}
+	if c.ReplicaUser != "" {
+		replica.User = c.ReplicaUser
+	}
+	if c.ReplicaPassword != "" {
+		replica.Password = c.ReplicaPassword
+	}
+	if c.ReplicaDatabase != "" {
+		replica.Database = c.ReplicaDatabase
+	}
+	return &replica, true
+}
+
+// ConnectRetryDelay is the wait before the given retry, counting from 1.
+func (c *PostgresConfig) ConnectRetryDelay(retry int) time.Duration {
+	delay := c.ConnectRetryBackoff
+	for i := 1; i < retry && delay < MaxConnectRetryBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, MaxConnectRetryBackoff)
+}
+
+// ConnectRetryWait is the total time spent waiting between attempts when every
+// attempt fails.
+func (c *PostgresConfig) ConnectRetryWait() time.Duration {
+	var total time.Duration
+	for retry := 1; retry <= c.ConnectRetries; retry++ {
+		total += c.ConnectRetryDelay(retry)
+	}
+	return total
+}
+
+// ConnectRetryWait is the total time startup may spend waiting between
+// connection attempts to the primary and, when configured, the read replica.
+func (c *DatabaseConfig) ConnectRetryWait() time.Duration {
+	total := c.Postgres.ConnectRetryWait()
+	if replica, ok := c.Postgres.ReplicaConfig(); ok {
+		total += replica.ConnectRetryWait()
+	}
+	return total
+}
+
 func (c *PostgresConfig) GetMaxOpenConns() int {
 	return c.MaxOpenConns
 }
@@ -48,8 +149,60 @@ func (c *PostgresConfig) GetConnMaxIdleTime() time.Duration {
 
 func LoadDatabase() (*DatabaseConfig, error) {
 	var cfg DatabaseConfig
-	if err := envconfig.Process("", &cfg); err != nil {
+	if err := process(&cfg); err != nil {
+		return nil, err
+	}
+	if err := cfg.Validate(); err != nil {
 		return nil, err
 	}
 	return &cfg, nil
 }
+
+// Validate rejects settings that would only fail once the database is used.
+// Production additionally requires a database name and password and refuses
+// unencrypted connections.
+func (c *DatabaseConfig) Validate() error {
+	var p problems
+	c.check(&p)
+
+	pg := c.Postgres
+	if pg.Host == "" {
+		p.add("POSTGRES_HOST must be set")
+	}
+	if pg.Port < 1 || pg.Port > 65535 {
+		p.add("POSTGRES_PORT must be between 1 and 65535, got %d", pg.Port)
+	}
+	p.port("POSTGRES_REPLICA_PORT", pg.ReplicaPort)
+	p.nonNegative("POSTGRES_MAX_OPEN_CONNS", int64(pg.MaxOpenConns))
+	p.nonNegative("POSTGRES_MAX_IDLE_CONNS", int64(pg.MaxIdleConns))
+	p.nonNegative("POSTGRES_MIN_IDLE_CONNS", int64(pg.MinIdleConns))
+	p.nonNegative("POSTGRES_CONNECT_RETRIES", int64(pg.ConnectRetries))
+	p.nonNegativeDuration("POSTGRES_CONN_MAX_LIFETIME", pg.ConnMaxLifetime)
+	p.nonNegativeDuration("POSTGRES_CONN_MAX_IDLE_TIME", pg.ConnMaxIdleTime)
+	p.nonNegativeDuration("POSTGRES_QUERY_TIMEOUT", pg.QueryTimeout)
+	p.nonNegativeDuration("POSTGRES_CONNECT_RETRY_BACKOFF", pg.ConnectRetryBackoff)
+	p.nonNegativeDuration("POSTGRES_SLOW_QUERY_THRESHOLD", pg.SlowQueryThreshold)
+
+	if c.Outbox.Enabled {
+		if c.Outbox.PollInterval <= 0 {
+			p.add("OUTBOX_POLL_INTERVAL must be positive, got %s", c.Outbox.PollInterval)
+		}
+		if c.Outbox.BatchSize <= 0 {
+			p.add("OUTBOX_BATCH_SIZE must be positive, got %d", c.Outbox.BatchSize)
+		}
+	}
+
+	if c.IsProduction() {
+		if pg.Database == "" {
+			p.add("POSTGRES_DB must be set in production")
+		}
+		if pg.Password == "" {
+			p.add("POSTGRES_PASSWORD must be set in production")
+		}
+		if strings.EqualFold(pg.SSLMode, "disable") {
+			p.add("POSTGRES_SSL_MODE=disable is not allowed in production")
+		}
+	}
+
+	return p.err("database")
+}
@@ -21,7 +21,13 @@ func (s *DatabaseConfigTestSuite) SetupTest() {
 		"ENV", "LOGGER_LEVEL", "LOGGER_FORMAT", "USER",
 		"POSTGRES_HOST", "POSTGRES_PORT", "POSTGRES_USER", "POSTGRES_PASSWORD",
 		"POSTGRES_DB", "POSTGRES_SSL_MODE", "POSTGRES_MAX_OPEN_CONNS",
-		"POSTGRES_MAX_IDLE_CONNS", "POSTGRES_CONN_MAX_LIFETIME", "POSTGRES_CONN_MAX_IDLE_TIME",
+		"POSTGRES_MAX_IDLE_CONNS", "POSTGRES_MIN_IDLE_CONNS", "POSTGRES_CONN_MAX_LIFETIME", "POSTGRES_CONN_MAX_IDLE_TIME",
+		"POSTGRES_QUERY_TIMEOUT", "POSTGRES_SLOW_QUERY_THRESHOLD", "POSTGRES_LOG_QUERIES",
+		"POSTGRES_CONNECT_RETRIES", "POSTGRES_CONNECT_RETRY_BACKOFF",
+		"POSTGRES_REPLICA_HOST", "POSTGRES_REPLICA_PORT", "POSTGRES_REPLICA_USER",
+		"POSTGRES_REPLICA_PASSWORD", "POSTGRES_REPLICA_DB",
+		"CASE_INSENSITIVE_IDS", "VALIDATE_ON_READ", "DATABASE_MEMORY_FALLBACK",
+		"OUTBOX_ENABLED", "OUTBOX_POLL_INTERVAL", "OUTBOX_BATCH_SIZE",
 	}
 
 	for _, env := range envVars {
@@ -37,7 +43,13 @@ func (s *DatabaseConfigTestSuite) TearDownTest() {
 		"ENV", "LOGGER_LEVEL", "LOGGER_FORMAT", "USER",
 		"POSTGRES_HOST", "POSTGRES_PORT", "POSTGRES_USER", "POSTGRES_PASSWORD",
 		"POSTGRES_DB", "POSTGRES_SSL_MODE", "POSTGRES_MAX_OPEN_CONNS",
-		"POSTGRES_MAX_IDLE_CONNS", "POSTGRES_CONN_MAX_LIFETIME", "POSTGRES_CONN_MAX_IDLE_TIME",
+		"POSTGRES_MAX_IDLE_CONNS", "POSTGRES_MIN_IDLE_CONNS", "POSTGRES_CONN_MAX_LIFETIME", "POSTGRES_CONN_MAX_IDLE_TIME",
+		"POSTGRES_QUERY_TIMEOUT", "POSTGRES_SLOW_QUERY_THRESHOLD", "POSTGRES_LOG_QUERIES",
+		"POSTGRES_CONNECT_RETRIES", "POSTGRES_CONNECT_RETRY_BACKOFF",
+		"POSTGRES_REPLICA_HOST", "POSTGRES_REPLICA_PORT", "POSTGRES_REPLICA_USER",
+		"POSTGRES_REPLICA_PASSWORD", "POSTGRES_REPLICA_DB",
+		"CASE_INSENSITIVE_IDS", "VALIDATE_ON_READ", "DATABASE_MEMORY_FALLBACK",
+		"OUTBOX_ENABLED", "OUTBOX_POLL_INTERVAL", "OUTBOX_BATCH_SIZE",
 	}
 
 	for _, env := range envVars {
@@ -67,25 +79,55 @@ func (s *DatabaseConfigTestSuite) TestLoadDatabase_DefaultValues() {
 	s.Assert().Equal("disable", cfg.Postgres.SSLMode)
 	s.Assert().Equal(25, cfg.Postgres.MaxOpenConns)
 	s.Assert().Equal(5, cfg.Postgres.MaxIdleConns)
+	s.Assert().Zero(cfg.Postgres.MinIdleConns)
 	s.Assert().Equal(5*time.Minute, cfg.Postgres.ConnMaxLifetime)
 	s.Assert().Equal(5*time.Minute, cfg.Postgres.ConnMaxIdleTime)
+	s.Assert().Equal(5*time.Second, cfg.Postgres.QueryTimeout)
+	s.Assert().Equal(500*time.Millisecond, cfg.Postgres.SlowQueryThreshold)
+	s.Assert().False(cfg.Postgres.LogQueries)
+	s.Assert().Equal(5, cfg.Postgres.ConnectRetries)
+	s.Assert().Equal(time.Second, cfg.Postgres.ConnectRetryBackoff)
+	s.Assert().False(cfg.CaseInsensitiveIDs)
+	s.Assert().False(cfg.ValidateOnRead)
+	s.Assert().False(cfg.MemoryFallback)
+	s.Assert().False(cfg.Outbox.Enabled)
+	s.Assert().Equal(time.Second, cfg.Outbox.PollInterval)
+	s.Assert().Equal(100, cfg.Outbox.BatchSize)
+	s.Assert().Empty(cfg.Postgres.ReplicaHost)
+
+	_, ok := cfg.Postgres.ReplicaConfig()
+	s.Assert().False(ok)
 }
 
 func (s *DatabaseConfigTestSuite) TestLoadDatabase_WithEnvironmentVariables() {
 	envVars := map[string]string{
-		"ENV":                         EnvProduction,
-		"LOGGER_LEVEL":                "error",
-		"LOGGER_FORMAT":               "text",
-		"POSTGRES_HOST":               "db.example.com",
-		"POSTGRES_PORT":               "5433",
-		"POSTGRES_USER":               "myuser",
-		"POSTGRES_PASSWORD":           "mypassword",
-		"POSTGRES_DB":                 "mydatabase",
-		"POSTGRES_SSL_MODE":           "require",
-		"POSTGRES_MAX_OPEN_CONNS":     "50",
-		"POSTGRES_MAX_IDLE_CONNS":     "10",
-		"POSTGRES_CONN_MAX_LIFETIME":  "10m",
-		"POSTGRES_CONN_MAX_IDLE_TIME": "15m",
+		"ENV":                            EnvProduction,
+		"LOGGER_LEVEL":                   "error",
+		"LOGGER_FORMAT":                  "text",
+		"POSTGRES_HOST":                  "db.example.com",
+		"POSTGRES_PORT":                  "5433",
+		"POSTGRES_USER":                  "myuser",
+		"POSTGRES_PASSWORD":              "mypassword",
+		"POSTGRES_DB":                    "mydatabase",
+		"POSTGRES_SSL_MODE":              "require",
+		"POSTGRES_MAX_OPEN_CONNS":        "50",
+		"POSTGRES_MAX_IDLE_CONNS":        "10",
+		"POSTGRES_MIN_IDLE_CONNS":        "4",
+		"POSTGRES_CONN_MAX_LIFETIME":     "10m",
+		"POSTGRES_CONN_MAX_IDLE_TIME":    "15m",
+		"POSTGRES_QUERY_TIMEOUT":         "2s",
+		"POSTGRES_SLOW_QUERY_THRESHOLD":  "1s",
+		"POSTGRES_LOG_QUERIES":           "true",
+		"POSTGRES_CONNECT_RETRIES":       "3",
+		"POSTGRES_CONNECT_RETRY_BACKOFF": "500ms",
+		"POSTGRES_REPLICA_HOST":          "replica.example.com",
+		"POSTGRES_REPLICA_PORT":          "5434",
+		"CASE_INSENSITIVE_IDS":           "true",
+		"VALIDATE_ON_READ":               "true",
+		"DATABASE_MEMORY_FALLBACK":       "true",
+		"OUTBOX_ENABLED":                 "true",
+		"OUTBOX_POLL_INTERVAL":           "250ms",
+		"OUTBOX_BATCH_SIZE":              "20",
 	}
 
 	for key, value := range envVars {
@@ -109,8 +151,22 @@ func (s *DatabaseConfigTestSuite) TestLoadDatabase_WithEnvironmentVariables() {
 	s.Assert().Equal("require", cfg.Postgres.SSLMode)
 	s.Assert().Equal(50, cfg.Postgres.MaxOpenConns)
 	s.Assert().Equal(10, cfg.Postgres.MaxIdleConns)
+	s.Assert().Equal(4, cfg.Postgres.MinIdleConns)
 	s.Assert().Equal(10*time.Minute, cfg.Postgres.ConnMaxLifetime)
 	s.Assert().Equal(15*time.Minute, cfg.Postgres.ConnMaxIdleTime)
+	s.Assert().Equal(2*time.Second, cfg.Postgres.QueryTimeout)
+	s.Assert().Equal(time.Second, cfg.Postgres.SlowQueryThreshold)
+	s.Assert().True(cfg.Postgres.LogQueries)
+	s.Assert().Equal(3, cfg.Postgres.ConnectRetries)
+	s.Assert().Equal(500*time.Millisecond, cfg.Postgres.ConnectRetryBackoff)
+	s.Assert().True(cfg.CaseInsensitiveIDs)
+	s.Assert().True(cfg.ValidateOnRead)
+	s.Assert().True(cfg.MemoryFallback)
+	s.Assert().True(cfg.Outbox.Enabled)
+	s.Assert().Equal(250*time.Millisecond, cfg.Outbox.PollInterval)
+	s.Assert().Equal(20, cfg.Outbox.BatchSize)
+	s.Assert().Equal("replica.example.com", cfg.Postgres.ReplicaHost)
+	s.Assert().Equal(5434, cfg.Postgres.ReplicaPort)
 
 	for key := range envVars {
 		s.Require().NoError(os.Unsetenv(key))
@@ -181,6 +237,51 @@ func (s *DatabaseConfigTestSuite) TestPostgresConfig_DSN() {
 	}
 }
 
+func (s *DatabaseConfigTestSuite) TestPostgresConfig_ReplicaConfig() {
+	primary := PostgresConfig{
+		Host:         "primary.example.com",
+		Port:         5432,
+		User:         "app",
+		Password:     "secret",
+		Database:     "microservice",
+		SSLMode:      "require",
+		MaxOpenConns: 25,
+		QueryTimeout: 5 * time.Second,
+	}
+
+	s.Run("not_configured", func() {
+		_, ok := primary.ReplicaConfig()
+		s.Assert().False(ok)
+	})
+
+	s.Run("inherits_primary_settings", func() {
+		cfg := primary
+		cfg.ReplicaHost = "replica.example.com"
+
+		replica, ok := cfg.ReplicaConfig()
+
+		s.Require().True(ok)
+		s.Assert().Equal("host=replica.example.com port=5432 user=app password=secret dbname=microservice sslmode=require", replica.DSN())
+		s.Assert().Equal(25, replica.GetMaxOpenConns())
+		s.Assert().Equal(5*time.Second, replica.QueryTimeout)
+		s.Assert().Equal("primary.example.com", cfg.Host)
+	})
+
+	s.Run("overrides", func() {
+		cfg := primary
+		cfg.ReplicaHost = "replica.example.com"
+		cfg.ReplicaPort = 5433
+		cfg.ReplicaUser = "reader"
+		cfg.ReplicaPassword = "readonly"
+		cfg.ReplicaDatabase = "microservice_ro"
+
+		replica, ok := cfg.ReplicaConfig()
+
+		s.Require().True(ok)
+		s.Assert().Equal("host=replica.example.com port=5433 user=reader password=readonly dbname=microservice_ro sslmode=require", replica.DSN())
+	})
+}
+
 func (s *DatabaseConfigTestSuite) TestPostgresConfig_Getters() {
 	config := PostgresConfig{
 		MaxOpenConns:    25,
@@ -447,6 +548,22 @@ func BenchmarkPostgresConfig_DSN(b *testing.B) {
 	}
 }
 
+func (s *DatabaseConfigTestSuite) TestConnectRetryWait_CapsBackoff() {
+	cfg := DatabaseConfig{Postgres: PostgresConfig{ConnectRetries: 5, ConnectRetryBackoff: time.Second}}
+
+	s.Assert().Equal(time.Second, cfg.Postgres.ConnectRetryDelay(1))
+	s.Assert().Equal(8*time.Second, cfg.Postgres.ConnectRetryDelay(4))
+	s.Assert().Equal(MaxConnectRetryBackoff, cfg.Postgres.ConnectRetryDelay(5))
+	s.Assert().Equal(MaxConnectRetryBackoff, cfg.Postgres.ConnectRetryDelay(60))
+	s.Assert().Equal(25*time.Second, cfg.ConnectRetryWait())
+
+	cfg.Postgres.ReplicaHost = "replica"
+	s.Assert().Equal(50*time.Second, cfg.ConnectRetryWait())
+
+	cfg.Postgres.ConnectRetries = 0
+	s.Assert().Zero(cfg.ConnectRetryWait())
+}
+
 func BenchmarkPostgresConfig_Getters(b *testing.B) {
 	config := PostgresConfig{
 		MaxOpenConns:    25,
@@ -0,0 +1,71 @@
+package config
+
+import (
+	"microservice/internal/platform/logger"
+	"os"
+
+	"github.com/kelseyhightower/envconfig"
+)
+
+// DeprecatedKey is an environment variable that was renamed to Replacement.
+// Its value is still used for Replacement while Replacement itself is unset.
+type DeprecatedKey struct {
+	Key         string
+	Replacement string
+}
+
+// DeprecatedKeys lists the renamed variables that are still honored.
+var DeprecatedKeys = []DeprecatedKey{
+	{Key: "SERVICE_NAME", Replacement: "METRICS_SERVICE_NAME"},
+}
+
+// process loads cfg from the environment after mapping deprecated variables
+// to their replacements.
+func process(cfg interface{}) error {
+	if err := applyDeprecatedKeys(); err != nil {
+		return err
+	}
+	return envconfig.Process("", cfg)
+}
+
+func applyDeprecatedKeys() error {
+	for _, key := range DeprecatedKeys {
+		value, ok := os.LookupEnv(key.Key)
+		if !ok {
+			continue
+		}
+		if _, set := os.LookupEnv(key.Replacement); set {
+			continue
+		}
+		if err := os.Setenv(key.Replacement, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeprecatedKeysInUse returns the deprecated variables that are set.
+func DeprecatedKeysInUse() []DeprecatedKey {
+	var inUse []DeprecatedKey
+	for _, key := range DeprecatedKeys {
+		if _, ok := os.LookupEnv(key.Key); ok {
+			inUse = append(inUse, key)
+		}
+	}
+	return inUse
+}
+
+// WarnDeprecatedKeys logs a warning for every deprecated variable that is set
+// and passes it to record, for example to count it in a metric. Config is
+// loaded before logging is set up, so this runs once the logger exists.
+func WarnDeprecatedKeys(log logger.Logger, record func(DeprecatedKey)) {
+	for _, key := range DeprecatedKeysInUse() {
+		log.Warn("Deprecated config key is set",
+			logger.String("key", key.Key),
+			logger.String("replacement", key.Replacement),
+		)
+		if record != nil {
+			record(key)
+		}
+	}
+}
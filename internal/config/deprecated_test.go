@@ -0,0 +1,106 @@
+package config
+
+import (
+	"context"
+	"microservice/internal/platform/logger"
+	"microservice/internal/platform/metrics"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type warnRecorder struct {
+	logger.Logger
+	messages []string
+	fields   [][]logger.Field
+}
+
+func (w *warnRecorder) Warn(msg string, fields ...logger.Field) {
+	w.messages = append(w.messages, msg)
+	w.fields = append(w.fields, fields)
+}
+
+type DeprecatedKeysTestSuite struct {
+	suite.Suite
+	originalEnv map[string]string
+}
+
+func (s *DeprecatedKeysTestSuite) envVars() []string {
+	var vars []string
+	for _, key := range DeprecatedKeys {
+		vars = append(vars, key.Key, key.Replacement)
+	}
+	return vars
+}
+
+func (s *DeprecatedKeysTestSuite) SetupTest() {
+	s.originalEnv = make(map[string]string)
+	for _, env := range s.envVars() {
+		if val, exists := os.LookupEnv(env); exists {
+			s.originalEnv[env] = val
+		}
+		s.Require().NoError(os.Unsetenv(env))
+	}
+}
+
+func (s *DeprecatedKeysTestSuite) TearDownTest() {
+	for _, env := range s.envVars() {
+		s.Require().NoError(os.Unsetenv(env))
+	}
+	for env, val := range s.originalEnv {
+		s.Require().NoError(os.Setenv(env, val))
+	}
+}
+
+func (s *DeprecatedKeysTestSuite) TestLoadHttp_HonorsDeprecatedKey() {
+	s.Require().NoError(os.Setenv("SERVICE_NAME", "orders"))
+
+	cfg, err := LoadHttp()
+	s.Require().NoError(err)
+	s.Assert().Equal("orders", cfg.Metrics.ServiceName)
+}
+
+func (s *DeprecatedKeysTestSuite) TestLoadHttp_ReplacementWins() {
+	s.Require().NoError(os.Setenv("SERVICE_NAME", "orders"))
+	s.Require().NoError(os.Setenv("METRICS_SERVICE_NAME", "billing"))
+
+	cfg, err := LoadHttp()
+	s.Require().NoError(err)
+	s.Assert().Equal("billing", cfg.Metrics.ServiceName)
+}
+
+func (s *DeprecatedKeysTestSuite) TestWarnDeprecatedKeys_LogsAndRecords() {
+	s.Require().NoError(os.Setenv("SERVICE_NAME", "orders"))
+
+	provider, err := metrics.NewProvider()
+	s.Require().NoError(err)
+	log := &warnRecorder{Logger: logger.NewNop()}
+
+	WarnDeprecatedKeys(log, func(key DeprecatedKey) {
+		provider.RecordDeprecatedConfigKey(context.Background(), key.Key, key.Replacement)
+	})
+
+	s.Require().Equal([]string{"Deprecated config key is set"}, log.messages)
+	s.Assert().Contains(log.fields[0], logger.String("key", "SERVICE_NAME"))
+	s.Assert().Contains(log.fields[0], logger.String("replacement", "METRICS_SERVICE_NAME"))
+
+	w := httptest.NewRecorder()
+	provider.Handler().ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+	s.Assert().Regexp(`config_deprecated_keys_total\{[^}]*key="SERVICE_NAME"[^}]*\} 1`, w.Body.String())
+}
+
+func (s *DeprecatedKeysTestSuite) TestWarnDeprecatedKeys_NoneSet() {
+	log := &warnRecorder{Logger: logger.NewNop()}
+	recorded := 0
+
+	WarnDeprecatedKeys(log, func(DeprecatedKey) { recorded++ })
+
+	s.Assert().Empty(log.messages)
+	s.Assert().Zero(recorded)
+}
+
+func TestDeprecatedKeysTestSuite(t *testing.T) {
+	suite.Run(t, new(DeprecatedKeysTestSuite))
+}
@@ -1,14 +1,128 @@
 package config
 
 import (
-	"github.com/kelseyhightower/envconfig"
+	"os"
+	"strings"
 )
 
 type HttpConfig struct {
 	BaseConfig
-	Server    HttpServerConfig `envconfig:"HTTP_SERVER"`
-	RateLimit RateLimitConfig  `envconfig:"RATE_LIMIT"`
-	CORS      CORSConfig       `envconfig:"CORS"`
+	Server      HttpServerConfig  `envconfig:"HTTP_SERVER"`
+	TLS         TLSConfig         `envconfig:"HTTP_TLS"`
+	Admin       AdminServerConfig `envconfig:"HTTP_ADMIN"`
+	RateLimit   RateLimitConfig   `envconfig:"RATE_LIMIT"`
+	CORS        CORSConfig        `envconfig:"CORS"`
+	AdminCORS   CORSConfig        `envconfig:"ADMIN_CORS"`
+	Metrics     MetricsConfig     `envconfig:"METRICS"`
+	Security    SecurityConfig    `envconfig:"SECURITY"`
+	AccessLog   AccessLogConfig   `envconfig:"ACCESS_LOG"`
+	Idempotency IdempotencyConfig `envconfig:"IDEMPOTENCY"`
+	MaxBodySize int64             `envconfig:"HTTP_MAX_BODY_SIZE" default:"1048576"`
+
+	MaxBatchItems int `envconfig:"HTTP_MAX_BATCH_ITEMS" default:"1000"`
+	MaxJSONDepth  int `envconfig:"HTTP_MAX_JSON_DEPTH" default:"32"`
+	// HealthCacheTTL is in seconds; zero runs every check on each probe.
+	HealthCacheTTL int `envconfig:"HEALTH_CACHE_TTL" default:"0"`
+	// HealthCheckTimeout is in seconds; checks still running after it are
+	// reported as warn.
+	HealthCheckTimeout int `envconfig:"HEALTH_CHECK_TIMEOUT" default:"5"`
+	// HealthCheckConcurrency caps how many checks run at once; zero runs them
+	// all in parallel.
+	HealthCheckConcurrency int `envconfig:"HEALTH_CHECK_CONCURRENCY" default:"0"`
+	// DeploymentHeaders adds X-Served-By, X-App-Version and X-Env to responses.
+	// Keep it off in production so instance details are not exposed.
+	DeploymentHeaders bool `envconfig:"HTTP_DEPLOYMENT_HEADERS" default:"false"`
+	// MaxBulkInFlight caps concurrent bulk requests across all clients; excess
+	// ones get 503. Zero or less disables the cap.
+	MaxBulkInFlight int `envconfig:"HTTP_MAX_BULK_IN_FLIGHT" default:"4"`
+	// EventsHeartbeat is in seconds; EventsBuffer is how many events a slow
+	// /api/events client may fall behind before it is disconnected.
+	EventsHeartbeat int `envconfig:"HTTP_EVENTS_HEARTBEAT" default:"15"`
+	EventsBuffer    int `envconfig:"HTTP_EVENTS_BUFFER" default:"64"`
+
+	// CertFiles lists extra PEM files whose expiry is reported by readiness,
+	// next to the TLS certificate. Within CertExpiryWarnDays of expiry the
+	// check warns; once expired it fails.
+	CertFiles          []string `envconfig:"HEALTH_CERT_FILES" default:""`
+	CertExpiryWarnDays int      `envconfig:"HEALTH_CERT_EXPIRY_WARN_DAYS" default:"14"`
+
+	// DiskPath enables a readiness check that fails when the filesystem
+	// holding it has less than DiskMinFreeBytes available.
+	DiskPath         string `envconfig:"HEALTH_DISK_PATH" default:""`
+	DiskMinFreeBytes uint64 `envconfig:"HEALTH_DISK_MIN_FREE_BYTES" default:"104857600"`
+
+	// MethodOverride lets POST requests carry X-HTTP-Method-Override to reach
+	// PUT, PATCH and DELETE routes, for clients behind restrictive proxies.
+	MethodOverride bool `envconfig:"HTTP_METHOD_OVERRIDE" default:"false"`
+	// RequiredHeaders must be sent on every /api/examples request, and
+	// RequiredWriteHeaders additionally on its POST and PATCH routes. Requests
+	// missing any get 400.
+	RequiredHeaders      []string `envconfig:"HTTP_REQUIRED_HEADERS" default:""`
+	RequiredWriteHeaders []string `envconfig:"HTTP_REQUIRED_WRITE_HEADERS" default:""`
+
+	// ServerTiming adds a Server-Timing header breaking responses down into
+	// validate, db and encode time. With ServerTimingHeader set, only requests
+	// carrying that header get it. Meant for development.
+	ServerTiming       bool   `envconfig:"HTTP_SERVER_TIMING" default:"false"`
+	ServerTimingHeader string `envconfig:"HTTP_SERVER_TIMING_HEADER" default:""`
+
+	// StrictQuery rejects requests that repeat a query parameter bound to a
+	// single value with 400 instead of using the first one.
+	StrictQuery bool `envconfig:"HTTP_STRICT_QUERY" default:"false"`
+	// DisallowUnknownFields rejects /api/examples bodies with fields the
+	// request does not declare with 400 instead of ignoring them.
+	DisallowUnknownFields bool `envconfig:"HTTP_DISALLOW_UNKNOWN_FIELDS" default:"false"`
+
+	// EntityIDMode is IDModeClient when create requests must carry an ID and
+	// IDModeServer when the service generates IDs for requests that omit one.
+	EntityIDMode string `envconfig:"ENTITY_ID_MODE" default:"client"`
+
+	// RequestIDMaxLength caps incoming X-Request-Id values that are reused;
+	// longer ones, or ones with unsafe characters, are replaced.
+	RequestIDMaxLength int `envconfig:"HTTP_REQUEST_ID_MAX_LENGTH" default:"128"`
+
+	// BasePath mounts every route under a prefix such as /svc/examples, for
+	// path-based gateways. Health, metrics, /version and /admin stay at the
+	// root while OperationalAtRoot is set.
+	BasePath          string `envconfig:"HTTP_BASE_PATH" default:""`
+	OperationalAtRoot bool   `envconfig:"HTTP_OPERATIONAL_AT_ROOT" default:"true"`
+
+	// JSONPretty indents JSON responses; development always gets them
+	// indented. JSONOmitNull sends an empty body instead of null.
+	JSONPretty   bool `envconfig:"JSON_PRETTY" default:"false"`
+	JSONOmitNull bool `envconfig:"JSON_OMIT_NULL" default:"false"`
+
+	// MaintenanceMode starts the service answering /api requests with 503;
+	// PUT /admin/maintenance flips it at runtime. MaintenanceRetryAfter is the
+	// Retry-After sent with those responses, in seconds.
+	MaintenanceMode       bool `envconfig:"HTTP_MAINTENANCE_MODE" default:"false"`
+	MaintenanceRetryAfter int  `envconfig:"HTTP_MAINTENANCE_RETRY_AFTER" default:"60"`
+}
+
+// Values of HttpConfig.EntityIDMode.
+const (
+	IDModeClient = "client"
+	IDModeServer = "server"
+)
+
+// RoutePrefix returns BasePath with a leading slash and without a trailing
+// one, or an empty string when no prefix is set.
+func (c *HttpConfig) RoutePrefix() string {
+	prefix := strings.Trim(strings.TrimSpace(c.BasePath), "/")
+	if prefix == "" {
+		return ""
+	}
+	return "/" + prefix
+}
+
+// PrettyJSON reports whether JSON responses are indented.
+func (c *HttpConfig) PrettyJSON() bool {
+	return c.JSONPretty || c.IsDevelopment()
+}
+
+// GeneratesIDs reports whether IDs omitted from create requests are generated.
+func (c *HttpConfig) GeneratesIDs() bool {
+	return strings.ToLower(c.EntityIDMode) == IDModeServer
 }
 
 type HttpServerConfig struct {
@@ -17,6 +131,25 @@ type HttpServerConfig struct {
 	ReadTimeout  int    `envconfig:"READ_TIMEOUT" default:"30"`
 	WriteTimeout int    `envconfig:"WRITE_TIMEOUT" default:"30"`
 	IdleTimeout  int    `envconfig:"IDLE_TIMEOUT" default:"120"`
+
+	// ReadHeaderTimeout bounds how long a client may take to send the request
+	// headers, in seconds, so slowloris clients cannot hold connections open.
+	ReadHeaderTimeout int `envconfig:"READ_HEADER_TIMEOUT" default:"5"`
+	MaxHeaderBytes    int `envconfig:"MAX_HEADER_BYTES" default:"1048576"`
+}
+
+// AdminServerConfig enables a second listener for health, metrics, pprof and
+// admin endpoints. When enabled they are no longer served by the API server.
+type AdminServerConfig struct {
+	Enabled bool   `envconfig:"ENABLED" default:"false"`
+	Host    string `envconfig:"HOST" default:"0.0.0.0"`
+	Port    int    `envconfig:"HTTP_ADMIN_PORT" default:"8081"`
+}
+
+type TLSConfig struct {
+	Enabled  bool   `envconfig:"ENABLED" default:"false"`
+	CertFile string `envconfig:"CERT_FILE"`
+	KeyFile  string `envconfig:"KEY_FILE"`
 }
 
 type RateLimitConfig struct {
@@ -24,21 +157,146 @@ type RateLimitConfig struct {
 	GlobalWindow   int `envconfig:"GLOBAL_WINDOW" default:"60"`
 	RequestsPerIP  int `envconfig:"REQUESTS_PER_IP" default:"100"`
 	WindowSeconds  int `envconfig:"WINDOW_SECONDS" default:"60"`
+
+	// Shadow limits only log and count the requests they would reject.
+	GlobalShadow bool `envconfig:"GLOBAL_SHADOW" default:"false"`
+	ShadowPerIP  bool `envconfig:"SHADOW_PER_IP" default:"false"`
 }
 
 type CORSConfig struct {
 	AllowedOrigins   []string `envconfig:"ALLOWED_ORIGINS" default:"*"`
-	AllowedMethods   []string `envconfig:"ALLOWED_METHODS" default:"GET,POST,PUT,DELETE,OPTIONS"`
-	AllowedHeaders   []string `envconfig:"ALLOWED_HEADERS" default:"Accept,Authorization,Content-Type,X-CSRF-Token"`
+	AllowedMethods   []string `envconfig:"ALLOWED_METHODS" default:"GET,POST,PUT,PATCH,DELETE,OPTIONS"`
+	AllowedHeaders   []string `envconfig:"ALLOWED_HEADERS" default:"Accept,Authorization,Content-Type,Idempotency-Key,X-CSRF-Token"`
 	ExposedHeaders   []string `envconfig:"EXPOSED_HEADERS" default:""`
 	AllowCredentials bool     `envconfig:"ALLOW_CREDENTIALS" default:"false"`
 	MaxAge           int      `envconfig:"MAX_AGE" default:"86400"`
 }
 
+type SecurityConfig struct {
+	FrameOptions          string `envconfig:"FRAME_OPTIONS" default:"DENY"`
+	ReferrerPolicy        string `envconfig:"REFERRER_POLICY" default:"no-referrer"`
+	ContentSecurityPolicy string `envconfig:"CONTENT_SECURITY_POLICY" default:""`
+	HSTSMaxAge            int    `envconfig:"HSTS_MAX_AGE" default:"31536000"`
+	HSTSIncludeSubdomains bool   `envconfig:"HSTS_INCLUDE_SUBDOMAINS" default:"false"`
+
+	// APIContentSecurityPolicy replaces ContentSecurityPolicy on /api routes.
+	APIContentSecurityPolicy string `envconfig:"API_CONTENT_SECURITY_POLICY" default:"default-src 'none'; frame-ancestors 'none'"`
+}
+
+type AccessLogConfig struct {
+	ClientFields   bool     `envconfig:"CLIENT_FIELDS" default:"false"`
+	MaxFieldLength int      `envconfig:"MAX_FIELD_LENGTH" default:"256"`
+	SkipPaths      []string `envconfig:"SKIP_PATHS" default:""`
+	SampleRate     float64  `envconfig:"SAMPLE_RATE" default:"1"`
+}
+
+type IdempotencyConfig struct {
+	TTL int `envconfig:"TTL" default:"86400"`
+	// MaxEntries caps the stored responses, evicting the least recently used;
+	// zero or less leaves it unbounded. SweepInterval is in seconds.
+	MaxEntries    int `envconfig:"MAX_ENTRIES" default:"10000"`
+	SweepInterval int `envconfig:"SWEEP_INTERVAL" default:"60"`
+}
+
+type MetricsConfig struct {
+	ServiceName string `envconfig:"SERVICE_NAME" default:"microservice"`
+	// Instance, when set, labels every metric as host and replaces the
+	// hostname in X-Served-By. Instance and Namespace have no envconfig tag on
+	// purpose: a tagged field is also read from its bare tag, and INSTANCE or
+	// NAMESPACE, such as the Kubernetes namespace, mean something else.
+	Instance string
+	// Namespace prefixes every metric name; empty keeps the bare names.
+	Namespace string
+	// DurationBuckets are the request duration histogram boundaries in
+	// seconds. They must be strictly increasing.
+	DurationBuckets []float64 `envconfig:"DURATION_BUCKETS" default:"0.001,0.005,0.01,0.025,0.05,0.1,0.25,0.5,1,2.5,5,10"`
+}
+
 func LoadHttp() (*HttpConfig, error) {
 	var cfg HttpConfig
-	if err := envconfig.Process("", &cfg); err != nil {
+	if err := process(&cfg); err != nil {
+		return nil, err
+	}
+
+	// Health, metrics and admin endpoints follow the API policy unless an
+	// ADMIN_CORS_* variable is set.
+	if !hasEnvPrefix("ADMIN_CORS_") {
+		cfg.AdminCORS = cfg.CORS
+	}
+	cfg.CORS.trimSpace()
+	cfg.AdminCORS.trimSpace()
+	cfg.AccessLog.SkipPaths = trimList(cfg.AccessLog.SkipPaths)
+	cfg.CertFiles = trimList(cfg.CertFiles)
+	cfg.RequiredHeaders = trimList(cfg.RequiredHeaders)
+	cfg.RequiredWriteHeaders = trimList(cfg.RequiredWriteHeaders)
+
+	if err := cfg.Validate(); err != nil {
 		return nil, err
 	}
 	return &cfg, nil
 }
+
+// Validate rejects settings that would only fail once the server is started
+// or serving, such as out-of-range ports or TLS without a certificate.
+func (c *HttpConfig) Validate() error {
+	var p problems
+	c.check(&p)
+
+	p.port("HTTP_SERVER_PORT", c.Server.Port)
+	p.nonNegative("HTTP_SERVER_READ_TIMEOUT", int64(c.Server.ReadTimeout))
+	p.nonNegative("HTTP_SERVER_WRITE_TIMEOUT", int64(c.Server.WriteTimeout))
+	p.nonNegative("HTTP_SERVER_IDLE_TIMEOUT", int64(c.Server.IdleTimeout))
+	p.nonNegative("HTTP_SERVER_READ_HEADER_TIMEOUT", int64(c.Server.ReadHeaderTimeout))
+	p.nonNegative("HTTP_SERVER_MAX_HEADER_BYTES", int64(c.Server.MaxHeaderBytes))
+	p.nonNegative("HTTP_MAINTENANCE_RETRY_AFTER", int64(c.MaintenanceRetryAfter))
+	p.nonNegative("HEALTH_CHECK_CONCURRENCY", int64(c.HealthCheckConcurrency))
+
+	if c.Admin.Enabled {
+		p.port("HTTP_ADMIN_PORT", c.Admin.Port)
+		if c.Admin.Port != 0 && c.Admin.Port == c.Server.Port {
+			p.add("HTTP_ADMIN_PORT must differ from HTTP_SERVER_PORT, both are %d", c.Admin.Port)
+		}
+	}
+	if c.TLS.Enabled && (c.TLS.CertFile == "" || c.TLS.KeyFile == "") {
+		p.add("HTTP_TLS_CERT_FILE and HTTP_TLS_KEY_FILE must be set when HTTP_TLS_ENABLED=true")
+	}
+
+	switch strings.ToLower(c.EntityIDMode) {
+	case IDModeClient, IDModeServer:
+	default:
+		p.add("ENTITY_ID_MODE must be %s or %s, got %q", IDModeClient, IDModeServer, c.EntityIDMode)
+	}
+
+	return p.err("http")
+}
+
+// trimSpace strips whitespace around list entries and drops empty ones, so
+// "a.com, b.com" matches the same origins as "a.com,b.com".
+func (c *CORSConfig) trimSpace() {
+	c.AllowedOrigins = trimList(c.AllowedOrigins)
+	c.AllowedMethods = trimList(c.AllowedMethods)
+	c.AllowedHeaders = trimList(c.AllowedHeaders)
+	c.ExposedHeaders = trimList(c.ExposedHeaders)
+}
+
+func trimList(values []string) []string {
+	if values == nil {
+		return nil
+	}
+	trimmed := make([]string, 0, len(values))
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			trimmed = append(trimmed, value)
+		}
+	}
+	return trimmed
+}
+
+func hasEnvPrefix(prefix string) bool {
+	for _, env := range os.Environ() {
+		if strings.HasPrefix(env, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -20,10 +20,20 @@ func (s *HttpConfigTestSuite) SetupTest() {
 		"ENV", "LOGGER_LEVEL", "LOGGER_FORMAT",
 		"HTTP_SERVER_HOST", "HTTP_SERVER_PORT",
 		"HTTP_SERVER_READ_TIMEOUT", "HTTP_SERVER_WRITE_TIMEOUT", "HTTP_SERVER_IDLE_TIMEOUT",
+		"HTTP_SERVER_READ_HEADER_TIMEOUT", "HTTP_SERVER_MAX_HEADER_BYTES",
 		"RATE_LIMIT_GLOBAL_REQUESTS", "RATE_LIMIT_GLOBAL_WINDOW",
 		"RATE_LIMIT_REQUESTS_PER_IP", "RATE_LIMIT_WINDOW_SECONDS",
+		"RATE_LIMIT_GLOBAL_SHADOW", "RATE_LIMIT_SHADOW_PER_IP",
 		"CORS_ALLOWED_ORIGINS", "CORS_ALLOWED_METHODS", "CORS_ALLOWED_HEADERS",
 		"CORS_EXPOSED_HEADERS", "CORS_ALLOW_CREDENTIALS", "CORS_MAX_AGE",
+		"HTTP_MAX_BODY_SIZE", "HTTP_MAX_BATCH_ITEMS", "HTTP_MAX_JSON_DEPTH", "HEALTH_CACHE_TTL", "HEALTH_CHECK_TIMEOUT", "HEALTH_CHECK_CONCURRENCY", "HTTP_DEPLOYMENT_HEADERS", "HTTP_MAX_BULK_IN_FLIGHT", "HTTP_EVENTS_HEARTBEAT", "HTTP_EVENTS_BUFFER", "HEALTH_CERT_FILES", "HEALTH_CERT_EXPIRY_WARN_DAYS", "HEALTH_DISK_PATH", "HEALTH_DISK_MIN_FREE_BYTES", "HTTP_METHOD_OVERRIDE", "HTTP_REQUIRED_HEADERS", "HTTP_REQUIRED_WRITE_HEADERS", "HTTP_SERVER_TIMING", "HTTP_SERVER_TIMING_HEADER", "HTTP_STRICT_QUERY", "HTTP_DISALLOW_UNKNOWN_FIELDS", "ENTITY_ID_MODE", "HTTP_REQUEST_ID_MAX_LENGTH", "HTTP_BASE_PATH", "HTTP_OPERATIONAL_AT_ROOT", "JSON_PRETTY", "JSON_OMIT_NULL", "HTTP_MAINTENANCE_MODE", "HTTP_MAINTENANCE_RETRY_AFTER", "METRICS_SERVICE_NAME", "SERVICE_NAME", "METRICS_INSTANCE", "INSTANCE", "METRICS_NAMESPACE", "NAMESPACE", "METRICS_DURATION_BUCKETS",
+		"SECURITY_FRAME_OPTIONS", "SECURITY_REFERRER_POLICY", "SECURITY_CONTENT_SECURITY_POLICY",
+		"SECURITY_HSTS_MAX_AGE", "SECURITY_HSTS_INCLUDE_SUBDOMAINS", "SECURITY_API_CONTENT_SECURITY_POLICY",
+		"HTTP_TLS_ENABLED", "HTTP_TLS_CERT_FILE", "HTTP_TLS_KEY_FILE",
+		"HTTP_ADMIN_ENABLED", "HTTP_ADMIN_HOST", "HTTP_ADMIN_PORT",
+		"ADMIN_CORS_ALLOWED_ORIGINS", "ADMIN_CORS_ALLOWED_METHODS",
+		"ACCESS_LOG_CLIENT_FIELDS", "ACCESS_LOG_MAX_FIELD_LENGTH", "ACCESS_LOG_SKIP_PATHS", "ACCESS_LOG_SAMPLE_RATE",
+		"IDEMPOTENCY_TTL", "IDEMPOTENCY_MAX_ENTRIES", "IDEMPOTENCY_SWEEP_INTERVAL",
 	}
 
 	for _, env := range envVars {
@@ -39,10 +49,20 @@ func (s *HttpConfigTestSuite) TearDownTest() {
 		"ENV", "LOGGER_LEVEL", "LOGGER_FORMAT",
 		"HTTP_SERVER_HOST", "HTTP_SERVER_PORT",
 		"HTTP_SERVER_READ_TIMEOUT", "HTTP_SERVER_WRITE_TIMEOUT", "HTTP_SERVER_IDLE_TIMEOUT",
+		"HTTP_SERVER_READ_HEADER_TIMEOUT", "HTTP_SERVER_MAX_HEADER_BYTES",
 		"RATE_LIMIT_GLOBAL_REQUESTS", "RATE_LIMIT_GLOBAL_WINDOW",
 		"RATE_LIMIT_REQUESTS_PER_IP", "RATE_LIMIT_WINDOW_SECONDS",
+		"RATE_LIMIT_GLOBAL_SHADOW", "RATE_LIMIT_SHADOW_PER_IP",
 		"CORS_ALLOWED_ORIGINS", "CORS_ALLOWED_METHODS", "CORS_ALLOWED_HEADERS",
 		"CORS_EXPOSED_HEADERS", "CORS_ALLOW_CREDENTIALS", "CORS_MAX_AGE",
+		"HTTP_MAX_BODY_SIZE", "HTTP_MAX_BATCH_ITEMS", "HTTP_MAX_JSON_DEPTH", "HEALTH_CACHE_TTL", "HEALTH_CHECK_TIMEOUT", "HEALTH_CHECK_CONCURRENCY", "HTTP_DEPLOYMENT_HEADERS", "HTTP_MAX_BULK_IN_FLIGHT", "HTTP_EVENTS_HEARTBEAT", "HTTP_EVENTS_BUFFER", "HEALTH_CERT_FILES", "HEALTH_CERT_EXPIRY_WARN_DAYS", "HEALTH_DISK_PATH", "HEALTH_DISK_MIN_FREE_BYTES", "HTTP_METHOD_OVERRIDE", "HTTP_REQUIRED_HEADERS", "HTTP_REQUIRED_WRITE_HEADERS", "HTTP_SERVER_TIMING", "HTTP_SERVER_TIMING_HEADER", "HTTP_STRICT_QUERY", "HTTP_DISALLOW_UNKNOWN_FIELDS", "ENTITY_ID_MODE", "HTTP_REQUEST_ID_MAX_LENGTH", "HTTP_BASE_PATH", "HTTP_OPERATIONAL_AT_ROOT", "JSON_PRETTY", "JSON_OMIT_NULL", "HTTP_MAINTENANCE_MODE", "HTTP_MAINTENANCE_RETRY_AFTER", "METRICS_SERVICE_NAME", "SERVICE_NAME", "METRICS_INSTANCE", "INSTANCE", "METRICS_NAMESPACE", "NAMESPACE", "METRICS_DURATION_BUCKETS",
+		"SECURITY_FRAME_OPTIONS", "SECURITY_REFERRER_POLICY", "SECURITY_CONTENT_SECURITY_POLICY",
+		"SECURITY_HSTS_MAX_AGE", "SECURITY_HSTS_INCLUDE_SUBDOMAINS", "SECURITY_API_CONTENT_SECURITY_POLICY",
+		"HTTP_TLS_ENABLED", "HTTP_TLS_CERT_FILE", "HTTP_TLS_KEY_FILE",
+		"HTTP_ADMIN_ENABLED", "HTTP_ADMIN_HOST", "HTTP_ADMIN_PORT",
+		"ADMIN_CORS_ALLOWED_ORIGINS", "ADMIN_CORS_ALLOWED_METHODS",
+		"ACCESS_LOG_CLIENT_FIELDS", "ACCESS_LOG_MAX_FIELD_LENGTH", "ACCESS_LOG_SKIP_PATHS", "ACCESS_LOG_SAMPLE_RATE",
+		"IDEMPOTENCY_TTL", "IDEMPOTENCY_MAX_ENTRIES", "IDEMPOTENCY_SWEEP_INTERVAL",
 	}
 
 	for _, env := range envVars {
@@ -69,40 +89,158 @@ func (s *HttpConfigTestSuite) TestLoadHttp_DefaultValues() {
 	s.Assert().Equal(30, cfg.Server.ReadTimeout)
 	s.Assert().Equal(30, cfg.Server.WriteTimeout)
 	s.Assert().Equal(120, cfg.Server.IdleTimeout)
+	s.Assert().Equal(5, cfg.Server.ReadHeaderTimeout)
+	s.Assert().Equal(1<<20, cfg.Server.MaxHeaderBytes)
+
+	s.Assert().False(cfg.TLS.Enabled)
+	s.Assert().Empty(cfg.TLS.CertFile)
+	s.Assert().Empty(cfg.TLS.KeyFile)
 
 	s.Assert().Equal(1000, cfg.RateLimit.GlobalRequests)
 	s.Assert().Equal(60, cfg.RateLimit.GlobalWindow)
 	s.Assert().Equal(100, cfg.RateLimit.RequestsPerIP)
 	s.Assert().Equal(60, cfg.RateLimit.WindowSeconds)
+	s.Assert().False(cfg.RateLimit.GlobalShadow)
+	s.Assert().False(cfg.RateLimit.ShadowPerIP)
 
 	s.Assert().Equal([]string{"*"}, cfg.CORS.AllowedOrigins)
-	s.Assert().Equal([]string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}, cfg.CORS.AllowedMethods)
-	s.Assert().Equal([]string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"}, cfg.CORS.AllowedHeaders)
+	s.Assert().Equal([]string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}, cfg.CORS.AllowedMethods)
+	s.Assert().Equal([]string{"Accept", "Authorization", "Content-Type", "Idempotency-Key", "X-CSRF-Token"}, cfg.CORS.AllowedHeaders)
 	s.Assert().Empty(cfg.CORS.ExposedHeaders)
 	s.Assert().False(cfg.CORS.AllowCredentials)
 	s.Assert().Equal(86400, cfg.CORS.MaxAge)
+
+	s.Assert().Equal("DENY", cfg.Security.FrameOptions)
+	s.Assert().Equal("no-referrer", cfg.Security.ReferrerPolicy)
+	s.Assert().Empty(cfg.Security.ContentSecurityPolicy)
+	s.Assert().Equal(31536000, cfg.Security.HSTSMaxAge)
+	s.Assert().False(cfg.Security.HSTSIncludeSubdomains)
+	s.Assert().Equal("default-src 'none'; frame-ancestors 'none'", cfg.Security.APIContentSecurityPolicy)
+
+	s.Assert().False(cfg.Admin.Enabled)
+	s.Assert().Equal("0.0.0.0", cfg.Admin.Host)
+	s.Assert().Equal(8081, cfg.Admin.Port)
+
+	s.Assert().False(cfg.AccessLog.ClientFields)
+	s.Assert().Equal(256, cfg.AccessLog.MaxFieldLength)
+	s.Assert().Empty(cfg.AccessLog.SkipPaths)
+	s.Assert().Equal(1.0, cfg.AccessLog.SampleRate)
+
+	s.Assert().Equal(86400, cfg.Idempotency.TTL)
+	s.Assert().Equal(10000, cfg.Idempotency.MaxEntries)
+	s.Assert().Equal(60, cfg.Idempotency.SweepInterval)
+
+	s.Assert().Equal("microservice", cfg.Metrics.ServiceName)
+	s.Assert().Empty(cfg.Metrics.Instance)
+	s.Assert().Empty(cfg.Metrics.Namespace)
+	s.Assert().Equal([]float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}, cfg.Metrics.DurationBuckets)
+
+	s.Assert().Equal(int64(1048576), cfg.MaxBodySize)
+	s.Assert().Equal(1000, cfg.MaxBatchItems)
+	s.Assert().Equal(32, cfg.MaxJSONDepth)
+	s.Assert().Equal(0, cfg.HealthCacheTTL)
+	s.Assert().Equal(5, cfg.HealthCheckTimeout)
+	s.Assert().Zero(cfg.HealthCheckConcurrency)
+	s.Assert().False(cfg.DeploymentHeaders)
+	s.Assert().Equal(4, cfg.MaxBulkInFlight)
+	s.Assert().Equal(15, cfg.EventsHeartbeat)
+	s.Assert().Equal(64, cfg.EventsBuffer)
+	s.Assert().Empty(cfg.CertFiles)
+	s.Assert().Equal(14, cfg.CertExpiryWarnDays)
+	s.Assert().Empty(cfg.DiskPath)
+	s.Assert().Equal(uint64(100<<20), cfg.DiskMinFreeBytes)
+	s.Assert().False(cfg.MethodOverride)
+	s.Assert().Empty(cfg.RequiredHeaders)
+	s.Assert().Empty(cfg.RequiredWriteHeaders)
+	s.Assert().False(cfg.ServerTiming)
+	s.Assert().Empty(cfg.ServerTimingHeader)
+	s.Assert().False(cfg.StrictQuery)
+	s.Assert().False(cfg.DisallowUnknownFields)
+	s.Assert().Equal(IDModeClient, cfg.EntityIDMode)
+	s.Assert().False(cfg.GeneratesIDs())
+	s.Assert().Equal(128, cfg.RequestIDMaxLength)
+	s.Assert().Empty(cfg.RoutePrefix())
+	s.Assert().True(cfg.OperationalAtRoot)
+	s.Assert().False(cfg.JSONPretty)
+	s.Assert().False(cfg.JSONOmitNull)
+	s.Assert().True(cfg.PrettyJSON(), "development indents JSON")
+	s.Assert().False(cfg.MaintenanceMode)
+	s.Assert().Equal(60, cfg.MaintenanceRetryAfter)
 }
 
 func (s *HttpConfigTestSuite) TestLoadHttp_WithEnvironmentVariables() {
 	envVars := map[string]string{
-		"ENV":                        EnvProduction,
-		"LOGGER_LEVEL":               "error",
-		"LOGGER_FORMAT":              "text",
-		"HTTP_SERVER_HOST":           "127.0.0.1",
-		"HTTP_SERVER_PORT":           "9090",
-		"HTTP_SERVER_READ_TIMEOUT":   "60",
-		"HTTP_SERVER_WRITE_TIMEOUT":  "60",
-		"HTTP_SERVER_IDLE_TIMEOUT":   "300",
-		"RATE_LIMIT_GLOBAL_REQUESTS": "2000",
-		"RATE_LIMIT_GLOBAL_WINDOW":   "120",
-		"RATE_LIMIT_REQUESTS_PER_IP": "200",
-		"RATE_LIMIT_WINDOW_SECONDS":  "120",
-		"CORS_ALLOWED_ORIGINS":       "https://example.com,https://api.example.com",
-		"CORS_ALLOWED_METHODS":       "GET,POST,PUT",
-		"CORS_ALLOWED_HEADERS":       "Content-Type,Authorization",
-		"CORS_EXPOSED_HEADERS":       "X-Total-Count,X-Page-Count",
-		"CORS_ALLOW_CREDENTIALS":     "true",
-		"CORS_MAX_AGE":               "7200",
+		"ENV":                                  EnvProduction,
+		"LOGGER_LEVEL":                         "error",
+		"LOGGER_FORMAT":                        "text",
+		"HTTP_SERVER_HOST":                     "127.0.0.1",
+		"HTTP_SERVER_PORT":                     "9090",
+		"HTTP_SERVER_READ_TIMEOUT":             "60",
+		"HTTP_SERVER_WRITE_TIMEOUT":            "60",
+		"HTTP_SERVER_IDLE_TIMEOUT":             "300",
+		"HTTP_SERVER_READ_HEADER_TIMEOUT":      "2",
+		"HTTP_SERVER_MAX_HEADER_BYTES":         "16384",
+		"RATE_LIMIT_GLOBAL_REQUESTS":           "2000",
+		"RATE_LIMIT_GLOBAL_WINDOW":             "120",
+		"RATE_LIMIT_REQUESTS_PER_IP":           "200",
+		"RATE_LIMIT_WINDOW_SECONDS":            "120",
+		"RATE_LIMIT_GLOBAL_SHADOW":             "true",
+		"RATE_LIMIT_SHADOW_PER_IP":             "true",
+		"CORS_ALLOWED_ORIGINS":                 "https://example.com,https://api.example.com",
+		"CORS_ALLOWED_METHODS":                 "GET,POST,PUT",
+		"CORS_ALLOWED_HEADERS":                 "Content-Type,Authorization",
+		"CORS_EXPOSED_HEADERS":                 "X-Total-Count,X-Page-Count",
+		"CORS_ALLOW_CREDENTIALS":               "true",
+		"CORS_MAX_AGE":                         "7200",
+		"HTTP_MAX_BODY_SIZE":                   "2048",
+		"HTTP_MAX_BATCH_ITEMS":                 "50",
+		"HTTP_MAX_JSON_DEPTH":                  "8",
+		"HEALTH_CACHE_TTL":                     "2",
+		"HEALTH_CHECK_TIMEOUT":                 "3",
+		"HEALTH_CHECK_CONCURRENCY":             "4",
+		"HTTP_DEPLOYMENT_HEADERS":              "true",
+		"HTTP_MAX_BULK_IN_FLIGHT":              "2",
+		"HTTP_EVENTS_HEARTBEAT":                "5",
+		"HTTP_EVENTS_BUFFER":                   "8",
+		"HEALTH_CERT_FILES":                    "/etc/ssl/ca.pem, /etc/ssl/client.pem",
+		"HEALTH_CERT_EXPIRY_WARN_DAYS":         "30",
+		"HEALTH_DISK_PATH":                     "/tmp",
+		"HEALTH_DISK_MIN_FREE_BYTES":           "1073741824",
+		"HTTP_METHOD_OVERRIDE":                 "true",
+		"HTTP_REQUIRED_HEADERS":                "X-Tenant-ID",
+		"HTTP_REQUIRED_WRITE_HEADERS":          "Idempotency-Key, X-Request-Source",
+		"HTTP_SERVER_TIMING":                   "true",
+		"HTTP_SERVER_TIMING_HEADER":            "X-Debug-Timing",
+		"HTTP_STRICT_QUERY":                    "true",
+		"HTTP_DISALLOW_UNKNOWN_FIELDS":         "true",
+		"ENTITY_ID_MODE":                       "server",
+		"HTTP_REQUEST_ID_MAX_LENGTH":           "64",
+		"HTTP_BASE_PATH":                       "svc/examples/",
+		"HTTP_OPERATIONAL_AT_ROOT":             "false",
+		"JSON_PRETTY":                          "true",
+		"JSON_OMIT_NULL":                       "true",
+		"HTTP_MAINTENANCE_MODE":                "true",
+		"HTTP_MAINTENANCE_RETRY_AFTER":         "300",
+		"METRICS_SERVICE_NAME":                 "orders",
+		"METRICS_INSTANCE":                     "orders-1",
+		"METRICS_NAMESPACE":                    "orders",
+		"METRICS_DURATION_BUCKETS":             "0.1,0.3,1.5",
+		"SECURITY_FRAME_OPTIONS":               "SAMEORIGIN",
+		"SECURITY_HSTS_MAX_AGE":                "600",
+		"SECURITY_API_CONTENT_SECURITY_POLICY": "default-src 'self'",
+		"HTTP_TLS_ENABLED":                     "true",
+		"HTTP_TLS_CERT_FILE":                   "/etc/tls/tls.crt",
+		"HTTP_TLS_KEY_FILE":                    "/etc/tls/tls.key",
+		"HTTP_ADMIN_ENABLED":                   "true",
+		"HTTP_ADMIN_HOST":                      "127.0.0.1",
+		"HTTP_ADMIN_PORT":                      "9100",
+		"ACCESS_LOG_CLIENT_FIELDS":             "true",
+		"ACCESS_LOG_MAX_FIELD_LENGTH":          "64",
+		"ACCESS_LOG_SKIP_PATHS":                "/health/live, /metrics",
+		"ACCESS_LOG_SAMPLE_RATE":               "0.25",
+		"IDEMPOTENCY_TTL":                      "3600",
+		"IDEMPOTENCY_MAX_ENTRIES":              "500",
+		"IDEMPOTENCY_SWEEP_INTERVAL":           "30",
 	}
 
 	for key, value := range envVars {
@@ -123,11 +261,23 @@ func (s *HttpConfigTestSuite) TestLoadHttp_WithEnvironmentVariables() {
 	s.Assert().Equal(60, cfg.Server.ReadTimeout)
 	s.Assert().Equal(60, cfg.Server.WriteTimeout)
 	s.Assert().Equal(300, cfg.Server.IdleTimeout)
+	s.Assert().Equal(2, cfg.Server.ReadHeaderTimeout)
+	s.Assert().Equal(16384, cfg.Server.MaxHeaderBytes)
+
+	s.Assert().True(cfg.TLS.Enabled)
+	s.Assert().Equal("/etc/tls/tls.crt", cfg.TLS.CertFile)
+	s.Assert().Equal("/etc/tls/tls.key", cfg.TLS.KeyFile)
+
+	s.Assert().True(cfg.Admin.Enabled)
+	s.Assert().Equal("127.0.0.1", cfg.Admin.Host)
+	s.Assert().Equal(9100, cfg.Admin.Port)
 
 	s.Assert().Equal(2000, cfg.RateLimit.GlobalRequests)
 	s.Assert().Equal(120, cfg.RateLimit.GlobalWindow)
 	s.Assert().Equal(200, cfg.RateLimit.RequestsPerIP)
 	s.Assert().Equal(120, cfg.RateLimit.WindowSeconds)
+	s.Assert().True(cfg.RateLimit.GlobalShadow)
+	s.Assert().True(cfg.RateLimit.ShadowPerIP)
 
 	s.Assert().Equal([]string{"https://example.com", "https://api.example.com"}, cfg.CORS.AllowedOrigins)
 	s.Assert().Equal([]string{"GET", "POST", "PUT"}, cfg.CORS.AllowedMethods)
@@ -136,6 +286,56 @@ func (s *HttpConfigTestSuite) TestLoadHttp_WithEnvironmentVariables() {
 	s.Assert().True(cfg.CORS.AllowCredentials)
 	s.Assert().Equal(7200, cfg.CORS.MaxAge)
 
+	s.Assert().Equal("SAMEORIGIN", cfg.Security.FrameOptions)
+	s.Assert().Equal(600, cfg.Security.HSTSMaxAge)
+	s.Assert().Equal("default-src 'self'", cfg.Security.APIContentSecurityPolicy)
+
+	s.Assert().True(cfg.AccessLog.ClientFields)
+	s.Assert().Equal(64, cfg.AccessLog.MaxFieldLength)
+	s.Assert().Equal([]string{"/health/live", "/metrics"}, cfg.AccessLog.SkipPaths)
+	s.Assert().Equal(0.25, cfg.AccessLog.SampleRate)
+
+	s.Assert().Equal(3600, cfg.Idempotency.TTL)
+	s.Assert().Equal(500, cfg.Idempotency.MaxEntries)
+	s.Assert().Equal(30, cfg.Idempotency.SweepInterval)
+
+	s.Assert().Equal("orders", cfg.Metrics.ServiceName)
+	s.Assert().Equal("orders-1", cfg.Metrics.Instance)
+	s.Assert().Equal("orders", cfg.Metrics.Namespace)
+	s.Assert().Equal([]float64{0.1, 0.3, 1.5}, cfg.Metrics.DurationBuckets)
+
+	s.Assert().Equal(int64(2048), cfg.MaxBodySize)
+	s.Assert().Equal(50, cfg.MaxBatchItems)
+	s.Assert().Equal(8, cfg.MaxJSONDepth)
+	s.Assert().Equal(2, cfg.HealthCacheTTL)
+	s.Assert().Equal(3, cfg.HealthCheckTimeout)
+	s.Assert().Equal(4, cfg.HealthCheckConcurrency)
+	s.Assert().True(cfg.DeploymentHeaders)
+	s.Assert().Equal(2, cfg.MaxBulkInFlight)
+	s.Assert().Equal(5, cfg.EventsHeartbeat)
+	s.Assert().Equal(8, cfg.EventsBuffer)
+	s.Assert().Equal([]string{"/etc/ssl/ca.pem", "/etc/ssl/client.pem"}, cfg.CertFiles)
+	s.Assert().Equal(30, cfg.CertExpiryWarnDays)
+	s.Assert().Equal("/tmp", cfg.DiskPath)
+	s.Assert().Equal(uint64(1<<30), cfg.DiskMinFreeBytes)
+	s.Assert().True(cfg.MethodOverride)
+	s.Assert().Equal([]string{"X-Tenant-ID"}, cfg.RequiredHeaders)
+	s.Assert().Equal([]string{"Idempotency-Key", "X-Request-Source"}, cfg.RequiredWriteHeaders)
+	s.Assert().True(cfg.ServerTiming)
+	s.Assert().Equal("X-Debug-Timing", cfg.ServerTimingHeader)
+	s.Assert().True(cfg.StrictQuery)
+	s.Assert().True(cfg.DisallowUnknownFields)
+	s.Assert().Equal(IDModeServer, cfg.EntityIDMode)
+	s.Assert().True(cfg.GeneratesIDs())
+	s.Assert().Equal(64, cfg.RequestIDMaxLength)
+	s.Assert().Equal("/svc/examples", cfg.RoutePrefix())
+	s.Assert().False(cfg.OperationalAtRoot)
+	s.Assert().True(cfg.JSONPretty)
+	s.Assert().True(cfg.JSONOmitNull)
+	s.Assert().True(cfg.PrettyJSON())
+	s.Assert().True(cfg.MaintenanceMode)
+	s.Assert().Equal(300, cfg.MaintenanceRetryAfter)
+
 	for key := range envVars {
 		s.Require().NoError(os.Unsetenv(key))
 	}
@@ -313,9 +513,9 @@ func (s *HttpConfigTestSuite) TestCORSConfig_EdgeCases() {
 				"CORS_ALLOWED_HEADERS": "Content-Type , Authorization,X-API-Key",
 			},
 			check: func(cfg *HttpConfig) {
-				expectedOrigins := []string{"https://example.com", " https://api.example.com ", " https://admin.example.com"}
-				expectedMethods := []string{"GET", " POST ", " PUT", "DELETE"}
-				expectedHeaders := []string{"Content-Type ", " Authorization", "X-API-Key"}
+				expectedOrigins := []string{"https://example.com", "https://api.example.com", "https://admin.example.com"}
+				expectedMethods := []string{"GET", "POST", "PUT", "DELETE"}
+				expectedHeaders := []string{"Content-Type", "Authorization", "X-API-Key"}
 
 				s.Assert().Equal(expectedOrigins, cfg.CORS.AllowedOrigins)
 				s.Assert().Equal(expectedMethods, cfg.CORS.AllowedMethods)
@@ -361,6 +561,50 @@ func (s *HttpConfigTestSuite) TestCORSConfig_EdgeCases() {
 	}
 }
 
+func (s *HttpConfigTestSuite) TestLoadHttp_CORSTrimsWhitespace() {
+	s.Require().NoError(os.Setenv("CORS_ALLOWED_ORIGINS", " https://api.example.com , https://app.example.com,, "))
+	s.Require().NoError(os.Setenv("CORS_ALLOWED_METHODS", "GET, POST"))
+
+	cfg, err := LoadHttp()
+
+	s.Require().NoError(err)
+	s.Assert().Equal([]string{"https://api.example.com", "https://app.example.com"}, cfg.CORS.AllowedOrigins)
+	s.Assert().Equal([]string{"GET", "POST"}, cfg.CORS.AllowedMethods)
+	s.Assert().Equal(cfg.CORS, cfg.AdminCORS)
+}
+
+func (s *HttpConfigTestSuite) TestLoadHttp_AdminCORS() {
+	s.Require().NoError(os.Setenv("CORS_ALLOWED_ORIGINS", "https://api.example.com"))
+	s.Require().NoError(os.Setenv("ADMIN_CORS_ALLOWED_ORIGINS", " https://ops.example.com "))
+	s.Require().NoError(os.Setenv("ADMIN_CORS_ALLOWED_METHODS", "GET"))
+
+	cfg, err := LoadHttp()
+
+	s.Require().NoError(err)
+	s.Assert().Equal([]string{"https://api.example.com"}, cfg.CORS.AllowedOrigins)
+	s.Assert().Equal([]string{"https://ops.example.com"}, cfg.AdminCORS.AllowedOrigins)
+	s.Assert().Equal([]string{"GET"}, cfg.AdminCORS.AllowedMethods)
+	s.Assert().Equal([]string{"Accept", "Authorization", "Content-Type", "Idempotency-Key", "X-CSRF-Token"}, cfg.AdminCORS.AllowedHeaders)
+}
+
+func (s *HttpConfigTestSuite) TestLoadHttp_MetricsNamespaceIgnoresBareNamespace() {
+	s.Require().NoError(os.Setenv("NAMESPACE", "payments-prod"))
+
+	cfg, err := LoadHttp()
+
+	s.Require().NoError(err)
+	s.Assert().Empty(cfg.Metrics.Namespace)
+}
+
+func (s *HttpConfigTestSuite) TestLoadHttp_MetricsInstanceIgnoresBareInstance() {
+	s.Require().NoError(os.Setenv("INSTANCE", "i-0abc"))
+
+	cfg, err := LoadHttp()
+
+	s.Require().NoError(err)
+	s.Assert().Empty(cfg.Metrics.Instance)
+}
+
 func (s *HttpConfigTestSuite) TestHttpConfig_InheritsBaseConfig() {
 	s.Require().NoError(os.Setenv("ENV", EnvStaging))
 	defer func() { s.Require().NoError(os.Unsetenv("ENV")) }()
@@ -397,6 +641,9 @@ func (s *HttpConfigTestSuite) TestZeroValues() {
 	s.Assert().Nil(cfg.CORS.ExposedHeaders)
 	s.Assert().False(cfg.CORS.AllowCredentials)
 	s.Assert().Equal(0, cfg.CORS.MaxAge)
+
+	s.Assert().Equal(int64(0), cfg.MaxBodySize)
+	s.Assert().Equal(0, cfg.MaxBatchItems)
 }
 
 func (s *HttpConfigTestSuite) TestLoadHttp_Performance() {
@@ -0,0 +1,49 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ValidationError lists every problem a Validate method found, so a broken
+// deployment is fixed in one round instead of one variable at a time.
+type ValidationError struct {
+	Config   string
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid %s config: %s", e.Config, strings.Join(e.Problems, "; "))
+}
+
+type problems []string
+
+func (p *problems) add(format string, args ...any) {
+	*p = append(*p, fmt.Sprintf(format, args...))
+}
+
+func (p *problems) port(key string, port int) {
+	if port < 0 || port > 65535 {
+		p.add("%s must be between 0 and 65535, got %d", key, port)
+	}
+}
+
+func (p *problems) nonNegative(key string, value int64) {
+	if value < 0 {
+		p.add("%s must not be negative, got %d", key, value)
+	}
+}
+
+func (p *problems) nonNegativeDuration(key string, d time.Duration) {
+	if d < 0 {
+		p.add("%s must not be negative, got %s", key, d)
+	}
+}
+
+func (p problems) err(config string) error {
+	if len(p) == 0 {
+		return nil
+	}
+	return &ValidationError{Config: config, Problems: p}
+}
@@ -0,0 +1,162 @@
+package config
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validHttpConfig() *HttpConfig {
+	return &HttpConfig{
+		BaseConfig:   BaseConfig{Environment: EnvDevelopment, ShutdownTimeout: 30},
+		Server:       HttpServerConfig{Port: 8080, ReadTimeout: 30, WriteTimeout: 30, IdleTimeout: 120, ReadHeaderTimeout: 5},
+		Admin:        AdminServerConfig{Port: 8081},
+		EntityIDMode: IDModeClient,
+	}
+}
+
+func validDatabaseConfig(env string) *DatabaseConfig {
+	return &DatabaseConfig{
+		BaseConfig: BaseConfig{Environment: env},
+		Postgres: PostgresConfig{
+			Host:         "db.internal",
+			Port:         5432,
+			User:         "service",
+			Password:     "secret",
+			Database:     "microservice",
+			SSLMode:      "verify-full",
+			QueryTimeout: 5 * time.Second,
+		},
+		Outbox: OutboxConfig{PollInterval: time.Second, BatchSize: 100},
+	}
+}
+
+func TestHttpConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(c *HttpConfig)
+		problem string
+	}{
+		{name: "unknown_environment", modify: func(c *HttpConfig) { c.Environment = "prod" }, problem: `ENV must be one of development, staging, production or test, got "prod"`},
+		{name: "negative_shutdown_timeout", modify: func(c *HttpConfig) { c.ShutdownTimeout = -1 }, problem: "SHUTDOWN_TIMEOUT must not be negative, got -1"},
+		{name: "negative_shutdown_pre_stop_delay", modify: func(c *HttpConfig) { c.ShutdownPreStopDelay = -1 }, problem: "SHUTDOWN_PRE_STOP_DELAY must not be negative, got -1"},
+		{name: "port_out_of_range", modify: func(c *HttpConfig) { c.Server.Port = 70000 }, problem: "HTTP_SERVER_PORT must be between 0 and 65535, got 70000"},
+		{name: "negative_read_timeout", modify: func(c *HttpConfig) { c.Server.ReadTimeout = -5 }, problem: "HTTP_SERVER_READ_TIMEOUT must not be negative, got -5"},
+		{name: "negative_write_timeout", modify: func(c *HttpConfig) { c.Server.WriteTimeout = -1 }, problem: "HTTP_SERVER_WRITE_TIMEOUT must not be negative, got -1"},
+		{name: "negative_idle_timeout", modify: func(c *HttpConfig) { c.Server.IdleTimeout = -1 }, problem: "HTTP_SERVER_IDLE_TIMEOUT must not be negative, got -1"},
+		{name: "negative_read_header_timeout", modify: func(c *HttpConfig) { c.Server.ReadHeaderTimeout = -1 }, problem: "HTTP_SERVER_READ_HEADER_TIMEOUT must not be negative, got -1"},
+		{name: "negative_max_header_bytes", modify: func(c *HttpConfig) { c.Server.MaxHeaderBytes = -1 }, problem: "HTTP_SERVER_MAX_HEADER_BYTES must not be negative, got -1"},
+		{name: "negative_health_check_concurrency", modify: func(c *HttpConfig) { c.HealthCheckConcurrency = -1 }, problem: "HEALTH_CHECK_CONCURRENCY must not be negative, got -1"},
+		{name: "negative_maintenance_retry_after", modify: func(c *HttpConfig) { c.MaintenanceRetryAfter = -1 }, problem: "HTTP_MAINTENANCE_RETRY_AFTER must not be negative, got -1"},
+		{name: "admin_port_out_of_range", modify: func(c *HttpConfig) { c.Admin.Enabled = true; c.Admin.Port = -1 }, problem: "HTTP_ADMIN_PORT must be between 0 and 65535, got -1"},
+		{name: "admin_port_clash", modify: func(c *HttpConfig) { c.Admin.Enabled = true; c.Admin.Port = 8080 }, problem: "HTTP_ADMIN_PORT must differ from HTTP_SERVER_PORT, both are 8080"},
+		{name: "tls_without_files", modify: func(c *HttpConfig) { c.TLS.Enabled = true; c.TLS.CertFile = "cert.pem" }, problem: "HTTP_TLS_CERT_FILE and HTTP_TLS_KEY_FILE must be set when HTTP_TLS_ENABLED=true"},
+		{name: "unknown_id_mode", modify: func(c *HttpConfig) { c.EntityIDMode = "auto" }, problem: `ENTITY_ID_MODE must be client or server, got "auto"`},
+	}
+
+	require.NoError(t, validHttpConfig().Validate())
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validHttpConfig()
+			tt.modify(cfg)
+
+			err := cfg.Validate()
+
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, "http", validationErr.Config)
+			assert.Equal(t, []string{tt.problem}, validationErr.Problems)
+		})
+	}
+}
+
+func TestHttpConfig_Validate_AdminPortIgnoredWhileDisabled(t *testing.T) {
+	cfg := validHttpConfig()
+	cfg.Admin.Port = cfg.Server.Port
+
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestDatabaseConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     string
+		modify  func(c *DatabaseConfig)
+		problem string
+	}{
+		{name: "empty_host", env: EnvDevelopment, modify: func(c *DatabaseConfig) { c.Postgres.Host = "" }, problem: "POSTGRES_HOST must be set"},
+		{name: "port_out_of_range", env: EnvDevelopment, modify: func(c *DatabaseConfig) { c.Postgres.Port = 0 }, problem: "POSTGRES_PORT must be between 1 and 65535, got 0"},
+		{name: "replica_port_out_of_range", env: EnvDevelopment, modify: func(c *DatabaseConfig) { c.Postgres.ReplicaPort = 99999 }, problem: "POSTGRES_REPLICA_PORT must be between 0 and 65535, got 99999"},
+		{name: "negative_max_open_conns", env: EnvDevelopment, modify: func(c *DatabaseConfig) { c.Postgres.MaxOpenConns = -1 }, problem: "POSTGRES_MAX_OPEN_CONNS must not be negative, got -1"},
+		{name: "negative_connect_retries", env: EnvDevelopment, modify: func(c *DatabaseConfig) { c.Postgres.ConnectRetries = -2 }, problem: "POSTGRES_CONNECT_RETRIES must not be negative, got -2"},
+		{name: "negative_query_timeout", env: EnvDevelopment, modify: func(c *DatabaseConfig) { c.Postgres.QueryTimeout = -time.Second }, problem: "POSTGRES_QUERY_TIMEOUT must not be negative, got -1s"},
+		{name: "negative_conn_max_lifetime", env: EnvDevelopment, modify: func(c *DatabaseConfig) { c.Postgres.ConnMaxLifetime = -time.Minute }, problem: "POSTGRES_CONN_MAX_LIFETIME must not be negative, got -1m0s"},
+		{name: "outbox_without_poll_interval", env: EnvDevelopment, modify: func(c *DatabaseConfig) { c.Outbox.Enabled = true; c.Outbox.PollInterval = 0 }, problem: "OUTBOX_POLL_INTERVAL must be positive, got 0s"},
+		{name: "outbox_without_batch_size", env: EnvDevelopment, modify: func(c *DatabaseConfig) { c.Outbox.Enabled = true; c.Outbox.BatchSize = 0 }, problem: "OUTBOX_BATCH_SIZE must be positive, got 0"},
+		{name: "production_empty_database", env: EnvProduction, modify: func(c *DatabaseConfig) { c.Postgres.Database = "" }, problem: "POSTGRES_DB must be set in production"},
+		{name: "production_empty_password", env: EnvProduction, modify: func(c *DatabaseConfig) { c.Postgres.Password = "" }, problem: "POSTGRES_PASSWORD must be set in production"},
+		{name: "production_sslmode_disable", env: EnvProduction, modify: func(c *DatabaseConfig) { c.Postgres.SSLMode = "disable" }, problem: "POSTGRES_SSL_MODE=disable is not allowed in production"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validDatabaseConfig(tt.env)
+			require.NoError(t, cfg.Validate())
+			tt.modify(cfg)
+
+			err := cfg.Validate()
+
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, "database", validationErr.Config)
+			assert.Equal(t, []string{tt.problem}, validationErr.Problems)
+		})
+	}
+}
+
+func TestDatabaseConfig_Validate_ProductionRulesOnlyInProduction(t *testing.T) {
+	for _, env := range []string{EnvDevelopment, EnvStaging, EnvTest} {
+		cfg := validDatabaseConfig(env)
+		cfg.Postgres.Database = ""
+		cfg.Postgres.Password = ""
+		cfg.Postgres.SSLMode = "disable"
+
+		assert.NoError(t, cfg.Validate(), env)
+	}
+}
+
+func TestDatabaseConfig_Validate_ReportsEveryProblem(t *testing.T) {
+	cfg := validDatabaseConfig(EnvProduction)
+	cfg.Postgres.Password = ""
+	cfg.Postgres.SSLMode = "disable"
+
+	err := cfg.Validate()
+
+	require.Error(t, err)
+	assert.Equal(t, "invalid database config: POSTGRES_PASSWORD must be set in production; POSTGRES_SSL_MODE=disable is not allowed in production", err.Error())
+}
+
+func TestLoadDatabase_FailsValidation(t *testing.T) {
+	for key, value := range map[string]string{"ENV": EnvProduction, "POSTGRES_PASSWORD": "", "POSTGRES_SSL_MODE": "require"} {
+		original, set := os.LookupEnv(key)
+		require.NoError(t, os.Setenv(key, value))
+		t.Cleanup(func() {
+			if set {
+				_ = os.Setenv(key, original)
+			} else {
+				_ = os.Unsetenv(key)
+			}
+		})
+	}
+
+	cfg, err := LoadDatabase()
+
+	assert.Nil(t, cfg)
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, []string{"POSTGRES_PASSWORD must be set in production"}, validationErr.Problems)
+}
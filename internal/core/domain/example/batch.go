@@ -0,0 +1,18 @@
+package example
+
+import "errors"
+
+var ErrBatchAborted = errors.New("batch aborted because another item failed")
+
+type CreateEntityParams struct {
+	ID    string
+	Email string
+	Name  string
+}
+
+// CreateEntityResult holds the outcome of one item of a batch create: the
+// created entity on success or the error that rejected the item.
+type CreateEntityResult struct {
+	Entity *Entity
+	Err    error
+}
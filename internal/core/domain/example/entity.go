@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"time"
 )
 
 var (
@@ -12,6 +13,9 @@ var (
 	ErrInvalidName     = errors.New("name cannot be empty")
 	emailRegex         = regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
 	ErrEntityNotFound  = errors.New("entity not found")
+	// ErrEntityNotDeleted is returned when restoring an entity that was never
+	// soft-deleted.
+	ErrEntityNotDeleted = errors.New("entity is not deleted")
 )
 
 type AlreadyExistsError struct {
@@ -26,25 +30,56 @@ type Entity struct {
 	ID    string
 	Email string
 	Name  string
+	// UpdatedAt is set by the repository whenever the entity is stored.
+	UpdatedAt time.Time
 }
 
 func (e *Entity) GetID() string {
 	return e.ID
 }
 
-func NewEntity(id, email, name string) (*Entity, error) {
-	if id == "" {
-		return nil, ErrInvalidEntityID
+// Update applies the provided fields to the entity. Nil arguments leave the
+// corresponding field unchanged; all provided values are validated before any
+// field is modified.
+func (e *Entity) Update(email, name *string) error {
+	if name != nil && *name == "" {
+		return ErrInvalidName
+	}
+	if email != nil && !emailRegex.MatchString(*email) {
+		return ErrInvalidEmail
+	}
+
+	if email != nil {
+		e.Email = *email
 	}
-	if name == "" {
-		return nil, ErrInvalidName
+	if name != nil {
+		e.Name = *name
+	}
+	return nil
+}
+
+// Validate reports the first invariant the entity violates, if any.
+func (e *Entity) Validate() error {
+	if e.ID == "" {
+		return ErrInvalidEntityID
 	}
-	if !emailRegex.MatchString(email) {
-		return nil, ErrInvalidEmail
+	if e.Name == "" {
+		return ErrInvalidName
 	}
-	return &Entity{
+	if !emailRegex.MatchString(e.Email) {
+		return ErrInvalidEmail
+	}
+	return nil
+}
+
+func NewEntity(id, email, name string) (*Entity, error) {
+	entity := &Entity{
 		ID:    id,
 		Email: email,
 		Name:  name,
-	}, nil
+	}
+	if err := entity.Validate(); err != nil {
+		return nil, err
+	}
+	return entity, nil
 }
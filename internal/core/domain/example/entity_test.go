@@ -80,6 +80,25 @@ func TestNewEntity(t *testing.T) {
 	}
 }
 
+func TestEntity_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		entity  Entity
+		wantErr error
+	}{
+		{name: "valid", entity: Entity{ID: "test-id", Email: "test@example.com", Name: "Test"}},
+		{name: "empty id", entity: Entity{Email: "test@example.com", Name: "Test"}, wantErr: ErrInvalidEntityID},
+		{name: "empty name", entity: Entity{ID: "test-id", Email: "test@example.com"}, wantErr: ErrInvalidName},
+		{name: "invalid email", entity: Entity{ID: "test-id", Email: "not-an-email", Name: "Test"}, wantErr: ErrInvalidEmail},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, tt.entity.Validate(), tt.wantErr)
+		})
+	}
+}
+
 func TestEntity_GetID(t *testing.T) {
 	entity, err := NewEntity("test-id", "test@example.com", "Test User")
 	assert.NoError(t, err)
@@ -94,3 +113,67 @@ func TestAlreadyExistsError_Error(t *testing.T) {
 	result := err.Error()
 	assert.Equal(t, expected, result)
 }
+
+func TestEntity_Update(t *testing.T) {
+	strPtr := func(s string) *string { return &s }
+
+	tests := []struct {
+		name          string
+		email         *string
+		entityName    *string
+		wantErr       error
+		expectedEmail string
+		expectedName  string
+	}{
+		{
+			name:          "no fields provided",
+			expectedEmail: "test@example.com",
+			expectedName:  "Test User",
+		},
+		{
+			name:          "update email only",
+			email:         strPtr("new@example.com"),
+			expectedEmail: "new@example.com",
+			expectedName:  "Test User",
+		},
+		{
+			name:          "update name only",
+			entityName:    strPtr("New Name"),
+			expectedEmail: "test@example.com",
+			expectedName:  "New Name",
+		},
+		{
+			name:          "empty name rejected",
+			email:         strPtr("new@example.com"),
+			entityName:    strPtr(""),
+			wantErr:       ErrInvalidName,
+			expectedEmail: "test@example.com",
+			expectedName:  "Test User",
+		},
+		{
+			name:          "invalid email rejected",
+			email:         strPtr("invalid-email"),
+			entityName:    strPtr("New Name"),
+			wantErr:       ErrInvalidEmail,
+			expectedEmail: "test@example.com",
+			expectedName:  "Test User",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entity, err := NewEntity("test-id", "test@example.com", "Test User")
+			require.NoError(t, err)
+
+			err = entity.Update(tt.email, tt.entityName)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedEmail, entity.Email)
+			assert.Equal(t, tt.expectedName, entity.Name)
+		})
+	}
+}
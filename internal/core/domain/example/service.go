@@ -16,6 +16,14 @@ func NewService() *Service {
 }
 
 func (s *Service) CheckEntityForCreation(entity *Entity) error {
+	return s.checkReservedName(entity)
+}
+
+func (s *Service) CheckEntityForUpdate(entity *Entity) error {
+	return s.checkReservedName(entity)
+}
+
+func (s *Service) checkReservedName(entity *Entity) error {
 	if strings.ToLower(entity.Name) == "admin" {
 		return ErrReservedName
 	}
@@ -72,6 +72,17 @@ func TestService_CheckEntityForCreation(t *testing.T) {
 	}
 }
 
+func TestService_CheckEntityForUpdate(t *testing.T) {
+	service := NewService()
+
+	entity, err := NewEntity("test-id", "test@example.com", "Test User")
+	require.NoError(t, err)
+	assert.NoError(t, service.CheckEntityForUpdate(entity))
+
+	entity.Name = "Admin"
+	assert.ErrorIs(t, service.CheckEntityForUpdate(entity), ErrReservedName)
+}
+
 func TestNewService(t *testing.T) {
 	service := NewService()
 
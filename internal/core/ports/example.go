@@ -8,4 +8,17 @@ import (
 type ExampleRepository interface {
 	Save(ctx context.Context, entity *example.Entity) error
 	GetByID(ctx context.Context, id string) (*example.Entity, error)
+	// GetByEmail matches the email regardless of case and ignores soft-deleted
+	// entities. Emails are not unique; when several entities share one, the
+	// entity with the lowest ID is returned.
+	GetByEmail(ctx context.Context, email string) (*example.Entity, error)
+	// List returns every live entity ordered by ID.
+	List(ctx context.Context) ([]*example.Entity, error)
+	Update(ctx context.Context, entity *example.Entity) error
+	// SaveBatch stores the entities in a single transaction and returns one
+	// error per entity. In atomic mode nothing is stored if any entity fails.
+	SaveBatch(ctx context.Context, entities []*example.Entity, atomic bool) ([]error, error)
+	// Restore brings back a soft-deleted entity. It returns ErrEntityNotFound
+	// when no entity has the ID and ErrEntityNotDeleted when it is live.
+	Restore(ctx context.Context, id string) error
 }
@@ -0,0 +1,7 @@
+package ports
+
+// IDGenerator creates identifiers for entities whose ID is assigned by the
+// service rather than by the client.
+type IDGenerator interface {
+	NewID() string
+}
@@ -38,6 +38,74 @@ func (_m *MockExampleRepository) EXPECT() *MockExampleRepository_Expecter {
 	return &MockExampleRepository_Expecter{mock: &_m.Mock}
 }
 
+// GetByEmail provides a mock function for the type MockExampleRepository
+func (_mock *MockExampleRepository) GetByEmail(ctx context.Context, email string) (*example.Entity, error) {
+	ret := _mock.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByEmail")
+	}
+
+	var r0 *example.Entity
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*example.Entity, error)); ok {
+		return returnFunc(ctx, email)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *example.Entity); ok {
+		r0 = returnFunc(ctx, email)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*example.Entity)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockExampleRepository_GetByEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByEmail'
+type MockExampleRepository_GetByEmail_Call struct {
+	*mock.Call
+}
+
+// GetByEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+func (_e *MockExampleRepository_Expecter) GetByEmail(ctx interface{}, email interface{}) *MockExampleRepository_GetByEmail_Call {
+	return &MockExampleRepository_GetByEmail_Call{Call: _e.mock.On("GetByEmail", ctx, email)}
+}
+
+func (_c *MockExampleRepository_GetByEmail_Call) Run(run func(ctx context.Context, email string)) *MockExampleRepository_GetByEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockExampleRepository_GetByEmail_Call) Return(entity *example.Entity, err error) *MockExampleRepository_GetByEmail_Call {
+	_c.Call.Return(entity, err)
+	return _c
+}
+
+func (_c *MockExampleRepository_GetByEmail_Call) RunAndReturn(run func(ctx context.Context, email string) (*example.Entity, error)) *MockExampleRepository_GetByEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetByID provides a mock function for the type MockExampleRepository
 func (_mock *MockExampleRepository) GetByID(ctx context.Context, id string) (*example.Entity, error) {
 	ret := _mock.Called(ctx, id)
@@ -106,6 +174,125 @@ func (_c *MockExampleRepository_GetByID_Call) RunAndReturn(run func(ctx context.
 	return _c
 }
 
+// List provides a mock function for the type MockExampleRepository
+func (_mock *MockExampleRepository) List(ctx context.Context) ([]*example.Entity, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*example.Entity
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]*example.Entity, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []*example.Entity); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*example.Entity)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockExampleRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockExampleRepository_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockExampleRepository_Expecter) List(ctx interface{}) *MockExampleRepository_List_Call {
+	return &MockExampleRepository_List_Call{Call: _e.mock.On("List", ctx)}
+}
+
+func (_c *MockExampleRepository_List_Call) Run(run func(ctx context.Context)) *MockExampleRepository_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockExampleRepository_List_Call) Return(entitys []*example.Entity, err error) *MockExampleRepository_List_Call {
+	_c.Call.Return(entitys, err)
+	return _c
+}
+
+func (_c *MockExampleRepository_List_Call) RunAndReturn(run func(ctx context.Context) ([]*example.Entity, error)) *MockExampleRepository_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Restore provides a mock function for the type MockExampleRepository
+func (_mock *MockExampleRepository) Restore(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Restore")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockExampleRepository_Restore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Restore'
+type MockExampleRepository_Restore_Call struct {
+	*mock.Call
+}
+
+// Restore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockExampleRepository_Expecter) Restore(ctx interface{}, id interface{}) *MockExampleRepository_Restore_Call {
+	return &MockExampleRepository_Restore_Call{Call: _e.mock.On("Restore", ctx, id)}
+}
+
+func (_c *MockExampleRepository_Restore_Call) Run(run func(ctx context.Context, id string)) *MockExampleRepository_Restore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockExampleRepository_Restore_Call) Return(err error) *MockExampleRepository_Restore_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockExampleRepository_Restore_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockExampleRepository_Restore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Save provides a mock function for the type MockExampleRepository
 func (_mock *MockExampleRepository) Save(ctx context.Context, entity *example.Entity) error {
 	ret := _mock.Called(ctx, entity)
@@ -162,3 +349,134 @@ func (_c *MockExampleRepository_Save_Call) RunAndReturn(run func(ctx context.Con
 	_c.Call.Return(run)
 	return _c
 }
+
+// SaveBatch provides a mock function for the type MockExampleRepository
+func (_mock *MockExampleRepository) SaveBatch(ctx context.Context, entities []*example.Entity, atomic bool) ([]error, error) {
+	ret := _mock.Called(ctx, entities, atomic)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveBatch")
+	}
+
+	var r0 []error
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []*example.Entity, bool) ([]error, error)); ok {
+		return returnFunc(ctx, entities, atomic)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []*example.Entity, bool) []error); ok {
+		r0 = returnFunc(ctx, entities, atomic)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]error)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []*example.Entity, bool) error); ok {
+		r1 = returnFunc(ctx, entities, atomic)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockExampleRepository_SaveBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveBatch'
+type MockExampleRepository_SaveBatch_Call struct {
+	*mock.Call
+}
+
+// SaveBatch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entities []*example.Entity
+//   - atomic bool
+func (_e *MockExampleRepository_Expecter) SaveBatch(ctx interface{}, entities interface{}, atomic interface{}) *MockExampleRepository_SaveBatch_Call {
+	return &MockExampleRepository_SaveBatch_Call{Call: _e.mock.On("SaveBatch", ctx, entities, atomic)}
+}
+
+func (_c *MockExampleRepository_SaveBatch_Call) Run(run func(ctx context.Context, entities []*example.Entity, atomic bool)) *MockExampleRepository_SaveBatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []*example.Entity
+		if args[1] != nil {
+			arg1 = args[1].([]*example.Entity)
+		}
+		var arg2 bool
+		if args[2] != nil {
+			arg2 = args[2].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockExampleRepository_SaveBatch_Call) Return(errs []error, err error) *MockExampleRepository_SaveBatch_Call {
+	_c.Call.Return(errs, err)
+	return _c
+}
+
+func (_c *MockExampleRepository_SaveBatch_Call) RunAndReturn(run func(ctx context.Context, entities []*example.Entity, atomic bool) ([]error, error)) *MockExampleRepository_SaveBatch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function for the type MockExampleRepository
+func (_mock *MockExampleRepository) Update(ctx context.Context, entity *example.Entity) error {
+	ret := _mock.Called(ctx, entity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *example.Entity) error); ok {
+		r0 = returnFunc(ctx, entity)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockExampleRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockExampleRepository_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entity *example.Entity
+func (_e *MockExampleRepository_Expecter) Update(ctx interface{}, entity interface{}) *MockExampleRepository_Update_Call {
+	return &MockExampleRepository_Update_Call{Call: _e.mock.On("Update", ctx, entity)}
+}
+
+func (_c *MockExampleRepository_Update_Call) Run(run func(ctx context.Context, entity *example.Entity)) *MockExampleRepository_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *example.Entity
+		if args[1] != nil {
+			arg1 = args[1].(*example.Entity)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockExampleRepository_Update_Call) Return(err error) *MockExampleRepository_Update_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockExampleRepository_Update_Call) RunAndReturn(run func(ctx context.Context, entity *example.Entity) error) *MockExampleRepository_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
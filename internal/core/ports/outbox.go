@@ -0,0 +1,25 @@
+package ports
+
+import "context"
+
+// OutboxMessage is an event waiting in the outbox to be published. ID is
+// assigned when the message is stored.
+type OutboxMessage struct {
+	ID      int64
+	Topic   string
+	Key     string
+	Payload []byte
+}
+
+// Outbox records messages for publishing. Add joins the transaction carried by
+// ctx, so a message is only stored when the write it describes is committed.
+type Outbox interface {
+	Add(ctx context.Context, message OutboxMessage) error
+}
+
+// Publisher hands outbox messages to a broker such as Kafka or NATS. Messages
+// are delivered at least once, so consumers must tolerate duplicates; the
+// message ID identifies them.
+type Publisher interface {
+	Publish(ctx context.Context, message OutboxMessage) error
+}
@@ -0,0 +1,9 @@
+package ports
+
+import "context"
+
+// Transactor runs fn so that every repository call made with the context it
+// receives is committed together, or not at all when fn returns an error.
+type Transactor interface {
+	InTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
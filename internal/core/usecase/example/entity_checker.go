@@ -4,4 +4,5 @@ import "microservice/internal/core/domain/example"
 
 type EntityChecker interface {
 	CheckEntityForCreation(entity *example.Entity) error
+	CheckEntityForUpdate(entity *example.Entity) error
 }
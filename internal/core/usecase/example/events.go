@@ -0,0 +1,76 @@
+package example
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"microservice/internal/core/domain/example"
+	"microservice/internal/core/ports"
+)
+
+// Topics of the messages recorded in the outbox. The key is the entity ID.
+const (
+	TopicEntityCreated  = "example.created"
+	TopicEntityUpdated  = "example.updated"
+	TopicEntityRestored = "example.restored"
+)
+
+// EntityEvent is the JSON payload of outbox messages about an entity.
+type EntityEvent struct {
+	ID        string    `json:"id"`
+	Email     string    `json:"email"`
+	Name      string    `json:"name"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// WithOutbox records a message in outbox for every created, updated or
+// restored entity.
+// The write and the message share a transaction from tx, so an event is
+// emitted exactly when the change is committed.
+func WithOutbox(tx ports.Transactor, outbox ports.Outbox) Option {
+	return func(uc *Usecase) {
+		uc.tx = tx
+		uc.outbox = outbox
+	}
+}
+
+// write runs store, together with recording a topic message for entity when
+// an outbox is configured.
+func (uc *Usecase) write(ctx context.Context, topic string, entity *example.Entity, store func(ctx context.Context) error) error {
+	return uc.inTx(ctx, func(ctx context.Context) error {
+		if err := store(ctx); err != nil {
+			return err
+		}
+		return uc.record(ctx, topic, entity)
+	})
+}
+
+// inTx runs fn in a transaction when an outbox is configured, so the messages
+// fn records are committed with its writes. Without one it just calls fn.
+func (uc *Usecase) inTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if uc.outbox == nil {
+		return fn(ctx)
+	}
+	return uc.tx.InTx(ctx, fn)
+}
+
+// record adds a topic message for entity to the outbox, if there is one.
+func (uc *Usecase) record(ctx context.Context, topic string, entity *example.Entity) error {
+	if uc.outbox == nil {
+		return nil
+	}
+
+	payload, err := json.Marshal(EntityEvent{
+		ID:        entity.ID,
+		Email:     entity.Email,
+		Name:      entity.Name,
+		UpdatedAt: entity.UpdatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("encode %s event: %w", topic, err)
+	}
+
+	return uc.outbox.Add(ctx, ports.OutboxMessage{Topic: topic, Key: entity.ID, Payload: payload})
+}
@@ -0,0 +1,215 @@
+package example
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"microservice/internal/core/domain/example"
+	"microservice/internal/core/ports"
+	portsMocks "microservice/internal/core/ports/mocks"
+	"microservice/internal/core/usecase/example/mocks"
+)
+
+type txKey struct{}
+
+// fakeTransactor marks the context it passes to fn, so tests can tell which
+// calls ran inside the transaction.
+type fakeTransactor struct {
+	calls int
+	err   error
+}
+
+func (f *fakeTransactor) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	f.calls++
+	if err := fn(context.WithValue(ctx, txKey{}, true)); err != nil {
+		return err
+	}
+	return f.err
+}
+
+func inTx(ctx context.Context) bool {
+	return ctx.Value(txKey{}) != nil
+}
+
+type fakeOutbox struct {
+	messages []ports.OutboxMessage
+	err      error
+}
+
+func (f *fakeOutbox) Add(ctx context.Context, message ports.OutboxMessage) error {
+	if !inTx(ctx) {
+		return errors.New("message added outside the transaction")
+	}
+	if f.err != nil {
+		return f.err
+	}
+	f.messages = append(f.messages, message)
+	return nil
+}
+
+func decodeEvent(t *testing.T, message ports.OutboxMessage) EntityEvent {
+	t.Helper()
+	var event EntityEvent
+	require.NoError(t, json.Unmarshal(message.Payload, &event))
+	return event
+}
+
+func TestUsecase_CreateEntity_RecordsOutboxMessage(t *testing.T) {
+	repo := portsMocks.NewMockExampleRepository(t)
+	checker := mocks.NewMockEntityChecker(t)
+	checker.EXPECT().CheckEntityForCreation(mock.Anything).Return(nil).Once()
+	repo.EXPECT().Save(mock.MatchedBy(inTx), mock.Anything).Return(nil).Once()
+	tx := &fakeTransactor{}
+	outbox := &fakeOutbox{}
+
+	uc := NewUsecase(repo, checker, WithOutbox(tx, outbox))
+	_, err := uc.CreateEntity(context.Background(), "new-id", "new@example.com", "New")
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, tx.calls)
+	require.Len(t, outbox.messages, 1)
+	assert.Equal(t, TopicEntityCreated, outbox.messages[0].Topic)
+	assert.Equal(t, "new-id", outbox.messages[0].Key)
+	assert.Equal(t, EntityEvent{ID: "new-id", Email: "new@example.com", Name: "New"}, decodeEvent(t, outbox.messages[0]))
+}
+
+func TestUsecase_CreateEntity_NoMessageWhenSaveFails(t *testing.T) {
+	repo := portsMocks.NewMockExampleRepository(t)
+	checker := mocks.NewMockEntityChecker(t)
+	checker.EXPECT().CheckEntityForCreation(mock.Anything).Return(nil).Once()
+	repo.EXPECT().Save(mock.Anything, mock.Anything).Return(&example.AlreadyExistsError{ID: "dup-id"}).Once()
+	outbox := &fakeOutbox{}
+
+	uc := NewUsecase(repo, checker, WithOutbox(&fakeTransactor{}, outbox))
+	_, err := uc.CreateEntity(context.Background(), "dup-id", "dup@example.com", "Dup")
+
+	var exists *example.AlreadyExistsError
+	assert.ErrorAs(t, err, &exists)
+	assert.Empty(t, outbox.messages)
+}
+
+func TestUsecase_CreateEntity_FailsWhenOutboxFails(t *testing.T) {
+	repo := portsMocks.NewMockExampleRepository(t)
+	checker := mocks.NewMockEntityChecker(t)
+	checker.EXPECT().CheckEntityForCreation(mock.Anything).Return(nil).Once()
+	repo.EXPECT().Save(mock.Anything, mock.Anything).Return(nil).Once()
+	outboxErr := errors.New("outbox insert failed")
+
+	uc := NewUsecase(repo, checker, WithOutbox(&fakeTransactor{}, &fakeOutbox{err: outboxErr}))
+	entity, err := uc.CreateEntity(context.Background(), "new-id", "new@example.com", "New")
+
+	assert.ErrorIs(t, err, outboxErr, "the transaction, and with it the entity, is rolled back")
+	assert.Nil(t, entity)
+}
+
+func TestUsecase_UpdateEntity_RecordsOutboxMessage(t *testing.T) {
+	repo := portsMocks.NewMockExampleRepository(t)
+	checker := mocks.NewMockEntityChecker(t)
+	repo.EXPECT().GetByID(mock.Anything, "id").
+		Return(&example.Entity{ID: "id", Email: "old@example.com", Name: "Name"}, nil).Once()
+	checker.EXPECT().CheckEntityForUpdate(mock.Anything).Return(nil).Once()
+	repo.EXPECT().Update(mock.MatchedBy(inTx), mock.Anything).Return(nil).Once()
+	outbox := &fakeOutbox{}
+
+	email := "new@example.com"
+	uc := NewUsecase(repo, checker, WithOutbox(&fakeTransactor{}, outbox))
+	_, err := uc.UpdateEntity(context.Background(), "id", &email, nil)
+
+	require.NoError(t, err)
+	require.Len(t, outbox.messages, 1)
+	assert.Equal(t, TopicEntityUpdated, outbox.messages[0].Topic)
+	assert.Equal(t, "new@example.com", decodeEvent(t, outbox.messages[0]).Email)
+}
+
+func TestUsecase_CreateEntities_RecordsMessagePerCreatedEntity(t *testing.T) {
+	repo := portsMocks.NewMockExampleRepository(t)
+	checker := mocks.NewMockEntityChecker(t)
+	checker.EXPECT().CheckEntityForCreation(mock.Anything).Return(nil).Times(3)
+	repo.EXPECT().SaveBatch(mock.MatchedBy(inTx), mock.Anything, false).
+		Return([]error{nil, &example.AlreadyExistsError{ID: "dup-id"}, nil}, nil).Once()
+	tx := &fakeTransactor{}
+	outbox := &fakeOutbox{}
+
+	uc := NewUsecase(repo, checker, WithOutbox(tx, outbox))
+	_, err := uc.CreateEntities(context.Background(), []example.CreateEntityParams{
+		{ID: "id-1", Email: "one@example.com", Name: "One"},
+		{ID: "dup-id", Email: "dup@example.com", Name: "Dup"},
+		{ID: "id-2", Email: "two@example.com", Name: "Two"},
+	}, false)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, tx.calls)
+	require.Len(t, outbox.messages, 2)
+	for i, id := range []string{"id-1", "id-2"} {
+		assert.Equal(t, TopicEntityCreated, outbox.messages[i].Topic)
+		assert.Equal(t, id, outbox.messages[i].Key)
+		assert.Equal(t, id, decodeEvent(t, outbox.messages[i]).ID)
+	}
+}
+
+func TestUsecase_CreateEntities_AtomicFailureRecordsNothing(t *testing.T) {
+	repo := portsMocks.NewMockExampleRepository(t)
+	checker := mocks.NewMockEntityChecker(t)
+	checker.EXPECT().CheckEntityForCreation(mock.Anything).Return(nil).Times(2)
+	repo.EXPECT().SaveBatch(mock.MatchedBy(inTx), mock.Anything, true).
+		Return([]error{nil, &example.AlreadyExistsError{ID: "dup-id"}}, nil).Once()
+	outbox := &fakeOutbox{}
+
+	uc := NewUsecase(repo, checker, WithOutbox(&fakeTransactor{}, outbox))
+	_, err := uc.CreateEntities(context.Background(), []example.CreateEntityParams{
+		{ID: "id-1", Email: "one@example.com", Name: "One"},
+		{ID: "dup-id", Email: "dup@example.com", Name: "Dup"},
+	}, true)
+
+	require.NoError(t, err)
+	assert.Empty(t, outbox.messages)
+}
+
+func TestUsecase_CreateEntities_FailsWhenOutboxFails(t *testing.T) {
+	repo := portsMocks.NewMockExampleRepository(t)
+	checker := mocks.NewMockEntityChecker(t)
+	checker.EXPECT().CheckEntityForCreation(mock.Anything).Return(nil).Once()
+	repo.EXPECT().SaveBatch(mock.Anything, mock.Anything, false).Return([]error{nil}, nil).Once()
+	outboxErr := errors.New("outbox insert failed")
+
+	uc := NewUsecase(repo, checker, WithOutbox(&fakeTransactor{}, &fakeOutbox{err: outboxErr}))
+	results, err := uc.CreateEntities(context.Background(), []example.CreateEntityParams{
+		{ID: "id-1", Email: "one@example.com", Name: "One"},
+	}, false)
+
+	assert.ErrorIs(t, err, outboxErr, "the transaction, and with it the batch, is rolled back")
+	assert.Nil(t, results)
+}
+
+func TestUsecase_RestoreEntity_RecordsOutboxMessage(t *testing.T) {
+	repo := portsMocks.NewMockExampleRepository(t)
+	repo.EXPECT().Restore(mock.MatchedBy(inTx), "id").Return(nil).Once()
+	repo.EXPECT().GetByID(mock.MatchedBy(inTx), "id").
+		Return(&example.Entity{ID: "id", Email: "back@example.com", Name: "Back"}, nil).Once()
+	outbox := &fakeOutbox{}
+
+	uc := NewUsecase(repo, mocks.NewMockEntityChecker(t), WithOutbox(&fakeTransactor{}, outbox))
+	_, err := uc.RestoreEntity(context.Background(), "id")
+
+	require.NoError(t, err)
+	require.Len(t, outbox.messages, 1)
+	assert.Equal(t, TopicEntityRestored, outbox.messages[0].Topic)
+	assert.Equal(t, EntityEvent{ID: "id", Email: "back@example.com", Name: "Back"}, decodeEvent(t, outbox.messages[0]))
+}
+
+func TestUsecase_CreateEntity_WithoutOutbox(t *testing.T) {
+	repo := portsMocks.NewMockExampleRepository(t)
+	checker := mocks.NewMockEntityChecker(t)
+	checker.EXPECT().CheckEntityForCreation(mock.Anything).Return(nil).Once()
+	repo.EXPECT().Save(mock.MatchedBy(func(ctx context.Context) bool { return !inTx(ctx) }), mock.Anything).Return(nil).Once()
+
+	_, err := NewUsecase(repo, checker).CreateEntity(context.Background(), "id", "id@example.com", "Name")
+
+	assert.NoError(t, err)
+}
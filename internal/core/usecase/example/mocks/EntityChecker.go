@@ -87,3 +87,54 @@ func (_c *MockEntityChecker_CheckEntityForCreation_Call) RunAndReturn(run func(e
 	_c.Call.Return(run)
 	return _c
 }
+
+// CheckEntityForUpdate provides a mock function for the type MockEntityChecker
+func (_mock *MockEntityChecker) CheckEntityForUpdate(entity *example.Entity) error {
+	ret := _mock.Called(entity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckEntityForUpdate")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(*example.Entity) error); ok {
+		r0 = returnFunc(entity)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockEntityChecker_CheckEntityForUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckEntityForUpdate'
+type MockEntityChecker_CheckEntityForUpdate_Call struct {
+	*mock.Call
+}
+
+// CheckEntityForUpdate is a helper method to define mock.On call
+//   - entity *example.Entity
+func (_e *MockEntityChecker_Expecter) CheckEntityForUpdate(entity interface{}) *MockEntityChecker_CheckEntityForUpdate_Call {
+	return &MockEntityChecker_CheckEntityForUpdate_Call{Call: _e.mock.On("CheckEntityForUpdate", entity)}
+}
+
+func (_c *MockEntityChecker_CheckEntityForUpdate_Call) Run(run func(entity *example.Entity)) *MockEntityChecker_CheckEntityForUpdate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *example.Entity
+		if args[0] != nil {
+			arg0 = args[0].(*example.Entity)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEntityChecker_CheckEntityForUpdate_Call) Return(err error) *MockEntityChecker_CheckEntityForUpdate_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockEntityChecker_CheckEntityForUpdate_Call) RunAndReturn(run func(entity *example.Entity) error) *MockEntityChecker_CheckEntityForUpdate_Call {
+	_c.Call.Return(run)
+	return _c
+}
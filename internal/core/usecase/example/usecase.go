@@ -3,6 +3,7 @@ package example
 import (
 	"context"
 	"microservice/internal/platform/logger"
+	"slices"
 
 	"microservice/internal/core/domain/example"
 	"microservice/internal/core/ports"
@@ -11,13 +12,39 @@ import (
 type Usecase struct {
 	repo    ports.ExampleRepository
 	checker EntityChecker
+	ids     ports.IDGenerator
+	tx      ports.Transactor
+	outbox  ports.Outbox
 }
 
-func NewUsecase(repo ports.ExampleRepository, checker EntityChecker) *Usecase {
-	return &Usecase{
+type Option func(*Usecase)
+
+// WithIDGenerator assigns an ID from ids to every entity created without one.
+// Without it the caller must supply each ID.
+func WithIDGenerator(ids ports.IDGenerator) Option {
+	return func(uc *Usecase) {
+		uc.ids = ids
+	}
+}
+
+func NewUsecase(repo ports.ExampleRepository, checker EntityChecker, opts ...Option) *Usecase {
+	uc := &Usecase{
 		repo:    repo,
 		checker: checker,
 	}
+	for _, opt := range opts {
+		opt(uc)
+	}
+	return uc
+}
+
+// entityID returns id, or a generated one when id is empty and IDs are
+// generated. An empty result is rejected by example.NewEntity.
+func (uc *Usecase) entityID(id string) string {
+	if id == "" && uc.ids != nil {
+		return uc.ids.NewID()
+	}
+	return id
 }
 
 func (uc *Usecase) GetEntity(ctx context.Context, id string) (*example.Entity, error) {
@@ -32,8 +59,26 @@ func (uc *Usecase) GetEntity(ctx context.Context, id string) (*example.Entity, e
 	return entity, nil
 }
 
+// GetEntityByEmail returns the entity with the email, or the one with the
+// lowest ID when several share it.
+func (uc *Usecase) GetEntityByEmail(ctx context.Context, email string) (*example.Entity, error) {
+	log := logger.FromContext(ctx)
+	log.Debug("Getting entity by email", logger.String("email", email))
+
+	return uc.repo.GetByEmail(ctx, email)
+}
+
+// ListEntities returns every live entity ordered by ID.
+func (uc *Usecase) ListEntities(ctx context.Context) ([]*example.Entity, error) {
+	log := logger.FromContext(ctx)
+	log.Debug("Listing entities")
+
+	return uc.repo.List(ctx)
+}
+
 func (uc *Usecase) CreateEntity(ctx context.Context, id, email, name string) (*example.Entity, error) {
 	log := logger.FromContext(ctx)
+	id = uc.entityID(id)
 	log.Debug("Creating entity", logger.String("entity_id", id), logger.String("email", email))
 
 	entity, err := example.NewEntity(id, email, name)
@@ -47,9 +92,147 @@ func (uc *Usecase) CreateEntity(ctx context.Context, id, email, name string) (*e
 		return nil, err
 	}
 
-	if err := uc.repo.Save(ctx, entity); err != nil {
+	if err := uc.write(ctx, TopicEntityCreated, entity, func(ctx context.Context) error {
+		return uc.repo.Save(ctx, entity)
+	}); err != nil {
 		return nil, err
 	}
 
 	return entity, nil
 }
+
+func (uc *Usecase) UpdateEntity(ctx context.Context, id string, email, name *string) (*example.Entity, error) {
+	log := logger.FromContext(ctx)
+	log.Debug("Updating entity", logger.String("entity_id", id))
+
+	existing, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	entity := *existing
+	if err := entity.Update(email, name); err != nil {
+		log.Warn("Invalid entity update provided", logger.String("entity_id", id), logger.Error(err))
+		return nil, err
+	}
+
+	if err := uc.checker.CheckEntityForUpdate(&entity); err != nil {
+		log.Warn("Entity update check failed", logger.String("entity_id", id), logger.Error(err))
+		return nil, err
+	}
+
+	if err := uc.write(ctx, TopicEntityUpdated, &entity, func(ctx context.Context) error {
+		return uc.repo.Update(ctx, &entity)
+	}); err != nil {
+		return nil, err
+	}
+
+	return &entity, nil
+}
+
+// RestoreEntity brings back a soft-deleted entity and returns it.
+func (uc *Usecase) RestoreEntity(ctx context.Context, id string) (*example.Entity, error) {
+	log := logger.FromContext(ctx)
+	log.Debug("Restoring entity", logger.String("entity_id", id))
+
+	var entity *example.Entity
+	if err := uc.inTx(ctx, func(ctx context.Context) error {
+		if err := uc.repo.Restore(ctx, id); err != nil {
+			return err
+		}
+		var err error
+		if entity, err = uc.repo.GetByID(ctx, id); err != nil {
+			return err
+		}
+		return uc.record(ctx, TopicEntityRestored, entity)
+	}); err != nil {
+		return nil, err
+	}
+
+	return entity, nil
+}
+
+func (uc *Usecase) CreateEntities(ctx context.Context, params []example.CreateEntityParams, atomic bool) ([]example.CreateEntityResult, error) {
+	log := logger.FromContext(ctx)
+	log.Debug("Creating entities in batch", logger.Int("count", len(params)))
+
+	results := make([]example.CreateEntityResult, len(params))
+	entities := make([]*example.Entity, 0, len(params))
+	indexes := make([]int, 0, len(params))
+	failed := false
+
+	for i, p := range params {
+		id := uc.entityID(p.ID)
+		entity, err := example.NewEntity(id, p.Email, p.Name)
+		if err == nil {
+			err = uc.checker.CheckEntityForCreation(entity)
+		}
+		if err != nil {
+			log.Warn("Batch item rejected", logger.String("entity_id", id), logger.Error(err))
+			results[i].Err = err
+			failed = true
+			continue
+		}
+
+		entities = append(entities, entity)
+		indexes = append(indexes, i)
+	}
+
+	if atomic && failed {
+		abortPending(results)
+		return results, nil
+	}
+
+	if len(entities) == 0 {
+		return results, nil
+	}
+
+	// Every stored entity gets its message in the transaction of the batch.
+	// A failed atomic batch stores nothing, so it records nothing either.
+	var errs []error
+	if err := uc.inTx(ctx, func(ctx context.Context) error {
+		var err error
+		if errs, err = uc.repo.SaveBatch(ctx, entities, atomic); err != nil {
+			return err
+		}
+		if atomic && slices.ContainsFunc(errs, func(err error) bool { return err != nil }) {
+			return nil
+		}
+		for j, entity := range entities {
+			if errs[j] != nil {
+				continue
+			}
+			if err := uc.record(ctx, TopicEntityCreated, entity); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	for j, i := range indexes {
+		if errs[j] != nil {
+			results[i].Err = errs[j]
+			failed = true
+			continue
+		}
+		results[i].Entity = entities[j]
+	}
+
+	if atomic && failed {
+		abortPending(results)
+	}
+
+	return results, nil
+}
+
+// abortPending marks every item without its own error as aborted, since an
+// atomic batch stores nothing once any item fails.
+func abortPending(results []example.CreateEntityResult) {
+	for i := range results {
+		if results[i].Err == nil {
+			results[i] = example.CreateEntityResult{Err: example.ErrBatchAborted}
+		}
+	}
+}
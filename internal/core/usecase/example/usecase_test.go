@@ -6,11 +6,13 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"microservice/internal/core/domain/example"
 	portsMocks "microservice/internal/core/ports/mocks"
 	"microservice/internal/core/usecase/example/mocks"
+	"microservice/internal/platform/idgen"
 )
 
 func TestNewUsecase(t *testing.T) {
@@ -91,6 +93,35 @@ func TestUsecase_GetEntity(t *testing.T) {
 	}
 }
 
+func TestUsecase_GetEntityByEmail(t *testing.T) {
+	mockRepo := portsMocks.NewMockExampleRepository(t)
+	entity := &example.Entity{ID: "test-id", Email: "test@example.com", Name: "Test User"}
+	mockRepo.EXPECT().GetByEmail(context.Background(), "test@example.com").Return(entity, nil).Once()
+	mockRepo.EXPECT().GetByEmail(context.Background(), "missing@example.com").Return(nil, example.ErrEntityNotFound).Once()
+
+	uc := NewUsecase(mockRepo, mocks.NewMockEntityChecker(t))
+
+	got, err := uc.GetEntityByEmail(context.Background(), "test@example.com")
+	require.NoError(t, err)
+	assert.Same(t, entity, got)
+
+	got, err = uc.GetEntityByEmail(context.Background(), "missing@example.com")
+	assert.ErrorIs(t, err, example.ErrEntityNotFound)
+	assert.Nil(t, got)
+}
+
+func TestUsecase_ListEntities(t *testing.T) {
+	mockRepo := portsMocks.NewMockExampleRepository(t)
+	entities := []*example.Entity{{ID: "a-id"}, {ID: "b-id"}}
+	mockRepo.EXPECT().List(context.Background()).Return(entities, nil).Once()
+
+	uc := NewUsecase(mockRepo, mocks.NewMockEntityChecker(t))
+
+	got, err := uc.ListEntities(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, entities, got)
+}
+
 func TestUsecase_CreateEntity(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -222,3 +253,279 @@ func TestUsecase_CreateEntity(t *testing.T) {
 		})
 	}
 }
+
+func TestUsecase_CreateEntities(t *testing.T) {
+	valid := []example.CreateEntityParams{
+		{ID: "id-1", Email: "one@example.com", Name: "One"},
+		{ID: "id-2", Email: "two@example.com", Name: "Two"},
+	}
+	withInvalid := []example.CreateEntityParams{
+		valid[0],
+		{ID: "id-bad", Email: "invalid-email", Name: "Bad"},
+		valid[1],
+	}
+
+	tests := []struct {
+		name          string
+		params        []example.CreateEntityParams
+		atomic        bool
+		setupMocks    func(*portsMocks.MockExampleRepository, *mocks.MockEntityChecker)
+		expectedErrs  []error
+		expectedError error
+	}{
+		{
+			name:   "all_created",
+			params: valid,
+			setupMocks: func(repo *portsMocks.MockExampleRepository, service *mocks.MockEntityChecker) {
+				service.EXPECT().CheckEntityForCreation(mock.Anything).Return(nil).Times(2)
+				repo.EXPECT().SaveBatch(mock.Anything, mock.Anything, false).Return([]error{nil, nil}, nil).Once()
+			},
+			expectedErrs: []error{nil, nil},
+		},
+		{
+			name:   "non_atomic_saves_valid_items",
+			params: withInvalid,
+			setupMocks: func(repo *portsMocks.MockExampleRepository, service *mocks.MockEntityChecker) {
+				service.EXPECT().CheckEntityForCreation(mock.Anything).Return(nil).Times(2)
+				repo.EXPECT().SaveBatch(mock.Anything, []*example.Entity{
+					{ID: "id-1", Email: "one@example.com", Name: "One"},
+					{ID: "id-2", Email: "two@example.com", Name: "Two"},
+				}, false).Return([]error{nil, &example.AlreadyExistsError{ID: "id-2"}}, nil).Once()
+			},
+			expectedErrs: []error{nil, example.ErrInvalidEmail, &example.AlreadyExistsError{ID: "id-2"}},
+		},
+		{
+			name:   "atomic_validation_failure_aborts_batch",
+			params: withInvalid,
+			atomic: true,
+			setupMocks: func(repo *portsMocks.MockExampleRepository, service *mocks.MockEntityChecker) {
+				service.EXPECT().CheckEntityForCreation(mock.Anything).Return(nil).Times(2)
+			},
+			expectedErrs: []error{example.ErrBatchAborted, example.ErrInvalidEmail, example.ErrBatchAborted},
+		},
+		{
+			name:   "atomic_repository_conflict_aborts_batch",
+			params: valid,
+			atomic: true,
+			setupMocks: func(repo *portsMocks.MockExampleRepository, service *mocks.MockEntityChecker) {
+				service.EXPECT().CheckEntityForCreation(mock.Anything).Return(nil).Times(2)
+				repo.EXPECT().SaveBatch(mock.Anything, mock.Anything, true).
+					Return([]error{&example.AlreadyExistsError{ID: "id-1"}, nil}, nil).Once()
+			},
+			expectedErrs: []error{&example.AlreadyExistsError{ID: "id-1"}, example.ErrBatchAborted},
+		},
+		{
+			name:   "repository_failure",
+			params: valid,
+			setupMocks: func(repo *portsMocks.MockExampleRepository, service *mocks.MockEntityChecker) {
+				service.EXPECT().CheckEntityForCreation(mock.Anything).Return(nil).Times(2)
+				repo.EXPECT().SaveBatch(mock.Anything, mock.Anything, false).Return(nil, errors.New("connection refused")).Once()
+			},
+			expectedError: errors.New("connection refused"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := portsMocks.NewMockExampleRepository(t)
+			mockService := mocks.NewMockEntityChecker(t)
+
+			tt.setupMocks(mockRepo, mockService)
+
+			uc := NewUsecase(mockRepo, mockService)
+
+			results, err := uc.CreateEntities(context.Background(), tt.params, tt.atomic)
+
+			if tt.expectedError != nil {
+				require.EqualError(t, err, tt.expectedError.Error())
+				assert.Nil(t, results)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, results, len(tt.expectedErrs))
+			for i, expected := range tt.expectedErrs {
+				if expected == nil {
+					assert.NoError(t, results[i].Err)
+					require.NotNil(t, results[i].Entity)
+					assert.Equal(t, tt.params[i].ID, results[i].Entity.ID)
+					continue
+				}
+				assert.Nil(t, results[i].Entity)
+				assert.Equal(t, expected.Error(), results[i].Err.Error())
+			}
+		})
+	}
+}
+
+func TestUsecase_CreateEntity_GeneratesMissingID(t *testing.T) {
+	mockRepo := portsMocks.NewMockExampleRepository(t)
+	mockChecker := mocks.NewMockEntityChecker(t)
+	uc := NewUsecase(mockRepo, mockChecker, WithIDGenerator(idgen.NewSequence("gen")))
+
+	mockChecker.EXPECT().CheckEntityForCreation(mock.Anything).Return(nil).Twice()
+	mockRepo.EXPECT().Save(mock.Anything, mock.Anything).Return(nil).Twice()
+
+	generated, err := uc.CreateEntity(context.Background(), "", "test@example.com", "Test User")
+	require.NoError(t, err)
+	assert.Equal(t, "gen-1", generated.ID)
+
+	supplied, err := uc.CreateEntity(context.Background(), "client-id", "test@example.com", "Test User")
+	require.NoError(t, err)
+	assert.Equal(t, "client-id", supplied.ID, "client IDs are kept")
+}
+
+func TestUsecase_CreateEntities_GeneratesMissingIDs(t *testing.T) {
+	mockRepo := portsMocks.NewMockExampleRepository(t)
+	mockChecker := mocks.NewMockEntityChecker(t)
+	uc := NewUsecase(mockRepo, mockChecker, WithIDGenerator(idgen.NewSequence("gen")))
+
+	mockChecker.EXPECT().CheckEntityForCreation(mock.Anything).Return(nil).Times(3)
+	mockRepo.EXPECT().SaveBatch(mock.Anything, []*example.Entity{
+		{ID: "gen-1", Email: "one@example.com", Name: "One"},
+		{ID: "client-id", Email: "two@example.com", Name: "Two"},
+		{ID: "gen-2", Email: "three@example.com", Name: "Three"},
+	}, false).Return([]error{nil, nil, nil}, nil).Once()
+
+	results, err := uc.CreateEntities(context.Background(), []example.CreateEntityParams{
+		{Email: "one@example.com", Name: "One"},
+		{ID: "client-id", Email: "two@example.com", Name: "Two"},
+		{Email: "three@example.com", Name: "Three"},
+	}, false)
+
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	assert.Equal(t, "gen-1", results[0].Entity.ID)
+	assert.Equal(t, "client-id", results[1].Entity.ID)
+	assert.Equal(t, "gen-2", results[2].Entity.ID)
+}
+
+func TestUsecase_UpdateEntity(t *testing.T) {
+	strPtr := func(s string) *string { return &s }
+	existing := func() *example.Entity {
+		return &example.Entity{ID: "test-id", Email: "test@example.com", Name: "Test User"}
+	}
+
+	tests := []struct {
+		name           string
+		email          *string
+		entityName     *string
+		setupMocks     func(*portsMocks.MockExampleRepository, *mocks.MockEntityChecker)
+		expectedEntity *example.Entity
+		expectedError  error
+	}{
+		{
+			name:       "successful_partial_update",
+			entityName: strPtr("New Name"),
+			setupMocks: func(repo *portsMocks.MockExampleRepository, service *mocks.MockEntityChecker) {
+				updated := &example.Entity{ID: "test-id", Email: "test@example.com", Name: "New Name"}
+				repo.EXPECT().GetByID(context.Background(), "test-id").Return(existing(), nil).Once()
+				service.EXPECT().CheckEntityForUpdate(updated).Return(nil).Once()
+				repo.EXPECT().Update(context.Background(), updated).Return(nil).Once()
+			},
+			expectedEntity: &example.Entity{ID: "test-id", Email: "test@example.com", Name: "New Name"},
+		},
+		{
+			name:  "entity_not_found",
+			email: strPtr("new@example.com"),
+			setupMocks: func(repo *portsMocks.MockExampleRepository, service *mocks.MockEntityChecker) {
+				repo.EXPECT().GetByID(context.Background(), "test-id").Return(nil, example.ErrEntityNotFound).Once()
+			},
+			expectedError: example.ErrEntityNotFound,
+		},
+		{
+			name:  "invalid_email",
+			email: strPtr("invalid-email"),
+			setupMocks: func(repo *portsMocks.MockExampleRepository, service *mocks.MockEntityChecker) {
+				repo.EXPECT().GetByID(context.Background(), "test-id").Return(existing(), nil).Once()
+			},
+			expectedError: example.ErrInvalidEmail,
+		},
+		{
+			name:       "reserved_name",
+			entityName: strPtr("admin"),
+			setupMocks: func(repo *portsMocks.MockExampleRepository, service *mocks.MockEntityChecker) {
+				repo.EXPECT().GetByID(context.Background(), "test-id").Return(existing(), nil).Once()
+				service.EXPECT().CheckEntityForUpdate(&example.Entity{ID: "test-id", Email: "test@example.com", Name: "admin"}).Return(example.ErrReservedName).Once()
+			},
+			expectedError: example.ErrReservedName,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := portsMocks.NewMockExampleRepository(t)
+			mockService := mocks.NewMockEntityChecker(t)
+
+			tt.setupMocks(mockRepo, mockService)
+
+			uc := NewUsecase(mockRepo, mockService)
+
+			entity, err := uc.UpdateEntity(context.Background(), "test-id", tt.email, tt.entityName)
+
+			if tt.expectedError != nil {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, entity)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedEntity, entity)
+		})
+	}
+}
+
+func TestUsecase_RestoreEntity(t *testing.T) {
+	restored := &example.Entity{ID: "test-id", Email: "test@example.com", Name: "Test User"}
+
+	tests := []struct {
+		name           string
+		setupMocks     func(*portsMocks.MockExampleRepository)
+		expectedEntity *example.Entity
+		expectedError  error
+	}{
+		{
+			name: "restored",
+			setupMocks: func(repo *portsMocks.MockExampleRepository) {
+				repo.EXPECT().Restore(context.Background(), "test-id").Return(nil).Once()
+				repo.EXPECT().GetByID(context.Background(), "test-id").Return(restored, nil).Once()
+			},
+			expectedEntity: restored,
+		},
+		{
+			name: "not_found",
+			setupMocks: func(repo *portsMocks.MockExampleRepository) {
+				repo.EXPECT().Restore(context.Background(), "test-id").Return(example.ErrEntityNotFound).Once()
+			},
+			expectedError: example.ErrEntityNotFound,
+		},
+		{
+			name: "not_deleted",
+			setupMocks: func(repo *portsMocks.MockExampleRepository) {
+				repo.EXPECT().Restore(context.Background(), "test-id").Return(example.ErrEntityNotDeleted).Once()
+			},
+			expectedError: example.ErrEntityNotDeleted,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := portsMocks.NewMockExampleRepository(t)
+			tt.setupMocks(mockRepo)
+
+			uc := NewUsecase(mockRepo, mocks.NewMockEntityChecker(t))
+
+			entity, err := uc.RestoreEntity(context.Background(), "test-id")
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, entity)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedEntity, entity)
+		})
+	}
+}
@@ -46,6 +46,53 @@ func (db *DB) Ping(ctx context.Context) error {
 	return db.DB.PingContext(ctx)
 }
 
+// WarmUp opens n connections, pings them and hands them back to the pool as
+// idle connections, so the first requests do not pay for connecting. n is
+// capped at the pool's idle and open limits, since the pool would close any
+// extra connection straight away. Every connection gets the same timeout as
+// Ping, and WarmUp stops at the first failure or when ctx ends. It returns how
+// many connections were warmed.
+func (db *DB) WarmUp(ctx context.Context, n int) (int, error) {
+	if db.config != nil {
+		n = min(n, db.config.GetMaxIdleConns())
+		if maxOpen := db.config.GetMaxOpenConns(); maxOpen > 0 {
+			n = min(n, maxOpen)
+		}
+	}
+
+	// Hold every connection until the end so the pool cannot hand the same
+	// one out twice.
+	conns := make([]*sql.Conn, 0, max(n, 0))
+	defer func() {
+		for _, conn := range conns {
+			_ = conn.Close()
+		}
+	}()
+
+	for len(conns) < n {
+		if err := db.warmConn(ctx, &conns); err != nil {
+			return len(conns), err
+		}
+	}
+	return len(conns), nil
+}
+
+func (db *DB) warmConn(ctx context.Context, conns *[]*sql.Conn) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	conn, err := db.DB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	if err := conn.PingContext(ctx); err != nil {
+		_ = conn.Close()
+		return err
+	}
+	*conns = append(*conns, conn)
+	return nil
+}
+
 func (db *DB) Close() error {
 	return db.DB.Close()
 }
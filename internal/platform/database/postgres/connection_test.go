@@ -221,6 +221,84 @@ func (s *PostgresConnectionTestSuite) TestPing_InternalTimeout() {
 	s.Assert().Greater(duration, 4*time.Second, "Ping should take at least ~5 seconds to timeout")
 }
 
+func (s *PostgresConnectionTestSuite) newWarmUpDB(maxIdle, maxOpen int) (*DB, sqlmock.Sqlmock) {
+	mockDB, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	s.Require().NoError(err)
+	s.T().Cleanup(func() { _ = mockDB.Close() })
+	mockDB.SetMaxIdleConns(maxIdle)
+	mockDB.SetMaxOpenConns(maxOpen)
+
+	return &DB{
+		DB:     mockDB,
+		config: &MockConfig{maxIdleConns: maxIdle, maxOpenConns: maxOpen},
+	}, mock
+}
+
+func (s *PostgresConnectionTestSuite) TestWarmUp_LeavesConnectionsIdle() {
+	db, mock := s.newWarmUpDB(5, 25)
+	for i := 0; i < 3; i++ {
+		mock.ExpectPing()
+	}
+
+	warmed, err := db.WarmUp(context.Background(), 3)
+
+	s.Require().NoError(err)
+	s.Assert().Equal(3, warmed)
+	s.Assert().Equal(3, db.Stats().Idle)
+	s.Assert().NoError(mock.ExpectationsWereMet())
+}
+
+func (s *PostgresConnectionTestSuite) TestWarmUp_CappedByPoolLimits() {
+	tests := []struct {
+		name     string
+		maxIdle  int
+		maxOpen  int
+		expected int
+	}{
+		{name: "idle_limit", maxIdle: 2, maxOpen: 25, expected: 2},
+		{name: "open_limit", maxIdle: 5, maxOpen: 1, expected: 1},
+		{name: "no_idle_connections", maxIdle: 0, maxOpen: 25, expected: 0},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			db, mock := s.newWarmUpDB(tt.maxIdle, tt.maxOpen)
+			for i := 0; i < tt.expected; i++ {
+				mock.ExpectPing()
+			}
+
+			warmed, err := db.WarmUp(context.Background(), 10)
+
+			s.Require().NoError(err)
+			s.Assert().Equal(tt.expected, warmed)
+			s.Assert().NoError(mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func (s *PostgresConnectionTestSuite) TestWarmUp_StopsAtFirstFailure() {
+	db, mock := s.newWarmUpDB(5, 25)
+	mock.ExpectPing()
+	mock.ExpectPing().WillReturnError(driver.ErrBadConn)
+
+	warmed, err := db.WarmUp(context.Background(), 4)
+
+	s.Assert().Error(err)
+	s.Assert().Equal(1, warmed)
+	s.Assert().NoError(mock.ExpectationsWereMet())
+}
+
+func (s *PostgresConnectionTestSuite) TestWarmUp_CancelledContext() {
+	db, _ := s.newWarmUpDB(5, 25)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	warmed, err := db.WarmUp(ctx, 3)
+
+	s.Assert().ErrorIs(err, context.Canceled)
+	s.Assert().Zero(warmed)
+}
+
 func (s *PostgresConnectionTestSuite) TestClose_Success() {
 	mockDB, mock, err := sqlmock.New()
 	s.Require().NoError(err)
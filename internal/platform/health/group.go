@@ -0,0 +1,28 @@
+package health
+
+import "context"
+
+const (
+	GroupCore     = "core"
+	GroupOptional = "optional"
+	GroupExternal = "external"
+)
+
+type groupedChecker struct {
+	Checker
+	group string
+}
+
+// WithGroup tags a checker with a category that is reported alongside its results.
+func WithGroup(checker Checker, group string) Checker {
+	return &groupedChecker{
+		Checker: checker,
+		group:   group,
+	}
+}
+
+func (c *groupedChecker) Check(ctx context.Context) CheckResult {
+	result := c.Checker.Check(ctx)
+	result.Group = c.group
+	return result
+}
@@ -2,6 +2,7 @@ package health
 
 import (
 	"context"
+	"fmt"
 	"sync"
 	"time"
 )
@@ -11,13 +12,22 @@ type Status string
 const (
 	StatusHealthy   Status = "healthy"
 	StatusUnhealthy Status = "unhealthy"
+	// StatusUnknown marks a checker that did not finish before the deadline.
+	StatusUnknown Status = "unknown"
+	// StatusDegraded marks a check that still passes but needs attention soon.
+	StatusDegraded Status = "degraded"
 )
 
 type CheckResult struct {
-	Status  Status        `json:"status"`
-	Message string        `json:"message,omitempty"`
-	Latency time.Duration `json:"latency"`
-	Error   string        `json:"error,omitempty"`
+	Status   Status        `json:"status"`
+	Message  string        `json:"message,omitempty"`
+	Latency  time.Duration `json:"latency"`
+	Error    string        `json:"error,omitempty"`
+	Group    string        `json:"group,omitempty"`
+	Optional bool          `json:"optional,omitempty"`
+	// Components holds the results behind a check that rolls up several
+	// dependencies, keyed by dependency name.
+	Components map[string]CheckResult `json:"components,omitempty"`
 }
 
 type Checker interface {
@@ -27,54 +37,329 @@ type Checker interface {
 
 type ManagerInterface interface {
 	Register(checker Checker)
+	RegisterOptional(checker Checker)
 	CheckAll(ctx context.Context) map[string]CheckResult
 	IsHealthy(ctx context.Context) bool
 }
 
+// backgroundRefreshTimeout bounds a cache refresh that is no longer tied to
+// the request that triggered it.
+const backgroundRefreshTimeout = 10 * time.Second
+
 type Manager struct {
-	checkers []Checker
-	mu       sync.RWMutex
+	checkers    []Checker
+	mu          sync.RWMutex
+	concurrency int
+
+	cacheTTL   time.Duration
+	cached     map[string]CheckResult
+	cachedAt   time.Time
+	refreshing chan struct{}
+	generation uint64
+	cacheMu    sync.Mutex
 }
 
 // Compile-time interface check
 var _ ManagerInterface = (*Manager)(nil)
 
-func NewManager() *Manager {
-	return &Manager{
+type Option func(*Manager)
+
+// WithCacheTTL makes CheckAll reuse results for ttl. Stale results are served
+// while a single background run refreshes them. Zero disables caching.
+func WithCacheTTL(ttl time.Duration) Option {
+	return func(m *Manager) {
+		m.cacheTTL = ttl
+	}
+}
+
+// WithConcurrencyLimit runs at most limit checkers at a time, for services
+// with many checkers hitting the same dependency. Checkers waiting for a slot
+// count against the CheckAll deadline. Zero or less runs them all at once.
+func WithConcurrencyLimit(limit int) Option {
+	return func(m *Manager) {
+		m.concurrency = limit
+	}
+}
+
+func NewManager(opts ...Option) *Manager {
+	m := &Manager{
 		checkers: make([]Checker, 0),
 	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
 }
 
 func (m *Manager) Register(checker Checker) {
 	m.mu.Lock()
-	defer m.mu.Unlock()
 	m.checkers = append(m.checkers, checker)
+	m.mu.Unlock()
+
+	m.invalidate()
+}
+
+// RegisterUnique registers checker like Register, except that a checker whose
+// name is already taken is registered as name-2, name-3 and so on, so that
+// checkers sharing a name no longer overwrite each other's results. It returns
+// the name the checker was registered under.
+func (m *Manager) RegisterUnique(checker Checker) string {
+	m.mu.Lock()
+	taken := make(map[string]bool, len(m.checkers))
+	for _, c := range m.checkers {
+		taken[c.Name()] = true
+	}
+	name := checker.Name()
+	if taken[name] {
+		for i := 2; ; i++ {
+			if candidate := fmt.Sprintf("%s-%d", name, i); !taken[candidate] {
+				name = candidate
+				break
+			}
+		}
+		checker = &renamedChecker{Checker: checker, name: name}
+	}
+	m.checkers = append(m.checkers, checker)
+	m.mu.Unlock()
+
+	m.invalidate()
+	return name
+}
+
+type renamedChecker struct {
+	Checker
+	name string
+}
+
+func (c *renamedChecker) Name() string {
+	return c.name
+}
+
+// invalidate drops cached results after the set of checkers changed.
+func (m *Manager) invalidate() {
+	m.cacheMu.Lock()
+	m.cached = nil
+	m.generation++
+	m.cacheMu.Unlock()
+}
+
+// RegisterOptional registers a checker for a soft dependency. Its results are
+// marked Optional, so an outage is reported without making the service
+// unhealthy.
+func (m *Manager) RegisterOptional(checker Checker) {
+	m.Register(&optionalChecker{Checker: checker})
+}
+
+type optionalChecker struct {
+	Checker
+}
+
+func (c *optionalChecker) Check(ctx context.Context) CheckResult {
+	result := c.Checker.Check(ctx)
+	result.Optional = true
+	return result
 }
 
 func (m *Manager) CheckAll(ctx context.Context) map[string]CheckResult {
+	if m.cacheTTL <= 0 {
+		return m.runChecks(ctx)
+	}
+
+	for {
+		m.cacheMu.Lock()
+		if m.cached != nil {
+			results := copyResults(m.cached)
+			if time.Since(m.cachedAt) >= m.cacheTTL && m.refreshing == nil {
+				m.startRefresh()
+				go m.refresh(context.WithoutCancel(ctx))
+			}
+			m.cacheMu.Unlock()
+			return results
+		}
+
+		// Nothing cached yet: one caller runs the checks, the rest wait for it.
+		if m.refreshing == nil {
+			m.startRefresh()
+			m.cacheMu.Unlock()
+			m.refresh(ctx)
+			continue
+		}
+		done := m.refreshing
+		m.cacheMu.Unlock()
+
+		select {
+		case <-done:
+		case <-ctx.Done():
+			return m.runChecks(ctx)
+		}
+	}
+}
+
+// startRefresh marks a refresh as in flight. cacheMu must be held.
+func (m *Manager) startRefresh() {
+	m.refreshing = make(chan struct{})
+}
+
+func (m *Manager) refresh(ctx context.Context) {
+	m.cacheMu.Lock()
+	generation := m.generation
+	m.cacheMu.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, backgroundRefreshTimeout)
+	defer cancel()
+	results := m.runChecks(ctx)
+
+	m.cacheMu.Lock()
+	defer m.cacheMu.Unlock()
+	// Results gathered before a Register would miss the new checker.
+	if generation == m.generation {
+		m.cached = results
+		m.cachedAt = time.Now()
+	}
+	close(m.refreshing)
+	m.refreshing = nil
+}
+
+func copyResults(results map[string]CheckResult) map[string]CheckResult {
+	copied := make(map[string]CheckResult, len(results))
+	for name, result := range results {
+		copied[name] = result
+	}
+	return copied
+}
+
+// runChecks runs every checker concurrently, up to the concurrency limit, so
+// a run takes about as long as its slowest checker. When ctx is done before
+// all of them finish, the finished results are returned and the rest are reported as
+// StatusUnknown. A nil or panicking checker is reported as StatusUnhealthy
+// instead of taking the process down.
+func (m *Manager) runChecks(ctx context.Context) map[string]CheckResult {
 	m.mu.RLock()
 	checkers := make([]Checker, len(m.checkers))
 	copy(checkers, m.checkers)
 	m.mu.RUnlock()
 
-	results := make(map[string]CheckResult)
+	type namedResult struct {
+		name   string
+		result CheckResult
+	}
 
-	for _, checker := range checkers {
-		start := time.Now()
-		result := checker.Check(ctx)
-		result.Latency = time.Since(start)
+	names := make([]string, len(checkers))
+	for i, checker := range checkers {
+		names[i] = checkerName(checker, i)
+	}
+
+	var slots chan struct{}
+	if m.concurrency > 0 && m.concurrency < len(checkers) {
+		slots = make(chan struct{}, m.concurrency)
+	}
 
-		results[checker.Name()] = result
+	start := time.Now()
+	done := make(chan namedResult, len(checkers))
+	for i, checker := range checkers {
+		go func(name string, checker Checker) {
+			if slots != nil {
+				select {
+				case slots <- struct{}{}:
+					defer func() { <-slots }()
+				case <-ctx.Done():
+					// Reported as StatusUnknown below.
+					return
+				}
+			}
+			done <- namedResult{name: name, result: runCheck(ctx, checker)}
+		}(names[i], checker)
+	}
+
+	results := make(map[string]CheckResult, len(checkers))
+	for pending := len(checkers); pending > 0; pending-- {
+		select {
+		case r := <-done:
+			results[r.name] = r.result
+		case <-ctx.Done():
+			for i, checker := range checkers {
+				if _, ok := results[names[i]]; ok {
+					continue
+				}
+				result := CheckResult{
+					Status:  StatusUnknown,
+					Message: "Check did not complete before the deadline",
+					Latency: time.Since(start),
+					Error:   ctx.Err().Error(),
+				}
+				describe(checker, &result)
+				results[names[i]] = result
+			}
+			return results
+		}
 	}
 
 	return results
 }
 
+// runCheck calls checker.Check and measures its latency, turning a nil checker
+// or a panic into an unhealthy result.
+func runCheck(ctx context.Context, checker Checker) (result CheckResult) {
+	start := time.Now()
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			result = CheckResult{
+				Status:  StatusUnhealthy,
+				Message: "Check panicked",
+				Error:   fmt.Sprint(recovered),
+			}
+			describe(checker, &result)
+		}
+		result.Latency = time.Since(start)
+	}()
+
+	if checker == nil {
+		return CheckResult{Status: StatusUnhealthy, Message: "Checker is nil"}
+	}
+	return checker.Check(ctx)
+}
+
+// checkerName returns checker.Name(), or checker-<index> for a nil checker or
+// one whose Name panics, so every checker still gets a result.
+func checkerName(checker Checker, index int) (name string) {
+	fallback := fmt.Sprintf("checker-%d", index)
+	if checker == nil {
+		return fallback
+	}
+	defer func() {
+		if recover() != nil {
+			name = fallback
+		}
+	}()
+	return checker.Name()
+}
+
+// describe copies the metadata that checker wrappers add to their results, for
+// results that were produced without calling Check.
+func describe(checker Checker, result *CheckResult) {
+	for {
+		switch c := checker.(type) {
+		case *groupedChecker:
+			if result.Group == "" {
+				result.Group = c.group
+			}
+			checker = c.Checker
+		case *optionalChecker:
+			result.Optional = true
+			checker = c.Checker
+		case *renamedChecker:
+			checker = c.Checker
+		default:
+			return
+		}
+	}
+}
+
 func (m *Manager) IsHealthy(ctx context.Context) bool {
 	results := m.CheckAll(ctx)
 
 	for _, result := range results {
-		if result.Status == StatusUnhealthy {
+		if result.Status == StatusUnhealthy && !result.Optional {
 			return false
 		}
 	}
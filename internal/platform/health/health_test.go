@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -62,6 +63,39 @@ func (suite *HealthTestSuite) TestRegister_MultipleCheckers() {
 	suite.manager.mu.RUnlock()
 }
 
+func (suite *HealthTestSuite) TestRegisterUnique_KeepsResultsOfSharedNames() {
+	primary := &mockHealthChecker{name: "database", result: CheckResult{Status: StatusHealthy, Message: "primary"}}
+	replica := &mockHealthChecker{name: "database", result: CheckResult{Status: StatusUnhealthy, Message: "replica"}}
+	analytics := &mockHealthChecker{name: "database", result: CheckResult{Status: StatusHealthy, Message: "analytics"}}
+
+	assert.Equal(suite.T(), "database", suite.manager.RegisterUnique(primary))
+	assert.Equal(suite.T(), "database-2", suite.manager.RegisterUnique(replica))
+	assert.Equal(suite.T(), "database-3", suite.manager.RegisterUnique(analytics))
+
+	results := suite.manager.CheckAll(suite.ctx)
+
+	require.Len(suite.T(), results, 3)
+	assert.Equal(suite.T(), "primary", results["database"].Message)
+	assert.Equal(suite.T(), "replica", results["database-2"].Message)
+	assert.Equal(suite.T(), StatusUnhealthy, results["database-2"].Status)
+	assert.Equal(suite.T(), "analytics", results["database-3"].Message)
+	assert.False(suite.T(), suite.manager.IsHealthy(suite.ctx))
+}
+
+func (suite *HealthTestSuite) TestRegisterUnique_KeepsWrapperMetadataOnDeadline() {
+	slow := &mockHealthChecker{name: "database", delay: time.Second}
+	suite.manager.Register(&mockHealthChecker{name: "database", result: CheckResult{Status: StatusHealthy}})
+	suite.manager.RegisterUnique(WithGroup(slow, GroupExternal))
+
+	ctx, cancel := context.WithTimeout(suite.ctx, 50*time.Millisecond)
+	defer cancel()
+	results := suite.manager.CheckAll(ctx)
+
+	require.Contains(suite.T(), results, "database-2")
+	assert.Equal(suite.T(), StatusUnknown, results["database-2"].Status)
+	assert.Equal(suite.T(), GroupExternal, results["database-2"].Group)
+}
+
 func (suite *HealthTestSuite) TestCheckAll_NoCheckers() {
 	results := suite.manager.CheckAll(suite.ctx)
 
@@ -151,6 +185,108 @@ func (suite *HealthTestSuite) TestCheckAll_LatencyMeasurement() {
 	assert.GreaterOrEqual(suite.T(), totalDuration, 50*time.Millisecond)
 }
 
+func (suite *HealthTestSuite) TestCheckAll_RunsCheckersConcurrently() {
+	for _, name := range []string{"db", "cache", "queue"} {
+		suite.manager.Register(&mockHealthChecker{name: name, result: CheckResult{Status: StatusHealthy}, delay: 100 * time.Millisecond})
+	}
+
+	start := time.Now()
+	results := suite.manager.CheckAll(suite.ctx)
+
+	assert.Less(suite.T(), time.Since(start), 250*time.Millisecond)
+	require.Len(suite.T(), results, 3)
+	for name, result := range results {
+		assert.Equal(suite.T(), StatusHealthy, result.Status, name)
+	}
+}
+
+func (suite *HealthTestSuite) TestCheckAll_TakesAsLongAsSlowestChecker() {
+	for _, name := range []string{"db", "cache", "queue"} {
+		suite.manager.Register(&mockHealthChecker{name: name, result: CheckResult{Status: StatusHealthy}, delay: 50 * time.Millisecond})
+	}
+
+	start := time.Now()
+	results := suite.manager.CheckAll(suite.ctx)
+	elapsed := time.Since(start)
+
+	assert.Less(suite.T(), elapsed, 120*time.Millisecond)
+	require.Len(suite.T(), results, 3)
+	for name, result := range results {
+		assert.GreaterOrEqual(suite.T(), result.Latency, 50*time.Millisecond, name)
+		assert.LessOrEqual(suite.T(), result.Latency, elapsed, name)
+	}
+}
+
+func (suite *HealthTestSuite) TestCheckAll_ConcurrencyLimit() {
+	manager := NewManager(WithConcurrencyLimit(2))
+	var running, peak atomic.Int32
+	for _, name := range []string{"db", "cache", "queue", "search"} {
+		manager.Register(&funcChecker{name: name, check: func(context.Context) CheckResult {
+			now := running.Add(1)
+			for {
+				seen := peak.Load()
+				if now <= seen || peak.CompareAndSwap(seen, now) {
+					break
+				}
+			}
+			time.Sleep(30 * time.Millisecond)
+			running.Add(-1)
+			return CheckResult{Status: StatusHealthy}
+		}})
+	}
+
+	start := time.Now()
+	results := manager.CheckAll(suite.ctx)
+
+	require.Len(suite.T(), results, 4)
+	assert.Equal(suite.T(), int32(2), peak.Load())
+	assert.GreaterOrEqual(suite.T(), time.Since(start), 60*time.Millisecond)
+	for name, result := range results {
+		assert.Equal(suite.T(), StatusHealthy, result.Status, name)
+		assert.Less(suite.T(), result.Latency, 60*time.Millisecond, name, "waiting for a slot is not latency")
+	}
+}
+
+func (suite *HealthTestSuite) TestCheckAll_ConcurrencyLimitDeadline() {
+	manager := NewManager(WithConcurrencyLimit(1))
+	manager.Register(&mockHealthChecker{name: "slow", result: CheckResult{Status: StatusHealthy}, delay: time.Second})
+	manager.Register(&mockHealthChecker{name: "queued", result: CheckResult{Status: StatusHealthy}})
+
+	ctx, cancel := context.WithTimeout(suite.ctx, 50*time.Millisecond)
+	defer cancel()
+	results := manager.CheckAll(ctx)
+
+	// Whichever order the checkers get the slot in, the slow one cannot finish.
+	require.Len(suite.T(), results, 2)
+	assert.Equal(suite.T(), StatusUnknown, results["slow"].Status)
+}
+
+func (suite *HealthTestSuite) TestCheckAll_DeadlineReturnsPartialResults() {
+	suite.manager.Register(&mockHealthChecker{name: "memory", result: CheckResult{Status: StatusHealthy}})
+	suite.manager.Register(WithGroup(&mockHealthChecker{name: "db", result: CheckResult{Status: StatusHealthy}, delay: time.Second}, GroupCore))
+	suite.manager.RegisterOptional(&mockHealthChecker{name: "cache", result: CheckResult{Status: StatusHealthy}, delay: time.Second})
+	suite.manager.Register(&mockHealthChecker{name: "queue", result: CheckResult{Status: StatusHealthy}, delay: time.Second})
+
+	ctx, cancel := context.WithTimeout(suite.ctx, 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	results := suite.manager.CheckAll(ctx)
+
+	assert.Less(suite.T(), time.Since(start), 500*time.Millisecond)
+	require.Len(suite.T(), results, 4)
+	assert.Equal(suite.T(), StatusHealthy, results["memory"].Status)
+	for _, name := range []string{"db", "cache", "queue"} {
+		result := results[name]
+		assert.Equal(suite.T(), StatusUnknown, result.Status, name)
+		assert.Equal(suite.T(), context.DeadlineExceeded.Error(), result.Error, name)
+		assert.GreaterOrEqual(suite.T(), result.Latency, 50*time.Millisecond, name)
+	}
+	assert.Equal(suite.T(), GroupCore, results["db"].Group)
+	assert.True(suite.T(), results["cache"].Optional)
+	assert.True(suite.T(), suite.manager.IsHealthy(suite.ctx))
+}
+
 func (suite *HealthTestSuite) TestIsHealthy_AllHealthy() {
 	checker1 := &mockHealthChecker{name: "db", result: CheckResult{Status: StatusHealthy}}
 	checker2 := &mockHealthChecker{name: "redis", result: CheckResult{Status: StatusHealthy}}
@@ -173,6 +309,111 @@ func (suite *HealthTestSuite) TestIsHealthy_SomeUnhealthy() {
 	assert.False(suite.T(), isHealthy)
 }
 
+func (suite *HealthTestSuite) TestRegisterOptional_MarksResults() {
+	suite.manager.Register(&mockHealthChecker{name: "db", result: CheckResult{Status: StatusHealthy}})
+	suite.manager.RegisterOptional(&mockHealthChecker{name: "api", result: CheckResult{Status: StatusUnhealthy, Group: GroupExternal}})
+
+	results := suite.manager.CheckAll(suite.ctx)
+
+	require.Len(suite.T(), results, 2)
+	assert.False(suite.T(), results["db"].Optional)
+	assert.True(suite.T(), results["api"].Optional)
+	assert.Equal(suite.T(), StatusUnhealthy, results["api"].Status)
+	assert.Equal(suite.T(), GroupExternal, results["api"].Group)
+}
+
+func (suite *HealthTestSuite) TestIsHealthy_OptionalUnhealthy() {
+	suite.manager.Register(&mockHealthChecker{name: "db", result: CheckResult{Status: StatusHealthy}})
+	suite.manager.RegisterOptional(&mockHealthChecker{name: "api", result: CheckResult{Status: StatusUnhealthy}})
+
+	assert.True(suite.T(), suite.manager.IsHealthy(suite.ctx))
+
+	suite.manager.Register(&mockHealthChecker{name: "cache", result: CheckResult{Status: StatusUnhealthy}})
+
+	assert.False(suite.T(), suite.manager.IsHealthy(suite.ctx))
+}
+
+func (suite *HealthTestSuite) TestCheckAll_NoCacheByDefault() {
+	checker := &mockHealthChecker{name: "db", result: CheckResult{Status: StatusHealthy}}
+	suite.manager.Register(checker)
+
+	suite.manager.CheckAll(suite.ctx)
+	suite.manager.CheckAll(suite.ctx)
+
+	assert.Equal(suite.T(), 2, checker.CallCount())
+}
+
+func (suite *HealthTestSuite) TestCheckAll_CachedWithinTTL() {
+	manager := NewManager(WithCacheTTL(time.Minute))
+	checker := &mockHealthChecker{name: "db", result: CheckResult{Status: StatusHealthy}}
+	manager.Register(checker)
+
+	first := manager.CheckAll(suite.ctx)
+	second := manager.CheckAll(suite.ctx)
+
+	assert.Equal(suite.T(), 1, checker.CallCount())
+	assert.Equal(suite.T(), first, second)
+
+	second["db"] = CheckResult{Status: StatusUnhealthy}
+	assert.Equal(suite.T(), StatusHealthy, manager.CheckAll(suite.ctx)["db"].Status)
+}
+
+func (suite *HealthTestSuite) TestCheckAll_ConcurrentCallersShareOneRun() {
+	manager := NewManager(WithCacheTTL(time.Minute))
+	checker := &mockHealthChecker{name: "db", result: CheckResult{Status: StatusHealthy}, delay: 20 * time.Millisecond}
+	manager.Register(checker)
+
+	const callers = 50
+	var wg sync.WaitGroup
+	wg.Add(callers)
+	for i := 0; i < callers; i++ {
+		go func() {
+			defer wg.Done()
+			results := manager.CheckAll(suite.ctx)
+			assert.Equal(suite.T(), StatusHealthy, results["db"].Status)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(suite.T(), 1, checker.CallCount())
+}
+
+func (suite *HealthTestSuite) TestCheckAll_StaleResultsRefreshInBackground() {
+	manager := NewManager(WithCacheTTL(10 * time.Millisecond))
+	checker := &mockHealthChecker{name: "db", result: CheckResult{Status: StatusHealthy}, delay: 20 * time.Millisecond}
+	manager.Register(checker)
+
+	manager.CheckAll(suite.ctx)
+	time.Sleep(15 * time.Millisecond)
+
+	const callers = 20
+	var wg sync.WaitGroup
+	wg.Add(callers)
+	for i := 0; i < callers; i++ {
+		go func() {
+			defer wg.Done()
+			start := time.Now()
+			manager.CheckAll(suite.ctx)
+			assert.Less(suite.T(), time.Since(start), checker.delay, "stale results should be served without waiting")
+		}()
+	}
+	wg.Wait()
+
+	assert.Eventually(suite.T(), func() bool { return checker.CallCount() == 2 }, time.Second, 5*time.Millisecond)
+	time.Sleep(30 * time.Millisecond)
+	assert.Equal(suite.T(), 2, checker.CallCount())
+}
+
+func (suite *HealthTestSuite) TestCheckAll_RegisterInvalidatesCache() {
+	manager := NewManager(WithCacheTTL(time.Minute))
+	manager.Register(&mockHealthChecker{name: "db", result: CheckResult{Status: StatusHealthy}})
+	require.Len(suite.T(), manager.CheckAll(suite.ctx), 1)
+
+	manager.Register(&mockHealthChecker{name: "cache", result: CheckResult{Status: StatusHealthy}})
+
+	assert.Len(suite.T(), manager.CheckAll(suite.ctx), 2)
+}
+
 func (suite *HealthTestSuite) TestIsHealthy_NoCheckers() {
 	isHealthy := suite.manager.IsHealthy(suite.ctx)
 	assert.True(suite.T(), isHealthy)
@@ -261,6 +502,24 @@ func TestCheckResult_Structure(t *testing.T) {
 	assert.Empty(t, result.Error)
 }
 
+type funcChecker struct {
+	name  string
+	check func(context.Context) CheckResult
+}
+
+func (c *funcChecker) Name() string { return c.name }
+
+func (c *funcChecker) Check(ctx context.Context) CheckResult { return c.check(ctx) }
+
+type panickingChecker struct{}
+
+func (panickingChecker) Name() string { return "panicky" }
+
+func (panickingChecker) Check(context.Context) CheckResult {
+	time.Sleep(time.Millisecond)
+	panic("boom")
+}
+
 func TestManager_EdgeCases(t *testing.T) {
 	t.Run("register nil checker", func(t *testing.T) {
 		manager := NewManager()
@@ -269,9 +528,33 @@ func TestManager_EdgeCases(t *testing.T) {
 			manager.Register(nil)
 		})
 
-		assert.Panics(t, func() {
-			manager.CheckAll(context.Background())
+		var results map[string]CheckResult
+		assert.NotPanics(t, func() {
+			results = manager.CheckAll(context.Background())
 		})
+		require.Contains(t, results, "checker-0")
+		assert.Equal(t, StatusUnhealthy, results["checker-0"].Status)
+		assert.Equal(t, "Checker is nil", results["checker-0"].Message)
+		assert.False(t, manager.IsHealthy(context.Background()))
+	})
+
+	t.Run("panicking checker", func(t *testing.T) {
+		manager := NewManager()
+		manager.Register(&mockHealthChecker{name: "healthy", result: CheckResult{Status: StatusHealthy}})
+		manager.Register(WithGroup(panickingChecker{}, GroupCore))
+
+		var results map[string]CheckResult
+		assert.NotPanics(t, func() {
+			results = manager.CheckAll(context.Background())
+		})
+		require.Len(t, results, 2)
+		assert.Equal(t, StatusHealthy, results["healthy"].Status)
+		panicked := results["panicky"]
+		assert.Equal(t, StatusUnhealthy, panicked.Status)
+		assert.Equal(t, "Check panicked", panicked.Message)
+		assert.Equal(t, "boom", panicked.Error)
+		assert.Equal(t, GroupCore, panicked.Group)
+		assert.Positive(t, panicked.Latency)
 	})
 
 	t.Run("context cancellation", func(t *testing.T) {
@@ -310,6 +593,37 @@ func TestManager_MemoryUsage(t *testing.T) {
 	assert.Len(t, results, 1000)
 }
 
+func TestWithGroup(t *testing.T) {
+	checker := &mockHealthChecker{
+		name:   "database",
+		result: CheckResult{Status: StatusHealthy, Message: "OK"},
+	}
+
+	grouped := WithGroup(checker, GroupCore)
+
+	assert.Equal(t, "database", grouped.Name())
+
+	result := grouped.Check(context.Background())
+	assert.Equal(t, StatusHealthy, result.Status)
+	assert.Equal(t, "OK", result.Message)
+	assert.Equal(t, GroupCore, result.Group)
+	assert.Equal(t, 1, checker.CallCount())
+}
+
+func TestManager_CheckAll_WithGroups(t *testing.T) {
+	manager := NewManager()
+	manager.Register(WithGroup(&mockHealthChecker{name: "database", result: CheckResult{Status: StatusHealthy}}, GroupCore))
+	manager.Register(WithGroup(&mockHealthChecker{name: "cache", result: CheckResult{Status: StatusHealthy}}, GroupOptional))
+	manager.Register(&mockHealthChecker{name: "plain", result: CheckResult{Status: StatusHealthy}})
+
+	results := manager.CheckAll(context.Background())
+
+	require.Len(t, results, 3)
+	assert.Equal(t, GroupCore, results["database"].Group)
+	assert.Equal(t, GroupOptional, results["cache"].Group)
+	assert.Empty(t, results["plain"].Group)
+}
+
 type mockHealthChecker struct {
 	name   string
 	result CheckResult
@@ -181,3 +181,43 @@ func (_c *MockManagerInterface_Register_Call) RunAndReturn(run func(checker heal
 	_c.Run(run)
 	return _c
 }
+
+// RegisterOptional provides a mock function for the type MockManagerInterface
+func (_mock *MockManagerInterface) RegisterOptional(checker health.Checker) {
+	_mock.Called(checker)
+	return
+}
+
+// MockManagerInterface_RegisterOptional_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RegisterOptional'
+type MockManagerInterface_RegisterOptional_Call struct {
+	*mock.Call
+}
+
+// RegisterOptional is a helper method to define mock.On call
+//   - checker health.Checker
+func (_e *MockManagerInterface_Expecter) RegisterOptional(checker interface{}) *MockManagerInterface_RegisterOptional_Call {
+	return &MockManagerInterface_RegisterOptional_Call{Call: _e.mock.On("RegisterOptional", checker)}
+}
+
+func (_c *MockManagerInterface_RegisterOptional_Call) Run(run func(checker health.Checker)) *MockManagerInterface_RegisterOptional_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 health.Checker
+		if args[0] != nil {
+			arg0 = args[0].(health.Checker)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockManagerInterface_RegisterOptional_Call) Return() *MockManagerInterface_RegisterOptional_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockManagerInterface_RegisterOptional_Call) RunAndReturn(run func(checker health.Checker)) *MockManagerInterface_RegisterOptional_Call {
+	_c.Run(run)
+	return _c
+}
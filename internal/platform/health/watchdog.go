@@ -0,0 +1,39 @@
+package health
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Watchdog detects a loop that stopped making progress while the process is
+// still alive, such as a deadlocked worker. The loop calls Kick on every
+// iteration, and Healthy reports false once no kick arrived for longer than
+// the interval.
+type Watchdog struct {
+	name     string
+	interval time.Duration
+	lastKick atomic.Int64
+	now      func() time.Time
+}
+
+// NewWatchdog returns a watchdog that counts as kicked when it is created, so
+// the loop has one interval to start.
+func NewWatchdog(name string, interval time.Duration) *Watchdog {
+	w := &Watchdog{name: name, interval: interval, now: time.Now}
+	w.Kick()
+	return w
+}
+
+func (w *Watchdog) Name() string {
+	return w.name
+}
+
+// Kick records that the watched loop is making progress.
+func (w *Watchdog) Kick() {
+	w.lastKick.Store(w.now().UnixNano())
+}
+
+// Healthy reports whether the last kick is at most one interval old.
+func (w *Watchdog) Healthy() bool {
+	return w.now().Sub(time.Unix(0, w.lastKick.Load())) <= w.interval
+}
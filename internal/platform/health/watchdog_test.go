@@ -0,0 +1,31 @@
+package health
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatchdog(t *testing.T) {
+	now := time.Now()
+	w := NewWatchdog("event-loop", time.Minute)
+	w.now = func() time.Time { return now }
+	w.Kick()
+
+	assert.Equal(t, "event-loop", w.Name())
+	assert.True(t, w.Healthy())
+
+	now = now.Add(time.Minute)
+	assert.True(t, w.Healthy(), "a kick exactly one interval old still counts")
+
+	now = now.Add(time.Second)
+	assert.False(t, w.Healthy())
+
+	w.Kick()
+	assert.True(t, w.Healthy(), "a kick recovers the watchdog")
+}
+
+func TestWatchdog_HealthyWhenCreated(t *testing.T) {
+	assert.True(t, NewWatchdog("worker", time.Second).Healthy())
+}
@@ -4,6 +4,10 @@ import (
 	"net/http"
 )
 
+// StatusClientClosedRequest is the non-standard status, popularised by nginx,
+// for a request the client abandoned before the response was ready.
+const StatusClientClosedRequest = 499
+
 type Error struct {
 	StatusCode int
 	Message    string
@@ -0,0 +1,34 @@
+package idgen
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/google/uuid"
+)
+
+// UUID generates random version 4 UUIDs.
+type UUID struct{}
+
+func NewUUID() UUID {
+	return UUID{}
+}
+
+func (UUID) NewID() string {
+	return uuid.NewString()
+}
+
+// Sequence generates prefix-1, prefix-2 and so on, for tests that need to know
+// the IDs in advance. It is safe for concurrent use.
+type Sequence struct {
+	prefix string
+	next   atomic.Uint64
+}
+
+func NewSequence(prefix string) *Sequence {
+	return &Sequence{prefix: prefix}
+}
+
+func (s *Sequence) NewID() string {
+	return fmt.Sprintf("%s-%d", s.prefix, s.next.Add(1))
+}
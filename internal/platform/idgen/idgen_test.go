@@ -0,0 +1,52 @@
+package idgen
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUUID_NewID(t *testing.T) {
+	gen := NewUUID()
+
+	first, second := gen.NewID(), gen.NewID()
+
+	parsed, err := uuid.Parse(first)
+	require.NoError(t, err)
+	assert.Equal(t, uuid.Version(4), parsed.Version())
+	assert.NotEqual(t, first, second)
+}
+
+func TestSequence_NewID(t *testing.T) {
+	gen := NewSequence("entity")
+
+	assert.Equal(t, "entity-1", gen.NewID())
+	assert.Equal(t, "entity-2", gen.NewID())
+}
+
+func TestSequence_Concurrent(t *testing.T) {
+	gen := NewSequence("id")
+	const n = 100
+
+	var (
+		mu   sync.Mutex
+		seen = make(map[string]struct{}, n)
+		wg   sync.WaitGroup
+	)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			id := gen.NewID()
+			mu.Lock()
+			seen[id] = struct{}{}
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, seen, n)
+}
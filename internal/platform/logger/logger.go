@@ -21,6 +21,13 @@ type Logger interface {
 	With(fields ...Field) Logger
 }
 
+// LevelSetter is implemented by loggers whose level can be changed at runtime.
+// Loggers derived through With share the level of their parent.
+type LevelSetter interface {
+	Level() Level
+	SetLevel(level Level)
+}
+
 type Field struct {
 	Key   string
 	Value interface{}
@@ -34,10 +41,24 @@ func Int(key string, value int) Field {
 	return Field{Key: key, Value: value}
 }
 
+func Strings(key string, values []string) Field {
+	return Field{Key: key, Value: values}
+}
+
+func Ints(key string, values []int) Field {
+	return Field{Key: key, Value: values}
+}
+
 func Error(err error) Field {
 	return Field{Key: "error", Value: err}
 }
 
+// Any logs value with reflection-based encoding, such as a map of attributes
+// or a struct. Prefer the typed helpers when one fits; they are cheaper.
+func Any(key string, value interface{}) Field {
+	return Field{Key: key, Value: value}
+}
+
 type Level string
 
 const (
@@ -88,9 +109,19 @@ func WithLogger(ctx context.Context, logger Logger) context.Context {
 	return context.WithValue(ctx, loggerKey{}, logger)
 }
 
+// FromContext returns the request-scoped logger stored by WithLogger, or a
+// no-op logger. Usecases and repositories log through it rather than holding
+// their own logger, so every layer carries the request_id of the request.
 func FromContext(ctx context.Context) Logger {
-	if logger, ok := ctx.Value(loggerKey{}).(Logger); ok {
+	if logger, ok := LookupContext(ctx); ok {
 		return logger
 	}
 	return &nopLogger{}
 }
+
+// LookupContext returns the logger stored by WithLogger and whether there was
+// one.
+func LookupContext(ctx context.Context) (Logger, bool) {
+	logger, ok := ctx.Value(loggerKey{}).(Logger)
+	return logger, ok
+}
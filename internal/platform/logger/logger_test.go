@@ -152,6 +152,16 @@ func TestFromContext_NoLogger(t *testing.T) {
 	assert.NotNil(t, withLogger)
 }
 
+func TestLookupContext(t *testing.T) {
+	_, ok := LookupContext(context.Background())
+	assert.False(t, ok)
+
+	logger := NewNop()
+	found, ok := LookupContext(WithLogger(context.Background(), logger))
+	assert.True(t, ok)
+	assert.Equal(t, logger, found)
+}
+
 func TestFromContext_WrongType(t *testing.T) {
 	ctx := context.WithValue(context.Background(), loggerKey{}, "not a logger")
 	logger := FromContext(ctx)
@@ -1,6 +1,10 @@
 package logger
 
-type nopLogger struct{}
+import "sync/atomic"
+
+type nopLogger struct {
+	level atomic.Value
+}
 
 func NewNop() Logger {
 	return &nopLogger{}
@@ -15,3 +19,14 @@ func (n *nopLogger) With(fields ...Field) Logger {
 	_ = fields
 	return n
 }
+
+func (n *nopLogger) Level() Level {
+	if level, ok := n.level.Load().(Level); ok {
+		return level
+	}
+	return LevelInfo
+}
+
+func (n *nopLogger) SetLevel(level Level) {
+	n.level.Store(level)
+}
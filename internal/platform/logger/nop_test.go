@@ -175,6 +175,18 @@ func (s *NopLoggerTestSuite) TestNopLogger_Performance() {
 	}
 }
 
+func (s *NopLoggerTestSuite) TestNopLogger_LevelSetter() {
+	logger := NewNop()
+
+	setter, ok := logger.(LevelSetter)
+	s.Require().True(ok)
+	s.Assert().Equal(LevelInfo, setter.Level())
+
+	setter.SetLevel(LevelDebug)
+	s.Assert().Equal(LevelDebug, setter.Level())
+	s.Assert().Equal(LevelDebug, logger.With(String("key", "value")).(LevelSetter).Level())
+}
+
 func (s *NopLoggerTestSuite) TestNopLogger_Chaining() {
 	logger := NewNop()
 	result := logger.
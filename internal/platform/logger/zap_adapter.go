@@ -7,6 +7,7 @@ import (
 
 type zapLogger struct {
 	logger *zap.Logger
+	level  zap.AtomicLevel
 }
 
 func NewZapLogger(config Config) (Logger, error) {
@@ -43,6 +44,7 @@ func NewZapLogger(config Config) (Logger, error) {
 
 	return &zapLogger{
 		logger: logger,
+		level:  zapConfig.Level,
 	}, nil
 }
 
@@ -65,9 +67,27 @@ func (l *zapLogger) Warn(msg string, fields ...Field) {
 func (l *zapLogger) With(fields ...Field) Logger {
 	return &zapLogger{
 		logger: l.logger.With(fieldsToZapFields(fields)...),
+		level:  l.level,
 	}
 }
 
+func (l *zapLogger) Level() Level {
+	switch l.level.Level() {
+	case zapcore.DebugLevel:
+		return LevelDebug
+	case zapcore.WarnLevel:
+		return LevelWarn
+	case zapcore.ErrorLevel:
+		return LevelError
+	default:
+		return LevelInfo
+	}
+}
+
+func (l *zapLogger) SetLevel(level Level) {
+	l.level.SetLevel(parseZapLevel(level))
+}
+
 func parseZapLevel(level Level) zapcore.Level {
 	switch level {
 	case LevelDebug:
@@ -91,6 +111,10 @@ func fieldsToZapFields(fields []Field) []zap.Field {
 			zapFields = append(zapFields, zap.String(field.Key, v))
 		case int:
 			zapFields = append(zapFields, zap.Int(field.Key, v))
+		case []string:
+			zapFields = append(zapFields, zap.Strings(field.Key, v))
+		case []int:
+			zapFields = append(zapFields, zap.Ints(field.Key, v))
 		case error:
 			zapFields = append(zapFields, zap.Error(v))
 		default:
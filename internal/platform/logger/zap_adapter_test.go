@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"strings"
 	"testing"
 
@@ -239,6 +240,40 @@ func (s *ZapAdapterTestSuite) TestFieldsToZapFields_IntField() {
 	s.Assert().Equal(int64(42), zapFields[0].Integer)
 }
 
+func (s *ZapAdapterTestSuite) TestFieldsToZapFields_SliceFields() {
+	fields := []Field{
+		Strings("ids", []string{"a", "b"}),
+		Ints("codes", []int{200, 404}),
+	}
+	zapFields := fieldsToZapFields(fields)
+
+	s.Require().Len(zapFields, 2)
+	s.Assert().Equal(zap.Strings("ids", []string{"a", "b"}), zapFields[0])
+	s.Assert().Equal(zap.Ints("codes", []int{200, 404}), zapFields[1])
+}
+
+func (s *ZapAdapterTestSuite) TestZapLogger_EncodesSliceAndAnyFields() {
+	zapConfig := zap.NewProductionConfig()
+	core := zapcore.NewCore(
+		zapcore.NewJSONEncoder(zapConfig.EncoderConfig),
+		zapcore.AddSync(s.buffer),
+		zapcore.DebugLevel,
+	)
+	log := &zapLogger{logger: zap.New(core), level: zap.NewAtomicLevelAt(zapcore.DebugLevel)}
+
+	log.Info("batch",
+		Strings("ids", []string{"a", "b"}),
+		Ints("codes", []int{200, 404}),
+		Any("attributes", map[string]string{"tenant": "acme"}),
+	)
+
+	var entry map[string]interface{}
+	s.Require().NoError(json.Unmarshal(s.buffer.Bytes(), &entry))
+	s.Assert().Equal([]interface{}{"a", "b"}, entry["ids"])
+	s.Assert().Equal([]interface{}{float64(200), float64(404)}, entry["codes"])
+	s.Assert().Equal(map[string]interface{}{"tenant": "acme"}, entry["attributes"])
+}
+
 func (s *ZapAdapterTestSuite) TestFieldsToZapFields_ErrorField() {
 	testErr := errors.New("test error")
 	fields := []Field{Error(testErr)}
@@ -361,6 +396,42 @@ func (s *ZapAdapterTestSuite) TestZapLogger_LevelFiltering() {
 	s.Assert().Contains(s.buffer.String(), "error message")
 }
 
+func (s *ZapAdapterTestSuite) TestZapLogger_SetLevel() {
+	level := zap.NewAtomicLevelAt(zapcore.InfoLevel)
+	core := zapcore.NewCore(
+		zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()),
+		zapcore.AddSync(s.buffer),
+		level,
+	)
+	logger := &zapLogger{logger: zap.New(core), level: level}
+	child := logger.With(String("component", "test"))
+
+	child.Debug("hidden debug message")
+	s.Assert().Empty(s.buffer.String())
+	s.Assert().Equal(LevelInfo, logger.Level())
+
+	logger.SetLevel(LevelDebug)
+
+	s.Assert().Equal(LevelDebug, logger.Level())
+	s.Assert().Equal(LevelDebug, child.(LevelSetter).Level())
+	child.Debug("visible debug message")
+	s.Assert().Contains(s.buffer.String(), "visible debug message")
+}
+
+func (s *ZapAdapterTestSuite) TestNewZapLogger_LevelSetter() {
+	logger, err := NewZapLogger(Config{Environment: "production", Level: LevelWarn, Format: FormatJSON})
+	s.Require().NoError(err)
+
+	setter, ok := logger.(LevelSetter)
+	s.Require().True(ok)
+	s.Assert().Equal(LevelWarn, setter.Level())
+
+	for _, level := range []Level{LevelDebug, LevelInfo, LevelWarn, LevelError} {
+		setter.SetLevel(level)
+		s.Assert().Equal(level, setter.Level())
+	}
+}
+
 func (s *ZapAdapterTestSuite) TestZapLogger_Performance() {
 	config := Config{
 		Environment: "production",
@@ -407,6 +478,40 @@ func BenchmarkFieldsToZapFields(b *testing.B) {
 	}
 }
 
+var benchmarkIDs = []string{"6f1c", "9a2e", "c40b", "e7d5", "1b93", "44fa", "8d0c", "a5e1"}
+
+func benchmarkZapFields(b *testing.B, fields func() []zap.Field) {
+	log := zap.New(zapcore.NewCore(
+		zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()),
+		zapcore.AddSync(io.Discard),
+		zapcore.InfoLevel,
+	))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		log.Info("batch", fields()...)
+	}
+}
+
+func BenchmarkFieldsToZapFields_Strings(b *testing.B) {
+	fields := []Field{Strings("ids", benchmarkIDs)}
+	benchmarkZapFields(b, func() []zap.Field { return fieldsToZapFields(fields) })
+}
+
+func BenchmarkFieldsToZapFields_StringsAsAny(b *testing.B) {
+	benchmarkZapFields(b, func() []zap.Field { return []zap.Field{zap.Any("ids", benchmarkIDs)} })
+}
+
+func BenchmarkFieldsToZapFields_StringsAsInterfaces(b *testing.B) {
+	ids := make([]interface{}, len(benchmarkIDs))
+	for i, id := range benchmarkIDs {
+		ids[i] = id
+	}
+	fields := []Field{Any("ids", ids)}
+	benchmarkZapFields(b, func() []zap.Field { return fieldsToZapFields(fields) })
+}
+
 func BenchmarkParseZapLevel(b *testing.B) {
 	levels := []Level{LevelDebug, LevelInfo, LevelWarn, LevelError}
 
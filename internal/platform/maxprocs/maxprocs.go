@@ -0,0 +1,132 @@
+package maxprocs
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"microservice/internal/platform/logger"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+const cgroupRoot = "/sys/fs/cgroup"
+
+// QuotaFunc reports the CPU quota available to the process in cores.
+// defined is false when no limit is configured.
+type QuotaFunc func() (quota float64, defined bool, err error)
+
+// Set aligns GOMAXPROCS with the CPU quota reported by quota and returns the
+// resulting value. It leaves GOMAXPROCS unchanged when disabled, when the
+// GOMAXPROCS environment variable is set explicitly, or when no quota applies.
+func Set(log logger.Logger, enabled bool, quota QuotaFunc) int {
+	current := runtime.GOMAXPROCS(0)
+
+	if !enabled {
+		log.Info("GOMAXPROCS alignment disabled", logger.Int("gomaxprocs", current))
+		return current
+	}
+
+	if value, ok := os.LookupEnv("GOMAXPROCS"); ok {
+		log.Info("GOMAXPROCS set via environment, skipping alignment", logger.String("gomaxprocs", value))
+		return current
+	}
+
+	cpus, defined, err := quota()
+	if err != nil {
+		log.Warn("Failed to read CPU quota, leaving GOMAXPROCS unchanged", logger.Int("gomaxprocs", current), logger.Error(err))
+		return current
+	}
+	if !defined {
+		log.Info("No CPU quota defined, leaving GOMAXPROCS unchanged", logger.Int("gomaxprocs", current))
+		return current
+	}
+
+	procs := FromQuota(cpus)
+	runtime.GOMAXPROCS(procs)
+	log.Info("GOMAXPROCS aligned to CPU quota",
+		logger.Int("gomaxprocs", procs),
+		logger.String("cpu_quota", strconv.FormatFloat(cpus, 'f', -1, 64)),
+	)
+	return procs
+}
+
+// FromQuota converts a CPU quota in cores to a GOMAXPROCS value, rounding down
+// and never returning less than one.
+func FromQuota(quota float64) int {
+	procs := int(math.Floor(quota))
+	if procs < 1 {
+		return 1
+	}
+	return procs
+}
+
+// CgroupQuota reads the CPU quota from the cgroup v2 or v1 filesystem.
+func CgroupQuota() (float64, bool, error) {
+	return readCgroupQuota(cgroupRoot)
+}
+
+func readCgroupQuota(root string) (float64, bool, error) {
+	data, err := os.ReadFile(filepath.Join(root, "cpu.max"))
+	if err == nil {
+		return parseCgroupV2(string(data))
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return 0, false, err
+	}
+
+	quota, err := readInt(filepath.Join(root, "cpu", "cpu.cfs_quota_us"))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return 0, false, nil
+		}
+		return 0, false, err
+	}
+	if quota <= 0 {
+		return 0, false, nil
+	}
+
+	period, err := readInt(filepath.Join(root, "cpu", "cpu.cfs_period_us"))
+	if err != nil {
+		return 0, false, err
+	}
+	if period <= 0 {
+		return 0, false, fmt.Errorf("invalid cgroup cpu period: %d", period)
+	}
+
+	return float64(quota) / float64(period), true, nil
+}
+
+func parseCgroupV2(content string) (float64, bool, error) {
+	fields := strings.Fields(content)
+	if len(fields) != 2 {
+		return 0, false, fmt.Errorf("unexpected cpu.max format: %q", content)
+	}
+	if fields[0] == "max" {
+		return 0, false, nil
+	}
+
+	quota, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid cpu.max quota: %w", err)
+	}
+	period, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid cpu.max period: %w", err)
+	}
+	if period <= 0 {
+		return 0, false, fmt.Errorf("invalid cpu.max period: %d", period)
+	}
+
+	return float64(quota) / float64(period), true, nil
+}
+
+func readInt(path string) (int64, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+}
@@ -0,0 +1,162 @@
+package maxprocs
+
+import (
+	"errors"
+	"microservice/internal/platform/logger"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func restoreGOMAXPROCS(t *testing.T) {
+	original := runtime.GOMAXPROCS(0)
+	t.Cleanup(func() { runtime.GOMAXPROCS(original) })
+
+	if value, ok := os.LookupEnv("GOMAXPROCS"); ok {
+		require.NoError(t, os.Unsetenv("GOMAXPROCS"))
+		t.Cleanup(func() { _ = os.Setenv("GOMAXPROCS", value) })
+	}
+}
+
+func fakeQuota(quota float64, defined bool, err error) QuotaFunc {
+	return func() (float64, bool, error) {
+		return quota, defined, err
+	}
+}
+
+func TestFromQuota(t *testing.T) {
+	tests := []struct {
+		name     string
+		quota    float64
+		expected int
+	}{
+		{"whole_cores", 4, 4},
+		{"fractional_rounds_down", 2.5, 2},
+		{"below_one_core", 0.5, 1},
+		{"zero", 0, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, FromQuota(tt.quota))
+		})
+	}
+}
+
+func TestSet_AlignsToQuota(t *testing.T) {
+	restoreGOMAXPROCS(t)
+	runtime.GOMAXPROCS(8)
+
+	procs := Set(logger.NewNop(), true, fakeQuota(2.5, true, nil))
+
+	assert.Equal(t, 2, procs)
+	assert.Equal(t, 2, runtime.GOMAXPROCS(0))
+}
+
+func TestSet_Disabled(t *testing.T) {
+	restoreGOMAXPROCS(t)
+	runtime.GOMAXPROCS(8)
+
+	procs := Set(logger.NewNop(), false, fakeQuota(2, true, nil))
+
+	assert.Equal(t, 8, procs)
+	assert.Equal(t, 8, runtime.GOMAXPROCS(0))
+}
+
+func TestSet_LeavesUnchanged(t *testing.T) {
+	tests := []struct {
+		name  string
+		quota QuotaFunc
+		env   string
+	}{
+		{"no_quota", fakeQuota(0, false, nil), ""},
+		{"quota_error", fakeQuota(0, false, errors.New("read failed")), ""},
+		{"explicit_env", fakeQuota(2, true, nil), "6"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			restoreGOMAXPROCS(t)
+			runtime.GOMAXPROCS(8)
+			if tt.env != "" {
+				t.Setenv("GOMAXPROCS", tt.env)
+			}
+
+			procs := Set(logger.NewNop(), true, tt.quota)
+
+			assert.Equal(t, 8, procs)
+			assert.Equal(t, 8, runtime.GOMAXPROCS(0))
+		})
+	}
+}
+
+func TestReadCgroupQuota(t *testing.T) {
+	tests := []struct {
+		name            string
+		files           map[string]string
+		expectedQuota   float64
+		expectedDefined bool
+		expectError     bool
+	}{
+		{
+			name:            "cgroup_v2_limited",
+			files:           map[string]string{"cpu.max": "150000 100000\n"},
+			expectedQuota:   1.5,
+			expectedDefined: true,
+		},
+		{
+			name:  "cgroup_v2_unlimited",
+			files: map[string]string{"cpu.max": "max 100000\n"},
+		},
+		{
+			name:        "cgroup_v2_malformed",
+			files:       map[string]string{"cpu.max": "garbage"},
+			expectError: true,
+		},
+		{
+			name: "cgroup_v1_limited",
+			files: map[string]string{
+				"cpu/cpu.cfs_quota_us":  "300000\n",
+				"cpu/cpu.cfs_period_us": "100000\n",
+			},
+			expectedQuota:   3,
+			expectedDefined: true,
+		},
+		{
+			name: "cgroup_v1_unlimited",
+			files: map[string]string{
+				"cpu/cpu.cfs_quota_us":  "-1\n",
+				"cpu/cpu.cfs_period_us": "100000\n",
+			},
+		},
+		{
+			name:  "no_cgroup",
+			files: map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			for name, content := range tt.files {
+				path := filepath.Join(root, name)
+				require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+				require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+			}
+
+			quota, defined, err := readCgroupQuota(root)
+
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedDefined, defined)
+			assert.InDelta(t, tt.expectedQuota, quota, 0.0001)
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	RecycleReasonMaxIdleTime = "max_idle_time"
+	RecycleReasonMaxLifetime = "max_lifetime"
+)
+
+// DBStatsSource exposes connection pool statistics. ok is false while no
+// connection pool is available, in which case nothing is reported.
+type DBStatsSource interface {
+	Stats() (stats sql.DBStats, ok bool)
+}
+
+// DBStatsCollector reports sql.DBStats through observable instruments. Values
+// are read on every scrape, so no polling goroutine is needed.
+type DBStatsCollector struct {
+	meter  metric.Meter
+	source DBStatsSource
+
+	openConnections metric.Int64ObservableGauge
+	inUse           metric.Int64ObservableGauge
+	idle            metric.Int64ObservableGauge
+	waitCount       metric.Int64ObservableCounter
+	waitDuration    metric.Float64ObservableCounter
+	recycled        metric.Int64ObservableCounter
+
+	// Recycled connections are accumulated from deltas between samples so the
+	// counter stays monotonic when the pool is replaced and its stats reset.
+	lastIdleClosed     int64
+	lastLifetimeClosed int64
+	idleRecycled       int64
+	lifetimeRecycled   int64
+	sampleMu           sync.Mutex
+
+	registration metric.Registration
+	mu           sync.Mutex
+}
+
+func NewDBStatsCollector(provider *Provider, source DBStatsSource) (*DBStatsCollector, error) {
+	meter := provider.Meter()
+
+	openConnections, err := meter.Int64ObservableGauge(
+		"db_pool_open_connections",
+		metric.WithDescription("Number of established database connections, both in use and idle"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	inUse, err := meter.Int64ObservableGauge(
+		"db_pool_in_use_connections",
+		metric.WithDescription("Number of database connections currently in use"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	idle, err := meter.Int64ObservableGauge(
+		"db_pool_idle_connections",
+		metric.WithDescription("Number of idle database connections"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	waitCount, err := meter.Int64ObservableCounter(
+		"db_pool_wait",
+		metric.WithDescription("Total number of connections waited for"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	waitDuration, err := meter.Float64ObservableCounter(
+		"db_pool_wait_duration",
+		metric.WithDescription("Total time blocked waiting for a new connection"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	recycled, err := meter.Int64ObservableCounter(
+		"db_connections_recycled",
+		metric.WithDescription("Total number of connections closed by the pool due to SetConnMaxIdleTime or SetConnMaxLifetime"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DBStatsCollector{
+		meter:           meter,
+		source:          source,
+		openConnections: openConnections,
+		inUse:           inUse,
+		idle:            idle,
+		waitCount:       waitCount,
+		waitDuration:    waitDuration,
+		recycled:        recycled,
+	}, nil
+}
+
+func (c *DBStatsCollector) Start(ctx context.Context) error {
+	_ = ctx
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.registration != nil {
+		return nil
+	}
+
+	registration, err := c.meter.RegisterCallback(
+		c.observe,
+		c.openConnections,
+		c.inUse,
+		c.idle,
+		c.waitCount,
+		c.waitDuration,
+		c.recycled,
+	)
+	if err != nil {
+		return err
+	}
+
+	c.registration = registration
+	return nil
+}
+
+func (c *DBStatsCollector) Stop(ctx context.Context) error {
+	_ = ctx
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.registration == nil {
+		return nil
+	}
+
+	err := c.registration.Unregister()
+	c.registration = nil
+	return err
+}
+
+func (c *DBStatsCollector) observe(_ context.Context, observer metric.Observer) error {
+	stats, ok := c.source.Stats()
+	if !ok {
+		return nil
+	}
+
+	observer.ObserveInt64(c.openConnections, int64(stats.OpenConnections))
+	observer.ObserveInt64(c.inUse, int64(stats.InUse))
+	observer.ObserveInt64(c.idle, int64(stats.Idle))
+	observer.ObserveInt64(c.waitCount, stats.WaitCount)
+	observer.ObserveFloat64(c.waitDuration, stats.WaitDuration.Seconds())
+
+	idleRecycled, lifetimeRecycled := c.sampleRecycled(stats)
+	observer.ObserveInt64(c.recycled, idleRecycled, metric.WithAttributes(attribute.String("reason", RecycleReasonMaxIdleTime)))
+	observer.ObserveInt64(c.recycled, lifetimeRecycled, metric.WithAttributes(attribute.String("reason", RecycleReasonMaxLifetime)))
+	return nil
+}
+
+func (c *DBStatsCollector) sampleRecycled(stats sql.DBStats) (idle, lifetime int64) {
+	c.sampleMu.Lock()
+	defer c.sampleMu.Unlock()
+
+	c.idleRecycled += counterDelta(c.lastIdleClosed, stats.MaxIdleTimeClosed)
+	c.lifetimeRecycled += counterDelta(c.lastLifetimeClosed, stats.MaxLifetimeClosed)
+	c.lastIdleClosed = stats.MaxIdleTimeClosed
+	c.lastLifetimeClosed = stats.MaxLifetimeClosed
+
+	return c.idleRecycled, c.lifetimeRecycled
+}
+
+// counterDelta returns how much a cumulative counter grew since the previous
+// sample. A smaller value means the counter was reset, so it counts in full.
+func counterDelta(previous, current int64) int64 {
+	if current < previous {
+		return current
+	}
+	return current - previous
+}
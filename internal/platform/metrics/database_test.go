@@ -0,0 +1,151 @@
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type fakeDBStatsSource struct {
+	stats sql.DBStats
+	ok    bool
+}
+
+func (f *fakeDBStatsSource) Stats() (sql.DBStats, bool) {
+	return f.stats, f.ok
+}
+
+type DBStatsCollectorTestSuite struct {
+	suite.Suite
+	provider *Provider
+	source   *fakeDBStatsSource
+}
+
+func (s *DBStatsCollectorTestSuite) SetupTest() {
+	var err error
+	s.provider, err = NewProvider()
+	s.Require().NoError(err)
+
+	s.source = &fakeDBStatsSource{
+		stats: sql.DBStats{
+			OpenConnections: 7,
+			InUse:           4,
+			Idle:            3,
+			WaitCount:       12,
+			WaitDuration:    1500 * time.Millisecond,
+		},
+		ok: true,
+	}
+}
+
+func (s *DBStatsCollectorTestSuite) scrape() string {
+	w := httptest.NewRecorder()
+	s.provider.Handler().ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+	return w.Body.String()
+}
+
+func (s *DBStatsCollectorTestSuite) TestStart_ReportsPoolStats() {
+	collector, err := NewDBStatsCollector(s.provider, s.source)
+	s.Require().NoError(err)
+	s.Require().NoError(collector.Start(context.Background()))
+	defer func() { s.Require().NoError(collector.Stop(context.Background())) }()
+
+	body := s.scrape()
+
+	s.Assert().Contains(body, "db_pool_open_connections{")
+	s.Assert().Regexp(`db_pool_open_connections\{[^}]*\} 7`, body)
+	s.Assert().Regexp(`db_pool_in_use_connections\{[^}]*\} 4`, body)
+	s.Assert().Regexp(`db_pool_idle_connections\{[^}]*\} 3`, body)
+	s.Assert().Regexp(`db_pool_wait_total\{[^}]*\} 12`, body)
+	s.Assert().Regexp(`db_pool_wait_duration_seconds_total\{[^}]*\} 1.5`, body)
+}
+
+func (s *DBStatsCollectorTestSuite) TestStart_ReflectsLatestStats() {
+	collector, err := NewDBStatsCollector(s.provider, s.source)
+	s.Require().NoError(err)
+	s.Require().NoError(collector.Start(context.Background()))
+	defer func() { s.Require().NoError(collector.Stop(context.Background())) }()
+
+	s.source.stats.InUse = 9
+
+	s.Assert().Regexp(`db_pool_in_use_connections\{[^}]*\} 9`, s.scrape())
+}
+
+func (s *DBStatsCollectorTestSuite) TestRecycled_ReflectsStatDeltas() {
+	s.source.stats.MaxIdleTimeClosed = 5
+	s.source.stats.MaxLifetimeClosed = 2
+
+	collector, err := NewDBStatsCollector(s.provider, s.source)
+	s.Require().NoError(err)
+	s.Require().NoError(collector.Start(context.Background()))
+	defer func() { s.Require().NoError(collector.Stop(context.Background())) }()
+
+	body := s.scrape()
+	s.Assert().Regexp(`db_connections_recycled_total\{[^}]*reason="max_idle_time"[^}]*\} 5\n`, body)
+	s.Assert().Regexp(`db_connections_recycled_total\{[^}]*reason="max_lifetime"[^}]*\} 2\n`, body)
+
+	s.source.stats.MaxIdleTimeClosed = 8
+	s.source.stats.MaxLifetimeClosed = 2
+
+	body = s.scrape()
+	s.Assert().Regexp(`db_connections_recycled_total\{[^}]*reason="max_idle_time"[^}]*\} 8\n`, body)
+	s.Assert().Regexp(`db_connections_recycled_total\{[^}]*reason="max_lifetime"[^}]*\} 2\n`, body)
+}
+
+func (s *DBStatsCollectorTestSuite) TestRecycled_StaysMonotonicWhenStatsReset() {
+	s.source.stats.MaxIdleTimeClosed = 5
+	s.source.stats.MaxLifetimeClosed = 2
+
+	collector, err := NewDBStatsCollector(s.provider, s.source)
+	s.Require().NoError(err)
+	s.Require().NoError(collector.Start(context.Background()))
+	defer func() { s.Require().NoError(collector.Stop(context.Background())) }()
+
+	s.scrape()
+
+	// A new pool starts counting from zero again.
+	s.source.stats.MaxIdleTimeClosed = 1
+	s.source.stats.MaxLifetimeClosed = 0
+
+	body := s.scrape()
+	s.Assert().Regexp(`db_connections_recycled_total\{[^}]*reason="max_idle_time"[^}]*\} 6\n`, body)
+	s.Assert().Regexp(`db_connections_recycled_total\{[^}]*reason="max_lifetime"[^}]*\} 2\n`, body)
+}
+
+func (s *DBStatsCollectorTestSuite) TestNoConnection_ReportsNothing() {
+	s.source.ok = false
+
+	collector, err := NewDBStatsCollector(s.provider, s.source)
+	s.Require().NoError(err)
+	s.Require().NoError(collector.Start(context.Background()))
+	defer func() { s.Require().NoError(collector.Stop(context.Background())) }()
+
+	s.Assert().NotContains(s.scrape(), "db_pool_open_connections{")
+}
+
+func (s *DBStatsCollectorTestSuite) TestStop_UnregistersCallback() {
+	collector, err := NewDBStatsCollector(s.provider, s.source)
+	s.Require().NoError(err)
+	s.Require().NoError(collector.Start(context.Background()))
+	s.Require().NoError(collector.Stop(context.Background()))
+
+	s.Assert().NotContains(s.scrape(), "db_pool_open_connections{")
+}
+
+func (s *DBStatsCollectorTestSuite) TestStartStop_Idempotent() {
+	collector, err := NewDBStatsCollector(s.provider, s.source)
+	s.Require().NoError(err)
+
+	s.Require().NoError(collector.Start(context.Background()))
+	s.Require().NoError(collector.Start(context.Background()))
+	s.Require().NoError(collector.Stop(context.Background()))
+	s.Require().NoError(collector.Stop(context.Background()))
+}
+
+func TestDBStatsCollectorTestSuite(t *testing.T) {
+	suite.Run(t, new(DBStatsCollectorTestSuite))
+}
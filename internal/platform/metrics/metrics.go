@@ -1,28 +1,120 @@
 package metrics
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	promexporter "go.opentelemetry.io/otel/exporters/prometheus"
 	"go.opentelemetry.io/otel/metric"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 )
 
+// Reasons reported on service_degraded_responses_total.
+const (
+	DegradedReasonStaleServed    = "stale_served"
+	DegradedReasonDependencyWarn = "dependency_warn"
+)
+
+// DefaultDurationBuckets are the http_request_duration bucket boundaries, in
+// seconds, used unless WithDurationBuckets sets others.
+var DefaultDurationBuckets = []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// SizeBuckets are the http_request_size and http_response_size bucket
+// boundaries, in bytes, from 64 B to 16 MiB in steps of four.
+var SizeBuckets = []float64{64, 256, 1024, 4096, 16384, 65536, 262144, 1048576, 4194304, 16777216}
+
+var ErrInvalidBuckets = errors.New("histogram buckets must be non-empty and strictly increasing")
+
 type Provider struct {
-	RequestsTotal    metric.Int64Counter
-	RequestDuration  metric.Float64Histogram
-	RequestsInFlight metric.Int64UpDownCounter
-	registry         *prometheus.Registry
+	RequestsTotal     metric.Int64Counter
+	RequestDuration   metric.Float64Histogram
+	RequestsInFlight  metric.Int64UpDownCounter
+	RequestSizeBytes  metric.Int64Histogram
+	ResponseSizeBytes metric.Int64Histogram
+	DegradedResponses metric.Int64Counter
+	RateLimited       metric.Int64Counter
+	RateLimitShadowed metric.Int64Counter
+	DeprecatedConfig  metric.Int64Counter
+	meter             metric.Meter
+	registry          *prometheus.Registry
 }
 
-func NewProvider() (*Provider, error) {
+type options struct {
+	constLabels prometheus.Labels
+	namespace   string
+	meterName   string
+
+	durationBuckets []float64
+}
+
+type Option func(*options)
+
+// WithServiceLabels attaches constant service and host labels to every
+// exported metric so scrapes from several replicas can be told apart. Empty
+// values are skipped. The replica goes in host rather than instance, which
+// Prometheus sets to the scrape target itself.
+func WithServiceLabels(service, host string) Option {
+	return func(o *options) {
+		if service != "" {
+			o.constLabels["service"] = service
+			o.meterName = service
+		}
+		if host != "" {
+			o.constLabels["host"] = host
+		}
+	}
+}
+
+// WithNamespace prefixes every exported metric name, so http_requests_total
+// becomes <namespace>_http_requests_total. This keeps names from colliding
+// when several services are scraped into one Prometheus.
+func WithNamespace(namespace string) Option {
+	return func(o *options) {
+		o.namespace = namespace
+	}
+}
+
+// WithDurationBuckets replaces the http_request_duration bucket boundaries, in
+// seconds, so they can line up with the service's latency objectives.
+// NewProvider fails unless they are non-empty and strictly increasing.
+func WithDurationBuckets(buckets ...float64) Option {
+	return func(o *options) {
+		o.durationBuckets = append([]float64{}, buckets...)
+	}
+}
+
+func validateBuckets(buckets []float64) error {
+	if len(buckets) == 0 {
+		return fmt.Errorf("%w: no buckets given", ErrInvalidBuckets)
+	}
+	for i := 1; i < len(buckets); i++ {
+		if buckets[i] <= buckets[i-1] {
+			return fmt.Errorf("%w: %v is not greater than %v", ErrInvalidBuckets, buckets[i], buckets[i-1])
+		}
+	}
+	return nil
+}
+
+func NewProvider(opts ...Option) (*Provider, error) {
+	o := options{constLabels: prometheus.Labels{}, meterName: "microservice", durationBuckets: DefaultDurationBuckets}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if err := validateBuckets(o.durationBuckets); err != nil {
+		return nil, err
+	}
+
 	registry := prometheus.NewRegistry()
 
 	exporter, err := promexporter.New(
-		promexporter.WithRegisterer(registry),
+		promexporter.WithRegisterer(prometheus.WrapRegistererWith(o.constLabels, registry)),
+		promexporter.WithNamespace(o.namespace),
 	)
 	if err != nil {
 		return nil, err
@@ -31,7 +123,7 @@ func NewProvider() (*Provider, error) {
 	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(exporter))
 	otel.SetMeterProvider(provider)
 
-	meter := provider.Meter("microservice")
+	meter := provider.Meter(o.meterName)
 
 	requestsTotal, err := meter.Int64Counter(
 		"http_requests",
@@ -45,7 +137,7 @@ func NewProvider() (*Provider, error) {
 		"http_request_duration",
 		metric.WithDescription("HTTP request duration in seconds"),
 		metric.WithUnit("s"),
-		metric.WithExplicitBucketBoundaries(0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10),
+		metric.WithExplicitBucketBoundaries(o.durationBuckets...),
 	)
 	if err != nil {
 		return nil, err
@@ -59,14 +151,133 @@ func NewProvider() (*Provider, error) {
 		return nil, err
 	}
 
+	requestSize, err := meter.Int64Histogram(
+		"http_request_size",
+		metric.WithDescription("HTTP request body size in bytes"),
+		metric.WithUnit("By"),
+		metric.WithExplicitBucketBoundaries(SizeBuckets...),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	responseSize, err := meter.Int64Histogram(
+		"http_response_size",
+		metric.WithDescription("HTTP response body size in bytes"),
+		metric.WithUnit("By"),
+		metric.WithExplicitBucketBoundaries(SizeBuckets...),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	degradedResponses, err := meter.Int64Counter(
+		"service_degraded_responses",
+		metric.WithDescription("Total number of responses served in a degraded mode, by reason"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	rateLimited, err := meter.Int64Counter(
+		"rate_limit_exceeded",
+		metric.WithDescription("Total number of requests rejected by a rate limit, by scope"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	rateLimitShadowed, err := meter.Int64Counter(
+		"rate_limit_would_exceed",
+		metric.WithDescription("Total number of requests a shadow rate limit would have rejected, by scope"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	deprecatedConfig, err := meter.Int64Counter(
+		"config_deprecated_keys",
+		metric.WithDescription("Deprecated config keys set at startup, by key"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Provider{
-		RequestsTotal:    requestsTotal,
-		RequestDuration:  requestDuration,
-		RequestsInFlight: requestsInFlight,
-		registry:         registry,
+		RequestsTotal:     requestsTotal,
+		RequestDuration:   requestDuration,
+		RequestsInFlight:  requestsInFlight,
+		RequestSizeBytes:  requestSize,
+		ResponseSizeBytes: responseSize,
+		DegradedResponses: degradedResponses,
+		RateLimited:       rateLimited,
+		RateLimitShadowed: rateLimitShadowed,
+		DeprecatedConfig:  deprecatedConfig,
+		meter:             meter,
+		registry:          registry,
 	}, nil
 }
 
+// RecordDegraded counts a response that was served through a degraded path,
+// such as a stale cache entry or a dependency reporting a warning.
+func (p *Provider) RecordDegraded(ctx context.Context, reason string) {
+	p.DegradedResponses.Add(ctx, 1, metric.WithAttributes(attribute.String("reason", reason)))
+}
+
+// RecordRateLimited counts a request that was throttled by the named limit.
+func (p *Provider) RecordRateLimited(ctx context.Context, scope string) {
+	p.RateLimited.Add(ctx, 1, metric.WithAttributes(attribute.String("scope", scope)))
+}
+
+// RecordRateLimitWouldExceed counts a request that a shadow limit let through
+// although it was over the limit.
+func (p *Provider) RecordRateLimitWouldExceed(ctx context.Context, scope string) {
+	p.RateLimitShadowed.Add(ctx, 1, metric.WithAttributes(attribute.String("scope", scope)))
+}
+
+// RecordDeprecatedConfigKey counts a deprecated config key that is still set,
+// labeled with the key that replaces it.
+func (p *Provider) RecordDeprecatedConfigKey(ctx context.Context, key, replacement string) {
+	p.DeprecatedConfig.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("key", key),
+		attribute.String("replacement", replacement),
+	))
+}
+
+// Meter returns the meter behind the built-in HTTP metrics. Instruments
+// created from it, at any time, are exported on Handler alongside them.
+// NewProvider also installs itself as the OTel global meter provider, but with
+// several providers (as in tests) the global one is simply the last created,
+// so application code should use this meter rather than otel.Meter.
+func (p *Provider) Meter() metric.Meter {
+	return p.meter
+}
+
+// NewCounter creates a business counter exported as <name>_total.
+func (p *Provider) NewCounter(name, description string) (metric.Int64Counter, error) {
+	return p.meter.Int64Counter(name, metric.WithDescription(description))
+}
+
+// NewHistogram creates a business histogram. Without explicit buckets the
+// OTel default boundaries are used.
+func (p *Provider) NewHistogram(name, description, unit string, buckets ...float64) (metric.Float64Histogram, error) {
+	opts := []metric.Float64HistogramOption{metric.WithDescription(description)}
+	if unit != "" {
+		opts = append(opts, metric.WithUnit(unit))
+	}
+	if len(buckets) > 0 {
+		opts = append(opts, metric.WithExplicitBucketBoundaries(buckets...))
+	}
+	return p.meter.Float64Histogram(name, opts...)
+}
+
+// Registry returns the registry served by Handler, so callers can register
+// their own collectors. Collectors registered here do not get the service
+// labels or namespace applied.
+func (p *Provider) Registry() *prometheus.Registry {
+	return p.registry
+}
+
 func (p *Provider) Handler() http.Handler {
 	return promhttp.HandlerFor(p.registry, promhttp.HandlerOpts{})
 }
@@ -4,10 +4,12 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"regexp"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/suite"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
@@ -33,6 +35,10 @@ func (s *MetricsTestSuite) TestNewProvider_Success() {
 	s.Assert().NotNil(provider.RequestsTotal)
 	s.Assert().NotNil(provider.RequestDuration)
 	s.Assert().NotNil(provider.RequestsInFlight)
+	s.Assert().NotNil(provider.RequestSizeBytes)
+	s.Assert().NotNil(provider.ResponseSizeBytes)
+	s.Assert().NotNil(provider.DegradedResponses)
+	s.Assert().NotNil(provider.RateLimited)
 	s.Assert().NotNil(provider.registry)
 }
 
@@ -49,6 +55,231 @@ func (s *MetricsTestSuite) TestNewProvider_MultipleProviders() {
 	s.Assert().NotEqual(provider1.registry, provider2.registry)
 }
 
+func (s *MetricsTestSuite) TestNewProvider_WithServiceLabels() {
+	provider, err := NewProvider(WithServiceLabels("orders", "orders-7f9c"))
+	s.Require().NoError(err)
+
+	provider.RequestsTotal.Add(context.Background(), 1, metric.WithAttributes(attribute.String("method", "GET")))
+
+	req := httptest.NewRequest("GET", "/metrics", nil)
+	w := httptest.NewRecorder()
+	provider.Handler().ServeHTTP(w, req)
+
+	s.Assert().Regexp(`http_requests_total\{[^}]*host="orders-7f9c"[^}]*service="orders"[^}]*\} 1`, w.Body.String())
+}
+
+func (s *MetricsTestSuite) TestNewProvider_WithNamespace() {
+	provider, err := NewProvider(WithNamespace("orders"))
+	s.Require().NoError(err)
+
+	provider.RequestsTotal.Add(context.Background(), 1)
+
+	req := httptest.NewRequest("GET", "/metrics", nil)
+	w := httptest.NewRecorder()
+	provider.Handler().ServeHTTP(w, req)
+
+	body := w.Body.String()
+	s.Assert().Regexp(`(?m)^orders_http_requests_total\{[^}]*\} 1$`, body)
+	s.Assert().NotRegexp(`(?m)^http_requests_total`, body)
+}
+
+func (s *MetricsTestSuite) TestNewProvider_DefaultNamespace() {
+	provider, err := NewProvider()
+	s.Require().NoError(err)
+
+	provider.RequestsTotal.Add(context.Background(), 1)
+
+	req := httptest.NewRequest("GET", "/metrics", nil)
+	w := httptest.NewRecorder()
+	provider.Handler().ServeHTTP(w, req)
+
+	body := w.Body.String()
+	s.Assert().Regexp(`(?m)^http_requests_total\{[^}]*otel_scope_name="microservice"[^}]*\} 1$`, body)
+}
+
+func (s *MetricsTestSuite) TestNewProvider_DurationBuckets() {
+	tests := []struct {
+		name     string
+		opts     []Option
+		expected []string
+	}{
+		{
+			name:     "default",
+			expected: []string{"0.001", "0.005", "0.01", "0.025", "0.05", "0.1", "0.25", "0.5", "1", "2.5", "5", "10", "+Inf"},
+		},
+		{
+			name:     "custom",
+			opts:     []Option{WithDurationBuckets(0.05, 0.2, 0.75)},
+			expected: []string{"0.05", "0.2", "0.75", "+Inf"},
+		},
+	}
+	bucket := regexp.MustCompile(`(?m)^http_request_duration_seconds_bucket\{[^}]*le="([^"]+)"\} \d+$`)
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			provider, err := NewProvider(tt.opts...)
+			s.Require().NoError(err)
+
+			provider.RequestDuration.Record(context.Background(), 0.1)
+
+			var boundaries []string
+			for _, match := range bucket.FindAllStringSubmatch(s.scrape(provider), -1) {
+				boundaries = append(boundaries, match[1])
+			}
+			s.Assert().Equal(tt.expected, boundaries)
+		})
+	}
+}
+
+func (s *MetricsTestSuite) TestNewProvider_InvalidDurationBuckets() {
+	tests := map[string][]float64{
+		"empty":      {},
+		"decreasing": {0.1, 0.5, 0.2},
+		"duplicate":  {0.1, 0.1},
+	}
+
+	for name, buckets := range tests {
+		s.Run(name, func() {
+			provider, err := NewProvider(WithDurationBuckets(buckets...))
+
+			s.Assert().ErrorIs(err, ErrInvalidBuckets)
+			s.Assert().Nil(provider)
+		})
+	}
+}
+
+func (s *MetricsTestSuite) scrape(provider *Provider) string {
+	w := httptest.NewRecorder()
+	provider.Handler().ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+	return w.Body.String()
+}
+
+func (s *MetricsTestSuite) TestNewCounter_ExportedAfterStartup() {
+	provider, err := NewProvider(WithServiceLabels("orders", ""))
+	s.Require().NoError(err)
+
+	// The handler has already served a scrape before the instrument exists.
+	s.scrape(provider)
+
+	orders, err := provider.NewCounter("orders_placed", "Total number of orders placed")
+	s.Require().NoError(err)
+	orders.Add(context.Background(), 2, metric.WithAttributes(attribute.String("channel", "web")))
+
+	body := s.scrape(provider)
+	s.Assert().Contains(body, "# HELP orders_placed_total Total number of orders placed")
+	s.Assert().Regexp(`orders_placed_total\{[^}]*channel="web"[^}]*service="orders"[^}]*\} 2\n`, body)
+}
+
+func (s *MetricsTestSuite) TestNewHistogram() {
+	provider, err := NewProvider()
+	s.Require().NoError(err)
+
+	value, err := provider.NewHistogram("order_value", "Order value", "", 10, 100)
+	s.Require().NoError(err)
+	value.Record(context.Background(), 42)
+
+	body := s.scrape(provider)
+	s.Assert().Regexp(`order_value_bucket\{[^}]*le="10"[^}]*\} 0\n`, body)
+	s.Assert().Regexp(`order_value_bucket\{[^}]*le="100"[^}]*\} 1\n`, body)
+	s.Assert().Regexp(`order_value_sum\{[^}]*\} 42\n`, body)
+}
+
+func (s *MetricsTestSuite) TestMeter_IsolatedBetweenProviders() {
+	first, err := NewProvider()
+	s.Require().NoError(err)
+	second, err := NewProvider()
+	s.Require().NoError(err)
+
+	counter, err := first.Meter().Int64Counter("jobs_processed")
+	s.Require().NoError(err)
+	counter.Add(context.Background(), 1)
+
+	s.Assert().Contains(s.scrape(first), "jobs_processed_total")
+	s.Assert().NotContains(s.scrape(second), "jobs_processed_total")
+}
+
+func (s *MetricsTestSuite) TestRegistry_ServesCustomCollectors() {
+	provider, err := NewProvider()
+	s.Require().NoError(err)
+
+	orders := prometheus.NewCounter(prometheus.CounterOpts{Name: "orders_placed_total", Help: "Orders placed"})
+	s.Require().NoError(provider.Registry().Register(orders))
+	orders.Add(3)
+
+	req := httptest.NewRequest("GET", "/metrics", nil)
+	w := httptest.NewRecorder()
+	provider.Handler().ServeHTTP(w, req)
+
+	s.Assert().Regexp(`(?m)^orders_placed_total 3$`, w.Body.String())
+}
+
+func (s *MetricsTestSuite) TestNewProvider_WithServiceLabels_SkipsEmpty() {
+	provider, err := NewProvider(WithServiceLabels("orders", ""))
+	s.Require().NoError(err)
+
+	provider.RequestsTotal.Add(context.Background(), 1)
+
+	req := httptest.NewRequest("GET", "/metrics", nil)
+	w := httptest.NewRecorder()
+	provider.Handler().ServeHTTP(w, req)
+
+	body := w.Body.String()
+	s.Assert().Contains(body, `service="orders"`)
+	s.Assert().NotContains(body, `host=`)
+	s.Assert().NotContains(body, `instance=`)
+}
+
+func (s *MetricsTestSuite) TestProvider_RecordDegraded() {
+	ctx := context.Background()
+	s.provider.RecordDegraded(ctx, DegradedReasonStaleServed)
+	s.provider.RecordDegraded(ctx, DegradedReasonStaleServed)
+	s.provider.RecordDegraded(ctx, DegradedReasonDependencyWarn)
+
+	req := httptest.NewRequest("GET", "/metrics", nil)
+	w := httptest.NewRecorder()
+	s.provider.Handler().ServeHTTP(w, req)
+
+	body := w.Body.String()
+	s.Assert().Regexp(`service_degraded_responses_total\{[^}]*reason="stale_served"[^}]*\} 2`, body)
+	s.Assert().Regexp(`service_degraded_responses_total\{[^}]*reason="dependency_warn"[^}]*\} 1`, body)
+}
+
+func (s *MetricsTestSuite) TestProvider_RecordRateLimited() {
+	ctx := context.Background()
+	s.provider.RecordRateLimited(ctx, "ip")
+	s.provider.RecordRateLimited(ctx, "ip")
+	s.provider.RecordRateLimited(ctx, "global")
+
+	req := httptest.NewRequest("GET", "/metrics", nil)
+	w := httptest.NewRecorder()
+	s.provider.Handler().ServeHTTP(w, req)
+
+	body := w.Body.String()
+	s.Assert().Regexp(`rate_limit_exceeded_total\{[^}]*scope="ip"[^}]*\} 2`, body)
+	s.Assert().Regexp(`rate_limit_exceeded_total\{[^}]*scope="global"[^}]*\} 1`, body)
+}
+
+func (s *MetricsTestSuite) TestProvider_RecordRateLimitWouldExceed() {
+	s.provider.RecordRateLimitWouldExceed(context.Background(), "ip")
+
+	req := httptest.NewRequest("GET", "/metrics", nil)
+	w := httptest.NewRecorder()
+	s.provider.Handler().ServeHTTP(w, req)
+
+	s.Assert().Regexp(`rate_limit_would_exceed_total\{[^}]*scope="ip"[^}]*\} 1`, w.Body.String())
+	s.Assert().NotContains(w.Body.String(), "rate_limit_exceeded_total")
+}
+
+func (s *MetricsTestSuite) TestProvider_RecordDeprecatedConfigKey() {
+	s.provider.RecordDeprecatedConfigKey(context.Background(), "SERVICE_NAME", "METRICS_SERVICE_NAME")
+
+	req := httptest.NewRequest("GET", "/metrics", nil)
+	w := httptest.NewRecorder()
+	s.provider.Handler().ServeHTTP(w, req)
+
+	s.Assert().Regexp(`config_deprecated_keys_total\{[^}]*key="SERVICE_NAME"[^}]*replacement="METRICS_SERVICE_NAME"[^}]*\} 1`, w.Body.String())
+}
+
 func (s *MetricsTestSuite) TestProvider_Handler() {
 	handler := s.provider.Handler()
 
@@ -103,6 +334,21 @@ func (s *MetricsTestSuite) TestProvider_RequestDuration_Histogram() {
 	s.Assert().Contains(body, "http_request_duration_seconds")
 }
 
+func (s *MetricsTestSuite) TestProvider_PayloadSize_Histograms() {
+	ctx := context.Background()
+
+	s.provider.RequestSizeBytes.Record(ctx, 300, metric.WithAttributes(attribute.String("method", "POST")))
+	s.provider.ResponseSizeBytes.Record(ctx, 5000, metric.WithAttributes(attribute.String("method", "POST")))
+
+	w := httptest.NewRecorder()
+	s.provider.Handler().ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+
+	body := w.Body.String()
+	s.Assert().Regexp(`(?m)^http_request_size_bytes_bucket\{[^}]*le="1024"\} 1$`, body)
+	s.Assert().Regexp(`(?m)^http_response_size_bytes_bucket\{[^}]*le="4096"\} 0$`, body)
+	s.Assert().Regexp(`(?m)^http_response_size_bytes_bucket\{[^}]*le="16384"\} 1$`, body)
+}
+
 func (s *MetricsTestSuite) TestProvider_RequestsInFlight_UpDownCounter() {
 	ctx := context.Background()
 
@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"net/http"
+)
+
+// MaxBodySize caps the number of bytes read from the request body. Reads past
+// the limit fail with *http.MaxBytesError, which handlers translate into a
+// 413 response. A non-positive limit disables the check.
+func MaxBodySize(limit int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if limit > 0 && r.Body != nil {
+				r.Body = http.MaxBytesReader(w, r.Body, limit)
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"microservice/internal/platform/logger"
+	"microservice/internal/platform/metrics"
+	"net/http"
+)
+
+// ConcurrencyLimitOptions caps how many requests may run the wrapped handlers
+// at the same time. A non-positive Limit disables the cap.
+type ConcurrencyLimitOptions struct {
+	Scope string
+	Limit int
+}
+
+// ConcurrencyLimit rejects requests with a JSON 503 and Retry-After while Limit
+// requests are already in progress instead of queueing them. Rejections are
+// counted on rate_limit_exceeded_total under opts.Scope and logged at warn
+// level like rate limit violations. Each call creates an independent limiter.
+func ConcurrencyLimit(provider *metrics.Provider, opts ConcurrencyLimitOptions) func(http.Handler) http.Handler {
+	if opts.Limit <= 0 {
+		return func(next http.Handler) http.Handler { return next }
+	}
+
+	slots := make(chan struct{}, opts.Limit)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case slots <- struct{}{}:
+				defer func() { <-slots }()
+				next.ServeHTTP(w, r)
+			default:
+				provider.RecordRateLimited(r.Context(), opts.Scope)
+				logger.FromContext(r.Context()).Warn("Concurrency limit exceeded",
+					logger.String("scope", opts.Scope),
+					logger.Int("limit", opts.Limit),
+					logger.String("remote_ip", remoteIP(r.RemoteAddr)),
+					logger.String("method", r.Method),
+					logger.String("route", findRoute(r)),
+				)
+				w.Header().Set("Retry-After", "1")
+				writeJSONError(w, http.StatusServiceUnavailable, errorResponse{Error: "too many bulk requests in progress"})
+			}
+		})
+	}
+}
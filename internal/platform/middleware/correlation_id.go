@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// CorrelationIDHeader carries the correlation ID on requests and responses.
+const CorrelationIDHeader = "X-Correlation-ID"
+
+type correlationIDKey struct{}
+
+// CorrelationID stores the request's X-Correlation-ID in the context and
+// echoes it on the response. A missing value, or one that fails
+// ValidRequestID, is replaced with a new UUID. Unlike the request ID, which
+// identifies a single hop, the correlation ID is meant to stay the same along
+// the whole call chain, so outgoing calls forward it through
+// CorrelationTransport.
+func CorrelationID() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id := r.Header.Get(CorrelationIDHeader)
+			if !ValidRequestID(id, DefaultRequestIDMaxLength) {
+				id = uuid.NewString()
+			}
+			w.Header().Set(CorrelationIDHeader, id)
+			next.ServeHTTP(w, r.WithContext(WithCorrelationID(r.Context(), id)))
+		})
+	}
+}
+
+// WithCorrelationID returns a copy of ctx carrying id, for work that does not
+// start with an HTTP request, such as consumed messages.
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationIDKey{}, id)
+}
+
+// CorrelationIDFromContext returns the correlation ID carried by ctx, or an
+// empty string.
+func CorrelationIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(correlationIDKey{}).(string)
+	return id
+}
+
+// CorrelationTransport sets X-Correlation-ID on outgoing requests from the
+// correlation ID in their context, unless the request already has one. Base
+// defaults to http.DefaultTransport.
+type CorrelationTransport struct {
+	Base http.RoundTripper
+}
+
+func (t CorrelationTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	id := CorrelationIDFromContext(req.Context())
+	if id == "" || req.Header.Get(CorrelationIDHeader) != "" {
+		return base.RoundTrip(req)
+	}
+
+	// A RoundTripper must not modify the caller's request.
+	req = req.Clone(req.Context())
+	req.Header.Set(CorrelationIDHeader, id)
+	return base.RoundTrip(req)
+}
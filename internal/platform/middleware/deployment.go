@@ -0,0 +1,43 @@
+package middleware
+
+import "net/http"
+
+const (
+	ServedByHeader    = "X-Served-By"
+	AppVersionHeader  = "X-App-Version"
+	EnvironmentHeader = "X-Env"
+)
+
+// DeploymentInfo identifies the instance that serves a request.
+type DeploymentInfo struct {
+	Instance    string
+	Version     string
+	Environment string
+}
+
+// DeploymentHeaders adds X-Served-By, X-App-Version and X-Env to every
+// response so a request can be traced to the instance that handled it. Empty
+// values are not sent.
+func DeploymentHeaders(info DeploymentInfo) func(http.Handler) http.Handler {
+	headers := make(map[string]string, 3)
+	for name, value := range map[string]string{
+		ServedByHeader:    info.Instance,
+		AppVersionHeader:  info.Version,
+		EnvironmentHeader: info.Environment,
+	} {
+		if value != "" {
+			headers[name] = value
+		}
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			h := w.Header()
+			for name, value := range headers {
+				h.Set(name, value)
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
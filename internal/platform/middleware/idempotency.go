@@ -0,0 +1,372 @@
+package middleware
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	IdempotencyKeyHeader     = "Idempotency-Key"
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+	maxIdempotencyKeyLength  = 255
+)
+
+// replayedHeaders describe the cached representation and are the only headers
+// captured for replay. Others, such as request and correlation IDs,
+// Server-Timing or CORS, belong to the request being answered.
+var replayedHeaders = []string{
+	"Cache-Control",
+	"Content-Language",
+	"Content-Location",
+	"Content-Type",
+	"ETag",
+	"Last-Modified",
+	"Location",
+}
+
+// CachedResponse is a response captured for replay. RequestHash identifies the
+// request body it answered.
+type CachedResponse struct {
+	StatusCode  int
+	Header      http.Header
+	Body        []byte
+	RequestHash string
+}
+
+// IdempotencyStore keeps responses by idempotency key. Lock serializes
+// requests sharing a key: it blocks until the key is free or ctx is done and
+// returns a function that releases it.
+type IdempotencyStore interface {
+	Lock(ctx context.Context, key string) (unlock func(), err error)
+	Get(ctx context.Context, key string) (*CachedResponse, bool)
+	Set(ctx context.Context, key string, response *CachedResponse)
+}
+
+// Idempotency replays the first response for mutating requests that carry an
+// Idempotency-Key header. Keys are scoped by method and path, and concurrent
+// requests with the same key run one at a time. 5xx responses are not cached
+// so transient failures can be retried. Reusing a key with a different body is
+// rejected with 422.
+func Idempotency(store IdempotencyStore) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(IdempotencyKeyHeader)
+			if key == "" || !isMutating(r.Method) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if len(key) > maxIdempotencyKeyLength {
+				writeJSONError(w, http.StatusBadRequest, errorResponse{Error: "Idempotency-Key must not exceed 255 characters"})
+				return
+			}
+
+			var body []byte
+			if r.Body != nil {
+				var err error
+				body, err = io.ReadAll(r.Body)
+				r.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), r.Body))
+				if err != nil {
+					// Let the handler report the failed read, such as a body
+					// over the size limit, the way it does without a key.
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+			sum := sha256.Sum256(body)
+			requestHash := hex.EncodeToString(sum[:])
+
+			ctx := r.Context()
+			storeKey := r.Method + " " + r.URL.Path + " " + key
+
+			unlock, err := store.Lock(ctx, storeKey)
+			if err != nil {
+				// The client went away while waiting for the first request.
+				return
+			}
+			defer unlock()
+
+			if cached, ok := store.Get(ctx, storeKey); ok {
+				if cached.RequestHash != requestHash {
+					writeJSONError(w, http.StatusUnprocessableEntity, errorResponse{Error: "Idempotency-Key was already used with a different request body"})
+					return
+				}
+				replay(w, cached)
+				return
+			}
+
+			rec := &capturingWriter{ResponseWriter: w}
+			next.ServeHTTP(rec, r)
+
+			status := rec.statusCode()
+			if status >= http.StatusInternalServerError {
+				return
+			}
+			store.Set(ctx, storeKey, &CachedResponse{
+				StatusCode:  status,
+				Header:      rec.header,
+				Body:        rec.body.Bytes(),
+				RequestHash: requestHash,
+			})
+		})
+	}
+}
+
+func isMutating(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+func replay(w http.ResponseWriter, cached *CachedResponse) {
+	header := w.Header()
+	for name, values := range cached.Header {
+		header[name] = append([]string(nil), values...)
+	}
+	header.Set(IdempotentReplayedHeader, "true")
+	w.WriteHeader(cached.StatusCode)
+	_, _ = w.Write(cached.Body)
+}
+
+// capturingWriter passes the response through while keeping a copy of it.
+type capturingWriter struct {
+	http.ResponseWriter
+	status int
+	header http.Header
+	body   bytes.Buffer
+}
+
+func (c *capturingWriter) WriteHeader(status int) {
+	if c.status == 0 {
+		c.status = status
+		c.header = make(http.Header)
+		for _, name := range replayedHeaders {
+			if values := c.ResponseWriter.Header().Values(name); len(values) > 0 {
+				c.header[name] = append([]string(nil), values...)
+			}
+		}
+	}
+	c.ResponseWriter.WriteHeader(status)
+}
+
+func (c *capturingWriter) Write(b []byte) (int, error) {
+	if c.status == 0 {
+		c.WriteHeader(http.StatusOK)
+	}
+	c.body.Write(b)
+	return c.ResponseWriter.Write(b)
+}
+
+func (c *capturingWriter) statusCode() int {
+	if c.status == 0 {
+		return http.StatusOK
+	}
+	return c.status
+}
+
+const defaultIdempotencySweepInterval = time.Minute
+
+type idempotencyEntry struct {
+	key       string
+	response  *CachedResponse
+	expiresAt time.Time
+}
+
+// MemoryIdempotencyStore is an in-process IdempotencyStore. Entries expire
+// after the configured TTL and are removed by a background sweeper that starts
+// with the first Set and runs until Close. With a max entries limit the least
+// recently used responses are evicted to make room.
+type MemoryIdempotencyStore struct {
+	ttl           time.Duration
+	maxEntries    int
+	sweepInterval time.Duration
+	entries       map[string]*list.Element
+	// order holds the entries from most to least recently used.
+	order    *list.List
+	inflight map[string]chan struct{}
+	sweeping bool
+	closed   bool
+	stop     chan struct{}
+	stopped  chan struct{}
+	mu       sync.Mutex
+}
+
+type IdempotencyStoreOption func(*MemoryIdempotencyStore)
+
+// WithIdempotencyMaxEntries caps the number of stored responses. Zero or less
+// leaves the store unbounded.
+func WithIdempotencyMaxEntries(n int) IdempotencyStoreOption {
+	return func(s *MemoryIdempotencyStore) {
+		s.maxEntries = n
+	}
+}
+
+// WithIdempotencySweepInterval sets how often expired responses are removed.
+// It defaults to one minute.
+func WithIdempotencySweepInterval(interval time.Duration) IdempotencyStoreOption {
+	return func(s *MemoryIdempotencyStore) {
+		if interval > 0 {
+			s.sweepInterval = interval
+		}
+	}
+}
+
+func NewMemoryIdempotencyStore(ttl time.Duration, opts ...IdempotencyStoreOption) *MemoryIdempotencyStore {
+	s := &MemoryIdempotencyStore{
+		ttl:           ttl,
+		sweepInterval: defaultIdempotencySweepInterval,
+		entries:       make(map[string]*list.Element),
+		order:         list.New(),
+		inflight:      make(map[string]chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func (s *MemoryIdempotencyStore) Lock(ctx context.Context, key string) (func(), error) {
+	for {
+		s.mu.Lock()
+		done, busy := s.inflight[key]
+		if !busy {
+			done = make(chan struct{})
+			s.inflight[key] = done
+			s.mu.Unlock()
+
+			var once sync.Once
+			return func() {
+				once.Do(func() {
+					s.mu.Lock()
+					delete(s.inflight, key)
+					s.mu.Unlock()
+					close(done)
+				})
+			}, nil
+		}
+		s.mu.Unlock()
+
+		select {
+		case <-done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func (s *MemoryIdempotencyStore) Get(ctx context.Context, key string) (*CachedResponse, bool) {
+	_ = ctx
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	elem, ok := s.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*idempotencyEntry)
+	if !time.Now().Before(entry.expiresAt) {
+		s.remove(elem)
+		return nil, false
+	}
+	s.order.MoveToFront(elem)
+	return entry.response, true
+}
+
+func (s *MemoryIdempotencyStore) Set(ctx context.Context, key string, response *CachedResponse) {
+	_ = ctx
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	expiresAt := time.Now().Add(s.ttl)
+	if elem, ok := s.entries[key]; ok {
+		entry := elem.Value.(*idempotencyEntry)
+		entry.response, entry.expiresAt = response, expiresAt
+		s.order.MoveToFront(elem)
+	} else {
+		s.entries[key] = s.order.PushFront(&idempotencyEntry{key: key, response: response, expiresAt: expiresAt})
+	}
+
+	for s.maxEntries > 0 && s.order.Len() > s.maxEntries {
+		s.remove(s.order.Back())
+	}
+	s.startSweeper()
+}
+
+// Len returns the number of stored responses, including expired ones that
+// have not been swept yet.
+func (s *MemoryIdempotencyStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.order.Len()
+}
+
+// Close stops the sweeper and waits for it to exit. It is safe to call more
+// than once.
+func (s *MemoryIdempotencyStore) Close() {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return
+	}
+	s.closed = true
+	sweeping := s.sweeping
+	if sweeping {
+		close(s.stop)
+	}
+	s.mu.Unlock()
+
+	if sweeping {
+		<-s.stopped
+	}
+}
+
+// remove drops an entry. Callers must hold the lock.
+func (s *MemoryIdempotencyStore) remove(elem *list.Element) {
+	s.order.Remove(elem)
+	delete(s.entries, elem.Value.(*idempotencyEntry).key)
+}
+
+// startSweeper launches the expiry sweeper once. Callers must hold the lock.
+func (s *MemoryIdempotencyStore) startSweeper() {
+	if s.sweeping || s.closed {
+		return
+	}
+	s.sweeping = true
+	s.stop = make(chan struct{})
+	s.stopped = make(chan struct{})
+
+	go func() {
+		defer close(s.stopped)
+		ticker := time.NewTicker(s.sweepInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-s.stop:
+				return
+			case <-ticker.C:
+				s.sweep()
+			}
+		}
+	}()
+}
+
+func (s *MemoryIdempotencyStore) sweep() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for _, elem := range s.entries {
+		if !now.Before(elem.Value.(*idempotencyEntry).expiresAt) {
+			s.remove(elem)
+		}
+	}
+}
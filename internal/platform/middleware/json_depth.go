@@ -0,0 +1,93 @@
+package middleware
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// JSONDepthError is returned from reads of a request body whose JSON nesting
+// goes deeper than the configured limit.
+type JSONDepthError struct {
+	Limit int
+}
+
+func (e *JSONDepthError) Error() string {
+	return fmt.Sprintf("json: nesting depth exceeds %d", e.Limit)
+}
+
+// MaxJSONDepth rejects request bodies nested deeper than limit arrays or
+// objects. The body is checked while it is read, so reads fail with
+// *JSONDepthError as soon as the limit is crossed and handlers translate it
+// into a 400 response. Bodies declared as a non-JSON content type are left
+// alone. A non-positive limit disables the check.
+func MaxJSONDepth(limit int) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if limit > 0 && r.Body != nil && r.Body != http.NoBody && isJSONContent(r.Header.Get("Content-Type")) {
+				r.Body = &jsonDepthReader{ReadCloser: r.Body, limit: limit}
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func isJSONContent(contentType string) bool {
+	if contentType == "" {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// jsonDepthReader walks the JSON tokens passing through it, tracking string
+// state so brackets inside string values are not counted.
+type jsonDepthReader struct {
+	io.ReadCloser
+	limit    int
+	depth    int
+	inString bool
+	escaped  bool
+	err      error
+}
+
+func (j *jsonDepthReader) Read(p []byte) (int, error) {
+	if j.err != nil {
+		return 0, j.err
+	}
+
+	n, err := j.ReadCloser.Read(p)
+	for _, b := range p[:n] {
+		if j.inString {
+			switch {
+			case j.escaped:
+				j.escaped = false
+			case b == '\\':
+				j.escaped = true
+			case b == '"':
+				j.inString = false
+			}
+			continue
+		}
+
+		switch b {
+		case '"':
+			j.inString = true
+		case '{', '[':
+			j.depth++
+			if j.depth > j.limit {
+				j.err = &JSONDepthError{Limit: j.limit}
+				return 0, j.err
+			}
+		case '}', ']':
+			j.depth--
+		}
+	}
+	return n, err
+}
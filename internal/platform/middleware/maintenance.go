@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultMaintenanceMessage is the error returned while maintenance mode is on
+// and MaintenanceOptions.Message is empty.
+const DefaultMaintenanceMessage = "Service is under maintenance, try again later"
+
+// MaintenanceOptions shapes the responses sent while maintenance mode is on.
+// A RetryAfter of zero or less leaves out the Retry-After header.
+type MaintenanceOptions struct {
+	RetryAfter time.Duration
+	Message    string
+}
+
+// Maintenance answers every request with 503 and a JSON error while flag is
+// set, and passes requests through otherwise. Mount it only on the routes to
+// pause, such as /api, so health probes and metrics keep answering. The flag
+// is read on each request, so flipping it takes effect immediately.
+func Maintenance(flag *atomic.Bool, opts MaintenanceOptions) func(http.Handler) http.Handler {
+	message := opts.Message
+	if message == "" {
+		message = DefaultMaintenanceMessage
+	}
+	retryAfter := ""
+	if opts.RetryAfter > 0 {
+		retryAfter = strconv.Itoa(int((opts.RetryAfter + time.Second - 1) / time.Second))
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !flag.Load() {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if retryAfter != "" {
+				w.Header().Set("Retry-After", retryAfter)
+			}
+			writeJSONError(w, http.StatusServiceUnavailable, errorResponse{Error: message})
+		})
+	}
+}
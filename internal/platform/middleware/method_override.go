@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+)
+
+const MethodOverrideHeader = "X-HTTP-Method-Override"
+
+// overridableMethods are the methods a POST may be turned into. Safe methods
+// are left out so an override can never turn a write into a cacheable read.
+var overridableMethods = map[string]struct{}{
+	http.MethodPut:    {},
+	http.MethodPatch:  {},
+	http.MethodDelete: {},
+}
+
+// MethodOverride lets clients stuck behind proxies that only pass GET and POST
+// send PUT, PATCH or DELETE as a POST with an X-HTTP-Method-Override header.
+// Only POST requests are rewritten; other methods and unsupported override
+// values are passed on unchanged. It must run before routing.
+func MethodOverride(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			method := strings.ToUpper(strings.TrimSpace(r.Header.Get(MethodOverrideHeader)))
+			if _, ok := overridableMethods[method]; ok {
+				r.Method = method
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -1,9 +1,11 @@
 package middleware
 
 import (
+	"io"
 	"microservice/internal/platform/metrics"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5/middleware"
@@ -11,6 +13,9 @@ import (
 	"go.opentelemetry.io/otel/metric"
 )
 
+// UnmatchedRoute is the path label for requests that matched no route.
+const UnmatchedRoute = "unmatched"
+
 func MetricsMiddleware(metricsProvider *metrics.Provider) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -21,29 +26,60 @@ func MetricsMiddleware(metricsProvider *metrics.Provider) func(http.Handler) htt
 			defer metricsProvider.RequestsInFlight.Add(ctx, -1)
 
 			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			body := &countingBody{ReadCloser: r.Body}
+			if r.Body != nil && r.Body != http.NoBody {
+				r.Body = body
+			}
 
 			next.ServeHTTP(ww, r)
 
 			duration := time.Since(start).Seconds()
 			status := strconv.Itoa(ww.Status())
 			method := r.Method
-			path := r.URL.Path
-
-			metricsProvider.RequestsTotal.Add(ctx, 1,
-				metric.WithAttributes(
-					attribute.String("method", method),
-					attribute.String("path", path),
-					attribute.String("status", status),
-				),
-			)
+			path := metricsPath(r, ww.Status())
 
-			metricsProvider.RequestDuration.Record(ctx, duration,
-				metric.WithAttributes(
-					attribute.String("method", method),
-					attribute.String("path", path),
-					attribute.String("status", status),
-				),
+			attributes := metric.WithAttributes(
+				attribute.String("method", method),
+				attribute.String("path", path),
+				attribute.String("status", status),
 			)
+
+			metricsProvider.RequestsTotal.Add(ctx, 1, attributes)
+			metricsProvider.RequestDuration.Record(ctx, duration, attributes)
+			metricsProvider.RequestSizeBytes.Record(ctx, requestSize(r, body), attributes)
+			metricsProvider.ResponseSizeBytes.Record(ctx, int64(ww.BytesWritten()), attributes)
 		})
 	}
 }
+
+// countingBody counts the request body bytes read by the handler.
+type countingBody struct {
+	io.ReadCloser
+	read int64
+}
+
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.read += int64(n)
+	return n, err
+}
+
+// requestSize is the declared Content-Length, or the bytes the handler read
+// from a chunked body of unknown length.
+func requestSize(r *http.Request, body *countingBody) int64 {
+	if r.ContentLength >= 0 {
+		return r.ContentLength
+	}
+	return body.read
+}
+
+// metricsPath labels requests by route pattern so IDs in the URL do not create
+// new time series. A mount such as /api/* that is left as the pattern of a 404
+// means its subrouter matched nothing.
+func metricsPath(r *http.Request, status int) string {
+	pattern := routePattern(r)
+	if pattern == "" || (status == http.StatusNotFound && strings.HasSuffix(pattern, "/*")) {
+		return UnmatchedRoute
+	}
+	return pattern
+}
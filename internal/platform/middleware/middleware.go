@@ -1,32 +1,151 @@
 package middleware
 
 import (
+	"context"
+	"encoding/json"
+	"math/rand/v2"
 	"microservice/internal/platform/logger"
+	"net"
 	"net/http"
 	"time"
+	"unicode/utf8"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 )
 
-func RequestLogger(baseLogger logger.Logger) func(http.Handler) http.Handler {
+// RequestLoggerConfig controls what the access log emits.
+type RequestLoggerConfig struct {
+	// ClientFields adds the User-Agent and Referer request headers.
+	ClientFields bool
+	// MaxFieldLength caps client supplied fields, in bytes. Longer values are
+	// cut and suffixed with "...". Zero disables the cap.
+	MaxFieldLength int
+	// SkipPaths are request paths that are never logged, such as health checks.
+	SkipPaths []string
+	// SampleRate is the fraction of requests that are logged. Server errors
+	// are always logged. Zero or values of one and above log every request.
+	SampleRate float64
+}
+
+// LoggerInjector stores base, tagged with the request ID set by chi's
+// RequestID, in the request context, so handlers and the layers below them log
+// through logger.FromContext with the request_id field. It must run after
+// RequestID, and after CorrelationID to add the correlation_id field.
+func LoggerInjector(base logger.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, _ := withRequestLogger(r.Context(), base)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+func withRequestLogger(ctx context.Context, base logger.Logger) (context.Context, logger.Logger) {
+	fields := []logger.Field{logger.String("request_id", middleware.GetReqID(ctx))}
+	if id := CorrelationIDFromContext(ctx); id != "" {
+		fields = append(fields, logger.String("correlation_id", id))
+	}
+	requestLogger := base.With(fields...)
+	return logger.WithLogger(ctx, requestLogger), requestLogger
+}
+
+// RequestLogger writes the access log through the request-scoped logger that
+// LoggerInjector stored in the context, keeping any fields added to it since.
+// Without one it builds and stores that logger itself, so it also works on its
+// own after RequestID.
+func RequestLogger(baseLogger logger.Logger, cfg RequestLoggerConfig) func(http.Handler) http.Handler {
+	skip := make(map[string]struct{}, len(cfg.SkipPaths))
+	for _, path := range cfg.SkipPaths {
+		skip[path] = struct{}{}
+	}
+
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			start := time.Now()
 			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
 
-			reqID := middleware.GetReqID(r.Context())
-			contextLogger := baseLogger.With(logger.String("request_id", reqID))
-			ctx := logger.WithLogger(r.Context(), contextLogger)
+			contextLogger, ok := logger.LookupContext(r.Context())
+			if !ok {
+				var ctx context.Context
+				ctx, contextLogger = withRequestLogger(r.Context(), baseLogger)
+				r = r.WithContext(ctx)
+			}
+
+			next.ServeHTTP(ww, r)
 
-			next.ServeHTTP(ww, r.WithContext(ctx))
+			duration := time.Since(start)
+			if _, ok := skip[r.URL.Path]; ok {
+				return
+			}
+			if !sampled(cfg.SampleRate, ww.Status()) {
+				return
+			}
 
-			contextLogger.Info("HTTP Request",
+			fields := []logger.Field{
 				logger.String("method", r.Method),
 				logger.String("path", r.URL.Path),
-				logger.String("remote_addr", r.RemoteAddr),
+				logger.String("route", routePattern(r)),
 				logger.Int("status", ww.Status()),
-				logger.String("duration", time.Since(start).String()),
-			)
+				logger.Int("bytes", ww.BytesWritten()),
+				logger.String("duration", duration.String()),
+				logger.String("remote_ip", remoteIP(r.RemoteAddr)),
+			}
+			if cfg.ClientFields {
+				fields = append(fields,
+					logger.String("user_agent", truncate(r.UserAgent(), cfg.MaxFieldLength)),
+					logger.String("referer", truncate(r.Referer(), cfg.MaxFieldLength)),
+				)
+			}
+
+			contextLogger.Info("HTTP Request", fields...)
 		})
 	}
 }
+
+func sampled(rate float64, status int) bool {
+	if rate <= 0 || rate >= 1 || status >= http.StatusInternalServerError {
+		return true
+	}
+	return rand.Float64() < rate
+}
+
+// routePattern returns the matched chi route, such as /api/examples/{id}, so
+// requests can be grouped without the cardinality of raw paths.
+func routePattern(r *http.Request) string {
+	if rctx := chi.RouteContext(r.Context()); rctx != nil {
+		return rctx.RoutePattern()
+	}
+	return ""
+}
+
+func remoteIP(addr string) string {
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+	return addr
+}
+
+func truncate(s string, maxLen int) string {
+	if maxLen <= 0 || len(s) <= maxLen {
+		return s
+	}
+	s = s[:maxLen]
+	for len(s) > 0 && !utf8.ValidString(s) {
+		s = s[:len(s)-1]
+	}
+	return s + "..."
+}
+
+// errorResponse is the JSON body of a request rejected by a middleware.
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// writeJSONError answers with status and body encoded as JSON. Headers such as
+// Retry-After must be set before it is called.
+func writeJSONError(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
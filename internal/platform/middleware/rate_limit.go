@@ -0,0 +1,114 @@
+package middleware
+
+import (
+	"math"
+	"microservice/internal/platform/logger"
+	"microservice/internal/platform/metrics"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/httprate"
+)
+
+// Scopes reported on rate_limit_exceeded_total.
+const (
+	RateLimitScopeGlobal = "global"
+	RateLimitScopeIP     = "ip"
+	RateLimitScopeBulk   = "bulk"
+)
+
+// RateLimitOptions configures a single rate limit. Requests are counted per
+// client IP for RateLimitScopeIP and across all clients otherwise. A Shadow
+// limit only reports the requests it would reject and lets them through, so new
+// limits can be observed before they are enforced.
+type RateLimitOptions struct {
+	Scope    string
+	Requests int
+	Window   time.Duration
+	Shadow   bool
+}
+
+// RateLimit rejects requests over the limit with a JSON 429 whose Retry-After
+// is the number of seconds until the current window resets. Every response
+// carries X-RateLimit-Limit, X-RateLimit-Remaining and X-RateLimit-Reset.
+// Rejections are reported as a metric and a warn log carrying the client IP
+// and route. The log goes to the request logger, so RequestLogger must run
+// first.
+//
+// In shadow mode no rate limit headers are sent; requests over the limit are
+// counted on rate_limit_would_exceed_total and logged instead.
+func RateLimit(provider *metrics.Provider, opts RateLimitOptions) func(http.Handler) http.Handler {
+	keyFn := httprate.Key("*")
+	if opts.Scope == RateLimitScopeIP {
+		keyFn = httprate.KeyByIP
+	}
+
+	if opts.Shadow {
+		return shadowLimit(provider, opts, keyFn)
+	}
+	return enforceLimit(provider, opts, keyFn)
+}
+
+func enforceLimit(provider *metrics.Provider, opts RateLimitOptions, keyFn httprate.KeyFunc) func(http.Handler) http.Handler {
+	return httprate.Limit(opts.Requests, opts.Window,
+		httprate.WithKeyFuncs(keyFn),
+		httprate.WithLimitHandler(func(w http.ResponseWriter, r *http.Request) {
+			provider.RecordRateLimited(r.Context(), opts.Scope)
+			logger.FromContext(r.Context()).Warn("Rate limit exceeded",
+				logger.String("scope", opts.Scope),
+				logger.String("remote_ip", remoteIP(r.RemoteAddr)),
+				logger.String("method", r.Method),
+				logger.String("route", findRoute(r)),
+			)
+
+			w.Header().Set("Retry-After", strconv.Itoa(retryAfter(opts.Window, time.Now())))
+			writeJSONError(w, http.StatusTooManyRequests, errorResponse{Error: "rate limit exceeded"})
+		}),
+	)
+}
+
+func shadowLimit(provider *metrics.Provider, opts RateLimitOptions, keyFn httprate.KeyFunc) func(http.Handler) http.Handler {
+	limiter := httprate.NewRateLimiter(opts.Requests, opts.Window,
+		httprate.WithResponseHeaders(httprate.ResponseHeaders{}),
+	)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key, err := keyFn(r)
+			if err == nil && limiter.OnLimit(w, r, key) {
+				provider.RecordRateLimitWouldExceed(r.Context(), opts.Scope)
+				logger.FromContext(r.Context()).Warn("Rate limit would be exceeded",
+					logger.String("scope", opts.Scope),
+					logger.String("remote_ip", remoteIP(r.RemoteAddr)),
+					logger.String("method", r.Method),
+					logger.String("route", findRoute(r)),
+				)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// retryAfter is the number of whole seconds until the fixed window containing
+// now ends, and at least one. Windows are aligned the way httprate aligns them.
+func retryAfter(window time.Duration, now time.Time) int {
+	if window <= 0 {
+		return 1
+	}
+	now = now.UTC()
+	reset := now.Truncate(window).Add(window)
+	seconds := int(math.Ceil(reset.Sub(now).Seconds()))
+	return max(seconds, 1)
+}
+
+// findRoute resolves the route pattern a request would match. Limits run
+// before routing, so the pattern is looked up rather than read from the
+// route context.
+func findRoute(r *http.Request) string {
+	rctx := chi.RouteContext(r.Context())
+	if rctx == nil || rctx.Routes == nil {
+		return ""
+	}
+	return rctx.Routes.Find(chi.NewRouteContext(), r.Method, r.URL.Path)
+}
@@ -5,9 +5,25 @@ import (
 	"microservice/internal/platform/logger"
 	"net/http"
 	"runtime/debug"
+
+	"github.com/go-chi/chi/v5/middleware"
 )
 
-func Recovery(log logger.Logger) func(next http.Handler) http.Handler {
+// RecoveryConfig controls the 500 response sent after a panic. ExposePanic
+// adds the panic message, which is only safe outside production.
+// IncludeRequestID adds the request ID so users can report the failure.
+type RecoveryConfig struct {
+	ExposePanic      bool
+	IncludeRequestID bool
+}
+
+type recoveryResponse struct {
+	Error     string `json:"error"`
+	Panic     string `json:"panic,omitempty"`
+	RequestID string `json:"request_id,omitempty"`
+}
+
+func Recovery(log logger.Logger, cfg RecoveryConfig) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			defer func() {
@@ -17,18 +33,26 @@ func Recovery(log logger.Logger) func(next http.Handler) http.Handler {
 						contextLogger = log
 					}
 
+					message := fmt.Sprintf("%v", err)
 					contextLogger.Error("Panic recovered",
 						logger.String("method", r.Method),
 						logger.String("url", r.URL.Path),
 						logger.String("remote_addr", r.RemoteAddr),
 						logger.String("user_agent", r.UserAgent()),
-						logger.String("panic", fmt.Sprintf("%v", err)),
+						logger.String("panic", message),
 						logger.String("stack", string(debug.Stack())),
 					)
 
-					w.Header().Set("Connection", "close")
+					body := recoveryResponse{Error: http.StatusText(http.StatusInternalServerError)}
+					if cfg.ExposePanic {
+						body.Panic = message
+					}
+					if cfg.IncludeRequestID {
+						body.RequestID = middleware.GetReqID(r.Context())
+					}
 
-					http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+					w.Header().Set("Connection", "close")
+					writeJSONError(w, http.StatusInternalServerError, body)
 				}
 			}()
 
@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// DefaultRequestIDMaxLength is used when RequestID is given no positive cap.
+const DefaultRequestIDMaxLength = 128
+
+// RequestID stores the request ID in the context like chi's RequestID, but
+// only reuses an incoming X-Request-Id that passes ValidRequestID. Any other
+// value is replaced with a generated ID, so oversized or crafted IDs never
+// reach logs or downstream calls.
+func RequestID(maxLength int) func(http.Handler) http.Handler {
+	if maxLength <= 0 {
+		maxLength = DefaultRequestIDMaxLength
+	}
+	return func(next http.Handler) http.Handler {
+		withID := middleware.RequestID(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if id := r.Header.Get(middleware.RequestIDHeader); id != "" && !ValidRequestID(id, maxLength) {
+				r.Header.Del(middleware.RequestIDHeader)
+			}
+			withID.ServeHTTP(w, r)
+		})
+	}
+}
+
+// ValidRequestID reports whether id is at most maxLength bytes of letters,
+// digits and - _ . : / + =, which covers UUIDs, ULIDs, trace IDs and the IDs
+// chi generates while ruling out whitespace and control characters.
+func ValidRequestID(id string, maxLength int) bool {
+	if id == "" || len(id) > maxLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if !requestIDChar(id[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func requestIDChar(c byte) bool {
+	switch {
+	case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+		return true
+	}
+	switch c {
+	case '-', '_', '.', ':', '/', '+', '=':
+		return true
+	}
+	return false
+}
@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"mime"
+	"net/http"
+)
+
+// RequireJSON rejects POST, PUT and PATCH requests whose body is not declared
+// as application/json with 415, so form posts and plain text are never handed
+// to a JSON decoder. Parameters such as charset are allowed. Requests without
+// a body, like POST /api/examples/{id}/restore, pass through whatever their
+// Content-Type.
+func RequireJSON() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !mutating(r.Method) || !hasBody(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+			if err != nil || mediaType != "application/json" {
+				w.Header().Set("Accept-Post", "application/json")
+				w.Header().Set("Accept-Patch", "application/json")
+				writeJSONError(w, http.StatusUnsupportedMediaType, errorResponse{Error: "Content-Type must be application/json"})
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func mutating(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch:
+		return true
+	}
+	return false
+}
+
+// hasBody reports whether r declares a body. Chunked requests have an unknown
+// length of -1.
+func hasBody(r *http.Request) bool {
+	return r.Body != nil && r.Body != http.NoBody && r.ContentLength != 0
+}
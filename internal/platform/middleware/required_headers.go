@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+)
+
+type missingHeadersResponse struct {
+	Error   string   `json:"error"`
+	Missing []string `json:"missing"`
+}
+
+// RequireHeaders rejects requests that lack any of the given headers, or send
+// them empty, with 400 and a JSON body listing the missing ones. It is meant to
+// be attached per route, e.g. router.With(RequireHeaders("X-Tenant-ID")).
+// Without headers it passes every request through.
+func RequireHeaders(headers ...string) func(http.Handler) http.Handler {
+	required := make([]string, 0, len(headers))
+	seen := make(map[string]struct{}, len(headers))
+	for _, header := range headers {
+		header = http.CanonicalHeaderKey(strings.TrimSpace(header))
+		if _, dup := seen[header]; header == "" || dup {
+			continue
+		}
+		seen[header] = struct{}{}
+		required = append(required, header)
+	}
+
+	return func(next http.Handler) http.Handler {
+		if len(required) == 0 {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var missing []string
+			for _, header := range required {
+				if strings.TrimSpace(r.Header.Get(header)) == "" {
+					missing = append(missing, header)
+				}
+			}
+			if len(missing) > 0 {
+				writeJSONError(w, http.StatusBadRequest, missingHeadersResponse{
+					Error:   "missing required headers: " + strings.Join(missing, ", "),
+					Missing: missing,
+				})
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
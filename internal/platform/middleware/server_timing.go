@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"microservice/internal/platform/servertiming"
+	"net/http"
+	"time"
+)
+
+// ServerTiming adds a Server-Timing header with the sub-timings recorded
+// through servertiming during the request, followed by the total time until
+// the response headers were written. With a non-empty requestHeader the
+// header is only added to requests that carry it, so timings can be asked for
+// per request. Timings reveal internals and are meant for development.
+func ServerTiming(requestHeader string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if requestHeader != "" && r.Header.Get(requestHeader) == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			timings := servertiming.New()
+			tw := &serverTimingWriter{ResponseWriter: w, timings: timings, start: time.Now()}
+			next.ServeHTTP(tw, r.WithContext(servertiming.NewContext(r.Context(), timings)))
+		})
+	}
+}
+
+// serverTimingWriter sets the Server-Timing header just before the response
+// headers are sent, which is the last point it can be changed.
+type serverTimingWriter struct {
+	http.ResponseWriter
+	timings     *servertiming.Timings
+	start       time.Time
+	wroteHeader bool
+}
+
+func (w *serverTimingWriter) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		value := w.timings.Header()
+		total := servertiming.FormatMetric("total", time.Since(w.start))
+		if value != "" {
+			value += ", " + total
+		} else {
+			value = total
+		}
+		w.Header().Set(servertiming.HeaderName, value)
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *serverTimingWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *serverTimingWriter) Timings() *servertiming.Timings {
+	return w.timings
+}
+
+func (w *serverTimingWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *serverTimingWriter) Flush() {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
@@ -5,4 +5,7 @@ import "errors"
 var (
 	ErrNotFound      = errors.New("entity not found")
 	ErrAlreadyExists = errors.New("entity already exists")
+	ErrInvalidLimit  = errors.New("limit must be positive")
+	ErrInvalidTTL    = errors.New("ttl must be positive")
+	ErrNotDeleted    = errors.New("entity is not deleted")
 )
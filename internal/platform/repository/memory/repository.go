@@ -2,22 +2,114 @@ package memory
 
 import (
 	"context"
+	"sort"
 	"sync"
+	"time"
 )
 
+const defaultSweepInterval = time.Minute
+
 type Entity interface {
 	GetID() string
 }
 
 type Repository[T Entity] struct {
-	data map[string]T
-	mu   sync.RWMutex
+	data         map[string]T
+	normalizeKey func(string) string
+	mu           sync.RWMutex
+
+	// expiresAt holds the deadline of entities saved with SaveWithTTL.
+	expiresAt map[string]time.Time
+	// deletedAt marks soft-deleted entities, which reads skip but which keep
+	// their ID taken until they are restored or deleted.
+	deletedAt     map[string]time.Time
+	now           func() time.Time
+	sweepInterval time.Duration
+	sweeping      bool
+	closed        bool
+	stop          chan struct{}
+	stopped       chan struct{}
+}
+
+type Option func(*options)
+
+type options struct {
+	normalizeKey  func(string) string
+	sweepInterval time.Duration
+}
+
+// WithKeyNormalizer maps every ID through fn before it is used as a key, so
+// IDs that normalize to the same value address the same entity.
+func WithKeyNormalizer(fn func(string) string) Option {
+	return func(o *options) {
+		o.normalizeKey = fn
+	}
 }
 
-func New[T Entity]() *Repository[T] {
+// WithSweepInterval sets how often expired entities are removed in the
+// background. It defaults to one minute.
+func WithSweepInterval(interval time.Duration) Option {
+	return func(o *options) {
+		o.sweepInterval = interval
+	}
+}
+
+func New[T Entity](opts ...Option) *Repository[T] {
+	o := options{
+		normalizeKey:  func(id string) string { return id },
+		sweepInterval: defaultSweepInterval,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.sweepInterval <= 0 {
+		o.sweepInterval = defaultSweepInterval
+	}
+
 	return &Repository[T]{
-		data: make(map[string]T),
+		data:          make(map[string]T),
+		normalizeKey:  o.normalizeKey,
+		expiresAt:     make(map[string]time.Time),
+		deletedAt:     make(map[string]time.Time),
+		now:           time.Now,
+		sweepInterval: o.sweepInterval,
+	}
+}
+
+// expired reports whether the entity stored under key has outlived its TTL.
+// Callers must hold the lock.
+func (r *Repository[T]) expired(key string, now time.Time) bool {
+	deadline, ok := r.expiresAt[key]
+	return ok && !now.Before(deadline)
+}
+
+// exists reports whether a live entity is stored under key, removing it first
+// if it has expired. Callers must hold the write lock.
+func (r *Repository[T]) exists(key string, now time.Time) bool {
+	if _, ok := r.data[key]; !ok {
+		return false
+	}
+	if r.expired(key, now) {
+		r.remove(key)
+		return false
+	}
+	return true
+}
+
+// visible reports whether the entity stored under key is neither expired nor
+// soft-deleted. Callers must hold the lock.
+func (r *Repository[T]) visible(key string, now time.Time) bool {
+	if r.expired(key, now) {
+		return false
 	}
+	_, deleted := r.deletedAt[key]
+	return !deleted
+}
+
+func (r *Repository[T]) remove(key string) {
+	delete(r.data, key)
+	delete(r.expiresAt, key)
+	delete(r.deletedAt, key)
 }
 
 func (r *Repository[T]) Save(ctx context.Context, entity T) error {
@@ -25,36 +117,143 @@ func (r *Repository[T]) Save(ctx context.Context, entity T) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	id := entity.GetID()
-	if _, exists := r.data[id]; exists {
+	id := r.normalizeKey(entity.GetID())
+	if r.exists(id, r.now()) {
+		return ErrAlreadyExists
+	}
+
+	r.data[id] = entity
+	return nil
+}
+
+// SaveWithTTL stores the entity like Save, but it expires after ttl: reads
+// then treat it as missing and a background sweeper removes it. The sweeper
+// starts with the first call and runs until Close.
+func (r *Repository[T]) SaveWithTTL(ctx context.Context, entity T, ttl time.Duration) error {
+	_ = ctx
+	if ttl <= 0 {
+		return ErrInvalidTTL
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	id := r.normalizeKey(entity.GetID())
+	if r.exists(id, now) {
 		return ErrAlreadyExists
 	}
 
 	r.data[id] = entity
+	r.expiresAt[id] = now.Add(ttl)
+	r.startSweeper()
 	return nil
 }
 
+// SaveAll stores the entities under a single lock and returns one error per
+// entity. In atomic mode nothing is stored unless every entity can be saved.
+func (r *Repository[T]) SaveAll(ctx context.Context, entities []T, atomic bool) []error {
+	_ = ctx
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	errs := make([]error, len(entities))
+	seen := make(map[string]struct{}, len(entities))
+	failed := false
+	for i, entity := range entities {
+		id := r.normalizeKey(entity.GetID())
+		stored := r.exists(id, now)
+		_, duplicate := seen[id]
+		if stored || duplicate {
+			errs[i] = ErrAlreadyExists
+			failed = true
+			continue
+		}
+		seen[id] = struct{}{}
+	}
+
+	if atomic && failed {
+		return errs
+	}
+
+	for i, entity := range entities {
+		if errs[i] == nil {
+			r.data[r.normalizeKey(entity.GetID())] = entity
+		}
+	}
+
+	return errs
+}
+
 func (r *Repository[T]) GetByID(ctx context.Context, id string) (T, error) {
 	_ = ctx
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
 	var zero T
-	entity, exists := r.data[id]
-	if !exists {
+	key := r.normalizeKey(id)
+	entity, exists := r.data[key]
+	if !exists || !r.visible(key, r.now()) {
 		return zero, ErrNotFound
 	}
 
 	return entity, nil
 }
 
+// Find scans the live entities under the read lock and returns the one with
+// the lowest ID among those match accepts, or ErrNotFound when there is none.
+func (r *Repository[T]) Find(ctx context.Context, match func(T) bool) (T, error) {
+	_ = ctx
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var (
+		found    T
+		foundKey string
+		ok       bool
+	)
+	now := r.now()
+	for key, entity := range r.data {
+		if !r.visible(key, now) || (ok && key > foundKey) || !match(entity) {
+			continue
+		}
+		found, foundKey, ok = entity, key, true
+	}
+	if !ok {
+		return found, ErrNotFound
+	}
+	return found, nil
+}
+
+// GetByIDIncludingDeleted returns the entity even if it is soft-deleted,
+// together with the time it was deleted, or nil for live entities.
+func (r *Repository[T]) GetByIDIncludingDeleted(ctx context.Context, id string) (T, *time.Time, error) {
+	_ = ctx
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var zero T
+	key := r.normalizeKey(id)
+	entity, exists := r.data[key]
+	if !exists || r.expired(key, r.now()) {
+		return zero, nil, ErrNotFound
+	}
+
+	if deletedAt, deleted := r.deletedAt[key]; deleted {
+		return entity, &deletedAt, nil
+	}
+	return entity, nil, nil
+}
+
 func (r *Repository[T]) Update(ctx context.Context, entity T) error {
 	_ = ctx
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	id := entity.GetID()
-	if _, exists := r.data[id]; !exists {
+	id := r.normalizeKey(entity.GetID())
+	now := r.now()
+	if !r.exists(id, now) || !r.visible(id, now) {
 		return ErrNotFound
 	}
 
@@ -62,16 +261,54 @@ func (r *Repository[T]) Update(ctx context.Context, entity T) error {
 	return nil
 }
 
+// SoftDelete hides the entity from reads without freeing its ID: Save keeps
+// failing with ErrAlreadyExists until it is restored or deleted.
+func (r *Repository[T]) SoftDelete(ctx context.Context, id string) error {
+	_ = ctx
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id = r.normalizeKey(id)
+	now := r.now()
+	if !r.exists(id, now) || !r.visible(id, now) {
+		return ErrNotFound
+	}
+
+	r.deletedAt[id] = now
+	return nil
+}
+
+// Restore undoes SoftDelete. It returns ErrNotFound for a missing entity and
+// ErrNotDeleted for one that is not soft-deleted.
+func (r *Repository[T]) Restore(ctx context.Context, id string) error {
+	_ = ctx
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id = r.normalizeKey(id)
+	if !r.exists(id, r.now()) {
+		return ErrNotFound
+	}
+	if _, deleted := r.deletedAt[id]; !deleted {
+		return ErrNotDeleted
+	}
+
+	delete(r.deletedAt, id)
+	return nil
+}
+
+// Delete removes the entity for good, including soft-deleted ones.
 func (r *Repository[T]) Delete(ctx context.Context, id string) error {
 	_ = ctx
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	if _, exists := r.data[id]; !exists {
+	id = r.normalizeKey(id)
+	if !r.exists(id, r.now()) {
 		return ErrNotFound
 	}
 
-	delete(r.data, id)
+	r.remove(id)
 	return nil
 }
 
@@ -80,18 +317,138 @@ func (r *Repository[T]) List(ctx context.Context) ([]T, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
+	now := r.now()
 	entities := make([]T, 0, len(r.data))
-	for _, entity := range r.data {
-		entities = append(entities, entity)
+	for key, entity := range r.data {
+		if r.visible(key, now) {
+			entities = append(entities, entity)
+		}
 	}
 
 	return entities, nil
 }
 
+// ListPage returns up to limit entities ordered by ID, starting after afterID.
+// An empty afterID starts from the beginning. The returned cursor is the ID of
+// the last entity in the page, or empty when there are no more pages.
+func (r *Repository[T]) ListPage(ctx context.Context, afterID string, limit int) ([]T, string, error) {
+	_ = ctx
+	if limit <= 0 {
+		return nil, "", ErrInvalidLimit
+	}
+
+	type item struct {
+		key    string
+		entity T
+	}
+
+	after := ""
+	if afterID != "" {
+		after = r.normalizeKey(afterID)
+	}
+
+	r.mu.RLock()
+	now := r.now()
+	items := make([]item, 0, len(r.data))
+	for key, entity := range r.data {
+		if !r.visible(key, now) {
+			continue
+		}
+		if afterID == "" || key > after {
+			items = append(items, item{key: key, entity: entity})
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(items, func(i, j int) bool { return items[i].key < items[j].key })
+
+	n := limit
+	if len(items) < n {
+		n = len(items)
+	}
+	page := make([]T, n)
+	for i := range page {
+		page[i] = items[i].entity
+	}
+
+	next := ""
+	if len(items) > limit {
+		next = page[n-1].GetID()
+	}
+
+	return page, next, nil
+}
+
 func (r *Repository[T]) Count(ctx context.Context) (int, error) {
 	_ = ctx
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	return len(r.data), nil
+	now := r.now()
+	count := 0
+	for key := range r.data {
+		if r.visible(key, now) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// Close stops the expiry sweeper and waits for it to exit. Expired entities
+// are still hidden from reads afterwards. It is safe to call more than once.
+func (r *Repository[T]) Close() error {
+	r.mu.Lock()
+	if r.closed {
+		r.mu.Unlock()
+		return nil
+	}
+	r.closed = true
+	sweeping := r.sweeping
+	if sweeping {
+		close(r.stop)
+	}
+	r.mu.Unlock()
+
+	if sweeping {
+		<-r.stopped
+	}
+	return nil
+}
+
+// startSweeper launches the expiry sweeper once. Callers must hold the write
+// lock.
+func (r *Repository[T]) startSweeper() {
+	if r.sweeping || r.closed {
+		return
+	}
+	r.sweeping = true
+	r.stop = make(chan struct{})
+	r.stopped = make(chan struct{})
+
+	go func() {
+		defer close(r.stopped)
+		ticker := time.NewTicker(r.sweepInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-r.stop:
+				return
+			case <-ticker.C:
+				r.sweep()
+			}
+		}
+	}()
+}
+
+func (r *Repository[T]) sweep() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	for key := range r.expiresAt {
+		if r.expired(key, now) {
+			r.remove(key)
+		}
+	}
 }
@@ -3,6 +3,8 @@ package memory
 import (
 	"context"
 	"fmt"
+	"runtime"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -197,6 +199,90 @@ func (s *RepositoryTestSuite) TestGetByID() {
 	}
 }
 
+func (s *RepositoryTestSuite) TestWithKeyNormalizer() {
+	repo := New[*TestEntity](WithKeyNormalizer(strings.ToLower))
+	s.Require().NoError(repo.Save(s.ctx, s.createTestEntity("Test-ID", "Test Entity")))
+
+	entity, err := repo.GetByID(s.ctx, "TEST-id")
+	s.Require().NoError(err)
+	s.Assert().Equal("Test-ID", entity.ID)
+
+	err = repo.Save(s.ctx, s.createTestEntity("test-id", "Duplicate"))
+	s.Assert().ErrorIs(err, ErrAlreadyExists)
+
+	s.Require().NoError(repo.Update(s.ctx, s.createTestEntity("TEST-ID", "Updated")))
+	entity, err = repo.GetByID(s.ctx, "test-id")
+	s.Require().NoError(err)
+	s.Assert().Equal("Updated", entity.Name)
+
+	s.Require().NoError(repo.Delete(s.ctx, "tEsT-iD"))
+	_, err = repo.GetByID(s.ctx, "Test-ID")
+	s.Assert().ErrorIs(err, ErrNotFound)
+}
+
+func (s *RepositoryTestSuite) TestSaveAll() {
+	tests := []struct {
+		name        string
+		atomic      bool
+		ids         []string
+		expectedErr []error
+		storedCount int
+	}{
+		{
+			name:        "all_new",
+			ids:         []string{"new-1", "new-2"},
+			expectedErr: []error{nil, nil},
+			storedCount: 3,
+		},
+		{
+			name:        "non_atomic_skips_conflicts",
+			ids:         []string{"new-1", "existing", "new-1"},
+			expectedErr: []error{nil, ErrAlreadyExists, ErrAlreadyExists},
+			storedCount: 2,
+		},
+		{
+			name:        "atomic_stores_nothing_on_conflict",
+			atomic:      true,
+			ids:         []string{"new-1", "existing"},
+			expectedErr: []error{nil, ErrAlreadyExists},
+			storedCount: 1,
+		},
+		{
+			name:        "atomic_all_new",
+			atomic:      true,
+			ids:         []string{"new-1", "new-2"},
+			expectedErr: []error{nil, nil},
+			storedCount: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.saveTestEntity(s.createTestEntity("existing", "Existing"))
+
+			entities := make([]*TestEntity, len(tt.ids))
+			for i, id := range tt.ids {
+				entities[i] = s.createTestEntity(id, "Entity")
+			}
+
+			errs := s.repo.SaveAll(s.ctx, entities, tt.atomic)
+
+			s.Require().Len(errs, len(tt.expectedErr))
+			for i, expected := range tt.expectedErr {
+				if expected == nil {
+					s.Assert().NoError(errs[i])
+				} else {
+					s.Assert().ErrorIs(errs[i], expected)
+				}
+			}
+
+			count, err := s.repo.Count(s.ctx)
+			s.Require().NoError(err)
+			s.Assert().Equal(tt.storedCount, count)
+		})
+	}
+}
+
 func (s *RepositoryTestSuite) TestUpdate() {
 	tests := []struct {
 		name          string
@@ -394,6 +480,115 @@ func (s *RepositoryTestSuite) TestList() {
 	})
 }
 
+func (s *RepositoryTestSuite) TestListPage() {
+	tests := []struct {
+		name         string
+		afterID      string
+		limit        int
+		expectedIDs  []string
+		expectedNext string
+	}{
+		{name: "first_page", limit: 2, expectedIDs: []string{"a", "b"}, expectedNext: "b"},
+		{name: "middle_page", afterID: "b", limit: 2, expectedIDs: []string{"c", "d"}, expectedNext: "d"},
+		{name: "last_page", afterID: "d", limit: 2, expectedIDs: []string{"e"}},
+		{name: "exact_fit", afterID: "c", limit: 2, expectedIDs: []string{"d", "e"}},
+		{name: "cursor_past_end", afterID: "z", limit: 2, expectedIDs: []string{}},
+		{name: "cursor_not_stored", afterID: "bb", limit: 10, expectedIDs: []string{"c", "d", "e"}},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			for _, id := range []string{"c", "a", "e", "b", "d"} {
+				s.saveTestEntity(s.createTestEntity(id, "Entity "+id))
+			}
+
+			page, next, err := s.repo.ListPage(s.ctx, tt.afterID, tt.limit)
+			s.Require().NoError(err)
+
+			ids := make([]string, len(page))
+			for i, entity := range page {
+				ids[i] = entity.ID
+			}
+			s.Assert().Equal(tt.expectedIDs, ids)
+			s.Assert().Equal(tt.expectedNext, next)
+		})
+	}
+}
+
+func (s *RepositoryTestSuite) TestListPage_WalksAllPages() {
+	for i := 0; i < 25; i++ {
+		s.saveTestEntity(s.createTestEntity(fmt.Sprintf("entity-%02d", i), "Entity"))
+	}
+
+	var ids []string
+	cursor := ""
+	for {
+		page, next, err := s.repo.ListPage(s.ctx, cursor, 10)
+		s.Require().NoError(err)
+		for _, entity := range page {
+			ids = append(ids, entity.ID)
+		}
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+
+	s.Require().Len(ids, 25)
+	for i, id := range ids {
+		s.Assert().Equal(fmt.Sprintf("entity-%02d", i), id)
+	}
+}
+
+func (s *RepositoryTestSuite) TestListPage_InvalidLimit() {
+	_, _, err := s.repo.ListPage(s.ctx, "", 0)
+	s.Assert().ErrorIs(err, ErrInvalidLimit)
+}
+
+func (s *RepositoryTestSuite) TestListPage_WithKeyNormalizer() {
+	repo := New[*TestEntity](WithKeyNormalizer(strings.ToLower))
+	for _, id := range []string{"B", "a", "C"} {
+		s.Require().NoError(repo.Save(s.ctx, s.createTestEntity(id, "Entity")))
+	}
+
+	page, next, err := repo.ListPage(s.ctx, "A", 1)
+	s.Require().NoError(err)
+	s.Require().Len(page, 1)
+	s.Assert().Equal("B", page[0].ID)
+	s.Assert().Equal("B", next)
+}
+
+func (s *RepositoryTestSuite) TestListPage_ConcurrentWrites() {
+	for i := 0; i < 100; i++ {
+		s.saveTestEntity(s.createTestEntity(fmt.Sprintf("seed-%03d", i), "Seed"))
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 500; i++ {
+			_ = s.repo.Save(s.ctx, s.createTestEntity(fmt.Sprintf("new-%03d", i), "New"))
+			_ = s.repo.Delete(s.ctx, fmt.Sprintf("seed-%03d", i%100))
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 50; i++ {
+			cursor := ""
+			for {
+				_, next, err := s.repo.ListPage(s.ctx, cursor, 7)
+				if !s.Assert().NoError(err) || next == "" {
+					break
+				}
+				cursor = next
+			}
+		}
+	}()
+
+	s.Assert().NotPanics(wg.Wait)
+}
+
 func (s *RepositoryTestSuite) TestCount() {
 	s.Run("empty_repository", func() {
 		count, err := s.repo.Count(s.ctx)
@@ -696,6 +891,237 @@ func TestRepository_LargePayload(t *testing.T) {
 	}
 }
 
+// newClockedRepo returns a repository whose clock only moves when advance is
+// called.
+func newClockedRepo(opts ...Option) (*Repository[*TestEntity], func(time.Duration)) {
+	repo := New[*TestEntity](opts...)
+	current := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var mu sync.Mutex
+	repo.now = func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return current
+	}
+	return repo, func(d time.Duration) {
+		mu.Lock()
+		defer mu.Unlock()
+		current = current.Add(d)
+	}
+}
+
+func (s *RepositoryTestSuite) TestSaveWithTTL_ExpiresEntity() {
+	repo, advance := newClockedRepo()
+	defer func() { s.Require().NoError(repo.Close()) }()
+
+	s.Require().NoError(repo.SaveWithTTL(s.ctx, s.createTestEntity("cached", "Cached"), time.Minute))
+	s.Require().NoError(repo.Save(s.ctx, s.createTestEntity("permanent", "Permanent")))
+
+	entity, err := repo.GetByID(s.ctx, "cached")
+	s.Require().NoError(err)
+	s.Assert().Equal("Cached", entity.Name)
+	s.Assert().ErrorIs(repo.SaveWithTTL(s.ctx, s.createTestEntity("cached", "Again"), time.Minute), ErrAlreadyExists)
+
+	advance(time.Minute)
+
+	_, err = repo.GetByID(s.ctx, "cached")
+	s.Assert().ErrorIs(err, ErrNotFound)
+	count, err := repo.Count(s.ctx)
+	s.Require().NoError(err)
+	s.Assert().Equal(1, count)
+	list, err := repo.List(s.ctx)
+	s.Require().NoError(err)
+	s.Assert().Equal([]*TestEntity{{ID: "permanent", Name: "Permanent"}}, list)
+	page, _, err := repo.ListPage(s.ctx, "", 10)
+	s.Require().NoError(err)
+	s.Assert().Len(page, 1)
+	s.Assert().ErrorIs(repo.Update(s.ctx, s.createTestEntity("cached", "Updated")), ErrNotFound)
+	s.Assert().ErrorIs(repo.Delete(s.ctx, "cached"), ErrNotFound)
+
+	// An expired ID is free again, and a plain Save does not expire.
+	s.Require().NoError(repo.Save(s.ctx, s.createTestEntity("cached", "Fresh")))
+	advance(time.Hour)
+	entity, err = repo.GetByID(s.ctx, "cached")
+	s.Require().NoError(err)
+	s.Assert().Equal("Fresh", entity.Name)
+}
+
+func (s *RepositoryTestSuite) TestSaveWithTTL_UpdateKeepsExpiry() {
+	repo, advance := newClockedRepo()
+	defer func() { s.Require().NoError(repo.Close()) }()
+
+	s.Require().NoError(repo.SaveWithTTL(s.ctx, s.createTestEntity("cached", "Cached"), time.Minute))
+	advance(30 * time.Second)
+	s.Require().NoError(repo.Update(s.ctx, s.createTestEntity("cached", "Updated")))
+	advance(30 * time.Second)
+
+	_, err := repo.GetByID(s.ctx, "cached")
+	s.Assert().ErrorIs(err, ErrNotFound)
+}
+
+func (s *RepositoryTestSuite) TestSaveWithTTL_SaveAllTreatsExpiredAsFree() {
+	repo, advance := newClockedRepo()
+	defer func() { s.Require().NoError(repo.Close()) }()
+
+	s.Require().NoError(repo.SaveWithTTL(s.ctx, s.createTestEntity("cached", "Cached"), time.Minute))
+	advance(time.Minute)
+
+	errs := repo.SaveAll(s.ctx, []*TestEntity{s.createTestEntity("cached", "Batch")}, true)
+	s.Assert().Equal([]error{nil}, errs)
+}
+
+func (s *RepositoryTestSuite) TestSaveWithTTL_InvalidTTL() {
+	for _, ttl := range []time.Duration{0, -time.Second} {
+		err := s.repo.SaveWithTTL(s.ctx, s.createTestEntity("id", "Name"), ttl)
+		s.Assert().ErrorIs(err, ErrInvalidTTL)
+	}
+	s.Assert().False(s.repo.sweeping)
+}
+
+func (s *RepositoryTestSuite) TestSaveWithTTL_SweeperRemovesExpired() {
+	repo := New[*TestEntity](WithSweepInterval(5 * time.Millisecond))
+	defer func() { s.Require().NoError(repo.Close()) }()
+
+	s.Require().NoError(repo.SaveWithTTL(s.ctx, s.createTestEntity("short", "Short"), 10*time.Millisecond))
+	s.Require().NoError(repo.SaveWithTTL(s.ctx, s.createTestEntity("long", "Long"), time.Hour))
+	s.Require().NoError(repo.Save(s.ctx, s.createTestEntity("permanent", "Permanent")))
+
+	s.Eventually(func() bool {
+		repo.mu.RLock()
+		defer repo.mu.RUnlock()
+		_, stored := repo.data["short"]
+		return !stored
+	}, time.Second, 5*time.Millisecond)
+
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+	s.Assert().Len(repo.data, 2)
+	s.Assert().Len(repo.expiresAt, 1)
+}
+
+func (s *RepositoryTestSuite) TestSoftDelete_HidesEntity() {
+	s.saveTestEntity(s.createTestEntity("deleted", "Deleted"))
+	s.saveTestEntity(s.createTestEntity("live", "Live"))
+
+	s.Require().NoError(s.repo.SoftDelete(s.ctx, "deleted"))
+
+	_, err := s.repo.GetByID(s.ctx, "deleted")
+	s.Assert().ErrorIs(err, ErrNotFound)
+	s.Assert().ErrorIs(s.repo.Update(s.ctx, s.createTestEntity("deleted", "Updated")), ErrNotFound)
+	s.Assert().ErrorIs(s.repo.SoftDelete(s.ctx, "deleted"), ErrNotFound)
+	list, err := s.repo.List(s.ctx)
+	s.Require().NoError(err)
+	s.Assert().Equal([]*TestEntity{{ID: "live", Name: "Live"}}, list)
+	page, _, err := s.repo.ListPage(s.ctx, "", 10)
+	s.Require().NoError(err)
+	s.Assert().Equal([]*TestEntity{{ID: "live", Name: "Live"}}, page)
+	count, err := s.repo.Count(s.ctx)
+	s.Require().NoError(err)
+	s.Assert().Equal(1, count)
+
+	entity, deletedAt, err := s.repo.GetByIDIncludingDeleted(s.ctx, "deleted")
+	s.Require().NoError(err)
+	s.Assert().Equal("Deleted", entity.Name)
+	s.Assert().NotNil(deletedAt)
+	_, deletedAt, err = s.repo.GetByIDIncludingDeleted(s.ctx, "live")
+	s.Require().NoError(err)
+	s.Assert().Nil(deletedAt)
+}
+
+func (s *RepositoryTestSuite) TestSoftDelete_RecreateRequiresRestoreOrDelete() {
+	s.saveTestEntity(s.createTestEntity("id", "Original"))
+	s.Require().NoError(s.repo.SoftDelete(s.ctx, "id"))
+
+	s.Assert().ErrorIs(s.repo.Save(s.ctx, s.createTestEntity("id", "Again")), ErrAlreadyExists)
+	s.Assert().Equal([]error{ErrAlreadyExists}, s.repo.SaveAll(s.ctx, []*TestEntity{s.createTestEntity("id", "Again")}, false))
+
+	s.Require().NoError(s.repo.Restore(s.ctx, "id"))
+	entity, err := s.repo.GetByID(s.ctx, "id")
+	s.Require().NoError(err)
+	s.Assert().Equal("Original", entity.Name)
+	s.Assert().ErrorIs(s.repo.Restore(s.ctx, "id"), ErrNotDeleted)
+
+	s.Require().NoError(s.repo.SoftDelete(s.ctx, "id"))
+	s.Require().NoError(s.repo.Delete(s.ctx, "id"))
+	_, _, err = s.repo.GetByIDIncludingDeleted(s.ctx, "id")
+	s.Assert().ErrorIs(err, ErrNotFound)
+	s.saveTestEntity(s.createTestEntity("id", "Fresh"))
+	entity, err = s.repo.GetByID(s.ctx, "id")
+	s.Require().NoError(err)
+	s.Assert().Equal("Fresh", entity.Name)
+}
+
+func (s *RepositoryTestSuite) TestSoftDelete_Missing() {
+	s.Assert().ErrorIs(s.repo.SoftDelete(s.ctx, "missing"), ErrNotFound)
+	s.Assert().ErrorIs(s.repo.Restore(s.ctx, "missing"), ErrNotFound)
+}
+
+func (s *RepositoryTestSuite) TestFind() {
+	s.saveTestEntity(s.createTestEntity("c", "Shared"))
+	s.saveTestEntity(s.createTestEntity("b", "Shared"))
+	s.saveTestEntity(s.createTestEntity("a", "Shared"))
+	s.saveTestEntity(s.createTestEntity("d", "Other"))
+	s.Require().NoError(s.repo.SoftDelete(s.ctx, "a"))
+
+	shared := func(e *TestEntity) bool { return e.Name == "Shared" }
+	entity, err := s.repo.Find(s.ctx, shared)
+	s.Require().NoError(err)
+	s.Assert().Equal("b", entity.ID)
+
+	_, err = s.repo.Find(s.ctx, func(e *TestEntity) bool { return e.Name == "Missing" })
+	s.Assert().ErrorIs(err, ErrNotFound)
+}
+
+func (s *RepositoryTestSuite) TestClose() {
+	s.Require().NoError(s.repo.Close(), "closing without a sweeper is a no-op")
+
+	repo := New[*TestEntity](WithSweepInterval(time.Millisecond))
+	s.Require().NoError(repo.SaveWithTTL(s.ctx, s.createTestEntity("id", "Name"), time.Hour))
+	s.Require().True(repo.sweeping)
+
+	s.Require().NoError(repo.Close())
+	s.Require().NoError(repo.Close())
+	select {
+	case <-repo.stopped:
+	default:
+		s.Fail("sweeper still running after Close")
+	}
+
+	// Entities can still be stored after Close, without a new sweeper.
+	stopped := repo.stopped
+	s.Require().NoError(repo.SaveWithTTL(s.ctx, s.createTestEntity("late", "Late"), time.Hour))
+	s.Assert().Equal(stopped, repo.stopped)
+	_, err := repo.GetByID(s.ctx, "late")
+	s.Assert().NoError(err)
+}
+
+func TestRepository_TTLMemoryLeaks(t *testing.T) {
+	ctx := context.Background()
+	before := runtime.NumGoroutine()
+
+	const repos = 100
+	for i := 0; i < repos; i++ {
+		repo := New[*TestEntity](WithSweepInterval(time.Millisecond))
+		for j := 0; j < 100; j++ {
+			entity := &TestEntity{ID: fmt.Sprintf("ttl-leak-%d", j)}
+			if err := repo.SaveWithTTL(ctx, entity, time.Millisecond); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := repo.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Allow unrelated runtime goroutines to settle before comparing.
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if after := runtime.NumGoroutine(); after > before {
+		t.Errorf("Expected no leaked sweepers, goroutines went from %d to %d", before, after)
+	}
+}
+
 func TestRepositoryTestSuite(t *testing.T) {
 	suite.Run(t, new(RepositoryTestSuite))
 }
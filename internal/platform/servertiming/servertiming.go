@@ -0,0 +1,130 @@
+// Package servertiming collects per-request sub-timings that are reported in
+// the Server-Timing response header. Layers record into the accumulator
+// carried by the request context; recording is a no-op when there is none.
+package servertiming
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// HeaderName is the response header the timings are written to.
+const HeaderName = "Server-Timing"
+
+// Metric is the total time spent in one named part of a request.
+type Metric struct {
+	Name     string
+	Duration time.Duration
+}
+
+// Timings accumulates metrics for a single request. Durations recorded under
+// the same name are summed, so a request that runs three queries reports
+// their combined time.
+type Timings struct {
+	mu      sync.Mutex
+	metrics []Metric
+}
+
+func New() *Timings {
+	return &Timings{}
+}
+
+// Add records d under name. It is safe to call on a nil Timings.
+func (t *Timings) Add(name string, d time.Duration) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for i := range t.metrics {
+		if t.metrics[i].Name == name {
+			t.metrics[i].Duration += d
+			return
+		}
+	}
+	t.metrics = append(t.metrics, Metric{Name: name, Duration: d})
+}
+
+// Metrics returns the recorded metrics in the order they were first added.
+func (t *Timings) Metrics() []Metric {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return append([]Metric(nil), t.metrics...)
+}
+
+// Header formats the metrics as a Server-Timing header value, with durations
+// in milliseconds.
+func (t *Timings) Header() string {
+	metrics := t.Metrics()
+	parts := make([]string, len(metrics))
+	for i, m := range metrics {
+		parts[i] = FormatMetric(m.Name, m.Duration)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// FormatMetric formats a single Server-Timing entry.
+func FormatMetric(name string, d time.Duration) string {
+	return fmt.Sprintf("%s;dur=%.3f", name, float64(d)/float64(time.Millisecond))
+}
+
+type timingsKey struct{}
+
+func NewContext(ctx context.Context, t *Timings) context.Context {
+	return context.WithValue(ctx, timingsKey{}, t)
+}
+
+// FromContext returns the accumulator stored by NewContext, or nil.
+func FromContext(ctx context.Context) *Timings {
+	t, _ := ctx.Value(timingsKey{}).(*Timings)
+	return t
+}
+
+// Add records d under name in the accumulator carried by ctx, if any.
+func Add(ctx context.Context, name string, d time.Duration) {
+	FromContext(ctx).Add(name, d)
+}
+
+// Track starts timing name and returns the function that stops it:
+//
+//	defer servertiming.Track(ctx, "validate")()
+func Track(ctx context.Context, name string) func() {
+	t := FromContext(ctx)
+	if t == nil {
+		return func() {}
+	}
+	start := time.Now()
+	return func() { t.Add(name, time.Since(start)) }
+}
+
+// Carrier is implemented by response writers that hold the request's
+// accumulator, for code such as response encoders that has the writer but not
+// the request context.
+type Carrier interface {
+	Timings() *Timings
+}
+
+// FromWriter returns the accumulator of the first Carrier found by unwrapping
+// w, following the Unwrap convention of http.ResponseController, or nil.
+func FromWriter(w http.ResponseWriter) *Timings {
+	for w != nil {
+		if c, ok := w.(Carrier); ok {
+			return c.Timings()
+		}
+		u, ok := w.(interface{ Unwrap() http.ResponseWriter })
+		if !ok {
+			return nil
+		}
+		w = u.Unwrap()
+	}
+	return nil
+}
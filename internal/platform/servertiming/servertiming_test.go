@@ -0,0 +1,67 @@
+package servertiming
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimings_AddSumsByName(t *testing.T) {
+	timings := New()
+	timings.Add("db", 2*time.Millisecond)
+	timings.Add("encode", 500*time.Microsecond)
+	timings.Add("db", 3*time.Millisecond)
+
+	assert.Equal(t, []Metric{
+		{Name: "db", Duration: 5 * time.Millisecond},
+		{Name: "encode", Duration: 500 * time.Microsecond},
+	}, timings.Metrics())
+	assert.Equal(t, "db;dur=5.000, encode;dur=0.500", timings.Header())
+}
+
+func TestContext_WithoutTimings(t *testing.T) {
+	ctx := context.Background()
+
+	assert.Nil(t, FromContext(ctx))
+	assert.NotPanics(t, func() {
+		Add(ctx, "db", time.Millisecond)
+		Track(ctx, "validate")()
+	})
+}
+
+func TestTrack_RecordsIntoContext(t *testing.T) {
+	timings := New()
+	ctx := NewContext(context.Background(), timings)
+
+	stop := Track(ctx, "validate")
+	time.Sleep(time.Millisecond)
+	stop()
+
+	metrics := timings.Metrics()
+	assert.Len(t, metrics, 1)
+	assert.Equal(t, "validate", metrics[0].Name)
+	assert.GreaterOrEqual(t, metrics[0].Duration, time.Millisecond)
+}
+
+type carrierWriter struct {
+	http.ResponseWriter
+	timings *Timings
+}
+
+func (w *carrierWriter) Timings() *Timings { return w.timings }
+
+func (w *carrierWriter) Unwrap() http.ResponseWriter { return w.ResponseWriter }
+
+func TestFromWriter(t *testing.T) {
+	timings := New()
+	carrier := &carrierWriter{ResponseWriter: httptest.NewRecorder(), timings: timings}
+
+	assert.Same(t, timings, FromWriter(carrier))
+	assert.Same(t, timings, FromWriter(middleware.NewWrapResponseWriter(carrier, 1)), "found through wrapping writers")
+	assert.Nil(t, FromWriter(httptest.NewRecorder()))
+}
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_examples_lower_id;
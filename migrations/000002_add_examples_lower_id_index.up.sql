@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_examples_lower_id ON examples(LOWER(id));
@@ -0,0 +1 @@
+ALTER TABLE examples ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_examples_lower_email ON examples(LOWER(email)) WHERE deleted_at IS NULL;
@@ -0,0 +1,63 @@
+package migrations
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatestVersion(t *testing.T) {
+	version, err := LatestVersion()
+
+	require.NoError(t, err)
+	assert.Equal(t, uint(5), version)
+}
+
+func Test_latestVersion(t *testing.T) {
+	tests := []struct {
+		name          string
+		fsys          fstest.MapFS
+		expected      uint
+		expectedError bool
+	}{
+		{
+			name: "picks_highest_up_migration",
+			fsys: fstest.MapFS{
+				"000001_init.up.sql":     {},
+				"000001_init.down.sql":   {},
+				"000003_users.up.sql":    {},
+				"000002_indexes.up.sql":  {},
+				"000004_future.down.sql": {},
+			},
+			expected: 3,
+		},
+		{
+			name:          "no_migrations",
+			fsys:          fstest.MapFS{},
+			expectedError: true,
+		},
+		{
+			name: "invalid_version",
+			fsys: fstest.MapFS{
+				"init_schema.up.sql": {},
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version, err := latestVersion(tt.fsys)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, version)
+		})
+	}
+}